@@ -9,7 +9,7 @@ import (
 
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
-	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memdb"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/kaspanet/kaspad/infrastructure/os/execenv"
 	"github.com/kaspanet/kaspad/infrastructure/os/limits"
@@ -18,9 +18,11 @@ import (
 	"github.com/kaspanet/kaspad/util/panics"
 	"github.com/kaspanet/kaspad/util/profiling"
 	"github.com/kaspanet/kaspad/version"
-)
 
-const leveldbCacheSizeMiB = 256
+	// Register the database backends that can be selected with --dbtype
+	_ "github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	_ "github.com/kaspanet/kaspad/infrastructure/db/database/logdb"
+)
 
 var desiredLimits = &limits.DesiredLimits{
 	FileLimitWant: 2048,
@@ -98,6 +100,22 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 		}
 	}
 
+	if app.cfg.RestoreSnapshot != "" {
+		err := restoreSnapshot(app.cfg)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+
+	if app.cfg.MigrationDryRun {
+		err := dryRunDatabaseMigrations(app.cfg)
+		if err != nil {
+			log.Error(err)
+		}
+		return err
+	}
+
 	// Open the database
 	databaseContext, err := openDB(app.cfg)
 	if err != nil {
@@ -152,9 +170,20 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 
 	// Wait until the interrupt signal is received from an OS signal or
 	// shutdown is requested through one of the subsystems such as the RPC
-	// server.
-	<-interrupt
-	return nil
+	// server. Meanwhile, reload the configuration whenever an OS signal
+	// such as SIGHUP requests it.
+	reload := signal.ReloadListener(interrupt)
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case <-reload:
+			err := componentManager.ReloadConfig()
+			if err != nil {
+				log.Errorf("Failed to reload the configuration: %s", err)
+			}
+		}
+	}
 }
 
 // dbPath returns the path to the block database given a database type.
@@ -167,16 +196,38 @@ func removeDatabase(cfg *config.Config) error {
 	return os.RemoveAll(dbPath)
 }
 
+// databaseOptions returns the database options that are set in cfg
+func databaseOptions(cfg *config.Config) *database.Options {
+	return &database.Options{
+		CacheSizeMiB:       cfg.DbCacheSizeMiB,
+		WriteBufferSizeMiB: cfg.DbWriteBufferSizeMiB,
+		Compression:        cfg.DbCompression,
+		Sync:               cfg.DbSync,
+		SeeksCompaction:    cfg.DbSeeksCompaction,
+	}
+}
+
 func openDB(cfg *config.Config) (database.Database, error) {
 	dbPath := databasePath(cfg)
 
-	err := checkDatabaseVersion(dbPath)
+	// In-memory databases start out empty every time, so there's nothing to migrate
+	if cfg.DbType == memdb.BackendName {
+		log.Infof("Using an in-memory database. All data will be lost on shutdown")
+		return database.Open(cfg.DbType, dbPath, databaseOptions(cfg))
+	}
+
+	err := checkDatabaseVersion(cfg, dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Loading %s database from '%s'", cfg.DbType, dbPath)
+	db, err := database.Open(cfg.DbType, dbPath, databaseOptions(cfg))
 	if err != nil {
 		return nil, err
 	}
 
-	log.Infof("Loading database from '%s'", dbPath)
-	db, err := ldb.NewLevelDB(dbPath, leveldbCacheSizeMiB)
+	err = storeDatabaseVersionIfMissing(db)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,167 @@
+//go:build go1.18
+// +build go1.18
+
+package appmessage_test
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FuzzKaspadMessage makes sure that converting untrusted protowire messages to
+// appmessage never panics, and that every message that converts successfully
+// survives the round-trip back to protowire.
+//
+// A message that converted successfully doesn't necessarily serialize back to
+// the same bytes, since protobuf allows several encodings of the same message
+// and since the conversion drops unknown fields. Instead, its serialization is
+// expected to be a fixed point: converting it again must yield the same bytes.
+func FuzzKaspadMessage(f *testing.F) {
+	for _, seed := range kaspadMessageSeeds(f) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		message := &protowire.KaspadMessage{}
+		err := proto.Unmarshal(data, message)
+		if err != nil {
+			return
+		}
+		appMessage, err := message.ToAppMessage()
+		if err != nil {
+			return
+		}
+
+		serialized := serializeAppMessage(t, appMessage)
+		deserialized := &protowire.KaspadMessage{}
+		err = proto.Unmarshal(serialized, deserialized)
+		if err != nil {
+			t.Fatalf("Unmarshal: %+v", err)
+		}
+		roundTripAppMessage, err := deserialized.ToAppMessage()
+		if err != nil {
+			t.Fatalf("%s doesn't convert back to an appmessage: %+v", appMessage.Command(), err)
+		}
+		if roundTripAppMessage.Command() != appMessage.Command() {
+			t.Fatalf("%s converted back to %s", appMessage.Command(), roundTripAppMessage.Command())
+		}
+		reserialized := serializeAppMessage(t, roundTripAppMessage)
+		if !bytes.Equal(serialized, reserialized) {
+			t.Fatalf("%s changed after a round-trip:\n%x\n%x", appMessage.Command(), serialized, reserialized)
+		}
+	})
+}
+
+func serializeAppMessage(t *testing.T, appMessage appmessage.Message) []byte {
+	message, err := protowire.FromAppMessage(appMessage)
+	if err != nil {
+		t.Fatalf("%s doesn't convert to protowire: %+v", appMessage.Command(), err)
+	}
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		t.Fatalf("Marshal: %+v", err)
+	}
+	return serialized
+}
+
+// kaspadMessageSeeds returns the files in the testdata directory, decompressed
+// if they're bzip2 files, along with an empty message of every payload type, so
+// that the fuzzer reaches every conversion function right away, and a few
+// messages built from real blocks and transactions
+func kaspadMessageSeeds(f *testing.F) [][]byte {
+	seeds := testdataSeeds(f)
+
+	payloadFields := (&protowire.KaspadMessage{}).ProtoReflect().Descriptor().Oneofs().ByName("payload").Fields()
+	for i := 0; i < payloadFields.Len(); i++ {
+		field := payloadFields.Get(i)
+		message := (&protowire.KaspadMessage{}).ProtoReflect()
+		message.Set(field, protoreflect.ValueOfMessage(message.NewField(field).Message()))
+		seed, err := proto.Marshal(message.Interface())
+		if err != nil {
+			f.Fatalf("Marshal: %+v", err)
+		}
+		seeds = append(seeds, seed)
+	}
+
+	genesis := dagconfig.MainnetParams.GenesisBlock
+	coinbase := genesis.Transactions[0]
+	genesisHash := consensushashing.BlockHash(genesis)
+	outpoint := &externalapi.DomainOutpoint{TransactionID: *consensushashing.TransactionID(coinbase), Index: 0}
+	entry := utxo.NewUTXOEntry(1000, &externalapi.ScriptPublicKey{Script: []byte{0x51}, Version: 0}, true, 1)
+
+	appMessages := []appmessage.Message{
+		appmessage.DomainBlockToMsgBlock(genesis),
+		appmessage.NewMsgIBDBlock(appmessage.DomainBlockToMsgBlock(genesis)),
+		appmessage.DomainTransactionToMsgTx(coinbase),
+		appmessage.NewBlockHeadersMessage([]*appmessage.MsgBlockHeader{
+			appmessage.DomainBlockHeaderToBlockHeader(genesis.Header),
+		}),
+		appmessage.NewMsgAddresses([]*appmessage.NetAddress{
+			appmessage.NewNetAddressIPPort(net.ParseIP("127.0.0.1"), 16111),
+			appmessage.NewNetAddressIPPort(net.ParseIP("::1"), 16111),
+		}),
+		appmessage.NewMsgInvTransaction([]*externalapi.DomainTransactionID{consensushashing.TransactionID(coinbase)}),
+		appmessage.NewMsgIBDBlockLocatorHighestHash(genesisHash),
+		appmessage.NewMsgPruningPointUTXOSetChunk(appmessage.DomainOutpointAndUTXOEntryPairsToOutpointAndUTXOEntryPairs(
+			[]*externalapi.OutpointAndUTXOEntryPair{{Outpoint: outpoint, UTXOEntry: entry}})),
+		appmessage.NewMsgReject("reason"),
+		appmessage.NewMsgPing(1),
+		appmessage.NewSubmitBlockRequestMessage(appmessage.DomainBlockToRPCBlock(genesis)),
+		appmessage.NewGetBlockRequestMessage(genesisHash.String(), true),
+	}
+	for _, appMessage := range appMessages {
+		message, err := protowire.FromAppMessage(appMessage)
+		if err != nil {
+			f.Fatalf("FromAppMessage: %+v", err)
+		}
+		seed, err := proto.Marshal(message)
+		if err != nil {
+			f.Fatalf("Marshal: %+v", err)
+		}
+		seeds = append(seeds, seed)
+	}
+
+	return seeds
+}
+
+func testdataSeeds(f *testing.F) [][]byte {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil {
+		f.Fatalf("Glob: %+v", err)
+	}
+
+	seeds := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			f.Fatalf("Open: %+v", err)
+		}
+		var seed []byte
+		if strings.HasSuffix(path, ".bz2") {
+			seed, err = ioutil.ReadAll(bzip2.NewReader(file))
+		} else {
+			seed, err = ioutil.ReadAll(file)
+		}
+		file.Close()
+		if err != nil {
+			f.Fatalf("error reading %s: %+v", path, err)
+		}
+		seeds = append(seeds, seed)
+	}
+	return seeds
+}
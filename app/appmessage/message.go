@@ -63,6 +63,9 @@ const (
 	CmdBlockHeaders
 	CmdRequestNextPruningPointUTXOSetChunk
 	CmdDonePruningPointUTXOSetChunks
+	CmdCompactBlock
+	CmdRequestBlockTransactions
+	CmdBlockTransactions
 
 	// rpc
 	CmdGetCurrentNetworkRequestMessage
@@ -140,6 +143,26 @@ const (
 	CmdNotifyVirtualDaaScoreChangedRequestMessage
 	CmdNotifyVirtualDaaScoreChangedResponseMessage
 	CmdVirtualDaaScoreChangedNotificationMessage
+	CmdCompactDatabaseRequestMessage
+	CmdCompactDatabaseResponseMessage
+	CmdGetDatabaseStatsRequestMessage
+	CmdGetDatabaseStatsResponseMessage
+	CmdCreateSnapshotRequestMessage
+	CmdCreateSnapshotResponseMessage
+	CmdGetSyncStatusRequestMessage
+	CmdGetSyncStatusResponseMessage
+	CmdSetLogLevelRequestMessage
+	CmdSetLogLevelResponseMessage
+	CmdReloadConfigRequestMessage
+	CmdReloadConfigResponseMessage
+	CmdGetCacheStatsRequestMessage
+	CmdGetCacheStatsResponseMessage
+	CmdGenerateBlocksRequestMessage
+	CmdGenerateBlocksResponseMessage
+	CmdExportDAGRequestMessage
+	CmdExportDAGResponseMessage
+	CmdGetSnapshotStatusRequestMessage
+	CmdGetSnapshotStatusResponseMessage
 )
 
 // ProtocolMessageCommandToString maps all MessageCommands to their string representation
@@ -177,6 +200,9 @@ var ProtocolMessageCommandToString = map[MessageCommand]string{
 	CmdBlockHeaders:                        "BlockHeaders",
 	CmdRequestNextPruningPointUTXOSetChunk: "RequestNextPruningPointUTXOSetChunk",
 	CmdDonePruningPointUTXOSetChunks:       "DonePruningPointUTXOSetChunks",
+	CmdCompactBlock:                        "CompactBlock",
+	CmdRequestBlockTransactions:            "RequestBlockTransactions",
+	CmdBlockTransactions:                   "BlockTransactions",
 }
 
 // RPCMessageCommandToString maps all MessageCommands to their string representation
@@ -254,6 +280,26 @@ var RPCMessageCommandToString = map[MessageCommand]string{
 	CmdNotifyVirtualDaaScoreChangedRequestMessage:                 "NotifyVirtualDaaScoreChangedRequest",
 	CmdNotifyVirtualDaaScoreChangedResponseMessage:                "NotifyVirtualDaaScoreChangedResponse",
 	CmdVirtualDaaScoreChangedNotificationMessage:                  "VirtualDaaScoreChangedNotification",
+	CmdCompactDatabaseRequestMessage:                              "CompactDatabaseRequest",
+	CmdCompactDatabaseResponseMessage:                             "CompactDatabaseResponse",
+	CmdGetDatabaseStatsRequestMessage:                             "GetDatabaseStatsRequest",
+	CmdGetDatabaseStatsResponseMessage:                            "GetDatabaseStatsResponse",
+	CmdCreateSnapshotRequestMessage:                               "CreateSnapshotRequest",
+	CmdCreateSnapshotResponseMessage:                              "CreateSnapshotResponse",
+	CmdGetSyncStatusRequestMessage:                                "GetSyncStatusRequest",
+	CmdGetSyncStatusResponseMessage:                               "GetSyncStatusResponse",
+	CmdSetLogLevelRequestMessage:                                  "SetLogLevelRequest",
+	CmdSetLogLevelResponseMessage:                                 "SetLogLevelResponse",
+	CmdReloadConfigRequestMessage:                                 "ReloadConfigRequest",
+	CmdReloadConfigResponseMessage:                                "ReloadConfigResponse",
+	CmdGetCacheStatsRequestMessage:                                "GetCacheStatsRequest",
+	CmdGetCacheStatsResponseMessage:                               "GetCacheStatsResponse",
+	CmdGenerateBlocksRequestMessage:                               "GenerateBlocksRequest",
+	CmdGenerateBlocksResponseMessage:                              "GenerateBlocksResponse",
+	CmdExportDAGRequestMessage:                                    "ExportDAGRequest",
+	CmdExportDAGResponseMessage:                                   "ExportDAGResponse",
+	CmdGetSnapshotStatusRequestMessage:                            "GetSnapshotStatusRequest",
+	CmdGetSnapshotStatusResponseMessage:                           "GetSnapshotStatusResponse",
 }
 
 // Message is an interface that describes a kaspa message. A type that
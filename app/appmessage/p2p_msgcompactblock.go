@@ -0,0 +1,42 @@
+package appmessage
+
+// PrefilledTransaction is a transaction that's sent in full as part of a
+// MsgCompactBlock, along with its index within the block
+type PrefilledTransaction struct {
+	Index       uint32
+	Transaction *MsgTx
+}
+
+// MsgCompactBlock implements the Message interface and represents a kaspa
+// CompactBlock message. It is used to relay a block as its header and the
+// short IDs of its transactions, so that the receiver may reconstruct the
+// block out of the transactions it already has in its mempool.
+//
+// ShortIDs holds the short IDs of all the transactions in the block that
+// are not included in PrefilledTransactions, in block order.
+type MsgCompactBlock struct {
+	baseMessage
+	Header                MsgBlockHeader
+	ShortIDSalt           uint64
+	ShortIDs              []uint64
+	PrefilledTransactions []*PrefilledTransaction
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgCompactBlock) Command() MessageCommand {
+	return CmdCompactBlock
+}
+
+// NewMsgCompactBlock returns a new kaspa CompactBlock message that conforms to the
+// Message interface. See MsgCompactBlock for details.
+func NewMsgCompactBlock(header *MsgBlockHeader, shortIDSalt uint64, shortIDs []uint64,
+	prefilledTransactions []*PrefilledTransaction) *MsgCompactBlock {
+
+	return &MsgCompactBlock{
+		Header:                *header,
+		ShortIDSalt:           shortIDSalt,
+		ShortIDs:              shortIDs,
+		PrefilledTransactions: prefilledTransactions,
+	}
+}
@@ -15,8 +15,9 @@ const (
 	ProtocolVersion uint32 = 1
 
 	// DefaultServices describes the default services that are supported by
-	// the server.
-	DefaultServices = SFNodeNetwork | SFNodeBloom | SFNodeCF
+	// the server. SFNodeArchival is added on top of these by nodes that run
+	// with --archival.
+	DefaultServices = SFNodeNetwork | SFNodeCompactBlocks
 )
 
 // ServiceFlag identifies services supported by a kaspa peer.
@@ -44,16 +45,27 @@ const (
 	// SFNodeCF is a flag used to indicate a peer supports committed
 	// filters (CFs).
 	SFNodeCF
+
+	// SFNodeCompactBlocks is a flag used to indicate a peer supports
+	// relaying blocks as compact blocks.
+	SFNodeCompactBlocks
+
+	// SFNodeArchival is a flag used to indicate a peer keeps the full
+	// block history and is able to serve block bodies below its pruning
+	// point.
+	SFNodeArchival
 )
 
 // Map of service flags back to their constant names for pretty printing.
 var sfStrings = map[ServiceFlag]string{
-	SFNodeNetwork: "SFNodeNetwork",
-	SFNodeGetUTXO: "SFNodeGetUTXO",
-	SFNodeBloom:   "SFNodeBloom",
-	SFNodeXthin:   "SFNodeXthin",
-	SFNodeBit5:    "SFNodeBit5",
-	SFNodeCF:      "SFNodeCF",
+	SFNodeNetwork:       "SFNodeNetwork",
+	SFNodeGetUTXO:       "SFNodeGetUTXO",
+	SFNodeBloom:         "SFNodeBloom",
+	SFNodeXthin:         "SFNodeXthin",
+	SFNodeBit5:          "SFNodeBit5",
+	SFNodeCF:            "SFNodeCF",
+	SFNodeCompactBlocks: "SFNodeCompactBlocks",
+	SFNodeArchival:      "SFNodeArchival",
 }
 
 // orderedSFStrings is an ordered list of service flags from highest to
@@ -65,6 +77,8 @@ var orderedSFStrings = []ServiceFlag{
 	SFNodeXthin,
 	SFNodeBit5,
 	SFNodeCF,
+	SFNodeCompactBlocks,
+	SFNodeArchival,
 }
 
 // String returns the ServiceFlag in human-readable form.
@@ -0,0 +1,34 @@
+package appmessage
+
+// CompactDatabaseRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type CompactDatabaseRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *CompactDatabaseRequestMessage) Command() MessageCommand {
+	return CmdCompactDatabaseRequestMessage
+}
+
+// NewCompactDatabaseRequestMessage returns a instance of the message
+func NewCompactDatabaseRequestMessage() *CompactDatabaseRequestMessage {
+	return &CompactDatabaseRequestMessage{}
+}
+
+// CompactDatabaseResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type CompactDatabaseResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *CompactDatabaseResponseMessage) Command() MessageCommand {
+	return CmdCompactDatabaseResponseMessage
+}
+
+// NewCompactDatabaseResponseMessage returns a instance of the message
+func NewCompactDatabaseResponseMessage() *CompactDatabaseResponseMessage {
+	return &CompactDatabaseResponseMessage{}
+}
@@ -0,0 +1,38 @@
+package appmessage
+
+// CreateSnapshotRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type CreateSnapshotRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *CreateSnapshotRequestMessage) Command() MessageCommand {
+	return CmdCreateSnapshotRequestMessage
+}
+
+// NewCreateSnapshotRequestMessage returns a instance of the message
+func NewCreateSnapshotRequestMessage() *CreateSnapshotRequestMessage {
+	return &CreateSnapshotRequestMessage{}
+}
+
+// CreateSnapshotResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type CreateSnapshotResponseMessage struct {
+	baseMessage
+	SnapshotPath string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *CreateSnapshotResponseMessage) Command() MessageCommand {
+	return CmdCreateSnapshotResponseMessage
+}
+
+// NewCreateSnapshotResponseMessage returns a instance of the message
+func NewCreateSnapshotResponseMessage(snapshotPath string) *CreateSnapshotResponseMessage {
+	return &CreateSnapshotResponseMessage{
+		SnapshotPath: snapshotPath,
+	}
+}
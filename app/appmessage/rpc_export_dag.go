@@ -0,0 +1,70 @@
+package appmessage
+
+// ExportDAGRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ExportDAGRequestMessage struct {
+	baseMessage
+	Depth uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *ExportDAGRequestMessage) Command() MessageCommand {
+	return CmdExportDAGRequestMessage
+}
+
+// NewExportDAGRequestMessage returns a instance of the message
+func NewExportDAGRequestMessage(depth uint64) *ExportDAGRequestMessage {
+	return &ExportDAGRequestMessage{
+		Depth: depth,
+	}
+}
+
+// ExportDAGResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ExportDAGResponseMessage struct {
+	baseMessage
+	VirtualParentHashes []string
+	VirtualBlueScore    uint64
+	VirtualDAAScore     uint64
+	PruningPointHash    string
+	FinalityPointHash   string
+	Blocks              []*DAGExportBlock
+
+	Error *RPCError
+}
+
+// DAGExportBlock holds a single block of an ExportDAGResponseMessage
+type DAGExportBlock struct {
+	Hash                           string
+	ParentHashes                   []string
+	SelectedParentHash             string
+	MergeSetBluesHashes            []string
+	MergeSetRedsHashes             []string
+	Status                         string
+	BlueScore                      uint64
+	BlueWork                       string
+	DAAScore                       uint64
+	Timestamp                      int64
+	Color                          string
+	IsInVirtualSelectedParentChain bool
+	IsInVirtualDAAWindow           bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *ExportDAGResponseMessage) Command() MessageCommand {
+	return CmdExportDAGResponseMessage
+}
+
+// NewExportDAGResponseMessage returns a instance of the message
+func NewExportDAGResponseMessage(virtualParentHashes []string, virtualBlueScore uint64, virtualDAAScore uint64,
+	pruningPointHash string, finalityPointHash string, blocks []*DAGExportBlock) *ExportDAGResponseMessage {
+
+	return &ExportDAGResponseMessage{
+		VirtualParentHashes: virtualParentHashes,
+		VirtualBlueScore:    virtualBlueScore,
+		VirtualDAAScore:     virtualDAAScore,
+		PruningPointHash:    pruningPointHash,
+		FinalityPointHash:   finalityPointHash,
+		Blocks:              blocks,
+	}
+}
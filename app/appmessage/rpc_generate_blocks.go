@@ -0,0 +1,49 @@
+package appmessage
+
+// GenerateBlocksRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GenerateBlocksRequestMessage struct {
+	baseMessage
+	PayAddress   string
+	BlockCount   uint32
+	ParentHashes []string
+	Timestamp    int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GenerateBlocksRequestMessage) Command() MessageCommand {
+	return CmdGenerateBlocksRequestMessage
+}
+
+// NewGenerateBlocksRequestMessage returns a instance of the message
+func NewGenerateBlocksRequestMessage(payAddress string, blockCount uint32,
+	parentHashes []string, timestamp int64) *GenerateBlocksRequestMessage {
+
+	return &GenerateBlocksRequestMessage{
+		PayAddress:   payAddress,
+		BlockCount:   blockCount,
+		ParentHashes: parentHashes,
+		Timestamp:    timestamp,
+	}
+}
+
+// GenerateBlocksResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GenerateBlocksResponseMessage struct {
+	baseMessage
+	BlockHashes []string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GenerateBlocksResponseMessage) Command() MessageCommand {
+	return CmdGenerateBlocksResponseMessage
+}
+
+// NewGenerateBlocksResponseMessage returns a instance of the message
+func NewGenerateBlocksResponseMessage(blockHashes []string) *GenerateBlocksResponseMessage {
+	return &GenerateBlocksResponseMessage{
+		BlockHashes: blockHashes,
+	}
+}
@@ -0,0 +1,49 @@
+package appmessage
+
+// GetCacheStatsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetCacheStatsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetCacheStatsRequestMessage) Command() MessageCommand {
+	return CmdGetCacheStatsRequestMessage
+}
+
+// NewGetCacheStatsRequestMessage returns a instance of the message
+func NewGetCacheStatsRequestMessage() *GetCacheStatsRequestMessage {
+	return &GetCacheStatsRequestMessage{}
+}
+
+// GetCacheStatsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetCacheStatsResponseMessage struct {
+	baseMessage
+	CacheStats []*CacheStatsMessage
+
+	Error *RPCError
+}
+
+// CacheStatsMessage holds the usage statistics of a single consensus cache
+type CacheStatsMessage struct {
+	Name      string
+	Entries   uint64
+	Size      uint64
+	Capacity  uint64
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetCacheStatsResponseMessage) Command() MessageCommand {
+	return CmdGetCacheStatsResponseMessage
+}
+
+// NewGetCacheStatsResponseMessage returns a instance of the message
+func NewGetCacheStatsResponseMessage(cacheStats []*CacheStatsMessage) *GetCacheStatsResponseMessage {
+	return &GetCacheStatsResponseMessage{
+		CacheStats: cacheStats,
+	}
+}
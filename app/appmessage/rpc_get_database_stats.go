@@ -0,0 +1,56 @@
+package appmessage
+
+// GetDatabaseStatsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetDatabaseStatsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetDatabaseStatsRequestMessage) Command() MessageCommand {
+	return CmdGetDatabaseStatsRequestMessage
+}
+
+// NewGetDatabaseStatsRequestMessage returns a instance of the message
+func NewGetDatabaseStatsRequestMessage() *GetDatabaseStatsRequestMessage {
+	return &GetDatabaseStatsRequestMessage{}
+}
+
+// GetDatabaseStatsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetDatabaseStatsResponseMessage struct {
+	baseMessage
+	Levels                         []*DatabaseLevelStats
+	IOReadBytes                    uint64
+	IOWrittenBytes                 uint64
+	WriteDelayCount                uint32
+	WriteDelayDurationMilliseconds uint64
+	BlockCacheSize                 uint64
+	OpenedTableCount               uint32
+	MemoryCompactionCount          uint32
+	Level0CompactionCount          uint32
+	NonLevel0CompactionCount       uint32
+	SeekCompactionCount            uint32
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetDatabaseStatsResponseMessage) Command() MessageCommand {
+	return CmdGetDatabaseStatsResponseMessage
+}
+
+// NewGetDatabaseStatsResponseMessage returns a instance of the message
+func NewGetDatabaseStatsResponseMessage() *GetDatabaseStatsResponseMessage {
+	return &GetDatabaseStatsResponseMessage{}
+}
+
+// DatabaseLevelStats holds the statistics of a single database level
+type DatabaseLevelStats struct {
+	Level                          uint32
+	TableCount                     uint32
+	Size                           uint64
+	ReadBytes                      uint64
+	WrittenBytes                   uint64
+	CompactionDurationMilliseconds uint64
+}
@@ -0,0 +1,44 @@
+package appmessage
+
+// GetSnapshotStatusRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetSnapshotStatusRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetSnapshotStatusRequestMessage) Command() MessageCommand {
+	return CmdGetSnapshotStatusRequestMessage
+}
+
+// NewGetSnapshotStatusRequestMessage returns a instance of the message
+func NewGetSnapshotStatusRequestMessage() *GetSnapshotStatusRequestMessage {
+	return &GetSnapshotStatusRequestMessage{}
+}
+
+// GetSnapshotStatusResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetSnapshotStatusResponseMessage struct {
+	baseMessage
+	SnapshotPath   string
+	IsInProgress   bool
+	FailureMessage string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetSnapshotStatusResponseMessage) Command() MessageCommand {
+	return CmdGetSnapshotStatusResponseMessage
+}
+
+// NewGetSnapshotStatusResponseMessage returns a instance of the message
+func NewGetSnapshotStatusResponseMessage(snapshotPath string, isInProgress bool,
+	failureMessage string) *GetSnapshotStatusResponseMessage {
+
+	return &GetSnapshotStatusResponseMessage{
+		SnapshotPath:   snapshotPath,
+		IsInProgress:   isInProgress,
+		FailureMessage: failureMessage,
+	}
+}
@@ -0,0 +1,42 @@
+package appmessage
+
+// GetSyncStatusRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetSyncStatusRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetSyncStatusRequestMessage) Command() MessageCommand {
+	return CmdGetSyncStatusRequestMessage
+}
+
+// NewGetSyncStatusRequestMessage returns a instance of the message
+func NewGetSyncStatusRequestMessage() *GetSyncStatusRequestMessage {
+	return &GetSyncStatusRequestMessage{}
+}
+
+// GetSyncStatusResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetSyncStatusResponseMessage struct {
+	baseMessage
+	IsSynced                       bool
+	IsIBDRunning                   bool
+	HeaderCount                    uint64
+	BlockCount                     uint64
+	EstimatedMillisecondsRemaining uint64
+	PeerCount                      uint32
+	LastBlockAgeMilliseconds       int64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetSyncStatusResponseMessage) Command() MessageCommand {
+	return CmdGetSyncStatusResponseMessage
+}
+
+// NewGetSyncStatusResponseMessage returns a instance of the message
+func NewGetSyncStatusResponseMessage() *GetSyncStatusResponseMessage {
+	return &GetSyncStatusResponseMessage{}
+}
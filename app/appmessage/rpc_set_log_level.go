@@ -0,0 +1,39 @@
+package appmessage
+
+// SetLogLevelRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type SetLogLevelRequestMessage struct {
+	baseMessage
+
+	LogLevel string
+}
+
+// Command returns the protocol command string for the message
+func (msg *SetLogLevelRequestMessage) Command() MessageCommand {
+	return CmdSetLogLevelRequestMessage
+}
+
+// NewSetLogLevelRequestMessage returns an instance of the message
+func NewSetLogLevelRequestMessage(logLevel string) *SetLogLevelRequestMessage {
+	return &SetLogLevelRequestMessage{
+		LogLevel: logLevel,
+	}
+}
+
+// SetLogLevelResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type SetLogLevelResponseMessage struct {
+	baseMessage
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *SetLogLevelResponseMessage) Command() MessageCommand {
+	return CmdSetLogLevelResponseMessage
+}
+
+// NewSetLogLevelResponseMessage returns a instance of the message
+func NewSetLogLevelResponseMessage() *SetLogLevelResponseMessage {
+	return &SetLogLevelResponseMessage{}
+}
@@ -5,6 +5,7 @@ import (
 	"sync/atomic"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/configreload"
 	"github.com/kaspanet/kaspad/app/protocol"
 	"github.com/kaspanet/kaspad/app/rpc"
 	"github.com/kaspanet/kaspad/domain"
@@ -12,22 +13,32 @@ import (
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	infrastructuredatabase "github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/health"
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/dnsseed"
+	"github.com/kaspanet/kaspad/infrastructure/network/nat"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/util/mstime"
 	"github.com/kaspanet/kaspad/util/panics"
 )
 
 // ComponentManager is a wrapper for all the kaspad services
 type ComponentManager struct {
 	cfg               *config.Config
+	domain            domain.Domain
 	addressManager    *addressmanager.AddressManager
 	protocolManager   *protocol.Manager
 	rpcManager        *rpc.Manager
 	connectionManager *connmanager.ConnectionManager
 	netAdapter        *netadapter.NetAdapter
+	natManager        *nat.Manager
+	metricsServer     *metrics.Server
+	healthServer      *health.Server
+	configReloader    *configreload.Reloader
 
 	started, shutdown int32
 }
@@ -46,6 +57,24 @@ func (a *ComponentManager) Start() {
 		panics.Exit(log, fmt.Sprintf("Error starting the net adapter: %+v", err))
 	}
 
+	if a.natManager != nil {
+		a.natManager.Start()
+	}
+
+	if a.metricsServer != nil {
+		err := a.metricsServer.Start()
+		if err != nil {
+			panics.Exit(log, fmt.Sprintf("Error starting the metrics server: %+v", err))
+		}
+	}
+
+	if a.healthServer != nil {
+		err := a.healthServer.Start()
+		if err != nil {
+			panics.Exit(log, fmt.Sprintf("Error starting the health server: %+v", err))
+		}
+	}
+
 	a.maybeSeedFromDNS()
 
 	a.connectionManager.Start()
@@ -63,6 +92,24 @@ func (a *ComponentManager) Stop() {
 
 	a.connectionManager.Stop()
 
+	if a.natManager != nil {
+		a.natManager.Stop()
+	}
+
+	if a.metricsServer != nil {
+		err := a.metricsServer.Stop()
+		if err != nil {
+			log.Errorf("Error stopping the metrics server: %+v", err)
+		}
+	}
+
+	if a.healthServer != nil {
+		err := a.healthServer.Stop()
+		if err != nil {
+			log.Errorf("Error stopping the health server: %+v", err)
+		}
+	}
+
 	err := a.netAdapter.Stop()
 	if err != nil {
 		log.Errorf("Error stopping the net adapter: %+v", err)
@@ -78,27 +125,58 @@ func (a *ComponentManager) Stop() {
 func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database, interrupt chan<- struct{}) (
 	*ComponentManager, error) {
 
+	return newComponentManager(cfg, db, netadapter.NewNetAdapter, mstime.SystemClock, interrupt)
+}
+
+// NewComponentManagerWithP2PServer returns a new ComponentManager instance that
+// communicates with its peers through the given p2pServer instead of over TCP,
+// and reads the current time from the given clock instead of from the system.
+// It's meant for running many nodes in a single process, e.g. in simulations.
+func NewComponentManagerWithP2PServer(cfg *config.Config, db infrastructuredatabase.Database,
+	p2pServer server.P2PServer, clock mstime.Clock, interrupt chan<- struct{}) (*ComponentManager, error) {
+
+	return newComponentManager(cfg, db, func(cfg *config.Config) (*netadapter.NetAdapter, error) {
+		return netadapter.NewNetAdapterWithP2PServer(cfg, p2pServer)
+	}, clock, interrupt)
+}
+
+func newComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
+	newNetAdapter func(cfg *config.Config) (*netadapter.NetAdapter, error), clock mstime.Clock,
+	interrupt chan<- struct{}) (*ComponentManager, error) {
+
 	consensusConfig := consensus.Config{
 		Params:                          *cfg.ActiveNetParams,
 		IsArchival:                      cfg.IsArchivalNode,
 		EnableSanityCheckPruningUTXOSet: cfg.EnableSanityCheckPruningUTXOSet,
+		MaxCacheSize:                    cfg.MaxUTXOCacheSize,
 	}
 
-	domain, err := domain.New(&consensusConfig, db)
+	domain, err := domain.New(&consensusConfig, db, cfg.RecordBlocks, clock)
 	if err != nil {
 		return nil, err
 	}
+	domain.MiningManager().SetMempoolPolicy(cfg.MinRelayTxFee, cfg.MaxOrphanTxs)
 
-	netAdapter, err := netadapter.NewNetAdapter(cfg)
+	netAdapter, err := newNetAdapter(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	addressManager, err := addressmanager.New(addressmanager.NewConfig(cfg), db)
+	addressManagerConfig := addressmanager.NewConfig(cfg)
+	addressManagerConfig.Clock = clock
+	addressManager, err := addressmanager.New(addressManagerConfig, db)
 	if err != nil {
 		return nil, err
 	}
 
+	var natManager *nat.Manager
+	if cfg.Upnp && !cfg.DisableListen && len(cfg.ExternalIPs) == 0 {
+		natManager, err = nat.New(cfg, addressManager)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var utxoIndex *utxoindex.UTXOIndex
 	if cfg.UTXOIndex {
 		utxoIndex, err = utxoindex.New(domain.Consensus(), db)
@@ -113,19 +191,36 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	if err != nil {
 		return nil, err
 	}
-	protocolManager, err := protocol.NewManager(cfg, domain, netAdapter, addressManager, connectionManager)
+	protocolManager, err := protocol.NewManager(cfg, domain, netAdapter, addressManager, connectionManager, clock)
 	if err != nil {
 		return nil, err
 	}
-	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, interrupt)
+	configReloader := configreload.New(cfg, domain.MiningManager(), connectionManager, addressManager)
+	rpcManager := setupRPC(cfg, domain, db, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex,
+		configReloader, interrupt)
+
+	var metricsServer *metrics.Server
+	if cfg.MetricsListen != "" {
+		metricsServer = setupMetrics(cfg.MetricsListen, domain, protocolManager)
+	}
+
+	var healthServer *health.Server
+	if cfg.HealthListen != "" {
+		healthServer = setupHealth(cfg, protocolManager)
+	}
 
 	return &ComponentManager{
 		cfg:               cfg,
+		domain:            domain,
 		protocolManager:   protocolManager,
 		rpcManager:        rpcManager,
 		connectionManager: connectionManager,
 		netAdapter:        netAdapter,
 		addressManager:    addressManager,
+		natManager:        natManager,
+		metricsServer:     metricsServer,
+		healthServer:      healthServer,
+		configReloader:    configReloader,
 	}, nil
 
 }
@@ -133,22 +228,26 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 func setupRPC(
 	cfg *config.Config,
 	domain domain.Domain,
+	db infrastructuredatabase.Database,
 	netAdapter *netadapter.NetAdapter,
 	protocolManager *protocol.Manager,
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	configReloader *configreload.Reloader,
 	shutDownChan chan<- struct{},
 ) *rpc.Manager {
 
 	rpcManager := rpc.NewManager(
 		cfg,
 		domain,
+		db,
 		netAdapter,
 		protocolManager,
 		connectionManager,
 		addressManager,
 		utxoIndex,
+		configReloader,
 		shutDownChan,
 	)
 	protocolManager.SetOnBlockAddedToDAGHandler(rpcManager.NotifyBlockAddedToDAG)
@@ -179,7 +278,27 @@ func (a *ComponentManager) P2PNodeID() *id.ID {
 	return a.netAdapter.ID()
 }
 
+// ReloadConfig reloads the part of the configuration that can change while kaspad is running
+func (a *ComponentManager) ReloadConfig() error {
+	return a.configReloader.Reload()
+}
+
 // AddressManager returns the AddressManager associated with this ComponentManager
 func (a *ComponentManager) AddressManager() *addressmanager.AddressManager {
 	return a.addressManager
 }
+
+// Domain returns the Domain associated with this ComponentManager
+func (a *ComponentManager) Domain() domain.Domain {
+	return a.domain
+}
+
+// ProtocolManager returns the protocol.Manager associated with this ComponentManager
+func (a *ComponentManager) ProtocolManager() *protocol.Manager {
+	return a.protocolManager
+}
+
+// ConnectionManager returns the ConnectionManager associated with this ComponentManager
+func (a *ComponentManager) ConnectionManager() *connmanager.ConnectionManager {
+	return a.connectionManager
+}
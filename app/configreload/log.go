@@ -0,0 +1,7 @@
+package configreload
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("CNFG")
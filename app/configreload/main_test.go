@@ -0,0 +1,14 @@
+package configreload
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogStdout(logger.LevelInfo)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,105 @@
+package configreload
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
+)
+
+// Reloader applies the part of the configuration that can change while
+// kaspad is running to the components that use it
+type Reloader struct {
+	cfg               *config.Config
+	miningManager     miningmanager.MiningManager
+	connectionManager *connmanager.ConnectionManager
+	addressManager    *addressmanager.AddressManager
+
+	current *config.ReloadableConfig
+	mutex   sync.Mutex
+
+	// reloadConfig reads the reloadable part of the configuration again.
+	// It's config.ReloadConfig, except in tests
+	reloadConfig func(cfg *config.Config) (*config.ReloadableConfig, error)
+}
+
+// New returns a new Reloader for the configuration that kaspad was
+// started with
+func New(cfg *config.Config, miningManager miningmanager.MiningManager,
+	connectionManager *connmanager.ConnectionManager, addressManager *addressmanager.AddressManager) *Reloader {
+
+	return &Reloader{
+		cfg:               cfg,
+		miningManager:     miningManager,
+		connectionManager: connectionManager,
+		addressManager:    addressManager,
+		current:           cfg.Reloadable(),
+		reloadConfig:      config.ReloadConfig,
+	}
+}
+
+// Reload reads the configuration file and the command line options again,
+// and applies the options that can change while kaspad is running: the log
+// levels, the peers from --addpeer or --connect, the ban settings and the
+// mempool policy. Nothing is applied if any of these options is invalid.
+func (r *Reloader) Reload() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reloaded, err := r.reloadConfig(r.cfg)
+	if err != nil {
+		return err
+	}
+
+	// The log levels are validated by ReloadConfig, so this can only fail
+	// if the set of subsystems has changed since, which never happens
+	err = logger.ParseAndSetLogLevels(reloaded.LogLevel)
+	if err != nil {
+		return err
+	}
+
+	r.reloadPeers(peers(r.current), peers(reloaded))
+	r.connectionManager.SetBanPolicy(reloaded.DisableBanning, reloaded.Whitelists)
+	r.addressManager.SetBanDuration(reloaded.BanDuration)
+	r.miningManager.SetMempoolPolicy(reloaded.MinRelayTxFee, reloaded.MaxOrphanTxs)
+
+	r.current = reloaded
+	log.Infof("Reloaded the configuration")
+	return nil
+}
+
+// peers returns the peers kaspad keeps permanent connections to
+// according to reloadableConfig
+func peers(reloadableConfig *config.ReloadableConfig) []string {
+	if len(reloadableConfig.ConnectPeers) > 0 {
+		return reloadableConfig.ConnectPeers
+	}
+	return reloadableConfig.AddPeers
+}
+
+func (r *Reloader) reloadPeers(currentPeers []string, reloadedPeers []string) {
+	currentPeerSet := make(map[string]struct{}, len(currentPeers))
+	for _, peer := range currentPeers {
+		currentPeerSet[peer] = struct{}{}
+	}
+	reloadedPeerSet := make(map[string]struct{}, len(reloadedPeers))
+	for _, peer := range reloadedPeers {
+		reloadedPeerSet[peer] = struct{}{}
+	}
+
+	for _, peer := range reloadedPeers {
+		if _, ok := currentPeerSet[peer]; !ok {
+			log.Infof("Adding peer %s", peer)
+			r.connectionManager.AddConnectionRequest(peer, true)
+		}
+	}
+	for _, peer := range currentPeers {
+		if _, ok := reloadedPeerSet[peer]; !ok {
+			log.Infof("Removing peer %s", peer)
+			r.connectionManager.RemoveConnection(peer)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package configreload
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// fakeMiningManager records the mempool policy it was given
+type fakeMiningManager struct {
+	miningmanager.MiningManager
+	minRelayTxFee util.Amount
+	maxOrphanTxs  int
+}
+
+func (f *fakeMiningManager) SetMempoolPolicy(minRelayTxFee util.Amount, maxOrphanTxs int) {
+	f.minRelayTxFee = minRelayTxFee
+	f.maxOrphanTxs = maxOrphanTxs
+}
+
+func TestReloaderApply(t *testing.T) {
+	datadir := t.TempDir()
+	database, err := ldb.NewLevelDB(datadir, 8)
+	if err != nil {
+		t.Fatalf("could not create a database: %s", err)
+	}
+	defer database.Close()
+
+	const removedPeer = "10.0.0.1:16111"
+	const addedPeer = "10.0.0.2:16111"
+	cfg := config.DefaultConfig()
+	cfg.AddPeers = []string{removedPeer}
+
+	clock := mstime.NewFakeClock(mstime.Now())
+	addressManagerConfig := addressmanager.NewConfig(cfg)
+	addressManagerConfig.Clock = clock
+	addressManager, err := addressmanager.New(addressManagerConfig, database)
+	if err != nil {
+		t.Fatalf("error creating address manager: %s", err)
+	}
+	netAdapter, err := netadapter.NewNetAdapter(cfg)
+	if err != nil {
+		t.Fatalf("error creating net adapter: %s", err)
+	}
+	connectionManager, err := connmanager.New(cfg, netAdapter, addressManager)
+	if err != nil {
+		t.Fatalf("error creating connection manager: %s", err)
+	}
+	miningManager := &fakeMiningManager{}
+	reloader := New(cfg, miningManager, connectionManager, addressManager)
+
+	// A configuration that fails to load is not applied at all
+	reloader.reloadConfig = func(*config.Config) (*config.ReloadableConfig, error) {
+		return nil, errors.New("invalid configuration")
+	}
+	err = reloader.Reload()
+	if err == nil {
+		t.Fatalf("Expected Reload to fail")
+	}
+	if connectionManager.IsBanningDisabled() || miningManager.maxOrphanTxs != 0 {
+		t.Fatalf("A configuration that failed to load was applied")
+	}
+
+	_, whitelist, err := net.ParseCIDR("10.1.0.0/16")
+	if err != nil {
+		t.Fatalf("ParseCIDR: %s", err)
+	}
+	reloaded := cfg.Reloadable()
+	reloaded.LogLevel = "CNFG=trace"
+	reloaded.AddPeers = []string{addedPeer}
+	reloaded.Whitelists = []*net.IPNet{whitelist}
+	reloaded.BanDuration = time.Hour
+	reloaded.MinRelayTxFee = 2000
+	reloaded.MaxOrphanTxs = 7
+	reloader.reloadConfig = func(*config.Config) (*config.ReloadableConfig, error) {
+		return reloaded, nil
+	}
+	defer func() {
+		err := logger.ParseAndSetLogLevels(cfg.LogLevel)
+		if err != nil {
+			t.Errorf("ParseAndSetLogLevels: %s", err)
+		}
+	}()
+	err = reloader.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %+v", err)
+	}
+
+	if log.Level() != logger.LevelTrace {
+		t.Fatalf("Expected the log level to be %s, but got %s", logger.LevelTrace, log.Level())
+	}
+	if miningManager.minRelayTxFee != 2000 || miningManager.maxOrphanTxs != 7 {
+		t.Fatalf("Expected the mempool policy to be 2000 and 7, but got %d and %d",
+			miningManager.minRelayTxFee, miningManager.maxOrphanTxs)
+	}
+
+	err = connectionManager.BanByIP(net.ParseIP("10.1.2.3"))
+	if !errors.Is(err, connmanager.ErrCannotBanWhitelisted) {
+		t.Fatalf("Expected an address in the new whitelist not to be bannable, but got: %v", err)
+	}
+
+	// The connection requests are added and removed asynchronously
+	deadline := time.Now().Add(time.Second)
+	for {
+		addedPeerErr := connectionManager.BanByIP(net.ParseIP("10.0.0.2"))
+		removedPeerErr := connectionManager.BanByIP(net.ParseIP("10.0.0.1"))
+		if errors.Is(addedPeerErr, connmanager.ErrCannotBanPermanent) && removedPeerErr == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected only the added peer to be permanent, but got %v for it and %v for the removed peer",
+				addedPeerErr, removedPeerErr)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The removed peer was banned above, for the new ban duration
+	removedPeerAddress := appmessage.NewNetAddressIPPort(net.ParseIP("10.0.0.1"), 0)
+	isBanned, err := addressManager.IsBanned(removedPeerAddress)
+	if err != nil {
+		t.Fatalf("IsBanned: %+v", err)
+	}
+	if !isBanned {
+		t.Fatalf("Expected the removed peer to be banned")
+	}
+	clock.Advance(2 * time.Hour)
+	isBanned, err = addressManager.IsBanned(removedPeerAddress)
+	if err == nil && isBanned {
+		t.Fatalf("Expected the ban to expire after the reloaded ban duration")
+	}
+
+	reloaded = cfg.Reloadable()
+	reloaded.DisableBanning = true
+	err = reloader.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %+v", err)
+	}
+	if !connectionManager.IsBanningDisabled() {
+		t.Fatalf("Expected banning to be disabled")
+	}
+}
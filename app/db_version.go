@@ -1,57 +1,159 @@
 package app
 
 import (
+	"fmt"
 	"os"
 	"path"
 	"strconv"
+	"time"
 
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/migration"
 	"github.com/pkg/errors"
 )
 
+// currentDatabaseVersion is the version of the way kaspad stores its data.
+// Whenever it changes, currentDatabaseVersion is bumped and a migration
+// to the new version is appended to databaseMigrations.
 const currentDatabaseVersion = 1
 
-func checkDatabaseVersion(dbPath string) (err error) {
-	versionFileName := versionFilePath(dbPath)
+// databaseMigrations upgrade databases created by older versions of kaspad,
+// ordered by version
+var databaseMigrations = []*migration.Migration{}
 
-	versionBytes, err := os.ReadFile(versionFileName)
+// checkDatabaseVersion makes sure the database in dbPath is of
+// currentDatabaseVersion, migrating it if it's of an older version
+func checkDatabaseVersion(cfg *config.Config, dbPath string) error {
+	databaseVersion, isNew, err := readDatabaseVersion(dbPath)
 	if err != nil {
-		if os.IsNotExist(err) { // If version file doesn't exist, we assume that the database is new
-			return createDatabaseVersionFile(dbPath, versionFileName)
-		}
 		return err
 	}
+	if isNew {
+		return writeDatabaseVersionFile(dbPath, currentDatabaseVersion)
+	}
 
-	databaseVersion, err := strconv.Atoi(string(versionBytes))
+	if databaseVersion > currentDatabaseVersion {
+		return errors.Errorf("Database version %d was created by a newer version of kaspad. "+
+			"Expected version: %d", databaseVersion, currentDatabaseVersion)
+	}
+	if databaseVersion == currentDatabaseVersion {
+		return nil
+	}
+
+	if cfg.MigrationBackup {
+		backupPath := fmt.Sprintf("%s-backup-v%d-%d", dbPath, databaseVersion, time.Now().Unix())
+		log.Infof("Backing up the database to '%s' before migrating it", backupPath)
+		err := migration.CopyDatabase(dbPath, backupPath)
+		if err != nil {
+			return err
+		}
+	}
+	return migrateDatabase(cfg, dbPath, databaseVersion)
+}
+
+// dryRunDatabaseMigrations runs the pending database migrations on a
+// temporary copy of the database, leaving the database itself untouched
+func dryRunDatabaseMigrations(cfg *config.Config) error {
+	dbPath := databasePath(cfg)
+	databaseVersion, isNew, err := readDatabaseVersion(dbPath)
 	if err != nil {
 		return err
 	}
+	if isNew || databaseVersion == currentDatabaseVersion {
+		log.Infof("The database is up to date. There are no migrations to run")
+		return nil
+	}
 
-	if databaseVersion != currentDatabaseVersion {
-		// TODO: Once there's more then one database version, it might make sense to add upgrade logic at this point
-		return errors.Errorf("Invalid database version %d. Expected version: %d", databaseVersion, currentDatabaseVersion)
+	dryRunPath := fmt.Sprintf("%s-migration-dry-run-%d", dbPath, time.Now().Unix())
+	log.Infof("Copying the database to '%s' for the migration dry run", dryRunPath)
+	err = migration.CopyDatabase(dbPath, dryRunPath)
+	if err != nil {
+		return err
 	}
+	defer func() {
+		err := os.RemoveAll(dryRunPath)
+		if err != nil {
+			log.Errorf("Failed to remove the migration dry run database '%s': %s", dryRunPath, err)
+		}
+	}()
 
+	err = migrateDatabase(cfg, dryRunPath, databaseVersion)
+	if err != nil {
+		return errors.Wrapf(err, "database migration dry run failed")
+	}
+	log.Infof("Database migration dry run from version %d to version %d completed successfully",
+		databaseVersion, currentDatabaseVersion)
 	return nil
 }
 
-func createDatabaseVersionFile(dbPath string, versionFileName string) error {
-	err := os.MkdirAll(dbPath, 0700)
+func migrateDatabase(cfg *config.Config, dbPath string, databaseVersion int) error {
+	db, err := database.Open(cfg.DbType, dbPath, databaseOptions(cfg))
+	if err != nil {
+		return err
+	}
+	err = migration.Run(db, databaseVersion, currentDatabaseVersion, databaseMigrations)
+	closeErr := db.Close()
 	if err != nil {
 		return err
 	}
+	if closeErr != nil {
+		return closeErr
+	}
 
-	versionFile, err := os.Create(versionFileName)
+	return writeDatabaseVersionFile(dbPath, currentDatabaseVersion)
+}
+
+// readDatabaseVersion reads the version file of the database in dbPath.
+// If the version file doesn't exist, the database is assumed to be new.
+func readDatabaseVersion(dbPath string) (version int, isNew bool, err error) {
+	versionBytes, err := os.ReadFile(versionFilePath(dbPath))
 	if err != nil {
-		return nil
+		if os.IsNotExist(err) {
+			return 0, true, nil
+		}
+		return 0, false, err
+	}
+
+	databaseVersion, err := strconv.Atoi(string(versionBytes))
+	if err != nil {
+		return 0, false, err
+	}
+	return databaseVersion, false, nil
+}
+
+// writeDatabaseVersionFile replaces the version file of the database
+// in dbPath atomically, so that a crash can't leave it half-written
+func writeDatabaseVersionFile(dbPath string, version int) error {
+	err := os.MkdirAll(dbPath, 0700)
+	if err != nil {
+		return err
 	}
-	defer versionFile.Close()
 
-	versionString := strconv.Itoa(currentDatabaseVersion)
-	_, err = versionFile.Write([]byte(versionString))
-	return err
+	versionFileName := versionFilePath(dbPath)
+	temporaryVersionFileName := versionFileName + ".tmp"
+	versionString := strconv.Itoa(version)
+	err = os.WriteFile(temporaryVersionFileName, []byte(versionString), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporaryVersionFileName, versionFileName)
 }
 
 func versionFilePath(dbPath string) string {
 	dbVersionFileName := path.Join(dbPath, "version")
 	return dbVersionFileName
 }
+
+// storeDatabaseVersionIfMissing stores currentDatabaseVersion in databases
+// that were created before the version was stored in the database itself
+func storeDatabaseVersionIfMissing(db database.Database) error {
+	_, found, err := migration.StoredVersion(db)
+	if err != nil {
+		return err
+	}
+	if found {
+		return nil
+	}
+	return migration.StoreVersion(db, currentDatabaseVersion)
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"github.com/kaspanet/kaspad/app/protocol"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/health"
+)
+
+// healthStatus is the status of this kaspad instance
+// that the health server reports
+type healthStatus struct {
+	IsSynced                       bool   `json:"isSynced"`
+	IsIBDRunning                   bool   `json:"isIbdRunning"`
+	HeaderCount                    uint64 `json:"headerCount"`
+	BlockCount                     uint64 `json:"blockCount"`
+	EstimatedMillisecondsRemaining int64  `json:"estimatedMillisecondsRemaining"`
+	PeerCount                      int    `json:"peerCount"`
+	LastBlockAgeMilliseconds       int64  `json:"lastBlockAgeMilliseconds"`
+	IsRPCEnabled                   bool   `json:"isRpcEnabled"`
+}
+
+// setupHealth returns a health server that reports this kaspad instance
+// as ready once it's synced
+func setupHealth(cfg *config.Config, protocolManager *protocol.Manager) *health.Server {
+	return health.NewServer(cfg.HealthListen, func() (interface{}, bool, error) {
+		syncStatus, err := protocolManager.SyncStatus()
+		if err != nil {
+			return nil, false, err
+		}
+
+		status := &healthStatus{
+			IsSynced:                       syncStatus.IsSynced,
+			IsIBDRunning:                   syncStatus.IsIBDRunning,
+			HeaderCount:                    syncStatus.HeaderCount,
+			BlockCount:                     syncStatus.BlockCount,
+			EstimatedMillisecondsRemaining: syncStatus.EstimatedTimeRemaining.Milliseconds(),
+			PeerCount:                      syncStatus.PeerCount,
+			LastBlockAgeMilliseconds:       -1,
+			IsRPCEnabled:                   !cfg.DisableRPC,
+		}
+		if !syncStatus.LastBlockTime.IsZero() {
+			status.LastBlockAgeMilliseconds = protocolManager.Clock().Now().Sub(syncStatus.LastBlockTime).Milliseconds()
+		}
+		return status, syncStatus.IsSynced, nil
+	})
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"github.com/kaspanet/kaspad/app/protocol"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+// feeRateBuckets are the histogram buckets of mempool fee rates, in sompi per gram of mass
+var feeRateBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000}
+
+// setupMetrics returns a metrics server that serves the process-wide metrics
+// along with the metrics of this kaspad instance
+func setupMetrics(listenAddress string, domain domain.Domain, protocolManager *protocol.Manager) *metrics.Server {
+	registry := metrics.NewRegistry()
+	registerDAGMetrics(registry, domain)
+	registerMempoolMetrics(registry, domain)
+	registerPeerMetrics(registry, protocolManager)
+
+	return metrics.NewServer(listenAddress, metrics.DefaultRegistry, registry)
+}
+
+func registerDAGMetrics(registry *metrics.Registry, domain domain.Domain) {
+	registry.NewGaugeFunc("kaspad_dag_virtual_daa_score", "The DAA score of the virtual block",
+		func() (float64, error) {
+			virtualInfo, err := domain.Consensus().GetVirtualInfo()
+			if err != nil {
+				return 0, err
+			}
+			return float64(virtualInfo.DAAScore), nil
+		})
+
+	registry.NewGaugeFunc("kaspad_dag_virtual_blue_score", "The blue score of the virtual block",
+		func() (float64, error) {
+			virtualInfo, err := domain.Consensus().GetVirtualInfo()
+			if err != nil {
+				return 0, err
+			}
+			return float64(virtualInfo.BlueScore), nil
+		})
+
+	registry.NewGaugeFunc("kaspad_dag_tips", "The number of tips in the DAG",
+		func() (float64, error) {
+			tips, err := domain.Consensus().Tips()
+			if err != nil {
+				return 0, err
+			}
+			return float64(len(tips)), nil
+		})
+
+	registry.NewGaugeFunc("kaspad_dag_pruning_point_blue_score", "The blue score of the current pruning point",
+		func() (float64, error) {
+			pruningPoint, err := domain.Consensus().PruningPoint()
+			if err != nil {
+				return 0, err
+			}
+			pruningPointInfo, err := domain.Consensus().GetBlockInfo(pruningPoint)
+			if err != nil {
+				return 0, err
+			}
+			return float64(pruningPointInfo.BlueScore), nil
+		})
+}
+
+func registerMempoolMetrics(registry *metrics.Registry, domain domain.Domain) {
+	registry.NewGaugeFunc("kaspad_mempool_transactions", "The number of transactions in the mempool",
+		func() (float64, error) {
+			return float64(domain.MiningManager().TransactionCount()), nil
+		})
+
+	registry.NewGaugeFunc("kaspad_mempool_orphans", "The number of orphan transactions in the mempool",
+		func() (float64, error) {
+			return float64(domain.MiningManager().OrphanCount()), nil
+		})
+
+	registry.NewHistogramFunc("kaspad_mempool_fee_rate",
+		"The fee rates of the transactions in the mempool, in sompi per gram of mass", feeRateBuckets,
+		func() ([]float64, error) {
+			transactions := domain.MiningManager().AllTransactions()
+			feeRates := make([]float64, 0, len(transactions))
+			for _, transaction := range transactions {
+				if transaction.Mass == 0 {
+					continue
+				}
+				feeRates = append(feeRates, float64(transaction.Fee)/float64(transaction.Mass))
+			}
+			return feeRates, nil
+		})
+}
+
+func registerPeerMetrics(registry *metrics.Registry, protocolManager *protocol.Manager) {
+	registry.NewGaugeFunc("kaspad_p2p_inbound_peers", "The number of connected inbound peers",
+		func() (float64, error) {
+			inboundCount := 0
+			for _, peer := range protocolManager.Peers() {
+				if !peer.IsOutbound() {
+					inboundCount++
+				}
+			}
+			return float64(inboundCount), nil
+		})
+
+	registry.NewGaugeFunc("kaspad_p2p_outbound_peers", "The number of connected outbound peers",
+		func() (float64, error) {
+			outboundCount := 0
+			for _, peer := range protocolManager.Peers() {
+				if peer.IsOutbound() {
+					outboundCount++
+				}
+			}
+			return float64(outboundCount), nil
+		})
+}
@@ -14,7 +14,7 @@ import (
 )
 
 // OnNewBlock updates the mempool after a new block arrival, and
-// relays newly unorphaned transactions and possibly rebroadcast
+// relays newly unorphaned blocks and transactions and possibly rebroadcast
 // manually added transactions when not in IBD.
 func (f *FlowContext) OnNewBlock(block *externalapi.DomainBlock,
 	blockInsertionResult *externalapi.BlockInsertionResult) error {
@@ -30,6 +30,8 @@ func (f *FlowContext) OnNewBlock(block *externalapi.DomainBlock,
 
 	log.Debugf("OnNewBlock: block %s unorphaned %d blocks", hash, len(unorphaningResults))
 
+	f.onBlocksAdded(1 + len(unorphaningResults))
+
 	newBlocks := []*externalapi.DomainBlock{block}
 	newBlockInsertionResults := []*externalapi.BlockInsertionResult{blockInsertionResult}
 	for _, unorphaningResult := range unorphaningResults {
@@ -56,9 +58,32 @@ func (f *FlowContext) OnNewBlock(block *externalapi.DomainBlock,
 		}
 	}
 
+	err = f.relayUnorphanedBlocks(unorphaningResults)
+	if err != nil {
+		return err
+	}
+
 	return f.broadcastTransactionsAfterBlockAdded(newBlocks, allAcceptedTransactions)
 }
 
+// relayUnorphanedBlocks relays the blocks that were unorphaned, since the flows
+// that received them didn't relay them while they were orphans
+func (f *FlowContext) relayUnorphanedBlocks(unorphaningResults []*UnorphaningResult) error {
+	// Block relay is disabled during IBD
+	if f.IsIBDRunning() {
+		return nil
+	}
+
+	for _, unorphaningResult := range unorphaningResults {
+		unorphanedBlockHash := consensushashing.BlockHash(unorphaningResult.block)
+		err := f.Broadcast(appmessage.NewMsgInvBlock(unorphanedBlockHash))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // OnPruningPointUTXOSetOverride calls the handler function whenever the UTXO set
 // resets due to pruning point change via IBD.
 func (f *FlowContext) OnPruningPointUTXOSetOverride() error {
@@ -78,28 +103,18 @@ func (f *FlowContext) broadcastTransactionsAfterBlockAdded(
 		return nil
 	}
 
-	var txIDsToRebroadcast []*externalapi.DomainTransactionID
-	if f.shouldRebroadcastTransactions() {
-		txIDsToRebroadcast = f.txIDsToRebroadcast()
-	}
-
-	txIDsToBroadcast := make([]*externalapi.DomainTransactionID, len(transactionsAcceptedToMempool)+len(txIDsToRebroadcast))
+	txIDsToBroadcast := make([]*externalapi.DomainTransactionID, len(transactionsAcceptedToMempool))
 	for i, tx := range transactionsAcceptedToMempool {
 		txIDsToBroadcast[i] = consensushashing.TransactionID(tx)
 	}
-	offset := len(transactionsAcceptedToMempool)
-	for i, txID := range txIDsToRebroadcast {
-		txIDsToBroadcast[offset+i] = txID
-	}
+	f.EnqueueTransactionIDsForPropagation(txIDsToBroadcast)
 
-	if len(txIDsToBroadcast) == 0 {
-		return nil
-	}
-	if len(txIDsToBroadcast) > appmessage.MaxInvPerTxInvMsg {
-		txIDsToBroadcast = txIDsToBroadcast[:appmessage.MaxInvPerTxInvMsg]
+	// Transactions that are rebroadcast are announced again even to
+	// peers that already know about them, since they might have dropped them
+	if f.shouldRebroadcastTransactions() {
+		f.enqueueTransactionIDsForPropagation(f.txIDsToRebroadcast(), true)
 	}
-	inv := appmessage.NewMsgInvTransaction(txIDsToBroadcast)
-	return f.Broadcast(inv)
+	return nil
 }
 
 // SharedRequestedBlocks returns a *blockrelay.SharedRequestedBlocks for sharing
@@ -146,6 +161,7 @@ func (f *FlowContext) TrySetIBDRunning(ibdPeer *peerpkg.Peer) bool {
 		return false
 	}
 	f.ibdPeer = ibdPeer
+	f.onIBDStarted()
 	log.Infof("IBD started")
 
 	return true
@@ -0,0 +1,27 @@
+package flowcontext
+
+import (
+	"testing"
+
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+)
+
+func TestRelayUnorphanedBlocksDuringIBD(t *testing.T) {
+	unorphanedBlock := &externalapi.DomainBlock{
+		Header: blockheader.NewImmutableBlockHeader(0, nil, &externalapi.DomainHash{},
+			&externalapi.DomainHash{}, &externalapi.DomainHash{}, 0, 0, 0),
+	}
+	unorphaningResults := []*UnorphaningResult{{block: unorphanedBlock}}
+
+	// The flow context has no net adapter, so it would panic if it tried to
+	// relay the unorphaned block
+	flowContext := &FlowContext{
+		ibdPeer: &peerpkg.Peer{},
+	}
+	err := flowContext.relayUnorphanedBlocks(unorphaningResults)
+	if err != nil {
+		t.Fatalf("relayUnorphanedBlocks: %+v", err)
+	}
+}
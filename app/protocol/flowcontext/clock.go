@@ -0,0 +1,8 @@
+package flowcontext
+
+import "github.com/kaspanet/kaspad/util/mstime"
+
+// Clock returns the clock the flows read the current time from.
+func (f *FlowContext) Clock() mstime.Clock {
+	return f.clock
+}
@@ -3,7 +3,6 @@ package flowcontext
 import (
 	"github.com/kaspanet/kaspad/util/mstime"
 	"sync"
-	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 
@@ -39,6 +38,7 @@ type FlowContext struct {
 	domain            domain.Domain
 	addressManager    *addressmanager.AddressManager
 	connectionManager *connmanager.ConnectionManager
+	clock             mstime.Clock
 
 	timeStarted int64
 
@@ -48,7 +48,7 @@ type FlowContext struct {
 
 	transactionsToRebroadcastLock sync.Mutex
 	transactionsToRebroadcast     map[externalapi.DomainTransactionID]*externalapi.DomainTransaction
-	lastRebroadcastTime           time.Time
+	lastRebroadcastTime           mstime.Time
 	sharedRequestedTransactions   *transactionrelay.SharedRequestedTransactions
 
 	sharedRequestedBlocks *blockrelay.SharedRequestedBlocks
@@ -56,6 +56,9 @@ type FlowContext struct {
 	ibdPeer      *peerpkg.Peer
 	ibdPeerMutex sync.RWMutex
 
+	syncProgress      syncProgress
+	syncProgressMutex sync.Mutex
+
 	peers      map[id.ID]*peerpkg.Peer
 	peersMutex sync.RWMutex
 
@@ -67,7 +70,8 @@ type FlowContext struct {
 
 // New returns a new instance of FlowContext.
 func New(cfg *config.Config, domain domain.Domain, addressManager *addressmanager.AddressManager,
-	netAdapter *netadapter.NetAdapter, connectionManager *connmanager.ConnectionManager) *FlowContext {
+	netAdapter *netadapter.NetAdapter, connectionManager *connmanager.ConnectionManager,
+	clock mstime.Clock) *FlowContext {
 
 	return &FlowContext{
 		cfg:                         cfg,
@@ -75,12 +79,13 @@ func New(cfg *config.Config, domain domain.Domain, addressManager *addressmanage
 		domain:                      domain,
 		addressManager:              addressManager,
 		connectionManager:           connectionManager,
+		clock:                       clock,
 		sharedRequestedTransactions: transactionrelay.NewSharedRequestedTransactions(),
 		sharedRequestedBlocks:       blockrelay.NewSharedRequestedBlocks(),
 		peers:                       make(map[id.ID]*peerpkg.Peer),
 		transactionsToRebroadcast:   make(map[externalapi.DomainTransactionID]*externalapi.DomainTransaction),
 		orphans:                     make(map[externalapi.DomainHash]*externalapi.DomainBlock),
-		timeStarted:                 mstime.Now().UnixMilliseconds(),
+		timeStarted:                 clock.Now().UnixMilliseconds(),
 		shutdownChan:                make(chan struct{}),
 	}
 }
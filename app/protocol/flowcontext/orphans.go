@@ -21,12 +21,25 @@ type UnorphaningResult struct {
 	blockInsertionResult *externalapi.BlockInsertionResult
 }
 
-// AddOrphan adds the block to the orphan set
-func (f *FlowContext) AddOrphan(orphanBlock *externalapi.DomainBlock) {
+// AddOrphan adds the block to the orphan set. It returns false without adding the
+// block if all of its parents were added to the DAG in the meantime, since then the
+// unorphaning of its parents might have already happened, and nothing would ever
+// take it out of the orphan set.
+func (f *FlowContext) AddOrphan(orphanBlock *externalapi.DomainBlock) (bool, error) {
 	f.orphansMutex.Lock()
 	defer f.orphansMutex.Unlock()
 
 	orphanHash := consensushashing.BlockHash(orphanBlock)
+	hasMissingParents, err := f.hasMissingParents(orphanBlock)
+	if err != nil {
+		return false, err
+	}
+	if !hasMissingParents {
+		log.Debugf("All the parents of %s were added in the meantime, so it's not added to the orphan pool",
+			orphanHash)
+		return false, nil
+	}
+
 	f.orphans[*orphanHash] = orphanBlock
 
 	if len(f.orphans) > maxOrphans {
@@ -35,6 +48,20 @@ func (f *FlowContext) AddOrphan(orphanBlock *externalapi.DomainBlock) {
 	}
 
 	log.Infof("Received a block with missing parents, adding to orphan pool: %s", orphanHash)
+	return true, nil
+}
+
+func (f *FlowContext) hasMissingParents(block *externalapi.DomainBlock) (bool, error) {
+	for _, parentHash := range block.Header.ParentHashes() {
+		parentInfo, err := f.domain.Consensus().GetBlockInfo(parentHash)
+		if err != nil {
+			return false, err
+		}
+		if !parentInfo.Exists || parentInfo.BlockStatus == externalapi.StatusHeaderOnly {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (f *FlowContext) evictRandomOrphan() {
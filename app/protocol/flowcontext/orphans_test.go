@@ -0,0 +1,84 @@
+package flowcontext
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+)
+
+// fakeDomain is a domain.Domain over a test consensus, without a mining manager
+type fakeDomain struct {
+	tc testapi.TestConsensus
+}
+
+func (d fakeDomain) Consensus() externalapi.Consensus {
+	return d.tc
+}
+
+func (d fakeDomain) MiningManager() miningmanager.MiningManager {
+	return nil
+}
+
+func TestAddOrphanWithAddedParents(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestAddOrphanWithAddedParents")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		// Build a parent and its child, and keep the parent out of the DAG
+		parent, _, err := tc.BuildBlockWithParents([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("BuildBlockWithParents: %+v", err)
+		}
+		otherTC, otherTeardown, err := factory.NewTestConsensus(consensusConfig, "TestAddOrphanWithAddedParents")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer otherTeardown(false)
+		_, err = otherTC.ValidateAndInsertBlock(parent)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+		child, _, err := otherTC.BuildBlockWithParents([]*externalapi.DomainHash{consensushashing.BlockHash(parent)}, nil, nil)
+		if err != nil {
+			t.Fatalf("BuildBlockWithParents: %+v", err)
+		}
+		childHash := consensushashing.BlockHash(child)
+
+		flowContext := &FlowContext{
+			domain:  fakeDomain{tc: tc},
+			orphans: make(map[externalapi.DomainHash]*externalapi.DomainBlock),
+		}
+
+		wasAdded, err := flowContext.AddOrphan(child)
+		if err != nil {
+			t.Fatalf("AddOrphan: %+v", err)
+		}
+		if !wasAdded || !flowContext.IsOrphan(childHash) {
+			t.Fatalf("Expected a block with a missing parent to be added to the orphan set")
+		}
+
+		// Once the parent is in the DAG, nothing would unorphan the child, so
+		// it must not be added to the orphan set
+		delete(flowContext.orphans, *childHash)
+		_, err = tc.ValidateAndInsertBlock(parent)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+		wasAdded, err = flowContext.AddOrphan(child)
+		if err != nil {
+			t.Fatalf("AddOrphan: %+v", err)
+		}
+		if wasAdded || flowContext.IsOrphan(childHash) {
+			t.Fatalf("Expected a block whose parents were all added not to be added to the orphan set")
+		}
+	})
+}
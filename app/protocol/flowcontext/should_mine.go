@@ -1,7 +1,5 @@
 package flowcontext
 
-import "github.com/kaspanet/kaspad/util/mstime"
-
 const (
 	maxSelectedParentTimeDiffToAllowMiningInMilliSeconds = 60 * 60 * 1000 // 1 Hour
 )
@@ -30,7 +28,7 @@ func (f *FlowContext) ShouldMine() (bool, error) {
 		return false, err
 	}
 
-	now := mstime.Now().UnixMilliseconds()
+	now := f.clock.Now().UnixMilliseconds()
 	if now-virtualSelectedParentHeader.TimeInMilliseconds() < maxSelectedParentTimeDiffToAllowMiningInMilliSeconds {
 		log.Debugf("The selected tip timestamp is recent (%d), so ShouldMine returns true",
 			virtualSelectedParentHeader.TimeInMilliseconds())
@@ -0,0 +1,91 @@
+package flowcontext
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+// SyncStatus describes how far the node is in syncing with the network
+type SyncStatus struct {
+	// IsSynced is true when the node is connected to peers, is not in
+	// IBD and its selected tip is recent. It's the same condition under
+	// which the node hands out block templates for mining.
+	IsSynced     bool
+	IsIBDRunning bool
+
+	HeaderCount uint64
+	BlockCount  uint64
+
+	// EstimatedTimeRemaining is the time the current IBD is expected to
+	// take to process the blocks of all the headers it already has. It's
+	// zero when the node is not in IBD or when it can't be estimated yet,
+	// such as while only headers are being downloaded.
+	EstimatedTimeRemaining time.Duration
+
+	PeerCount int
+
+	// LastBlockTime is the time in which a block was last added to the
+	// DAG, whether it was received from a peer or submitted through RPC.
+	// It's zero if no block was added since the node started.
+	LastBlockTime mstime.Time
+}
+
+// syncProgress tracks the blocks that are added to the DAG, in order
+// to estimate how long it takes to sync
+type syncProgress struct {
+	lastBlockTime mstime.Time
+
+	ibdStartTime   mstime.Time
+	ibdAddedBlocks uint64
+}
+
+func (f *FlowContext) onIBDStarted() {
+	f.syncProgressMutex.Lock()
+	defer f.syncProgressMutex.Unlock()
+
+	f.syncProgress.ibdStartTime = f.clock.Now()
+	f.syncProgress.ibdAddedBlocks = 0
+}
+
+func (f *FlowContext) onBlocksAdded(count int) {
+	isIBDRunning := f.IsIBDRunning()
+
+	f.syncProgressMutex.Lock()
+	defer f.syncProgressMutex.Unlock()
+
+	f.syncProgress.lastBlockTime = f.clock.Now()
+	if isIBDRunning {
+		f.syncProgress.ibdAddedBlocks += uint64(count)
+	}
+}
+
+// SyncStatus returns the current SyncStatus of the node
+func (f *FlowContext) SyncStatus() (*SyncStatus, error) {
+	isSynced, err := f.ShouldMine()
+	if err != nil {
+		return nil, err
+	}
+	syncInfo, err := f.domain.Consensus().GetSyncInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SyncStatus{
+		IsSynced:     isSynced,
+		IsIBDRunning: f.IsIBDRunning(),
+		HeaderCount:  syncInfo.HeaderCount,
+		BlockCount:   syncInfo.BlockCount,
+		PeerCount:    len(f.Peers()),
+	}
+
+	f.syncProgressMutex.Lock()
+	defer f.syncProgressMutex.Unlock()
+
+	status.LastBlockTime = f.syncProgress.lastBlockTime
+	if status.IsIBDRunning && f.syncProgress.ibdAddedBlocks > 0 && status.HeaderCount > status.BlockCount {
+		timePerBlock := f.clock.Now().Sub(f.syncProgress.ibdStartTime) / time.Duration(f.syncProgress.ibdAddedBlocks)
+		status.EstimatedTimeRemaining = time.Duration(status.HeaderCount-status.BlockCount) * timePerBlock
+	}
+	return status, nil
+}
@@ -3,7 +3,6 @@ package flowcontext
 import (
 	"time"
 
-	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol/flows/transactionrelay"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
@@ -21,8 +20,24 @@ func (f *FlowContext) AddTransaction(tx *externalapi.DomainTransaction) error {
 
 	transactionID := consensushashing.TransactionID(tx)
 	f.transactionsToRebroadcast[*transactionID] = tx
-	inv := appmessage.NewMsgInvTransaction([]*externalapi.DomainTransactionID{transactionID})
-	return f.Broadcast(inv)
+	f.EnqueueTransactionIDsForPropagation([]*externalapi.DomainTransactionID{transactionID})
+	return nil
+}
+
+// EnqueueTransactionIDsForPropagation queues the given transactions to be
+// announced to all the ready peers that don't already know about them.
+// The announcements themselves are batched and sent by the
+// SendTransactionInvs flow of every peer.
+func (f *FlowContext) EnqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID) {
+	f.enqueueTransactionIDsForPropagation(transactionIDs, false)
+}
+
+func (f *FlowContext) enqueueTransactionIDsForPropagation(
+	transactionIDs []*externalapi.DomainTransactionID, ignoreKnown bool) {
+
+	for _, peer := range f.Peers() {
+		peer.QueueTransactionInventory(transactionIDs, ignoreKnown)
+	}
 }
 
 func (f *FlowContext) updateTransactionsToRebroadcast(addedBlocks []*externalapi.DomainBlock) {
@@ -41,13 +56,15 @@ func (f *FlowContext) updateTransactionsToRebroadcast(addedBlocks []*externalapi
 
 func (f *FlowContext) shouldRebroadcastTransactions() bool {
 	const rebroadcastInterval = 30 * time.Second
-	return time.Since(f.lastRebroadcastTime) > rebroadcastInterval
+	return f.clock.Now().Sub(f.lastRebroadcastTime) > rebroadcastInterval
 }
 
 func (f *FlowContext) txIDsToRebroadcast() []*externalapi.DomainTransactionID {
 	f.transactionsToRebroadcastLock.Lock()
 	defer f.transactionsToRebroadcastLock.Unlock()
 
+	f.lastRebroadcastTime = f.clock.Now()
+
 	txIDs := make([]*externalapi.DomainTransactionID, len(f.transactionsToRebroadcast))
 	i := 0
 	for _, tx := range f.transactionsToRebroadcast {
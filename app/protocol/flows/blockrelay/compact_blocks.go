@@ -0,0 +1,195 @@
+package blockrelay
+
+import (
+	"encoding/binary"
+	"hash"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/util/random"
+	"golang.org/x/crypto/blake2b"
+)
+
+// shortTransactionIDSize is the number of bytes of a transaction
+// ID hash that are used as its short ID
+const shortTransactionIDSize = 6
+
+// shortTransactionIDHasher computes the short IDs of the transactions in a
+// single compact block. Short IDs are salted with the block hash and a random
+// salt, so that an attacker can't craft transactions whose short IDs collide
+// across all the blocks in the network.
+type shortTransactionIDHasher struct {
+	hasher hash.Hash
+}
+
+func newShortTransactionIDHasher(blockHash *externalapi.DomainHash, salt uint64) *shortTransactionIDHasher {
+	var key [externalapi.DomainHashSize + 8]byte
+	copy(key[:], blockHash.ByteSlice())
+	binary.LittleEndian.PutUint64(key[externalapi.DomainHashSize:], salt)
+
+	hasher, err := blake2b.New256(key[:])
+	if err != nil {
+		// blake2b.New256 only fails for keys longer than 64 bytes
+		panic(err)
+	}
+	return &shortTransactionIDHasher{hasher: hasher}
+}
+
+func (h *shortTransactionIDHasher) shortID(transactionID *externalapi.DomainTransactionID) uint64 {
+	h.hasher.Reset()
+	h.hasher.Write(transactionID.ByteSlice())
+	digest := h.hasher.Sum(nil)
+
+	var shortIDBytes [8]byte
+	copy(shortIDBytes[:], digest[:shortTransactionIDSize])
+	return binary.LittleEndian.Uint64(shortIDBytes[:])
+}
+
+// buildCompactBlock converts the given block to a compact block.
+// The coinbase transaction is always prefilled, since the receiver
+// can't possibly have it in its mempool.
+func buildCompactBlock(block *externalapi.DomainBlock) (*appmessage.MsgCompactBlock, error) {
+	salt, err := random.Uint64()
+	if err != nil {
+		return nil, err
+	}
+	hasher := newShortTransactionIDHasher(consensushashing.BlockHash(block), salt)
+
+	prefilledTransactions := make([]*appmessage.PrefilledTransaction, 0, 1)
+	shortIDs := make([]uint64, 0, len(block.Transactions))
+	for i, transaction := range block.Transactions {
+		if i == 0 {
+			prefilledTransactions = append(prefilledTransactions, &appmessage.PrefilledTransaction{
+				Index:       uint32(i),
+				Transaction: appmessage.DomainTransactionToMsgTx(transaction),
+			})
+			continue
+		}
+		shortIDs = append(shortIDs, hasher.shortID(consensushashing.TransactionID(transaction)))
+	}
+
+	header := appmessage.DomainBlockHeaderToBlockHeader(block.Header)
+	return appmessage.NewMsgCompactBlock(header, salt, shortIDs, prefilledTransactions), nil
+}
+
+// reconstructCompactBlock rebuilds the block out of the given compact block,
+// using transactions from the mempool and requesting any missing
+// transactions from the peer
+func (flow *handleRelayInvsFlow) reconstructCompactBlock(blockHash *externalapi.DomainHash,
+	compactBlock *appmessage.MsgCompactBlock) (*externalapi.DomainBlock, error) {
+
+	header := appmessage.BlockHeaderToDomainBlockHeader(&compactBlock.Header)
+	transactionCount := len(compactBlock.ShortIDs) + len(compactBlock.PrefilledTransactions)
+	transactions := make([]*externalapi.DomainTransaction, transactionCount)
+
+	lastPrefilledIndex := -1
+	for _, prefilledTransaction := range compactBlock.PrefilledTransactions {
+		index := int(prefilledTransaction.Index)
+		if index <= lastPrefilledIndex || index >= transactionCount {
+			return nil, protocolerrors.Errorf(true, "compact block %s has a prefilled transaction "+
+				"with an invalid index %d", blockHash, index)
+		}
+		transactions[index] = appmessage.MsgTxToDomainTransaction(prefilledTransaction.Transaction)
+		lastPrefilledIndex = index
+	}
+
+	mempoolTransactionsByShortID := flow.mempoolTransactionsByShortID(blockHash, compactBlock.ShortIDSalt)
+	missingIndexes := make([]uint32, 0)
+	nonPrefilledIndexes := make([]uint32, 0, len(compactBlock.ShortIDs))
+	shortIDIndex := 0
+	for i := range transactions {
+		if transactions[i] != nil {
+			continue
+		}
+		nonPrefilledIndexes = append(nonPrefilledIndexes, uint32(i))
+
+		transaction := mempoolTransactionsByShortID[compactBlock.ShortIDs[shortIDIndex]]
+		shortIDIndex++
+		if transaction == nil {
+			missingIndexes = append(missingIndexes, uint32(i))
+			continue
+		}
+		transactions[i] = transaction
+	}
+
+	log.Debugf("Reconstructing compact block %s: %d out of %d transactions are missing",
+		blockHash, len(missingIndexes), transactionCount)
+	err := flow.requestBlockTransactions(blockHash, transactions, missingIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	// A short ID collision, or a mempool transaction that differs from the
+	// block transaction only in its signature, results in a different block.
+	// In that case fall back to requesting all the non-prefilled transactions
+	// rather than reject the block and ban the peer.
+	if !merkle.CalculateHashMerkleRoot(transactions).Equal(header.HashMerkleRoot()) {
+		log.Debugf("Compact block %s was reconstructed incorrectly. "+
+			"Requesting all of its transactions", blockHash)
+		err := flow.requestBlockTransactions(blockHash, transactions, nonPrefilledIndexes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &externalapi.DomainBlock{
+		Header:       header,
+		Transactions: transactions,
+	}, nil
+}
+
+// mempoolTransactionsByShortID maps the short IDs of all the mempool transactions
+// to their transactions. Short IDs that belong to more than one transaction
+// are mapped to nil, so that those transactions are requested from the peer.
+func (flow *handleRelayInvsFlow) mempoolTransactionsByShortID(
+	blockHash *externalapi.DomainHash, salt uint64) map[uint64]*externalapi.DomainTransaction {
+
+	hasher := newShortTransactionIDHasher(blockHash, salt)
+	mempoolTransactions := flow.Domain().MiningManager().AllTransactions()
+	transactionsByShortID := make(map[uint64]*externalapi.DomainTransaction, len(mempoolTransactions))
+	for _, transaction := range mempoolTransactions {
+		shortID := hasher.shortID(consensushashing.TransactionID(transaction))
+		if _, ok := transactionsByShortID[shortID]; ok {
+			transactionsByShortID[shortID] = nil
+			continue
+		}
+		transactionsByShortID[shortID] = transaction
+	}
+	return transactionsByShortID
+}
+
+// requestBlockTransactions requests the transactions in the given indexes of
+// the given block from the peer, and fills them into transactions
+func (flow *handleRelayInvsFlow) requestBlockTransactions(blockHash *externalapi.DomainHash,
+	transactions []*externalapi.DomainTransaction, indexes []uint32) error {
+
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	err := flow.outgoingRoute.Enqueue(appmessage.NewMsgRequestBlockTransactions(blockHash, indexes))
+	if err != nil {
+		return err
+	}
+
+	msgBlockTransactions, err := flow.readMsgBlockTransactions()
+	if err != nil {
+		return err
+	}
+	if !msgBlockTransactions.BlockHash.Equal(blockHash) {
+		return protocolerrors.Errorf(true, "got transactions of block %s while expecting "+
+			"transactions of block %s", msgBlockTransactions.BlockHash, blockHash)
+	}
+	if len(msgBlockTransactions.Transactions) != len(indexes) {
+		return protocolerrors.Errorf(true, "got %d transactions of block %s while %d were requested",
+			len(msgBlockTransactions.Transactions), blockHash, len(indexes))
+	}
+
+	for i, index := range indexes {
+		transactions[index] = appmessage.MsgTxToDomainTransaction(msgBlockTransactions.Transactions[i])
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package blockrelay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// fakeCompactBlocksContext is the context of both the sender of a compact block,
+// which has the block, and of its receiver, which has mempoolTransactions
+type fakeCompactBlocksContext struct {
+	RelayInvsContext
+	block               *externalapi.DomainBlock
+	mempoolTransactions []*externalapi.DomainTransaction
+}
+
+func (f *fakeCompactBlocksContext) Domain() domain.Domain {
+	return &fakeCompactBlocksDomain{context: f}
+}
+
+type fakeCompactBlocksDomain struct {
+	domain.Domain
+	context *fakeCompactBlocksContext
+}
+
+func (f *fakeCompactBlocksDomain) Consensus() externalapi.Consensus {
+	return &fakeCompactBlocksConsensus{block: f.context.block}
+}
+
+func (f *fakeCompactBlocksDomain) MiningManager() miningmanager.MiningManager {
+	return &fakeCompactBlocksMiningManager{transactions: f.context.mempoolTransactions}
+}
+
+type fakeCompactBlocksConsensus struct {
+	externalapi.Consensus
+	block *externalapi.DomainBlock
+}
+
+func (f *fakeCompactBlocksConsensus) GetBlockInfo(blockHash *externalapi.DomainHash) (*externalapi.BlockInfo, error) {
+	if !blockHash.Equal(consensushashing.BlockHash(f.block)) {
+		return &externalapi.BlockInfo{}, nil
+	}
+	return &externalapi.BlockInfo{Exists: true, BlockStatus: externalapi.StatusUTXOValid}, nil
+}
+
+func (f *fakeCompactBlocksConsensus) GetBlock(*externalapi.DomainHash) (*externalapi.DomainBlock, error) {
+	return f.block, nil
+}
+
+type fakeCompactBlocksMiningManager struct {
+	miningmanager.MiningManager
+	transactions []*externalapi.DomainTransaction
+}
+
+func (f *fakeCompactBlocksMiningManager) AllTransactions() []*externalapi.DomainTransaction {
+	return f.transactions
+}
+
+func compactBlocksTestBlock(transactionCount int) *externalapi.DomainBlock {
+	transactions := make([]*externalapi.DomainTransaction, transactionCount)
+	transactions[0] = &externalapi.DomainTransaction{
+		Outputs:      []*externalapi.DomainTransactionOutput{{Value: 1, ScriptPublicKey: &externalapi.ScriptPublicKey{}}},
+		SubnetworkID: subnetworks.SubnetworkIDCoinbase,
+		Payload:      []byte{1, 2, 3},
+	}
+	for i := 1; i < transactionCount; i++ {
+		transactions[i] = &externalapi.DomainTransaction{
+			Inputs: []*externalapi.DomainTransactionInput{{
+				PreviousOutpoint: externalapi.DomainOutpoint{
+					TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{byte(i)}),
+				},
+				SignatureScript: []byte{byte(i)},
+			}},
+			Outputs:      []*externalapi.DomainTransactionOutput{{Value: uint64(i), ScriptPublicKey: &externalapi.ScriptPublicKey{}}},
+			SubnetworkID: subnetworks.SubnetworkIDNative,
+		}
+	}
+
+	return &externalapi.DomainBlock{
+		Header: blockheader.NewImmutableBlockHeader(
+			constants.MaxBlockVersion,
+			[]*externalapi.DomainHash{externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})},
+			merkle.CalculateHashMerkleRoot(transactions),
+			&externalapi.DomainHash{},
+			&externalapi.DomainHash{},
+			0,
+			0,
+			0,
+		),
+		Transactions: transactions,
+	}
+}
+
+func checkReconstructedBlock(t *testing.T, expected, reconstructed *externalapi.DomainBlock) {
+	if !consensushashing.BlockHash(reconstructed).Equal(consensushashing.BlockHash(expected)) {
+		t.Fatalf("The reconstructed block has a different hash than the original block")
+	}
+	if len(reconstructed.Transactions) != len(expected.Transactions) {
+		t.Fatalf("Expected %d transactions, but got %d", len(expected.Transactions), len(reconstructed.Transactions))
+	}
+	for i, transaction := range reconstructed.Transactions {
+		if !consensushashing.TransactionID(transaction).Equal(consensushashing.TransactionID(expected.Transactions[i])) {
+			t.Fatalf("Transaction %d of the reconstructed block differs from the original", i)
+		}
+	}
+}
+
+func TestCompactBlockFromMempool(t *testing.T) {
+	block := compactBlocksTestBlock(4)
+	blockHash := consensushashing.BlockHash(block)
+	compactBlock, err := buildCompactBlock(block)
+	if err != nil {
+		t.Fatalf("buildCompactBlock: %+v", err)
+	}
+	if len(compactBlock.PrefilledTransactions) != 1 || len(compactBlock.ShortIDs) != 3 {
+		t.Fatalf("Expected only the coinbase to be prefilled, but got %d prefilled transactions and %d short IDs",
+			len(compactBlock.PrefilledTransactions), len(compactBlock.ShortIDs))
+	}
+
+	// All the transactions except for the coinbase are in the mempool,
+	// so none are requested from the peer
+	outgoingRoute := router.NewRoute()
+	flow := &handleRelayInvsFlow{
+		RelayInvsContext: &fakeCompactBlocksContext{mempoolTransactions: block.Transactions[1:]},
+		incomingRoute:    router.NewRoute(),
+		outgoingRoute:    outgoingRoute,
+	}
+	reconstructed, err := flow.reconstructCompactBlock(blockHash, compactBlock)
+	if err != nil {
+		t.Fatalf("reconstructCompactBlock: %+v", err)
+	}
+	checkReconstructedBlock(t, block, reconstructed)
+
+	_, err = outgoingRoute.DequeueWithTimeout(time.Millisecond)
+	if err == nil {
+		t.Fatalf("Expected no transactions to be requested")
+	}
+}
+
+func TestCompactBlockWithMissingTransactions(t *testing.T) {
+	block := compactBlocksTestBlock(4)
+	blockHash := consensushashing.BlockHash(block)
+	compactBlock, err := buildCompactBlock(block)
+	if err != nil {
+		t.Fatalf("buildCompactBlock: %+v", err)
+	}
+
+	// The mempool of the receiver is missing the transaction at index 2,
+	// so it's requested from the sender
+	receiverIncomingRoute := router.NewRoute()
+	receiverOutgoingRoute := router.NewRoute()
+	flow := &handleRelayInvsFlow{
+		RelayInvsContext: &fakeCompactBlocksContext{
+			mempoolTransactions: []*externalapi.DomainTransaction{block.Transactions[1], block.Transactions[3]},
+		},
+		incomingRoute: receiverIncomingRoute,
+		outgoingRoute: receiverOutgoingRoute,
+	}
+
+	senderErrChan := make(chan error, 1)
+	go func() {
+		message, err := receiverOutgoingRoute.DequeueWithTimeout(time.Second)
+		if err != nil {
+			senderErrChan <- err
+			return
+		}
+		requestBlockTransactions := message.(*appmessage.MsgRequestBlockTransactions)
+		if len(requestBlockTransactions.Indexes) != 1 || requestBlockTransactions.Indexes[0] != 2 {
+			t.Errorf("Expected only transaction 2 to be requested, but got %v", requestBlockTransactions.Indexes)
+		}
+		senderContext := &fakeCompactBlocksContext{block: block}
+		senderErrChan <- sendBlockTransactions(senderContext, receiverIncomingRoute, requestBlockTransactions)
+	}()
+
+	reconstructed, err := flow.reconstructCompactBlock(blockHash, compactBlock)
+	if err != nil {
+		t.Fatalf("reconstructCompactBlock: %+v", err)
+	}
+	err = <-senderErrChan
+	if err != nil {
+		t.Fatalf("sendBlockTransactions: %+v", err)
+	}
+	checkReconstructedBlock(t, block, reconstructed)
+}
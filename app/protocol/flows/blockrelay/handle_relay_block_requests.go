@@ -16,7 +16,9 @@ type RelayBlockRequestsContext interface {
 }
 
 // HandleRelayBlockRequests listens to appmessage.MsgRequestRelayBlocks messages and sends
-// their corresponding blocks to the requesting peer.
+// their corresponding blocks to the requesting peer. Blocks are sent as compact blocks
+// to peers that support them, in which case appmessage.MsgRequestBlockTransactions
+// messages for the transactions the peer is missing are answered as well.
 func HandleRelayBlockRequests(context RelayBlockRequestsContext, incomingRoute *router.Route,
 	outgoingRoute *router.Route, peer *peerpkg.Peer) error {
 
@@ -25,29 +27,86 @@ func HandleRelayBlockRequests(context RelayBlockRequestsContext, incomingRoute *
 		if err != nil {
 			return err
 		}
-		getRelayBlocksMessage := message.(*appmessage.MsgRequestRelayBlocks)
-		log.Debugf("Got request for relay blocks with hashes %s", getRelayBlocksMessage.Hashes)
-		for _, hash := range getRelayBlocksMessage.Hashes {
-			// Fetch the block from the database.
-			blockInfo, err := context.Domain().Consensus().GetBlockInfo(hash)
+
+		switch message := message.(type) {
+		case *appmessage.MsgRequestRelayBlocks:
+			err = sendRelayBlocks(context, outgoingRoute, peer, message)
+		case *appmessage.MsgRequestBlockTransactions:
+			err = sendBlockTransactions(context, outgoingRoute, message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func sendRelayBlocks(context RelayBlockRequestsContext, outgoingRoute *router.Route, peer *peerpkg.Peer,
+	getRelayBlocksMessage *appmessage.MsgRequestRelayBlocks) error {
+
+	log.Debugf("Got request for relay blocks with hashes %s", getRelayBlocksMessage.Hashes)
+	for _, hash := range getRelayBlocksMessage.Hashes {
+		block, err := getBlockWithBody(context, hash)
+		if err != nil {
+			return err
+		}
+
+		// TODO (Partial nodes): Convert block to partial block if needed
+
+		if peer.SupportsCompactBlocks() {
+			compactBlock, err := buildCompactBlock(block)
 			if err != nil {
 				return err
 			}
-			if !blockInfo.Exists || blockInfo.BlockStatus == externalapi.StatusHeaderOnly {
-				return protocolerrors.Errorf(true, "block %s not found", hash)
-			}
-			block, err := context.Domain().Consensus().GetBlock(hash)
+			err = outgoingRoute.Enqueue(compactBlock)
 			if err != nil {
-				return errors.Wrapf(err, "unable to fetch requested block hash %s", hash)
+				return err
 			}
+			log.Debugf("Relayed compact block with hash %s", hash)
+			continue
+		}
 
-			// TODO (Partial nodes): Convert block to partial block if needed
+		err = outgoingRoute.Enqueue(appmessage.DomainBlockToMsgBlock(block))
+		if err != nil {
+			return err
+		}
+		log.Debugf("Relayed block with hash %s", hash)
+	}
+	return nil
+}
 
-			err = outgoingRoute.Enqueue(appmessage.DomainBlockToMsgBlock(block))
-			if err != nil {
-				return err
-			}
-			log.Debugf("Relayed block with hash %s", hash)
+func sendBlockTransactions(context RelayBlockRequestsContext, outgoingRoute *router.Route,
+	requestBlockTransactionsMessage *appmessage.MsgRequestBlockTransactions) error {
+
+	hash := requestBlockTransactionsMessage.BlockHash
+	log.Debugf("Got request for %d transactions of block %s", len(requestBlockTransactionsMessage.Indexes), hash)
+	block, err := getBlockWithBody(context, hash)
+	if err != nil {
+		return err
+	}
+
+	transactions := make([]*appmessage.MsgTx, len(requestBlockTransactionsMessage.Indexes))
+	for i, index := range requestBlockTransactionsMessage.Indexes {
+		if int(index) >= len(block.Transactions) {
+			return protocolerrors.Errorf(true, "requested transaction %d of block %s "+
+				"which has only %d transactions", index, hash, len(block.Transactions))
 		}
+		transactions[i] = appmessage.DomainTransactionToMsgTx(block.Transactions[index])
+	}
+	return outgoingRoute.Enqueue(appmessage.NewMsgBlockTransactions(hash, transactions))
+}
+
+func getBlockWithBody(context RelayBlockRequestsContext, hash *externalapi.DomainHash) (*externalapi.DomainBlock, error) {
+	// Fetch the block from the database.
+	blockInfo, err := context.Domain().Consensus().GetBlockInfo(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !blockInfo.Exists || blockInfo.BlockStatus == externalapi.StatusHeaderOnly {
+		return nil, protocolerrors.Errorf(true, "block %s not found", hash)
+	}
+	block, err := context.Domain().Consensus().GetBlock(hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch requested block hash %s", hash)
 	}
+	return block, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
 )
@@ -27,10 +28,11 @@ type RelayInvsContext interface {
 	OnPruningPointUTXOSetOverride() error
 	SharedRequestedBlocks() *SharedRequestedBlocks
 	Broadcast(message appmessage.Message) error
-	AddOrphan(orphanBlock *externalapi.DomainBlock)
+	AddOrphan(orphanBlock *externalapi.DomainBlock) (bool, error)
 	GetOrphanRoots(orphanHash *externalapi.DomainHash) ([]*externalapi.DomainHash, bool, error)
 	IsOrphan(blockHash *externalapi.DomainHash) bool
 	IsIBDRunning() bool
+	Peers() []*peerpkg.Peer
 	TrySetIBDRunning(ibdPeer *peerpkg.Peer) bool
 	UnsetIBDRunning()
 }
@@ -138,7 +140,7 @@ func (flow *handleRelayInvsFlow) start() error {
 		if err != nil {
 			return err
 		}
-		log.Infof("Accepted block %s via relay", inv.Hash)
+		log.WithFields(logger.Fields{"blockHash": inv.Hash}).Infof("Accepted block %s via relay", inv.Hash)
 		err = flow.OnNewBlock(block, blockInsertionResult)
 		if err != nil {
 			return err
@@ -191,12 +193,28 @@ func (flow *handleRelayInvsFlow) requestBlock(requestHash *externalapi.DomainHas
 		return nil, false, err
 	}
 
-	msgBlock, err := flow.readMsgBlock()
+	message, err := flow.readMsgBlockOrCompactBlock()
 	if err != nil {
 		return nil, false, err
 	}
 
-	block := appmessage.MsgBlockToDomainBlock(msgBlock)
+	var block *externalapi.DomainBlock
+	switch message := message.(type) {
+	case *appmessage.MsgBlock:
+		block = appmessage.MsgBlockToDomainBlock(message)
+	case *appmessage.MsgCompactBlock:
+		// The hash is checked before reconstruction, so that transactions are
+		// never requested for an unrequested block
+		compactBlockHash := consensushashing.HeaderHash(appmessage.BlockHeaderToDomainBlockHeader(&message.Header))
+		if !compactBlockHash.Equal(requestHash) {
+			return nil, false, protocolerrors.Errorf(true, "got unrequested block %s", compactBlockHash)
+		}
+		block, err = flow.reconstructCompactBlock(requestHash, message)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	blockHash := consensushashing.BlockHash(block)
 	if !blockHash.Equal(requestHash) {
 		return nil, false, protocolerrors.Errorf(true, "got unrequested block %s", blockHash)
@@ -205,10 +223,12 @@ func (flow *handleRelayInvsFlow) requestBlock(requestHash *externalapi.DomainHas
 	return block, false, nil
 }
 
-// readMsgBlock returns the next msgBlock in msgChan, and populates invsQueue with any inv messages that meanwhile arrive.
+// readMsgBlockOrCompactBlock returns the next msgBlock or msgCompactBlock in msgChan, and populates
+// invsQueue with any inv messages that meanwhile arrive.
 //
-// Note: this function assumes msgChan can contain only appmessage.MsgInvRelayBlock and appmessage.MsgBlock messages.
-func (flow *handleRelayInvsFlow) readMsgBlock() (msgBlock *appmessage.MsgBlock, err error) {
+// Note: this function assumes msgChan can contain only appmessage.MsgInvRelayBlock, appmessage.MsgBlock
+// and appmessage.MsgCompactBlock messages.
+func (flow *handleRelayInvsFlow) readMsgBlockOrCompactBlock() (appmessage.Message, error) {
 	for {
 		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
 		if err != nil {
@@ -218,7 +238,27 @@ func (flow *handleRelayInvsFlow) readMsgBlock() (msgBlock *appmessage.MsgBlock,
 		switch message := message.(type) {
 		case *appmessage.MsgInvRelayBlock:
 			flow.invsQueue = append(flow.invsQueue, message)
-		case *appmessage.MsgBlock:
+		case *appmessage.MsgBlock, *appmessage.MsgCompactBlock:
+			return message, nil
+		default:
+			return nil, errors.Errorf("unexpected message %s", message.Command())
+		}
+	}
+}
+
+// readMsgBlockTransactions returns the next msgBlockTransactions in msgChan, and populates invsQueue
+// with any inv messages that meanwhile arrive.
+func (flow *handleRelayInvsFlow) readMsgBlockTransactions() (*appmessage.MsgBlockTransactions, error) {
+	for {
+		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		switch message := message.(type) {
+		case *appmessage.MsgInvRelayBlock:
+			flow.invsQueue = append(flow.invsQueue, message)
+		case *appmessage.MsgBlockTransactions:
 			return message, nil
 		default:
 			return nil, errors.Errorf("unexpected message %s", message.Command())
@@ -266,7 +306,17 @@ func (flow *handleRelayInvsFlow) processOrphan(block *externalapi.DomainBlock, m
 	if isBlockInOrphanResolutionRange {
 		log.Debugf("Block %s is within orphan resolution range. "+
 			"Adding it to the orphan set", blockHash)
-		flow.AddOrphan(block)
+		wasAdded, err := flow.AddOrphan(block)
+		if err != nil {
+			return err
+		}
+		if !wasAdded {
+			// The missing parents were added while the block was being processed,
+			// so the block is requested again and processed as a regular block
+			log.Debugf("Requesting block %s again since its missing parents were added", blockHash)
+			flow.invsQueue = append([]*appmessage.MsgInvRelayBlock{appmessage.NewMsgInvBlock(blockHash)}, flow.invsQueue...)
+			return nil
+		}
 		log.Debugf("Requesting block %s missing ancestors", blockHash)
 		return flow.AddOrphanRootsToQueue(blockHash)
 	}
@@ -18,6 +18,16 @@ import (
 )
 
 func (flow *handleRelayInvsFlow) runIBDIfNotRunning(highHash *externalapi.DomainHash) error {
+	shouldWaitForArchivalPeer, err := flow.shouldWaitForArchivalPeer()
+	if err != nil {
+		return err
+	}
+	if shouldWaitForArchivalPeer {
+		log.Debugf("Skipping IBD with non-archival peer %s since the block history "+
+			"is needed and an archival peer is connected", flow.peer)
+		return nil
+	}
+
 	wasIBDNotRunning := flow.TrySetIBDRunning(flow.peer)
 	if !wasIBDNotRunning {
 		log.Debugf("IBD is already running")
@@ -59,6 +69,46 @@ func (flow *handleRelayInvsFlow) runIBDIfNotRunning(highHash *externalapi.Domain
 	return nil
 }
 
+// maxArchivalPeerWait is how long after connecting to a non-archival peer an
+// archival node that hasn't synced anything yet keeps waiting for an archival
+// peer before it runs IBD with the non-archival peer. Archival peers announce
+// their selected tip as soon as they connect, so an archival peer that has
+// blocks to sync is expected to start IBD well within that time
+const maxArchivalPeerWait = time.Minute
+
+// shouldWaitForArchivalPeer returns whether IBD with the peer should be
+// skipped in favor of an archival peer. An archival node that hasn't synced
+// anything yet needs the full block history, which non-archival peers can't
+// serve below their pruning point. Non-archival peers are still used when no
+// archival peer is connected, or once maxArchivalPeerWait has passed since
+// connecting to them, in case the connected archival peers have nothing
+// to sync from.
+func (flow *handleRelayInvsFlow) shouldWaitForArchivalPeer() (bool, error) {
+	if !flow.Config().IsArchivalNode || flow.peer.IsArchival() {
+		return false, nil
+	}
+	if flow.peer.TimeConnected() >= maxArchivalPeerWait {
+		return false, nil
+	}
+
+	virtualSelectedParent, err := flow.Domain().Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return false, err
+	}
+	if !virtualSelectedParent.Equal(flow.Config().ActiveNetParams.GenesisHash) {
+		return false, nil
+	}
+
+	for _, peer := range flow.Peers() {
+		if peer.IsArchival() {
+			return true, nil
+		}
+	}
+	log.Infof("No archival peer is connected, so the block history below the "+
+		"pruning point of %s will not be available", flow.peer)
+	return false, nil
+}
+
 // syncHeaders attempts to sync headers from the peer. This method may fail
 // because the peer and us have conflicting pruning points. In that case we
 // return (false, nil) so that we may stop IBD gracefully.
@@ -0,0 +1,104 @@
+package blockrelay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+// fakeArchivalRelayInvsContext implements only the parts of RelayInvsContext
+// that shouldWaitForArchivalPeer uses
+type fakeArchivalRelayInvsContext struct {
+	RelayInvsContext
+	config *config.Config
+	peers  []*peerpkg.Peer
+}
+
+func (f *fakeArchivalRelayInvsContext) Config() *config.Config {
+	return f.config
+}
+
+func (f *fakeArchivalRelayInvsContext) Domain() domain.Domain {
+	return &fakeGenesisDomain{consensus: &fakeGenesisConsensus{genesisHash: f.config.ActiveNetParams.GenesisHash}}
+}
+
+func (f *fakeArchivalRelayInvsContext) Peers() []*peerpkg.Peer {
+	return f.peers
+}
+
+// fakeGenesisDomain is the domain of a node that hasn't synced anything yet
+type fakeGenesisDomain struct {
+	domain.Domain
+	consensus externalapi.Consensus
+}
+
+func (f *fakeGenesisDomain) Consensus() externalapi.Consensus {
+	return f.consensus
+}
+
+type fakeGenesisConsensus struct {
+	externalapi.Consensus
+	genesisHash *externalapi.DomainHash
+}
+
+func (f *fakeGenesisConsensus) GetVirtualSelectedParent() (*externalapi.DomainHash, error) {
+	return f.genesisHash, nil
+}
+
+func TestShouldWaitForArchivalPeerFallback(t *testing.T) {
+	clock := mstime.NewFakeClock(mstime.Now())
+	newPeer := func(services appmessage.ServiceFlag) *peerpkg.Peer {
+		peer := peerpkg.New(nil, clock)
+		peer.UpdateFieldsFromMsgVersion(&appmessage.MsgVersion{Services: services, Timestamp: clock.Now()})
+		return peer
+	}
+	archivalPeer := newPeer(appmessage.SFNodeArchival)
+	nonArchivalPeer := newPeer(0)
+
+	cfg := &config.Config{Flags: &config.Flags{IsArchivalNode: true}}
+	cfg.ActiveNetParams = &dagconfig.SimnetParams
+	flow := &handleRelayInvsFlow{
+		RelayInvsContext: &fakeArchivalRelayInvsContext{
+			config: cfg,
+			peers:  []*peerpkg.Peer{archivalPeer, nonArchivalPeer},
+		},
+		peer: nonArchivalPeer,
+	}
+
+	shouldWait, err := flow.shouldWaitForArchivalPeer()
+	if err != nil {
+		t.Fatalf("shouldWaitForArchivalPeer: %+v", err)
+	}
+	if !shouldWait {
+		t.Fatalf("Expected to wait for the archival peer right after connecting to the non-archival peer")
+	}
+
+	// The archival peer never started IBD, so the non-archival
+	// peer is used once maxArchivalPeerWait has passed
+	clock.Advance(maxArchivalPeerWait)
+	shouldWait, err = flow.shouldWaitForArchivalPeer()
+	if err != nil {
+		t.Fatalf("shouldWaitForArchivalPeer: %+v", err)
+	}
+	if shouldWait {
+		t.Fatalf("Expected to stop waiting for the archival peer after %s", maxArchivalPeerWait)
+	}
+
+	// A peer that connected later is waited on for its own maxArchivalPeerWait
+	flow.peer = newPeer(0)
+	clock.Advance(maxArchivalPeerWait - time.Second)
+	shouldWait, err = flow.shouldWaitForArchivalPeer()
+	if err != nil {
+		t.Fatalf("shouldWaitForArchivalPeer: %+v", err)
+	}
+	if !shouldWait {
+		t.Fatalf("Expected to wait for the archival peer before %s passed", maxArchivalPeerWait)
+	}
+}
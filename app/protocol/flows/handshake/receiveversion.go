@@ -97,6 +97,13 @@ func (flow *receiveVersionFlow) start() (*appmessage.NetAddress, error) {
 		return nil, protocolerrors.New(false, "incompatible subnetworks")
 	}
 
+	// Disconnect outbound peers that don't provide the services we require
+	// from the nodes we connect to
+	if isOutbound && msgVersion.Services&defaultRequiredServices != defaultRequiredServices {
+		return nil, protocolerrors.Errorf(false, "outbound peer does not provide the required "+
+			"services %s (advertised %s)", defaultRequiredServices, msgVersion.Services)
+	}
+
 	flow.peer.UpdateFieldsFromMsgVersion(msgVersion)
 	err = flow.outgoingRoute.Enqueue(appmessage.NewMsgVerAck())
 	if err != nil {
@@ -105,5 +112,10 @@ func (flow *receiveVersionFlow) start() (*appmessage.NetAddress, error) {
 
 	flow.peer.Connection().SetID(msgVersion.ID)
 
+	// The services in the version message are the ones the peer actually
+	// provides, so they take precedence over whatever its address says
+	if msgVersion.Address != nil {
+		msgVersion.Address.Services = msgVersion.Services
+	}
 	return msgVersion.Address, nil
 }
@@ -60,8 +60,14 @@ func (flow *sendVersionFlow) start() error {
 		flow.Config().ActiveNetParams.Name, subnetworkID)
 	msg.AddUserAgent(userAgentName, userAgentVersion, flow.Config().UserAgentComments...)
 
+	// Advertise our current time, from which the peer computes our time offset
+	msg.Timestamp = flow.Clock().Now()
+
 	// Advertise the services flag
 	msg.Services = defaultServices
+	if flow.Config().IsArchivalNode {
+		msg.Services |= appmessage.SFNodeArchival
+	}
 
 	// Advertise our max supported protocol version.
 	msg.ProtocolVersion = appmessage.ProtocolVersion
@@ -9,6 +9,7 @@ import (
 	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/util/random"
+	"github.com/pkg/errors"
 )
 
 // SendPingsContext is the interface for the context needed for the SendPings flow.
@@ -37,14 +38,23 @@ func SendPings(context SendPingsContext, incomingRoute *router.Route, outgoingRo
 
 func (flow *sendPingsFlow) start() error {
 	const pingInterval = 2 * time.Minute
-	ticker := time.NewTicker(pingInterval)
-	defer ticker.Stop()
 
 	for {
+		// Wait on incomingRoute rather than on a timer, so that the flow
+		// stops as soon as the route is closed, and doesn't keep the peer
+		// around for up to pingInterval after it disconnects
+		message, err := flow.incomingRoute.DequeueWithTimeout(pingInterval)
+		if err == nil {
+			return protocolerrors.Errorf(true, "unexpected %s message while no ping is pending", message.Command())
+		}
+		if !errors.Is(err, router.ErrTimeout) {
+			return err
+		}
+
 		select {
 		case <-flow.ShutdownChan():
 			return nil
-		case <-ticker.C:
+		default:
 		}
 
 		nonce, err := random.Uint64()
@@ -59,7 +69,7 @@ func (flow *sendPingsFlow) start() error {
 			return err
 		}
 
-		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
+		message, err = flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
 		if err != nil {
 			return err
 		}
@@ -150,10 +150,25 @@ func (f *fakeRelayInvsContext) Anticone(blockHash *externalapi.DomainHash) ([]*e
 	panic(errors.Errorf("called unimplemented function from test '%s'", f.testName))
 }
 
+func (f *fakeRelayInvsContext) CacheStats() []*externalapi.CacheStats {
+	panic(errors.Errorf("called unimplemented function from test '%s'", f.testName))
+}
+
+func (f *fakeRelayInvsContext) ExportDAG(depth uint64) (*externalapi.DAGExport, error) {
+	panic(errors.Errorf("called unimplemented function from test '%s'", f.testName))
+}
+
 func (f *fakeRelayInvsContext) BuildBlock(coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (*externalapi.DomainBlock, error) {
 	panic(errors.Errorf("called unimplemented function from test '%s'", f.testName))
 }
 
+func (f *fakeRelayInvsContext) BuildBlockWithParents(parentHashes []*externalapi.DomainHash,
+	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
+	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
+
+	panic(errors.Errorf("called unimplemented function from test '%s'", f.testName))
+}
+
 func (f *fakeRelayInvsContext) ValidateAndInsertBlock(block *externalapi.DomainBlock) (*externalapi.BlockInsertionResult, error) {
 	return nil, f.validateAndInsertBlockResponse
 }
@@ -307,7 +322,7 @@ func (f *fakeRelayInvsContext) Broadcast(message appmessage.Message) error {
 	panic(errors.Errorf("called unimplemented function from test '%s'", f.testName))
 }
 
-func (f *fakeRelayInvsContext) AddOrphan(orphanBlock *externalapi.DomainBlock) {
+func (f *fakeRelayInvsContext) AddOrphan(orphanBlock *externalapi.DomainBlock) (bool, error) {
 	panic(errors.Errorf("called unimplemented function from test '%s'", f.testName))
 }
 
@@ -323,6 +338,10 @@ func (f *fakeRelayInvsContext) IsIBDRunning() bool {
 	return false
 }
 
+func (f *fakeRelayInvsContext) Peers() []*peerpkg.Peer {
+	return nil
+}
+
 func (f *fakeRelayInvsContext) TrySetIBDRunning(ibdPeer *peerpkg.Peer) bool {
 	f.rwLock.RLock()
 	defer f.rwLock.RUnlock()
@@ -533,6 +552,58 @@ func TestHandleRelayInvs(t *testing.T) {
 			expectsBan:           true,
 			expectsErrToContain:  "got invalid block",
 		},
+		{
+			name: "sending unrequested compact block",
+			funcToExecute: func(t *testing.T, incomingRoute, outgoingRoute *router.Route, context *fakeRelayInvsContext) {
+				err := incomingRoute.Enqueue(appmessage.NewMsgInvBlock(unknownBlockHash))
+				if err != nil {
+					t.Fatalf("Enqueue: %+v", err)
+				}
+
+				msg, err := outgoingRoute.DequeueWithTimeout(time.Second)
+				if err != nil {
+					t.Fatalf("DequeueWithTimeout: %+v", err)
+				}
+				_ = msg.(*appmessage.MsgRequestRelayBlocks)
+
+				err = incomingRoute.Enqueue(appmessage.NewMsgCompactBlock(
+					appmessage.DomainBlockHeaderToBlockHeader(invalidBlock.Header), 0, nil, nil))
+				if err != nil {
+					t.Fatalf("Enqueue: %+v", err)
+				}
+			},
+			expectsProtocolError: true,
+			expectsBan:           true,
+			expectsErrToContain:  "got unrequested block",
+		},
+		{
+			name: "sending compact block with an invalid prefilled transaction index",
+			funcToExecute: func(t *testing.T, incomingRoute, outgoingRoute *router.Route, context *fakeRelayInvsContext) {
+				err := incomingRoute.Enqueue(appmessage.NewMsgInvBlock(invalidBlockHash))
+				if err != nil {
+					t.Fatalf("Enqueue: %+v", err)
+				}
+
+				msg, err := outgoingRoute.DequeueWithTimeout(time.Second)
+				if err != nil {
+					t.Fatalf("DequeueWithTimeout: %+v", err)
+				}
+				_ = msg.(*appmessage.MsgRequestRelayBlocks)
+
+				prefilledTransactions := []*appmessage.PrefilledTransaction{{
+					Index:       1,
+					Transaction: appmessage.DomainTransactionToMsgTx(invalidBlock.Transactions[0]),
+				}}
+				err = incomingRoute.Enqueue(appmessage.NewMsgCompactBlock(
+					appmessage.DomainBlockHeaderToBlockHeader(invalidBlock.Header), 0, nil, prefilledTransactions))
+				if err != nil {
+					t.Fatalf("Enqueue: %+v", err)
+				}
+			},
+			expectsProtocolError: true,
+			expectsBan:           true,
+			expectsErrToContain:  "has a prefilled transaction with an invalid index",
+		},
 		{
 			name: "sending unexpected message instead of block locator",
 			funcToExecute: func(t *testing.T, incomingRoute, outgoingRoute *router.Route, context *fakeRelayInvsContext) {
@@ -1512,7 +1583,7 @@ func TestHandleRelayInvs(t *testing.T) {
 
 				incomingRoute := router.NewRoute()
 				outgoingRoute := router.NewRoute()
-				peer := peerpkg.New(nil)
+				peer := peerpkg.New(nil, mstime.SystemClock)
 				errChan := make(chan error)
 				context := &fakeRelayInvsContext{
 					testName:    test.name,
@@ -11,6 +11,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util/mstime"
 )
 
 type fakeReceiveAddressesContext struct{}
@@ -23,7 +24,7 @@ func TestReceiveAddressesErrors(t *testing.T) {
 	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
 		incomingRoute := router.NewRoute()
 		outgoingRoute := router.NewRoute()
-		peer := peerpkg.New(nil)
+		peer := peerpkg.New(nil, mstime.SystemClock)
 		errChan := make(chan error)
 		go func() {
 			errChan <- addressexchange.ReceiveAddresses(fakeReceiveAddressesContext{}, incomingRoute, outgoingRoute, peer)
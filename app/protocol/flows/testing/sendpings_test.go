@@ -0,0 +1,42 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/protocol/flows/ping"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+type fakeSendPingsContext struct {
+	shutdownChan chan struct{}
+}
+
+func (f fakeSendPingsContext) ShutdownChan() <-chan struct{} {
+	return f.shutdownChan
+}
+
+func TestSendPingsStopsOnDisconnect(t *testing.T) {
+	incomingRoute := router.NewRoute()
+	outgoingRoute := router.NewRoute()
+	peer := peerpkg.New(nil, mstime.SystemClock)
+	errChan := make(chan error)
+	go func() {
+		errChan <- ping.SendPings(fakeSendPingsContext{shutdownChan: make(chan struct{})}, incomingRoute, outgoingRoute, peer)
+	}()
+
+	// The flow should stop as soon as its route is closed, rather than
+	// when it next tries to send a ping
+	incomingRoute.Close()
+	select {
+	case err := <-errChan:
+		if !errors.Is(err, router.ErrRouteClosed) {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("SendPings didn't stop after its route was closed")
+	}
+}
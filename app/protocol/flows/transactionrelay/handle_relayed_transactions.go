@@ -3,6 +3,7 @@ package transactionrelay
 import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol/common"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
 	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
 	"github.com/kaspanet/kaspad/domain"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
@@ -13,29 +14,37 @@ import (
 	"github.com/pkg/errors"
 )
 
+// maxRequestedTransactionsPerPeer is the maximum number of transactions
+// that may be requested from a single peer at any given time
+const maxRequestedTransactionsPerPeer = 1000
+
 // TransactionsRelayContext is the interface for the context needed for the
 // HandleRelayedTransactions and HandleRequestedTransactions flows.
 type TransactionsRelayContext interface {
 	NetAdapter() *netadapter.NetAdapter
 	Domain() domain.Domain
 	SharedRequestedTransactions() *SharedRequestedTransactions
-	Broadcast(message appmessage.Message) error
+	EnqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID)
 	OnTransactionAddedToMempool()
 }
 
 type handleRelayedTransactionsFlow struct {
 	TransactionsRelayContext
 	incomingRoute, outgoingRoute *router.Route
+	peer                         *peerpkg.Peer
 	invsQueue                    []*appmessage.MsgInvTransaction
 }
 
 // HandleRelayedTransactions listens to appmessage.MsgInvTransaction messages, requests their corresponding transactions if they
 // are missing, adds them to the mempool and propagates them to the rest of the network.
-func HandleRelayedTransactions(context TransactionsRelayContext, incomingRoute *router.Route, outgoingRoute *router.Route) error {
+func HandleRelayedTransactions(context TransactionsRelayContext, incomingRoute *router.Route, outgoingRoute *router.Route,
+	peer *peerpkg.Peer) error {
+
 	flow := &handleRelayedTransactionsFlow{
 		TransactionsRelayContext: context,
 		incomingRoute:            incomingRoute,
 		outgoingRoute:            outgoingRoute,
+		peer:                     peer,
 		invsQueue:                make([]*appmessage.MsgInvTransaction, 0),
 	}
 	return flow.start()
@@ -48,23 +57,36 @@ func (flow *handleRelayedTransactionsFlow) start() error {
 			return err
 		}
 
-		requestedIDs, err := flow.requestInvTransactions(inv)
-		if err != nil {
-			return err
-		}
+		// The peer obviously has the transactions it announced, so there's
+		// no need to announce them back to it
+		flow.peer.MarkTransactionsAsKnown(inv.TxIDs)
 
-		err = flow.receiveTransactions(requestedIDs)
-		if err != nil {
-			return err
+		txIDs := inv.TxIDs
+		for len(txIDs) > 0 {
+			var requestedIDs []*externalapi.DomainTransactionID
+			requestedIDs, txIDs, err = flow.requestInvTransactions(txIDs)
+			if err != nil {
+				return err
+			}
+
+			err = flow.receiveTransactions(requestedIDs)
+			if err != nil {
+				return err
+			}
 		}
 	}
 }
 
-func (flow *handleRelayedTransactionsFlow) requestInvTransactions(
-	inv *appmessage.MsgInvTransaction) (requestedIDs []*externalapi.DomainTransactionID, err error) {
+// requestInvTransactions requests the missing transactions out of txIDs,
+// up to maxRequestedTransactionsPerPeer of them. The IDs that were not yet
+// examined are returned in remainingIDs.
+func (flow *handleRelayedTransactionsFlow) requestInvTransactions(txIDs []*externalapi.DomainTransactionID) (
+	requestedIDs []*externalapi.DomainTransactionID, remainingIDs []*externalapi.DomainTransactionID, err error) {
 
-	idsToRequest := make([]*externalapi.DomainTransactionID, 0, len(inv.TxIDs))
-	for _, txID := range inv.TxIDs {
+	idsToRequest := make([]*externalapi.DomainTransactionID, 0, len(txIDs))
+	i := 0
+	for ; i < len(txIDs) && len(idsToRequest) < maxRequestedTransactionsPerPeer; i++ {
+		txID := txIDs[i]
 		if flow.isKnownTransaction(txID) {
 			continue
 		}
@@ -74,18 +96,19 @@ func (flow *handleRelayedTransactionsFlow) requestInvTransactions(
 		}
 		idsToRequest = append(idsToRequest, txID)
 	}
+	remainingIDs = txIDs[i:]
 
 	if len(idsToRequest) == 0 {
-		return idsToRequest, nil
+		return idsToRequest, remainingIDs, nil
 	}
 
 	msgGetTransactions := appmessage.NewMsgRequestTransactions(idsToRequest)
 	err = flow.outgoingRoute.Enqueue(msgGetTransactions)
 	if err != nil {
 		flow.SharedRequestedTransactions().removeMany(idsToRequest)
-		return nil, err
+		return nil, nil, err
 	}
-	return idsToRequest, nil
+	return idsToRequest, remainingIDs, nil
 }
 
 func (flow *handleRelayedTransactionsFlow) isKnownTransaction(txID *externalapi.DomainTransactionID) bool {
@@ -118,9 +141,8 @@ func (flow *handleRelayedTransactionsFlow) readInv() (*appmessage.MsgInvTransact
 	return inv, nil
 }
 
-func (flow *handleRelayedTransactionsFlow) broadcastAcceptedTransactions(acceptedTxIDs []*externalapi.DomainTransactionID) error {
-	inv := appmessage.NewMsgInvTransaction(acceptedTxIDs)
-	return flow.Broadcast(inv)
+func (flow *handleRelayedTransactionsFlow) broadcastAcceptedTransactions(acceptedTxIDs []*externalapi.DomainTransactionID) {
+	flow.EnqueueTransactionIDsForPropagation(acceptedTxIDs)
 }
 
 // readMsgTxOrNotFound returns the next msgTx or msgTransactionNotFound in incomingRoute,
@@ -193,10 +215,7 @@ func (flow *handleRelayedTransactionsFlow) receiveTransactions(requestedTransact
 
 			return protocolerrors.Errorf(true, "rejected transaction %s: %s", txID, ruleErr)
 		}
-		err = flow.broadcastAcceptedTransactions([]*externalapi.DomainTransactionID{txID})
-		if err != nil {
-			return err
-		}
+		flow.broadcastAcceptedTransactions([]*externalapi.DomainTransactionID{txID})
 		flow.OnTransactionAddedToMempool()
 	}
 	return nil
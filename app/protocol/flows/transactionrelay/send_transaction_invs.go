@@ -0,0 +1,78 @@
+package transactionrelay
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// averageTransactionInvInterval is the average interval between two
+// consecutive transaction invs sent to the same peer
+const averageTransactionInvInterval = 500 * time.Millisecond
+
+// SendTransactionInvsContext is the interface for the context needed for the SendTransactionInvs flow.
+type SendTransactionInvsContext interface {
+	ShutdownChan() <-chan struct{}
+}
+
+type sendTransactionInvsFlow struct {
+	SendTransactionInvsContext
+	incomingRoute, outgoingRoute *router.Route
+	peer                         *peerpkg.Peer
+}
+
+// SendTransactionInvs periodically sends the transactions queued in the
+// peer's transaction inventory as a single MsgInvTransaction.
+// The intervals between invs are randomized (Poisson-distributed), so that
+// observers can't deduce which node a transaction originated from by
+// timing its announcements.
+// incomingRoute doesn't receive any messages: it's only used to learn
+// when the peer disconnects.
+func SendTransactionInvs(context SendTransactionInvsContext, incomingRoute *router.Route,
+	outgoingRoute *router.Route, peer *peerpkg.Peer) error {
+
+	flow := &sendTransactionInvsFlow{
+		SendTransactionInvsContext: context,
+		incomingRoute:              incomingRoute,
+		outgoingRoute:              outgoingRoute,
+		peer:                       peer,
+	}
+	return flow.start()
+}
+
+func (flow *sendTransactionInvsFlow) start() error {
+	for {
+		// Wait on incomingRoute rather than on a timer, so that the flow
+		// stops as soon as the peer disconnects
+		_, err := flow.incomingRoute.DequeueWithTimeout(nextTransactionInvInterval())
+		if !errors.Is(err, router.ErrTimeout) {
+			return err
+		}
+
+		select {
+		case <-flow.ShutdownChan():
+			return nil
+		default:
+		}
+
+		txIDs := flow.peer.DequeueTransactionInventory(appmessage.MaxInvPerTxInvMsg)
+		if len(txIDs) > 0 {
+			err = flow.outgoingRoute.Enqueue(appmessage.NewMsgInvTransaction(txIDs))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// nextTransactionInvInterval returns an exponentially distributed interval
+// with a mean of averageTransactionInvInterval, so that invs are sent as a
+// Poisson process
+func nextTransactionInvInterval() time.Duration {
+	return time.Duration(-math.Log(1-rand.Float64()) * float64(averageTransactionInvInterval))
+}
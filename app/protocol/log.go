@@ -2,8 +2,15 @@ package protocol
 
 import (
 	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	"github.com/kaspanet/kaspad/util/panics"
 )
 
 var log = logger.RegisterSubSystem("PROT")
 var spawn = panics.GoroutineWrapperFunc(log)
+
+// peerLog returns a logger that attaches the address of the
+// given peer connection to its log entries
+func peerLog(netConnection *netadapter.NetConnection) *logger.Logger {
+	return log.WithFields(logger.Fields{"peer": netConnection.Address()})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+	"github.com/kaspanet/kaspad/util/mstime"
 )
 
 // Manager manages the p2p protocol
@@ -27,10 +28,10 @@ type Manager struct {
 
 // NewManager creates a new instance of the p2p protocol manager
 func NewManager(cfg *config.Config, domain domain.Domain, netAdapter *netadapter.NetAdapter, addressManager *addressmanager.AddressManager,
-	connectionManager *connmanager.ConnectionManager) (*Manager, error) {
+	connectionManager *connmanager.ConnectionManager, clock mstime.Clock) (*Manager, error) {
 
 	manager := Manager{
-		context: flowcontext.New(cfg, domain, addressManager, netAdapter, connectionManager),
+		context: flowcontext.New(cfg, domain, addressManager, netAdapter, connectionManager, clock),
 	}
 
 	netAdapter.SetP2PRouterInitializer(manager.routerInitializer)
@@ -108,3 +109,13 @@ func (m *Manager) ShouldMine() (bool, error) {
 func (m *Manager) IsIBDRunning() bool {
 	return m.context.IsIBDRunning()
 }
+
+// SyncStatus returns the current SyncStatus of the node
+func (m *Manager) SyncStatus() (*flowcontext.SyncStatus, error) {
+	return m.context.SyncStatus()
+}
+
+// Clock returns the clock the protocol reads the current time from
+func (m *Manager) Clock() mstime.Clock {
+	return m.context.Clock()
+}
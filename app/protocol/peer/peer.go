@@ -28,20 +28,25 @@ type Peer struct {
 	disableRelayTx           bool
 	subnetworkID             *externalapi.DomainSubnetworkID
 
+	clock             mstime.Clock
 	timeOffset        time.Duration
-	connectionStarted time.Time
+	connectionStarted mstime.Time
 
 	pingLock         sync.RWMutex
 	lastPingNonce    uint64        // The nonce of the last ping we sent
-	lastPingTime     time.Time     // Time we sent last ping
+	lastPingTime     mstime.Time   // Time we sent last ping
 	lastPingDuration time.Duration // Time for last ping to return
+
+	transactionInventory *transactionInventory
 }
 
-// New returns a new Peer
-func New(connection *netadapter.NetConnection) *Peer {
+// New returns a new Peer that measures time with the given clock
+func New(connection *netadapter.NetConnection, clock mstime.Clock) *Peer {
 	return &Peer{
-		connection:        connection,
-		connectionStarted: time.Now(),
+		connection:           connection,
+		clock:                clock,
+		connectionStarted:    clock.Now(),
+		transactionInventory: newTransactionInventory(),
 	}
 }
 
@@ -78,7 +83,7 @@ func (p *Peer) AdvertisedProtocolVersion() uint32 {
 
 // TimeConnected returns the time since the connection to this been has been started.
 func (p *Peer) TimeConnected() time.Duration {
-	return time.Since(p.connectionStarted)
+	return p.clock.Now().Sub(p.connectionStarted)
 }
 
 // IsOutbound returns whether the peer is an outbound connection.
@@ -86,6 +91,23 @@ func (p *Peer) IsOutbound() bool {
 	return p.connection.IsOutbound()
 }
 
+// Services returns the services the peer advertised in its version message.
+func (p *Peer) Services() appmessage.ServiceFlag {
+	return p.services
+}
+
+// IsArchival returns whether the peer advertised that it keeps the full
+// block history.
+func (p *Peer) IsArchival() bool {
+	return p.services&appmessage.SFNodeArchival != 0
+}
+
+// SupportsCompactBlocks returns whether the peer advertised that it
+// supports relaying blocks as compact blocks.
+func (p *Peer) SupportsCompactBlocks() bool {
+	return p.services&appmessage.SFNodeCompactBlocks != 0
+}
+
 // UpdateFieldsFromMsgVersion updates the peer with the data from the version message.
 func (p *Peer) UpdateFieldsFromMsgVersion(msg *appmessage.MsgVersion) {
 	// Negotiate the protocol version.
@@ -104,7 +126,7 @@ func (p *Peer) UpdateFieldsFromMsgVersion(msg *appmessage.MsgVersion) {
 	p.disableRelayTx = msg.DisableRelayTx
 	p.subnetworkID = msg.SubnetworkID
 
-	p.timeOffset = mstime.Since(msg.Timestamp)
+	p.timeOffset = p.clock.Now().Sub(msg.Timestamp)
 }
 
 // SetPingPending sets the ping state of the peer to 'pending'
@@ -113,7 +135,7 @@ func (p *Peer) SetPingPending(nonce uint64) {
 	defer p.pingLock.Unlock()
 
 	p.lastPingNonce = nonce
-	p.lastPingTime = time.Now()
+	p.lastPingTime = p.clock.Now()
 }
 
 // SetPingIdle sets the ping state of the peer to 'idle'
@@ -122,7 +144,7 @@ func (p *Peer) SetPingIdle() {
 	defer p.pingLock.Unlock()
 
 	p.lastPingNonce = 0
-	p.lastPingDuration = time.Since(p.lastPingTime)
+	p.lastPingDuration = p.clock.Now().Sub(p.lastPingTime)
 }
 
 func (p *Peer) String() string {
@@ -0,0 +1,121 @@
+package peer
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// maxKnownTransactions is the maximum number of transaction IDs that are
+// remembered as known to a single peer. Once it is reached, the oldest
+// IDs are forgotten first.
+const maxKnownTransactions = 100_000
+
+// maxQueuedTransactions is the maximum number of transaction IDs that may
+// wait to be announced to a single peer. Transactions that are queued while
+// it's reached are not announced to the peer.
+const maxQueuedTransactions = 100_000
+
+// transactionInventory holds the transaction IDs that are waiting to be
+// announced to a peer, along with the IDs the peer is already known to have.
+type transactionInventory struct {
+	lock sync.Mutex
+
+	queue []*externalapi.DomainTransactionID
+
+	// queued and known are used to suppress duplicate announcements.
+	// queued maps every queued ID to whether it should be announced even
+	// if it's known to the peer.
+	// knownOrder holds the IDs in known by insertion order, so that the
+	// oldest ones can be evicted
+	queued     map[externalapi.DomainTransactionID]bool
+	known      map[externalapi.DomainTransactionID]struct{}
+	knownOrder []externalapi.DomainTransactionID
+}
+
+func newTransactionInventory() *transactionInventory {
+	return &transactionInventory{
+		queue:      make([]*externalapi.DomainTransactionID, 0),
+		queued:     make(map[externalapi.DomainTransactionID]bool),
+		known:      make(map[externalapi.DomainTransactionID]struct{}),
+		knownOrder: make([]externalapi.DomainTransactionID, 0),
+	}
+}
+
+func (ti *transactionInventory) addKnown(txID *externalapi.DomainTransactionID) {
+	if _, ok := ti.known[*txID]; ok {
+		return
+	}
+	if len(ti.knownOrder) == maxKnownTransactions {
+		delete(ti.known, ti.knownOrder[0])
+		ti.knownOrder = ti.knownOrder[1:]
+	}
+	ti.known[*txID] = struct{}{}
+	ti.knownOrder = append(ti.knownOrder, *txID)
+}
+
+// MarkTransactionsAsKnown marks the given transactions as known to the peer,
+// which means they will not be announced to it
+func (p *Peer) MarkTransactionsAsKnown(txIDs []*externalapi.DomainTransactionID) {
+	p.transactionInventory.lock.Lock()
+	defer p.transactionInventory.lock.Unlock()
+
+	for _, txID := range txIDs {
+		p.transactionInventory.addKnown(txID)
+	}
+}
+
+// QueueTransactionInventory queues the given transactions to be announced to
+// the peer. Transactions that are already queued are ignored, as are
+// transactions that are known to the peer unless ignoreKnown is set.
+// Nothing is queued for peers that asked not to be sent transactions, and
+// transactions beyond maxQueuedTransactions are dropped.
+func (p *Peer) QueueTransactionInventory(txIDs []*externalapi.DomainTransactionID, ignoreKnown bool) {
+	if p.disableRelayTx {
+		return
+	}
+
+	p.transactionInventory.lock.Lock()
+	defer p.transactionInventory.lock.Unlock()
+
+	for i, txID := range txIDs {
+		if len(p.transactionInventory.queue) == maxQueuedTransactions {
+			log.Debugf("The transaction inventory queue of %s is full. "+
+				"Dropping %d transactions", p, len(txIDs)-i)
+			return
+		}
+		if _, ok := p.transactionInventory.queued[*txID]; ok {
+			continue
+		}
+		if _, ok := p.transactionInventory.known[*txID]; ok && !ignoreKnown {
+			continue
+		}
+		p.transactionInventory.queued[*txID] = ignoreKnown
+		p.transactionInventory.queue = append(p.transactionInventory.queue, txID)
+	}
+}
+
+// DequeueTransactionInventory removes up to maxTransactions transaction IDs
+// from the peer's inventory queue and marks them as known to the peer.
+// IDs that became known to the peer while they were queued are dropped,
+// unless they were queued with ignoreKnown.
+func (p *Peer) DequeueTransactionInventory(maxTransactions int) []*externalapi.DomainTransactionID {
+	p.transactionInventory.lock.Lock()
+	defer p.transactionInventory.lock.Unlock()
+
+	txIDs := make([]*externalapi.DomainTransactionID, 0, maxTransactions)
+	i := 0
+	for ; i < len(p.transactionInventory.queue) && len(txIDs) < maxTransactions; i++ {
+		txID := p.transactionInventory.queue[i]
+		ignoreKnown := p.transactionInventory.queued[*txID]
+		delete(p.transactionInventory.queued, *txID)
+		if _, ok := p.transactionInventory.known[*txID]; ok && !ignoreKnown {
+			continue
+		}
+		p.transactionInventory.addKnown(txID)
+		txIDs = append(txIDs, txID)
+	}
+	p.transactionInventory.queue = p.transactionInventory.queue[i:]
+
+	return txIDs
+}
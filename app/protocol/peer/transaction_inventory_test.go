@@ -0,0 +1,74 @@
+package peer
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+func TestTransactionInventory(t *testing.T) {
+	peer := &Peer{transactionInventory: newTransactionInventory()}
+
+	txIDs := make([]*externalapi.DomainTransactionID, 4)
+	for i := range txIDs {
+		txIDs[i] = externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{byte(i)})
+	}
+
+	peer.MarkTransactionsAsKnown(txIDs[:1])
+	peer.QueueTransactionInventory(txIDs, false)
+	peer.QueueTransactionInventory(txIDs[1:2], false)
+
+	// The peer learns about txIDs[2] while it's queued
+	peer.MarkTransactionsAsKnown(txIDs[2:3])
+
+	dequeued := peer.DequeueTransactionInventory(1)
+	if len(dequeued) != 1 || !dequeued[0].Equal(txIDs[1]) {
+		t.Fatalf("expected to dequeue %s but got %v", txIDs[1], dequeued)
+	}
+	dequeued = peer.DequeueTransactionInventory(10)
+	if len(dequeued) != 1 || !dequeued[0].Equal(txIDs[3]) {
+		t.Fatalf("expected to dequeue %s but got %v", txIDs[3], dequeued)
+	}
+	dequeued = peer.DequeueTransactionInventory(10)
+	if len(dequeued) != 0 {
+		t.Fatalf("expected an empty inventory but got %v", dequeued)
+	}
+
+	// Dequeued transactions are known to the peer, and are
+	// only queued again when known transactions are ignored
+	peer.QueueTransactionInventory(txIDs, false)
+	dequeued = peer.DequeueTransactionInventory(10)
+	if len(dequeued) != 0 {
+		t.Fatalf("expected an empty inventory but got %v", dequeued)
+	}
+	peer.QueueTransactionInventory(txIDs, true)
+	dequeued = peer.DequeueTransactionInventory(10)
+	if len(dequeued) != len(txIDs) {
+		t.Fatalf("expected to dequeue %d transactions but got %d", len(txIDs), len(dequeued))
+	}
+}
+
+func TestTransactionInventoryQueueLimit(t *testing.T) {
+	peer := &Peer{transactionInventory: newTransactionInventory()}
+
+	txIDs := make([]*externalapi.DomainTransactionID, maxQueuedTransactions+10)
+	for i := range txIDs {
+		var txIDBytes [externalapi.DomainHashSize]byte
+		binary.LittleEndian.PutUint32(txIDBytes[:], uint32(i))
+		txIDs[i] = externalapi.NewDomainTransactionIDFromByteArray(&txIDBytes)
+	}
+
+	// Transactions beyond the limit are dropped, and room is made
+	// for new ones as the queue is drained
+	peer.QueueTransactionInventory(txIDs, false)
+	dequeued := peer.DequeueTransactionInventory(len(txIDs))
+	if len(dequeued) != maxQueuedTransactions {
+		t.Fatalf("expected to dequeue %d transactions but got %d", maxQueuedTransactions, len(dequeued))
+	}
+	peer.QueueTransactionInventory(txIDs[maxQueuedTransactions:], false)
+	dequeued = peer.DequeueTransactionInventory(len(txIDs))
+	if len(dequeued) != 10 {
+		t.Fatalf("expected to dequeue 10 transactions but got %d", len(dequeued))
+	}
+}
@@ -53,7 +53,7 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 			panic(err)
 		}
 		if isBanned {
-			log.Infof("Peer %s is banned. Disconnecting...", netConnection)
+			peerLog(netConnection).Infof("Peer %s is banned. Disconnecting...", netConnection)
 			netConnection.Disconnect()
 			return
 		}
@@ -74,7 +74,7 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 				if errors.Is(err, routerpkg.ErrRouteClosed) {
 					m.handleError(innerError, netConnection, router.OutgoingRoute())
 				} else {
-					log.Errorf("Peer %s sent invalid message: %s", netConnection, innerError)
+					peerLog(netConnection).Errorf("Peer %s sent invalid message: %s", netConnection, innerError)
 					m.handleError(err, netConnection, router.OutgoingRoute())
 				}
 			default:
@@ -102,11 +102,12 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 
 func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection, outgoingRoute *routerpkg.Route) {
 	if protocolErr := (protocolerrors.ProtocolError{}); errors.As(err, &protocolErr) {
-		if !m.context.Config().DisableBanning && protocolErr.ShouldBan {
-			log.Warnf("Banning %s (reason: %s)", netConnection, protocolErr.Cause)
+		if !m.context.ConnectionManager().IsBanningDisabled() && protocolErr.ShouldBan {
+			peerLog(netConnection).Warnf("Banning %s (reason: %s)", netConnection, protocolErr.Cause)
 
 			err := m.context.ConnectionManager().Ban(netConnection)
-			if !errors.Is(err, connmanager.ErrCannotBanPermanent) {
+			if err != nil && !errors.Is(err, connmanager.ErrCannotBanPermanent) &&
+				!errors.Is(err, connmanager.ErrCannotBanWhitelisted) {
 				panic(err)
 			}
 
@@ -115,12 +116,12 @@ func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection
 				panic(err)
 			}
 		}
-		log.Infof("Disconnecting from %s (reason: %s)", netConnection, protocolErr.Cause)
+		peerLog(netConnection).Infof("Disconnecting from %s (reason: %s)", netConnection, protocolErr.Cause)
 		netConnection.Disconnect()
 		return
 	}
 	if errors.Is(err, routerpkg.ErrTimeout) {
-		log.Warnf("Got timeout from %s. Disconnecting...", netConnection)
+		peerLog(netConnection).Warnf("Got timeout from %s. Disconnecting...", netConnection)
 		netConnection.Disconnect()
 		return
 	}
@@ -171,14 +172,17 @@ func (m *Manager) registerBlockRelayFlows(router *routerpkg.Router, isStopping *
 			appmessage.CmdInvRelayBlock, appmessage.CmdBlock, appmessage.CmdBlockLocator, appmessage.CmdIBDBlock,
 			appmessage.CmdDoneHeaders, appmessage.CmdUnexpectedPruningPoint, appmessage.CmdPruningPointUTXOSetChunk,
 			appmessage.CmdBlockHeaders, appmessage.CmdPruningPointHash, appmessage.CmdIBDBlockLocatorHighestHash,
-			appmessage.CmdIBDBlockLocatorHighestHashNotFound, appmessage.CmdDonePruningPointUTXOSetChunks},
+			appmessage.CmdIBDBlockLocatorHighestHashNotFound, appmessage.CmdDonePruningPointUTXOSetChunks,
+			appmessage.CmdCompactBlock, appmessage.CmdBlockTransactions},
 			isStopping, errChan, func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
 				return blockrelay.HandleRelayInvs(m.context, incomingRoute,
 					outgoingRoute, peer)
 			},
 		),
 
-		m.registerFlow("HandleRelayBlockRequests", router, []appmessage.MessageCommand{appmessage.CmdRequestRelayBlocks}, isStopping, errChan,
+		m.registerFlow("HandleRelayBlockRequests", router,
+			[]appmessage.MessageCommand{appmessage.CmdRequestRelayBlocks, appmessage.CmdRequestBlockTransactions},
+			isStopping, errChan,
 			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
 				return blockrelay.HandleRelayBlockRequests(m.context, incomingRoute, outgoingRoute, peer)
 			},
@@ -254,7 +258,12 @@ func (m *Manager) registerTransactionRelayFlow(router *routerpkg.Router, isStopp
 		m.registerFlowWithCapacity("HandleRelayedTransactions", 10_000, router,
 			[]appmessage.MessageCommand{appmessage.CmdInvTransaction, appmessage.CmdTx, appmessage.CmdTransactionNotFound}, isStopping, errChan,
 			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
-				return transactionrelay.HandleRelayedTransactions(m.context, incomingRoute, outgoingRoute)
+				return transactionrelay.HandleRelayedTransactions(m.context, incomingRoute, outgoingRoute, peer)
+			},
+		),
+		m.registerFlow("SendTransactionInvs", router, []appmessage.MessageCommand{}, isStopping, errChan,
+			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
+				return transactionrelay.SendTransactionInvs(m.context, incomingRoute, outgoingRoute, peer)
 			},
 		),
 		m.registerFlow("HandleRequestTransactions", router,
@@ -0,0 +1,13 @@
+package rpc
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var (
+	requestCounts = metrics.NewCounterVec("kaspad_rpc_requests_total",
+		"The number of RPC requests that were handled, by method", "method")
+
+	requestDurations = metrics.NewHistogramVec("kaspad_rpc_request_duration_seconds",
+		"The time it takes to handle an RPC request, by method", "method", metrics.DurationBuckets)
+)
@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/app/rpc/rpchandlers"
@@ -45,6 +47,16 @@ var handlers = map[appmessage.MessageCommand]handler{
 	appmessage.CmdNotifyPruningPointUTXOSetOverrideRequestMessage:           rpchandlers.HandleNotifyPruningPointUTXOSetOverrideRequest,
 	appmessage.CmdStopNotifyingPruningPointUTXOSetOverrideRequestMessage:    rpchandlers.HandleStopNotifyingPruningPointUTXOSetOverrideRequest,
 	appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage:                rpchandlers.HandleNotifyVirtualDaaScoreChanged,
+	appmessage.CmdCompactDatabaseRequestMessage:                             rpchandlers.HandleCompactDatabase,
+	appmessage.CmdGetDatabaseStatsRequestMessage:                            rpchandlers.HandleGetDatabaseStats,
+	appmessage.CmdCreateSnapshotRequestMessage:                              rpchandlers.HandleCreateSnapshot,
+	appmessage.CmdGetSnapshotStatusRequestMessage:                           rpchandlers.HandleGetSnapshotStatus,
+	appmessage.CmdGetSyncStatusRequestMessage:                               rpchandlers.HandleGetSyncStatus,
+	appmessage.CmdSetLogLevelRequestMessage:                                 rpchandlers.HandleSetLogLevel,
+	appmessage.CmdReloadConfigRequestMessage:                                rpchandlers.HandleReloadConfig,
+	appmessage.CmdGetCacheStatsRequestMessage:                               rpchandlers.HandleGetCacheStats,
+	appmessage.CmdGenerateBlocksRequestMessage:                              rpchandlers.HandleGenerateBlocks,
+	appmessage.CmdExportDAGRequestMessage:                                   rpchandlers.HandleExportDAG,
 }
 
 func (m *Manager) routerInitializer(router *router.Router, netConnection *netadapter.NetConnection) {
@@ -77,7 +89,11 @@ func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *r
 		if !ok {
 			return err
 		}
+		method := request.Command().String()
+		start := time.Now()
 		response, err := handler(m.context, router, request)
+		requestDurations.WithLabel(method).ObserveDuration(start)
+		requestCounts.WithLabel(method).Inc()
 		if err != nil {
 			return err
 		}
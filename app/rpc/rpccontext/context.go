@@ -1,10 +1,12 @@
 package rpccontext
 
 import (
+	"github.com/kaspanet/kaspad/app/configreload"
 	"github.com/kaspanet/kaspad/app/protocol"
 	"github.com/kaspanet/kaspad/domain"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
@@ -15,36 +17,44 @@ type Context struct {
 	Config            *config.Config
 	NetAdapter        *netadapter.NetAdapter
 	Domain            domain.Domain
+	Database          database.Database
 	ProtocolManager   *protocol.Manager
 	ConnectionManager *connmanager.ConnectionManager
 	AddressManager    *addressmanager.AddressManager
 	UTXOIndex         *utxoindex.UTXOIndex
+	ConfigReloader    *configreload.Reloader
 	ShutDownChan      chan<- struct{}
 
 	NotificationManager *NotificationManager
+	SnapshotTracker     *SnapshotTracker
 }
 
 // NewContext creates a new RPC context
 func NewContext(cfg *config.Config,
 	domain domain.Domain,
+	database database.Database,
 	netAdapter *netadapter.NetAdapter,
 	protocolManager *protocol.Manager,
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	configReloader *configreload.Reloader,
 	shutDownChan chan<- struct{}) *Context {
 
 	context := &Context{
 		Config:            cfg,
 		NetAdapter:        netAdapter,
 		Domain:            domain,
+		Database:          database,
 		ProtocolManager:   protocolManager,
 		ConnectionManager: connectionManager,
 		AddressManager:    addressManager,
 		UTXOIndex:         utxoIndex,
+		ConfigReloader:    configReloader,
 		ShutDownChan:      shutDownChan,
 	}
 	context.NotificationManager = NewNotificationManager()
+	context.SnapshotTracker = NewSnapshotTracker()
 
 	return context
 }
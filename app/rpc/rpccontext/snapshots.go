@@ -0,0 +1,54 @@
+package rpccontext
+
+import "sync"
+
+// SnapshotTracker keeps track of the latest database snapshot created
+// through RPC, so that only one snapshot is created at a time
+type SnapshotTracker struct {
+	path           string
+	isInProgress   bool
+	failureMessage string
+	mutex          sync.Mutex
+}
+
+// NewSnapshotTracker creates a new SnapshotTracker
+func NewSnapshotTracker() *SnapshotTracker {
+	return &SnapshotTracker{}
+}
+
+// Start marks the creation of a snapshot in the given path as started.
+// It returns false, and changes nothing, if a previous snapshot is still
+// being created.
+func (st *SnapshotTracker) Start(path string) bool {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if st.isInProgress {
+		return false
+	}
+	st.path = path
+	st.isInProgress = true
+	st.failureMessage = ""
+	return true
+}
+
+// Finish marks the snapshot that is being created as finished, with the
+// given error if creating it failed
+func (st *SnapshotTracker) Finish(err error) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.isInProgress = false
+	if err != nil {
+		st.failureMessage = err.Error()
+	}
+}
+
+// Status returns the path of the latest snapshot, whether it's still being
+// created, and why creating it failed, if it did
+func (st *SnapshotTracker) Status() (path string, isInProgress bool, failureMessage string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	return st.path, st.isInProgress, st.failureMessage
+}
@@ -0,0 +1,37 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleCompactDatabase handles the respectively named RPC command
+func HandleCompactDatabase(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	levelDB, ok := context.Database.(*ldb.LevelDB)
+	if !ok {
+		errorMessage := appmessage.NewCompactDatabaseResponseMessage()
+		errorMessage.Error = appmessage.RPCErrorf("Database compaction is only available "+
+			"for the %s database backend", ldb.BackendName)
+		return errorMessage, nil
+	}
+	if levelDB.IsCompacting() {
+		errorMessage := appmessage.NewCompactDatabaseResponseMessage()
+		errorMessage.Error = appmessage.RPCErrorf("A database compaction is already running")
+		return errorMessage, nil
+	}
+
+	log.Warn("CompactDatabase RPC called.")
+
+	// Compacting may take a long time, so it's done in the background
+	spawn("HandleCompactDatabase-Compact", func() {
+		err := levelDB.Compact()
+		if err != nil {
+			log.Errorf("Error compacting the database: %s", err)
+		}
+	})
+
+	response := appmessage.NewCompactDatabaseResponseMessage()
+	return response, nil
+}
@@ -0,0 +1,72 @@
+package rpchandlers
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memdb"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// snapshotCacheSizeMiB is the cache size of the database
+// a snapshot is copied into
+const snapshotCacheSizeMiB = 64
+
+// HandleCreateSnapshot handles the respectively named RPC command
+func HandleCreateSnapshot(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.Config.DbType == memdb.BackendName {
+		errorMessage := appmessage.NewCreateSnapshotResponseMessage("")
+		errorMessage.Error = appmessage.RPCErrorf("Snapshots are not available "+
+			"for the %s database backend", memdb.BackendName)
+		return errorMessage, nil
+	}
+
+	snapshotName := "snapshot-" + time.Now().UTC().Format("20060102-150405")
+	snapshotPath := filepath.Join(context.Config.AppDir, "snapshots", snapshotName)
+	_, err := os.Stat(snapshotPath)
+	if err == nil {
+		errorMessage := appmessage.NewCreateSnapshotResponseMessage("")
+		errorMessage.Error = appmessage.RPCErrorf("Snapshot %s already exists", snapshotPath)
+		return errorMessage, nil
+	}
+
+	// Snapshots created in the same second get the same path, and
+	// copying one would remove the incomplete copy of the other
+	if !context.SnapshotTracker.Start(snapshotPath) {
+		errorMessage := appmessage.NewCreateSnapshotResponseMessage("")
+		errorMessage.Error = appmessage.RPCErrorf("A snapshot is already being created")
+		return errorMessage, nil
+	}
+
+	snapshot, err := context.Database.Snapshot()
+	if err != nil {
+		context.SnapshotTracker.Finish(err)
+		return nil, err
+	}
+
+	log.Infof("Creating a database snapshot in %s", snapshotPath)
+
+	// Copying the snapshot may take a long time, so it's done in the background.
+	// Its outcome is reported by GetSnapshotStatus.
+	spawn("HandleCreateSnapshot-CopySnapshot", func() {
+		defer snapshot.Release()
+
+		options := database.DefaultOptions(snapshotCacheSizeMiB)
+		options.Compression = context.Config.DbCompression
+		start := time.Now()
+		err := database.CopySnapshot(snapshot, context.Config.DbType, snapshotPath, options)
+		context.SnapshotTracker.Finish(err)
+		if err != nil {
+			log.Errorf("Error creating the database snapshot in %s: %s", snapshotPath, err)
+			return
+		}
+		log.Infof("Created the database snapshot in %s in %s", snapshotPath, time.Since(start))
+	})
+
+	response := appmessage.NewCreateSnapshotResponseMessage(snapshotPath)
+	return response, nil
+}
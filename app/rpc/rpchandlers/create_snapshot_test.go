@@ -0,0 +1,105 @@
+package rpchandlers_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/app/rpc/rpchandlers"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+func TestHandleCreateSnapshot(t *testing.T) {
+	logger.InitLogStdout(logger.LevelInfo)
+
+	db, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %s", err)
+	}
+	defer db.Close()
+	err = db.Put(database.MakeBucket(nil).Key([]byte("key")), []byte("value"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.AppDir = t.TempDir()
+	cfg.DbType = ldb.BackendName
+	context := &rpccontext.Context{
+		Config:          cfg,
+		Database:        db,
+		SnapshotTracker: rpccontext.NewSnapshotTracker(),
+	}
+
+	createSnapshot := func() *appmessage.CreateSnapshotResponseMessage {
+		response, err := rpchandlers.HandleCreateSnapshot(context, nil, appmessage.NewCreateSnapshotRequestMessage())
+		if err != nil {
+			t.Fatalf("HandleCreateSnapshot: %+v", err)
+		}
+		return response.(*appmessage.CreateSnapshotResponseMessage)
+	}
+	waitForSnapshot := func() *appmessage.GetSnapshotStatusResponseMessage {
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			response, err := rpchandlers.HandleGetSnapshotStatus(context, nil, appmessage.NewGetSnapshotStatusRequestMessage())
+			if err != nil {
+				t.Fatalf("HandleGetSnapshotStatus: %+v", err)
+			}
+			status := response.(*appmessage.GetSnapshotStatusResponseMessage)
+			if !status.IsInProgress {
+				return status
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("The snapshot is still in progress")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// No snapshot can be created while another one is in progress
+	context.SnapshotTracker.Start(filepath.Join(cfg.AppDir, "snapshots", "snapshot-in-progress"))
+	response := createSnapshot()
+	if response.Error == nil {
+		t.Fatalf("Expected CreateSnapshot to fail while another snapshot is in progress")
+	}
+	context.SnapshotTracker.Finish(nil)
+
+	response = createSnapshot()
+	if response.Error != nil {
+		t.Fatalf("CreateSnapshot: %s", response.Error.Message)
+	}
+	status := waitForSnapshot()
+	if status.SnapshotPath != response.SnapshotPath || status.FailureMessage != "" {
+		t.Fatalf("Expected the snapshot in %s to complete successfully, but got the status %+v",
+			response.SnapshotPath, status)
+	}
+	_, err = os.Stat(response.SnapshotPath)
+	if err != nil {
+		t.Fatalf("The completed snapshot is missing: %s", err)
+	}
+
+	// A failed copy is reported by the status
+	err = os.RemoveAll(filepath.Join(cfg.AppDir, "snapshots"))
+	if err != nil {
+		t.Fatalf("RemoveAll: %s", err)
+	}
+	err = ioutil.WriteFile(filepath.Join(cfg.AppDir, "snapshots"), nil, 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	response = createSnapshot()
+	if response.Error != nil {
+		t.Fatalf("CreateSnapshot: %s", response.Error.Message)
+	}
+	status = waitForSnapshot()
+	if status.FailureMessage == "" {
+		t.Fatalf("Expected the status to report that creating the snapshot failed")
+	}
+}
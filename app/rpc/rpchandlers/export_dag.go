@@ -0,0 +1,66 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/hashes"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+const (
+	// defaultExportedDAGDepth is the depth of the exported DAG window
+	// when the ExportDAG request doesn't set one
+	defaultExportedDAGDepth = 100
+
+	// maxExportedDAGDepth is the maximum depth of the exported DAG window.
+	// Consensus is locked during the export, so it has to be bounded
+	maxExportedDAGDepth = 10_000
+)
+
+// HandleExportDAG handles the respectively named RPC command
+func HandleExportDAG(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	exportDAGRequest := request.(*appmessage.ExportDAGRequestMessage)
+
+	depth := exportDAGRequest.Depth
+	if depth == 0 {
+		depth = defaultExportedDAGDepth
+	}
+	if depth > maxExportedDAGDepth {
+		errorMessage := &appmessage.ExportDAGResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("depth may be at most %d", maxExportedDAGDepth)
+		return errorMessage, nil
+	}
+
+	dagExport, err := context.Domain.Consensus().ExportDAG(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*appmessage.DAGExportBlock, len(dagExport.Blocks))
+	for i, block := range dagExport.Blocks {
+		selectedParentHash := ""
+		if block.SelectedParent != nil {
+			selectedParentHash = block.SelectedParent.String()
+		}
+		blocks[i] = &appmessage.DAGExportBlock{
+			Hash:                           block.Hash.String(),
+			ParentHashes:                   hashes.ToStrings(block.Parents),
+			SelectedParentHash:             selectedParentHash,
+			MergeSetBluesHashes:            hashes.ToStrings(block.MergeSetBlues),
+			MergeSetRedsHashes:             hashes.ToStrings(block.MergeSetReds),
+			Status:                         block.Status.String(),
+			BlueScore:                      block.BlueScore,
+			BlueWork:                       block.BlueWork.Text(16),
+			DAAScore:                       block.DAAScore,
+			Timestamp:                      block.Timestamp,
+			Color:                          block.Color.String(),
+			IsInVirtualSelectedParentChain: block.IsInVirtualSelectedParentChain,
+			IsInVirtualDAAWindow:           block.IsInVirtualDAAWindow,
+		}
+	}
+
+	response := appmessage.NewExportDAGResponseMessage(hashes.ToStrings(dagExport.VirtualParents),
+		dagExport.VirtualBlueScore, dagExport.VirtualDAAScore, dagExport.PruningPoint.String(),
+		dagExport.FinalityPoint.String(), blocks)
+	return response, nil
+}
@@ -0,0 +1,146 @@
+package rpchandlers
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// maxGeneratedBlocks is the maximum number of blocks a single
+// GenerateBlocks request may generate
+const maxGeneratedBlocks = 1000
+
+// HandleGenerateBlocks handles the respectively named RPC command
+func HandleGenerateBlocks(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	generateBlocksRequest := request.(*appmessage.GenerateBlocksRequestMessage)
+
+	if !context.Config.Simnet && !context.Config.Devnet {
+		errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("GenerateBlocks is only available on simnet and devnet")
+		return errorMessage, nil
+	}
+
+	if generateBlocksRequest.BlockCount == 0 || generateBlocksRequest.BlockCount > maxGeneratedBlocks {
+		errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("blockCount must be between 1 and %d", maxGeneratedBlocks)
+		return errorMessage, nil
+	}
+
+	if context.ProtocolManager.IsIBDRunning() {
+		errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Blocks not generated - IBD is running")
+		return errorMessage, nil
+	}
+
+	payAddress, err := util.DecodeAddress(generateBlocksRequest.PayAddress, context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not decode address: %s", err)
+		return errorMessage, nil
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(payAddress)
+	if err != nil {
+		return nil, err
+	}
+	coinbaseData := &externalapi.DomainCoinbaseData{ScriptPublicKey: scriptPublicKey}
+
+	parentHashes := make([]*externalapi.DomainHash, len(generateBlocksRequest.ParentHashes))
+	for i, parentHashString := range generateBlocksRequest.ParentHashes {
+		parentHash, err := externalapi.NewDomainHashFromString(parentHashString)
+		if err != nil {
+			errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse parent hash %s: %s", parentHashString, err)
+			return errorMessage, nil
+		}
+		blockInfo, err := context.Domain.Consensus().GetBlockInfo(parentHash)
+		if err != nil {
+			return nil, err
+		}
+		if !blockInfo.Exists || blockInfo.BlockStatus == externalapi.StatusHeaderOnly {
+			errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Parent block %s was not found", parentHash)
+			return errorMessage, nil
+		}
+		parentHashes[i] = parentHash
+	}
+
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	targetTimePerBlockInMilliseconds := context.Config.ActiveNetParams.TargetTimePerBlock.Milliseconds()
+	blockHashes := make([]string, 0, generateBlocksRequest.BlockCount)
+	for i := uint32(0); i < generateBlocksRequest.BlockCount; i++ {
+		block, err := buildGeneratedBlock(context, coinbaseData, parentHashes)
+		if err != nil {
+			if !errors.As(err, &ruleerrors.RuleError{}) {
+				return nil, err
+			}
+			errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not build a block after generating %d blocks: %s",
+				len(blockHashes), err)
+			return errorMessage, nil
+		}
+
+		if generateBlocksRequest.Timestamp != 0 {
+			header := block.Header.ToMutable()
+			header.SetTimeInMilliseconds(generateBlocksRequest.Timestamp + int64(i)*targetTimePerBlockInMilliseconds)
+			block.Header = header.ToImmutable()
+		}
+		if !context.Config.ActiveNetParams.SkipProofOfWork {
+			mining.SolveBlock(block, random)
+		}
+
+		err = context.ProtocolManager.AddBlock(block)
+		if err != nil {
+			isProtocolOrRuleError := errors.As(err, &ruleerrors.RuleError{}) || errors.As(err, &protocolerrors.ProtocolError{})
+			if !isProtocolOrRuleError {
+				return nil, err
+			}
+			errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Block rejected after generating %d blocks. Reason: %s",
+				len(blockHashes), err)
+			return errorMessage, nil
+		}
+
+		blockHash := consensushashing.BlockHash(block)
+		blockHashes = append(blockHashes, blockHash.String())
+		if len(parentHashes) > 0 {
+			parentHashes = []*externalapi.DomainHash{blockHash}
+		}
+	}
+	log.Infof("Generated %d blocks via generateBlocks", len(blockHashes))
+
+	response := appmessage.NewGenerateBlocksResponseMessage(blockHashes)
+	return response, nil
+}
+
+// buildGeneratedBlock builds a block with no transactions other than its coinbase over
+// the given parents, or a block template with transactions from the mempool if no
+// parents are given
+func buildGeneratedBlock(context *rpccontext.Context, coinbaseData *externalapi.DomainCoinbaseData,
+	parentHashes []*externalapi.DomainHash) (*externalapi.DomainBlock, error) {
+
+	if len(parentHashes) > 0 {
+		// The mempool's transactions are validated against the virtual, so they
+		// might not be valid over other parents. Such blocks contain only their coinbase
+		block, _, err := context.Domain.Consensus().BuildBlockWithParents(parentHashes, coinbaseData, nil)
+		return block, err
+	}
+
+	templateBlock, err := context.Domain.MiningManager().GetBlockTemplate(coinbaseData)
+	if err != nil {
+		return nil, err
+	}
+
+	return miningmanager.BlockFromTemplate(templateBlock), nil
+}
@@ -0,0 +1,63 @@
+package rpchandlers_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/app/rpc/rpchandlers"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+func TestHandleGenerateBlocksValidation(t *testing.T) {
+	const payAddress = "kaspasim:qqqqnc0pxg7qw3qkc7l6sge8kfhsvvyt7mkw8uamtndqup27ftnd6c769gn66"
+
+	simnetConfig := &config.Config{Flags: &config.Flags{NetworkFlags: config.NetworkFlags{
+		Simnet: true, ActiveNetParams: &dagconfig.SimnetParams}}}
+	devnetConfig := &config.Config{Flags: &config.Flags{NetworkFlags: config.NetworkFlags{
+		Devnet: true, ActiveNetParams: &dagconfig.DevnetParams}}}
+	mainnetConfig := &config.Config{Flags: &config.Flags{NetworkFlags: config.NetworkFlags{
+		ActiveNetParams: &dagconfig.MainnetParams}}}
+	testnetConfig := &config.Config{Flags: &config.Flags{NetworkFlags: config.NetworkFlags{
+		Testnet: true, ActiveNetParams: &dagconfig.TestnetParams}}}
+	customNetworkConfig := &config.Config{Flags: &config.Flags{NetworkFlags: config.NetworkFlags{
+		NetworkDefinitionFile: "mynet.json", ActiveNetParams: &dagconfig.SimnetParams}}}
+
+	tests := []struct {
+		name          string
+		config        *config.Config
+		blockCount    uint32
+		expectedError string
+	}{
+		{name: "mainnet", config: mainnetConfig, blockCount: 1,
+			expectedError: "only available on simnet and devnet"},
+		{name: "testnet", config: testnetConfig, blockCount: 1,
+			expectedError: "only available on simnet and devnet"},
+		{name: "custom network", config: customNetworkConfig, blockCount: 1,
+			expectedError: "only available on simnet and devnet"},
+		{name: "no blocks on simnet", config: simnetConfig, blockCount: 0,
+			expectedError: "blockCount must be between 1 and 1000"},
+		{name: "too many blocks on simnet", config: simnetConfig, blockCount: 1001,
+			expectedError: "blockCount must be between 1 and 1000"},
+		{name: "too many blocks on devnet", config: devnetConfig, blockCount: 1001,
+			expectedError: "blockCount must be between 1 and 1000"},
+	}
+	for _, test := range tests {
+		context := &rpccontext.Context{Config: test.config}
+		request := appmessage.NewGenerateBlocksRequestMessage(payAddress, test.blockCount, nil, 0)
+		response, err := rpchandlers.HandleGenerateBlocks(context, nil, request)
+		if err != nil {
+			t.Fatalf("%s: HandleGenerateBlocks: %+v", test.name, err)
+		}
+		generateBlocksResponse := response.(*appmessage.GenerateBlocksResponseMessage)
+		if generateBlocksResponse.Error == nil {
+			t.Fatalf("%s: expected an error, but blocks were generated", test.name)
+		}
+		if !strings.Contains(generateBlocksResponse.Error.Message, test.expectedError) {
+			t.Fatalf("%s: expected an error containing %q, but got %q",
+				test.name, test.expectedError, generateBlocksResponse.Error.Message)
+		}
+	}
+}
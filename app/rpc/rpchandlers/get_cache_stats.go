@@ -0,0 +1,27 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetCacheStats handles the respectively named RPC command
+func HandleGetCacheStats(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	domainCacheStats := context.Domain.Consensus().CacheStats()
+	cacheStats := make([]*appmessage.CacheStatsMessage, len(domainCacheStats))
+	for i, stats := range domainCacheStats {
+		cacheStats[i] = &appmessage.CacheStatsMessage{
+			Name:      stats.Name,
+			Entries:   stats.Entries,
+			Size:      stats.Size,
+			Capacity:  stats.Capacity,
+			Hits:      stats.Hits,
+			Misses:    stats.Misses,
+			Evictions: stats.Evictions,
+		}
+	}
+
+	response := appmessage.NewGetCacheStatsResponseMessage(cacheStats)
+	return response, nil
+}
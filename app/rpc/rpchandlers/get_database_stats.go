@@ -0,0 +1,48 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetDatabaseStats handles the respectively named RPC command
+func HandleGetDatabaseStats(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	levelDB, ok := context.Database.(*ldb.LevelDB)
+	if !ok {
+		errorMessage := appmessage.NewGetDatabaseStatsResponseMessage()
+		errorMessage.Error = appmessage.RPCErrorf("Database stats are only available "+
+			"for the %s database backend", ldb.BackendName)
+		return errorMessage, nil
+	}
+
+	stats, err := levelDB.Stats()
+	if err != nil {
+		return nil, err
+	}
+
+	response := appmessage.NewGetDatabaseStatsResponseMessage()
+	response.Levels = make([]*appmessage.DatabaseLevelStats, len(stats.Levels))
+	for i, level := range stats.Levels {
+		response.Levels[i] = &appmessage.DatabaseLevelStats{
+			Level:                          uint32(level.Level),
+			TableCount:                     uint32(level.TableCount),
+			Size:                           uint64(level.Size),
+			ReadBytes:                      uint64(level.ReadBytes),
+			WrittenBytes:                   uint64(level.WrittenBytes),
+			CompactionDurationMilliseconds: uint64(level.CompactionDuration.Milliseconds()),
+		}
+	}
+	response.IOReadBytes = stats.IOReadBytes
+	response.IOWrittenBytes = stats.IOWrittenBytes
+	response.WriteDelayCount = uint32(stats.WriteDelayCount)
+	response.WriteDelayDurationMilliseconds = uint64(stats.WriteDelayDuration.Milliseconds())
+	response.BlockCacheSize = uint64(stats.BlockCacheSize)
+	response.OpenedTableCount = uint32(stats.OpenedTableCount)
+	response.MemoryCompactionCount = stats.MemoryCompactionCount
+	response.Level0CompactionCount = stats.Level0CompactionCount
+	response.NonLevel0CompactionCount = stats.NonLevel0CompactionCount
+	response.SeekCompactionCount = stats.SeekCompactionCount
+	return response, nil
+}
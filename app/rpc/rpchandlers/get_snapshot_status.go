@@ -0,0 +1,13 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetSnapshotStatus handles the respectively named RPC command
+func HandleGetSnapshotStatus(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	snapshotPath, isInProgress, failureMessage := context.SnapshotTracker.Status()
+	return appmessage.NewGetSnapshotStatusResponseMessage(snapshotPath, isInProgress, failureMessage), nil
+}
@@ -0,0 +1,28 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetSyncStatus handles the respectively named RPC command
+func HandleGetSyncStatus(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	syncStatus, err := context.ProtocolManager.SyncStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	response := appmessage.NewGetSyncStatusResponseMessage()
+	response.IsSynced = syncStatus.IsSynced
+	response.IsIBDRunning = syncStatus.IsIBDRunning
+	response.HeaderCount = syncStatus.HeaderCount
+	response.BlockCount = syncStatus.BlockCount
+	response.EstimatedMillisecondsRemaining = uint64(syncStatus.EstimatedTimeRemaining.Milliseconds())
+	response.PeerCount = uint32(syncStatus.PeerCount)
+	response.LastBlockAgeMilliseconds = -1
+	if !syncStatus.LastBlockTime.IsZero() {
+		response.LastBlockAgeMilliseconds = context.ProtocolManager.Clock().Now().Sub(syncStatus.LastBlockTime).Milliseconds()
+	}
+	return response, nil
+}
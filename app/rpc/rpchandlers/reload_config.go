@@ -0,0 +1,20 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleReloadConfig handles the respectively named RPC command
+func HandleReloadConfig(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	err := context.ConfigReloader.Reload()
+	if err != nil {
+		errorMessage := &appmessage.ReloadConfigResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not reload the configuration: %s", err)
+		return errorMessage, nil
+	}
+
+	response := appmessage.NewReloadConfigResponseMessage()
+	return response, nil
+}
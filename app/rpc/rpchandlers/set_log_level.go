@@ -0,0 +1,23 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleSetLogLevel handles the respectively named RPC command
+func HandleSetLogLevel(_ *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	setLogLevelRequest := request.(*appmessage.SetLogLevelRequestMessage)
+	err := logger.ParseAndSetLogLevels(setLogLevelRequest.LogLevel)
+	if err != nil {
+		errorMessage := &appmessage.SetLogLevelResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not set the log level: %s", err)
+		return errorMessage, nil
+	}
+
+	log.Infof("Set the log level to %s", setLogLevelRequest.LogLevel)
+	response := appmessage.NewSetLogLevelResponseMessage()
+	return response, nil
+}
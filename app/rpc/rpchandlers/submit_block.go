@@ -6,6 +6,7 @@ import (
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
 )
@@ -42,7 +43,8 @@ func HandleSubmitBlock(context *rpccontext.Context, _ *router.Router, request ap
 		}, nil
 	}
 
-	log.Infof("Accepted block %s via submitBlock", consensushashing.BlockHash(domainBlock))
+	blockHash := consensushashing.BlockHash(domainBlock)
+	log.WithFields(logger.Fields{"blockHash": blockHash}).Infof("Accepted block %s via submitBlock", blockHash)
 
 	response := appmessage.NewSubmitBlockResponseMessage()
 	return response, nil
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"os"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/multiset"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memdb"
+	"github.com/kaspanet/kaspad/infrastructure/db/migration"
+	"github.com/pkg/errors"
+)
+
+// pruningPointUTXOSetStep is the number of UTXOs that are read
+// at a time while validating the pruning point UTXO set
+const pruningPointUTXOSetStep = 1000
+
+// restoreSnapshot replaces the database with the snapshot in
+// cfg.RestoreSnapshot, once it's validated. The snapshot is copied
+// and validated next to the database, so a snapshot that fails to
+// validate leaves neither the snapshot nor the database changed.
+func restoreSnapshot(cfg *config.Config) (err error) {
+	if cfg.DbType == memdb.BackendName {
+		return errors.Errorf("snapshots cannot be restored into the %s database backend", memdb.BackendName)
+	}
+
+	dbPath := databasePath(cfg)
+	_, err = os.Stat(dbPath)
+	if err == nil {
+		return errors.Errorf("cannot restore a snapshot over the existing database in '%s'. "+
+			"Use --reset-db to remove it first", dbPath)
+	}
+	if !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+
+	restorePath := dbPath + "-restoring"
+	err = os.RemoveAll(restorePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(restorePath)
+		}
+	}()
+
+	log.Infof("Copying the snapshot in '%s' to '%s'", cfg.RestoreSnapshot, restorePath)
+	err = migration.CopyDatabase(cfg.RestoreSnapshot, restorePath)
+	if err != nil {
+		return err
+	}
+
+	// Snapshots only hold the database version inside the database,
+	// so the version file is created from it. Snapshots of older
+	// database versions are then migrated like any other database.
+	err = writeSnapshotVersionFile(cfg, restorePath)
+	if err != nil {
+		return err
+	}
+	err = checkDatabaseVersion(cfg, restorePath)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Validating the snapshot")
+	err = validateSnapshot(cfg, restorePath)
+	if err != nil {
+		return errors.Wrapf(err, "the snapshot in '%s' is invalid", cfg.RestoreSnapshot)
+	}
+
+	log.Infof("Restoring the snapshot into '%s'", dbPath)
+	return errors.WithStack(os.Rename(restorePath, dbPath))
+}
+
+func writeSnapshotVersionFile(cfg *config.Config, dbPath string) error {
+	db, err := database.Open(cfg.DbType, dbPath, databaseOptions(cfg))
+	if err != nil {
+		return err
+	}
+	version, found, err := migration.StoredVersion(db)
+	closeErr := db.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	if !found {
+		return errors.Errorf("'%s' isn't a snapshot of a %s database", cfg.RestoreSnapshot, cfg.DbType)
+	}
+	return writeDatabaseVersionFile(dbPath, version)
+}
+
+// validateSnapshot makes sure that the pruning point of the database in
+// dbPath is a valid pruning point of the active network, and that the
+// pruning point UTXO set matches the UTXO commitment of the pruning point
+func validateSnapshot(cfg *config.Config, dbPath string) error {
+	db, err := database.Open(cfg.DbType, dbPath, databaseOptions(cfg))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	consensusConfig := &consensus.Config{
+		Params:     *cfg.ActiveNetParams,
+		IsArchival: cfg.IsArchivalNode,
+	}
+	consensusInstance, err := consensus.NewFactory().NewConsensus(consensusConfig, db)
+	if err != nil {
+		return err
+	}
+
+	pruningPoint, err := consensusInstance.PruningPoint()
+	if err != nil {
+		return err
+	}
+	genesisHash := cfg.ActiveNetParams.GenesisHash
+	if !pruningPoint.Equal(genesisHash) {
+		isInSelectedChainOfGenesis, err := consensusInstance.IsInSelectedParentChainOf(genesisHash, pruningPoint)
+		if err != nil {
+			return err
+		}
+		if !isInSelectedChainOfGenesis {
+			return errors.Errorf("pruning point %s is not in the future of the genesis of %s",
+				pruningPoint, cfg.ActiveNetParams.Name)
+		}
+	}
+	isValidPruningPoint, err := consensusInstance.IsValidPruningPoint(pruningPoint)
+	if err != nil {
+		return err
+	}
+	if !isValidPruningPoint {
+		return errors.Errorf("%s is not a valid pruning point", pruningPoint)
+	}
+
+	pruningPointHeader, err := consensusInstance.GetBlockHeader(pruningPoint)
+	if err != nil {
+		return err
+	}
+	utxoSetHash, err := pruningPointUTXOSetHash(consensusInstance, pruningPoint)
+	if err != nil {
+		return err
+	}
+	if !pruningPointHeader.UTXOCommitment().Equal(utxoSetHash) {
+		return errors.Errorf("the UTXO set of pruning point %s doesn't match its UTXO commitment. "+
+			"Calculated UTXO set hash: %s. Commitment: %s",
+			pruningPoint, utxoSetHash, pruningPointHeader.UTXOCommitment())
+	}
+
+	log.Infof("Validated pruning point %s and its UTXO commitment %s", pruningPoint, utxoSetHash)
+	return nil
+}
+
+func pruningPointUTXOSetHash(consensusInstance externalapi.Consensus,
+	pruningPoint *externalapi.DomainHash) (*externalapi.DomainHash, error) {
+
+	utxoSetMultiset := multiset.New()
+	var fromOutpoint *externalapi.DomainOutpoint
+	for {
+		pruningPointUTXOs, err := consensusInstance.GetPruningPointUTXOs(pruningPoint, fromOutpoint, pruningPointUTXOSetStep)
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range pruningPointUTXOs {
+			serializedUTXO, err := utxo.SerializeUTXO(pair.UTXOEntry, pair.Outpoint)
+			if err != nil {
+				return nil, err
+			}
+			utxoSetMultiset.Add(serializedUTXO)
+		}
+		if len(pruningPointUTXOs) < pruningPointUTXOSetStep {
+			return utxoSetMultiset.Hash(), nil
+		}
+		fromOutpoint = pruningPointUTXOs[len(pruningPointUTXOs)-1].Outpoint
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+var (
+	defaultRPCPort = "16710"
+	defaultPort    = "16711"
+	defaultBase    = "devnet"
+)
+
+var baseNetworks = map[string]*dagconfig.Params{
+	"mainnet": &dagconfig.MainnetParams,
+	"testnet": &dagconfig.TestnetParams,
+	"simnet":  &dagconfig.SimnetParams,
+	"devnet":  &dagconfig.DevnetParams,
+}
+
+type configFlags struct {
+	Name                  string       `short:"n" long:"name" description:"The name of the network, e.g. kaspa-mynet" required:"true"`
+	Prefix                string       `short:"p" long:"prefix" description:"The address prefix of the network, e.g. kaspamynet" required:"true"`
+	Net                   *uint32      `long:"net" description:"The magic bytes of the network (default: derived from its name)"`
+	RPCPort               string       `long:"rpcport" description:"The default RPC port of the network"`
+	Port                  string       `long:"port" description:"The default P2P port of the network"`
+	Base                  string       `short:"b" long:"base" description:"The network whose params are used for everything that isn't set explicitly {mainnet, testnet, simnet, devnet}"`
+	K                     *model.KType `long:"k" description:"The GHOSTDAG K parameter"`
+	TargetTimePerBlock    *int64       `long:"target-time-per-block" description:"The target time per block, in milliseconds"`
+	BlockCoinbaseMaturity *uint64      `long:"block-coinbase-maturity" description:"The number of blocks before coinbase outputs, including the premine, can be spent"`
+	Bits                  *uint32      `long:"bits" description:"The difficulty bits of the genesis block (default: those of the base network)"`
+	Timestamp             *int64       `long:"timestamp" description:"The timestamp of the genesis block, in milliseconds since the epoch (default: now)"`
+	Premine               []string     `long:"premine" description:"An output of the genesis coinbase, as <address>=<amount in KAS>. Can be repeated"`
+	Output                string       `short:"o" long:"output" description:"The file to write the network definition to. If omitted, it is written to stdout"`
+}
+
+type premineOutput struct {
+	address string
+	amount  uint64
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		RPCPort: defaultRPCPort,
+		Port:    defaultPort,
+		Base:    defaultBase,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := baseNetworks[cfg.Base]; !ok {
+		return nil, errors.Errorf("unknown base network '%s'", cfg.Base)
+	}
+
+	return cfg, nil
+}
+
+func (cfg *configFlags) premineOutputs() ([]*premineOutput, error) {
+	outputs := make([]*premineOutput, len(cfg.Premine))
+	for i, premine := range cfg.Premine {
+		parts := strings.Split(premine, "=")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("premine '%s' is not of the form <address>=<amount>", premine)
+		}
+		amount, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || amount <= 0 {
+			return nil, errors.Errorf("premine '%s' has an invalid amount", premine)
+		}
+		outputs[i] = &premineOutput{
+			address: parts[0],
+			amount:  uint64(amount * util.SompiPerKaspa),
+		}
+	}
+	return outputs, nil
+}
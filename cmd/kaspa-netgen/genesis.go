@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/difficulty"
+	"github.com/pkg/errors"
+)
+
+// generateGenesisBlock builds the genesis block of the network, with the
+// premine outputs as the outputs of its coinbase, and solves its header
+func generateGenesisBlock(cfg *configFlags, params *dagconfig.Params) (*externalapi.DomainBlock, error) {
+	premineOutputs, err := cfg.premineOutputs()
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]*externalapi.DomainTransactionOutput, len(premineOutputs))
+	for i, premineOutput := range premineOutputs {
+		// Any known prefix is accepted, since the script public key of an
+		// address doesn't depend on its prefix
+		address, err := util.DecodeAddress(premineOutput.address, util.Bech32PrefixUnknown)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid premine address %s", premineOutput.address)
+		}
+		scriptPublicKey, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = &externalapi.DomainTransactionOutput{
+			Value:           premineOutput.amount,
+			ScriptPublicKey: scriptPublicKey,
+		}
+	}
+
+	bits := params.GenesisBlock.Header.Bits()
+	if cfg.Bits != nil {
+		bits = *cfg.Bits
+	}
+	if difficulty.CompactToBig(bits).Cmp(params.PowMax) > 0 {
+		return nil, errors.Errorf("the target of bits %#08x is higher than powMax", bits)
+	}
+
+	timestamp := time.Now().UnixNano() / int64(time.Millisecond)
+	if cfg.Timestamp != nil {
+		timestamp = *cfg.Timestamp
+	}
+
+	coinbasePayload := dagconfig.GenesisCoinbasePayload([]byte(params.Name))
+	genesisBlock := dagconfig.NewGenesisBlock(0, timestamp, bits, 0, coinbasePayload, outputs)
+
+	nonce, err := solveHeader(genesisBlock.Header.ToMutable())
+	if err != nil {
+		return nil, err
+	}
+	return dagconfig.NewGenesisBlock(0, timestamp, bits, nonce, coinbasePayload, outputs), nil
+}
+
+// solveHeader returns a nonce that satisfies the proof of work of the given header
+func solveHeader(header externalapi.MutableBlockHeader) (uint64, error) {
+	for nonce := uint64(0); nonce < math.MaxUint64; nonce++ {
+		header.SetNonce(nonce)
+		if pow.CheckProofOfWorkByBits(header) {
+			return nonce, nil
+		}
+	}
+	return 0, errors.Errorf("no nonce satisfies the proof of work of the genesis block")
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error parsing command-line arguments"))
+	}
+
+	params, err := newNetworkParams(cfg)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+	definition := dagconfig.NewNetworkDefinition(params)
+
+	// Make sure that the definition is loadable before writing it
+	_, err = definition.Params()
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "the generated network definition is invalid"))
+	}
+
+	var output io.Writer = os.Stdout
+	if cfg.Output != "" {
+		file, err := os.Create(cfg.Output)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(definition)
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error writing the network definition"))
+	}
+
+	fmt.Fprintf(os.Stderr, "Generated network %s with genesis %s\n", params.Name, params.GenesisHash)
+}
+
+// newNetworkParams returns the params of the base network, with everything
+// that identifies the network replaced and a newly generated genesis block
+func newNetworkParams(cfg *configFlags) (*dagconfig.Params, error) {
+	params := *baseNetworks[cfg.Base]
+
+	params.Name = cfg.Name
+	if cfg.Net != nil {
+		params.Net = appmessage.KaspaNet(*cfg.Net)
+	} else {
+		params.Net = netFromName(cfg.Name)
+	}
+	params.RPCPort = cfg.RPCPort
+	params.DefaultPort = cfg.Port
+	params.DNSSeeds = []string{}
+	params.GRPCSeeds = []string{}
+
+	prefix, err := util.RegisterBech32Prefix(cfg.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	params.Prefix = prefix
+
+	if cfg.K != nil {
+		params.K = *cfg.K
+	}
+	if cfg.TargetTimePerBlock != nil {
+		params.TargetTimePerBlock = time.Duration(*cfg.TargetTimePerBlock) * time.Millisecond
+	}
+	if cfg.BlockCoinbaseMaturity != nil {
+		params.BlockCoinbaseMaturity = *cfg.BlockCoinbaseMaturity
+	}
+
+	params.GenesisBlock, err = generateGenesisBlock(cfg, &params)
+	if err != nil {
+		return nil, err
+	}
+	params.GenesisHash = consensushashing.BlockHash(params.GenesisBlock)
+
+	return &params, nil
+}
+
+// netFromName derives the magic bytes of a network from its name, so that
+// networks with different names are unlikely to accept each other's peers
+func netFromName(name string) appmessage.KaspaNet {
+	hash := sha256.Sum256([]byte(name))
+	return appmessage.KaspaNet(binary.LittleEndian.Uint32(hash[:4]))
+}
+
+func printErrorAndExit(err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", err)
+	os.Exit(1)
+}
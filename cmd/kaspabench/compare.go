@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// comparison is the change in the median of a metric of a benchmark
+type comparison struct {
+	benchmarkName string
+	unit          string
+	oldMedian     float64
+	newMedian     float64
+}
+
+// deltaPercentage returns the change from the old median to the new one, in
+// percents of the old median
+func (c *comparison) deltaPercentage() float64 {
+	if c.oldMedian == 0 {
+		if c.newMedian == 0 {
+			return 0
+		}
+		return 100
+	}
+	return (c.newMedian - c.oldMedian) / c.oldMedian * 100
+}
+
+// compareResults compares the metrics that both results have. Benchmarks
+// that only one of the results has are skipped.
+func compareResults(oldResults, newResults *results) []*comparison {
+	oldBenchmarks := make(map[string]*benchmark, len(oldResults.Benchmarks))
+	for _, oldBenchmark := range oldResults.Benchmarks {
+		oldBenchmarks[oldBenchmark.Name] = oldBenchmark
+	}
+
+	var comparisons []*comparison
+	for _, newBenchmark := range newResults.Benchmarks {
+		oldBenchmark, ok := oldBenchmarks[newBenchmark.Name]
+		if !ok {
+			continue
+		}
+
+		units := make([]string, 0, len(newBenchmark.Samples))
+		for unit := range newBenchmark.Samples {
+			if _, ok := oldBenchmark.Samples[unit]; ok {
+				units = append(units, unit)
+			}
+		}
+		sort.Strings(units)
+
+		for _, unit := range units {
+			comparisons = append(comparisons, &comparison{
+				benchmarkName: newBenchmark.Name,
+				unit:          unit,
+				oldMedian:     median(oldBenchmark.Samples[unit]),
+				newMedian:     median(newBenchmark.Samples[unit]),
+			})
+		}
+	}
+	return comparisons
+}
+
+// compare prints the comparison of two results files, and returns whether any
+// metric grew by more than the threshold. All the metrics the suite reports
+// are better when lower.
+func compare(conf *compareConfig) (hasRegressions bool, err error) {
+	oldResults, err := readResults(conf.Positional.Old)
+	if err != nil {
+		return false, err
+	}
+	newResults, err := readResults(conf.Positional.New)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("old: %s\nnew: %s\n", oldResults.Commit, newResults.Commit)
+	if oldResults.CPU != newResults.CPU || oldResults.GOOS != newResults.GOOS ||
+		oldResults.GOARCH != newResults.GOARCH || oldResults.GoVersion != newResults.GoVersion {
+		fmt.Printf("Warning: the results were measured on different machines or Go versions "+
+			"(%s %s/%s %s vs. %s %s/%s %s)\n",
+			oldResults.CPU, oldResults.GOOS, oldResults.GOARCH, oldResults.GoVersion,
+			newResults.CPU, newResults.GOOS, newResults.GOARCH, newResults.GoVersion)
+	}
+	fmt.Println()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "benchmark\tunit\told\tnew\tdelta\t")
+	for _, comparison := range compareResults(oldResults, newResults) {
+		delta := comparison.deltaPercentage()
+		mark := ""
+		if delta > conf.Threshold {
+			mark = "regression"
+			hasRegressions = true
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%.4g\t%.4g\t%+.2f%%\t%s\n", comparison.benchmarkName, comparison.unit,
+			comparison.oldMedian, comparison.newMedian, delta, mark)
+	}
+	err = writer.Flush()
+	if err != nil {
+		return false, err
+	}
+	return hasRegressions, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+)
+
+const (
+	runSubCmd     = "run"
+	compareSubCmd = "compare"
+)
+
+type runConfig struct {
+	Output    string `short:"o" long:"output" description:"The file to write the results to" required:"true"`
+	Count     int    `long:"count" description:"Number of times to run each benchmark" default:"5"`
+	Bench     string `long:"bench" description:"Run only the benchmarks matching this regular expression" default:"."`
+	BenchTime string `long:"benchtime" description:"The -benchtime passed to go test, e.g. 2s or 100x"`
+	Package   string `long:"package" description:"The package of the benchmark suite" default:"./domain/benchmarks/"`
+}
+
+type compareConfig struct {
+	Threshold  float64 `long:"threshold" description:"Report a metric that grew by more than this percentage as a regression" default:"5"`
+	Positional struct {
+		Old string `positional-arg-name:"old-results" required:"true"`
+		New string `positional-arg-name:"new-results" required:"true"`
+	} `positional-args:"yes"`
+}
+
+func parseCommandLine() (subCommand string, config interface{}) {
+	parser := flags.NewParser(&struct{}{}, flags.PrintErrors|flags.HelpFlag)
+
+	runConf := &runConfig{}
+	parser.AddCommand(runSubCmd, "Runs the benchmark suite",
+		"Runs the benchmark suite of the current checkout, and writes the results along with the commit "+
+			"they were measured on", runConf)
+
+	compareConf := &compareConfig{}
+	parser.AddCommand(compareSubCmd, "Compares two results files",
+		"Compares the median of every metric in two results files, and exits with code 2 if any of them "+
+			"grew by more than the threshold", compareConf)
+
+	_, err := parser.Parse()
+	if err != nil {
+		var flagsErr *flags.Error
+		if ok := errors.As(err, &flagsErr); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		}
+		os.Exit(1)
+	}
+
+	switch parser.Command.Active.Name {
+	case runSubCmd:
+		if runConf.Count <= 0 {
+			printErrorAndExit(errors.New("--count must be positive"))
+		}
+		config = runConf
+	case compareSubCmd:
+		if compareConf.Threshold < 0 {
+			printErrorAndExit(errors.New("--threshold can't be negative"))
+		}
+		config = compareConf
+	}
+
+	return parser.Command.Active.Name, config
+}
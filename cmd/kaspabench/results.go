@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// results are the results of a run of the benchmark suite
+type results struct {
+	Commit     string       `json:"commit"`
+	GoVersion  string       `json:"goVersion"`
+	GOOS       string       `json:"goos"`
+	GOARCH     string       `json:"goarch"`
+	CPU        string       `json:"cpu"`
+	Benchmarks []*benchmark `json:"benchmarks"`
+}
+
+// benchmark holds the samples of every metric a benchmark reported, one
+// sample per run, keyed by the unit of the metric, e.g. ns/op
+type benchmark struct {
+	Name    string               `json:"name"`
+	Samples map[string][]float64 `json:"samples"`
+}
+
+// parseBenchmarkOutput parses the output of go test -bench into results with
+// no commit and Go version, with the benchmarks in the order they first appear
+func parseBenchmarkOutput(reader io.Reader) (*results, error) {
+	parsedResults := &results{}
+	benchmarksByName := make(map[string]*benchmark)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "goos: "):
+			parsedResults.GOOS = strings.TrimPrefix(line, "goos: ")
+			continue
+		case strings.HasPrefix(line, "goarch: "):
+			parsedResults.GOARCH = strings.TrimPrefix(line, "goarch: ")
+			continue
+		case strings.HasPrefix(line, "cpu: "):
+			parsedResults.CPU = strings.TrimPrefix(line, "cpu: ")
+			continue
+		}
+
+		// A result line is the name of the benchmark, the number of
+		// iterations, and pairs of a value and its unit
+		fields := strings.Fields(line)
+		if len(fields) < 4 || len(fields)%2 != 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		_, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		name := fields[0]
+		result, ok := benchmarksByName[name]
+		if !ok {
+			result = &benchmark{Name: name, Samples: make(map[string][]float64)}
+			benchmarksByName[name] = result
+			parsedResults.Benchmarks = append(parsedResults.Benchmarks, result)
+		}
+		for i := 2; i < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing the result line '%s'", line)
+			}
+			unit := fields[i+1]
+			result.Samples[unit] = append(result.Samples[unit], value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return parsedResults, nil
+}
+
+func median(samples []float64) float64 {
+	sorted := append([]float64{}, samples...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func writeResults(path string, runResults *results) error {
+	serializedResults, err := json.MarshalIndent(runResults, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(serializedResults, '\n'), 0644)
+}
+
+func readResults(path string) (*results, error) {
+	serializedResults, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	runResults := &results{}
+	err = json.Unmarshal(serializedResults, runResults)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s", path)
+	}
+	return runResults, nil
+}
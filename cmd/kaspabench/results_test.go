@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const benchmarkOutput = `goos: linux
+goarch: amd64
+pkg: github.com/kaspanet/kaspad/domain/benchmarks
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkGHOSTDAG/ghostdagmanager         	   40628	     28826 ns/op	    7203 B/op	      70 allocs/op
+BenchmarkValidateAndInsertBlock           	       1	2321948689 ns/op	   7739810 ns/block	657759008 B/op	 6800300 allocs/op
+BenchmarkGHOSTDAG/ghostdagmanager         	   41212	     28000 ns/op	    7203 B/op	      70 allocs/op
+PASS
+ok  	github.com/kaspanet/kaspad/domain/benchmarks	22.846s
+`
+
+func TestParseBenchmarkOutput(t *testing.T) {
+	parsedResults, err := parseBenchmarkOutput(strings.NewReader(benchmarkOutput))
+	if err != nil {
+		t.Fatalf("parseBenchmarkOutput: %+v", err)
+	}
+	if parsedResults.GOOS != "linux" || parsedResults.GOARCH != "amd64" || parsedResults.CPU != "Intel(R) Xeon(R) Processor" {
+		t.Fatalf("Unexpected environment: %s/%s %s", parsedResults.GOOS, parsedResults.GOARCH, parsedResults.CPU)
+	}
+
+	expectedBenchmarks := []*benchmark{
+		{
+			Name: "BenchmarkGHOSTDAG/ghostdagmanager",
+			Samples: map[string][]float64{
+				"ns/op":     {28826, 28000},
+				"B/op":      {7203, 7203},
+				"allocs/op": {70, 70},
+			},
+		},
+		{
+			Name: "BenchmarkValidateAndInsertBlock",
+			Samples: map[string][]float64{
+				"ns/op":     {2321948689},
+				"ns/block":  {7739810},
+				"B/op":      {657759008},
+				"allocs/op": {6800300},
+			},
+		},
+	}
+	if !reflect.DeepEqual(parsedResults.Benchmarks, expectedBenchmarks) {
+		t.Fatalf("Unexpected benchmarks.\nWant: %+v\nGot: %+v", expectedBenchmarks, parsedResults.Benchmarks)
+	}
+}
+
+func TestCompareResults(t *testing.T) {
+	oldResults := &results{Benchmarks: []*benchmark{
+		{Name: "BenchmarkA", Samples: map[string][]float64{"ns/op": {100, 300, 200}, "B/op": {10}}},
+		{Name: "BenchmarkRemoved", Samples: map[string][]float64{"ns/op": {1}}},
+	}}
+	newResults := &results{Benchmarks: []*benchmark{
+		{Name: "BenchmarkA", Samples: map[string][]float64{"ns/op": {250, 210}, "ns/block": {5}}},
+		{Name: "BenchmarkAdded", Samples: map[string][]float64{"ns/op": {1}}},
+	}}
+
+	comparisons := compareResults(oldResults, newResults)
+	if len(comparisons) != 1 {
+		t.Fatalf("Unexpected number of comparisons. Want: 1, got: %d", len(comparisons))
+	}
+	comparison := comparisons[0]
+	if comparison.benchmarkName != "BenchmarkA" || comparison.unit != "ns/op" {
+		t.Fatalf("Unexpected comparison of %s %s", comparison.benchmarkName, comparison.unit)
+	}
+	if comparison.oldMedian != 200 || comparison.newMedian != 230 {
+		t.Fatalf("Unexpected medians. Want: 200 and 230, got: %g and %g", comparison.oldMedian, comparison.newMedian)
+	}
+	if comparison.deltaPercentage() != 15 {
+		t.Fatalf("Unexpected delta. Want: 15%%, got: %g%%", comparison.deltaPercentage())
+	}
+}
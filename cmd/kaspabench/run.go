@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// run runs the benchmark suite with go test, prints its output as it goes,
+// and writes the parsed results to the output file
+func run(conf *runConfig) error {
+	commit, err := currentCommit()
+	if err != nil {
+		return err
+	}
+	goVersion, err := commandOutput("go", "env", "GOVERSION")
+	if err != nil {
+		return err
+	}
+
+	args := []string{"test", "-run", "^$", "-bench", conf.Bench, "-benchmem", "-count", fmt.Sprint(conf.Count)}
+	if conf.BenchTime != "" {
+		args = append(args, "-benchtime", conf.BenchTime)
+	}
+	args = append(args, conf.Package)
+
+	output := &bytes.Buffer{}
+	command := exec.Command("go", args...)
+	command.Stdout = io.MultiWriter(os.Stdout, output)
+	command.Stderr = os.Stderr
+	err = command.Run()
+	if err != nil {
+		return errors.Wrap(err, "error running the benchmarks")
+	}
+
+	runResults, err := parseBenchmarkOutput(output)
+	if err != nil {
+		return err
+	}
+	if len(runResults.Benchmarks) == 0 {
+		return errors.Errorf("no benchmark matched '%s'", conf.Bench)
+	}
+	runResults.Commit = commit
+	runResults.GoVersion = goVersion
+
+	err = writeResults(conf.Output, runResults)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wrote the results of %s to %s\n", commit, conf.Output)
+	return nil
+}
+
+// currentCommit returns the commit of the current checkout, suffixed with
+// -dirty if the checkout has uncommitted changes
+func currentCommit() (string, error) {
+	commit, err := commandOutput("git", "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	status, err := commandOutput("git", "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return "", err
+	}
+	if status != "" {
+		commit += "-dirty"
+	}
+	return commit, nil
+}
+
+func commandOutput(name string, args ...string) (string, error) {
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "error running %s %s", name, strings.Join(args, " "))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
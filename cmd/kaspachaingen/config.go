@@ -0,0 +1,58 @@
+package main
+
+import (
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/chaingenerator"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+type configFlags struct {
+	Output               string  `short:"o" long:"output" description:"The file to write the generated blocks to, in the format read by kaspareplay" required:"true"`
+	NumberOfBlocks       uint64  `short:"n" long:"blocks" description:"Number of blocks to generate, not including the genesis" required:"true"`
+	Seed                 int64   `long:"seed" description:"Seed of the random decisions of the generator -- The same seed and flags always generate the same blocks"`
+	BlockRate            float64 `long:"block-rate" description:"Number of blocks per second, which determines the block timestamps"`
+	Width                int     `long:"width" description:"Number of blocks that share the same parents"`
+	TransactionsPerBlock int     `long:"txs-per-block" description:"Average number of non-coinbase transactions in a block"`
+	UTXOSetSize          int     `long:"utxo-set-size" description:"Number of spendable outputs the generated transactions aim to keep"`
+	ReorgInterval        uint64  `long:"reorg-interval" description:"Average number of block rounds between reorgs -- 0 disables reorgs"`
+	MaxReorgDepth        uint64  `long:"max-reorg-depth" description:"Maximum number of block rounds a reorg reverts"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	defaultConfig := chaingenerator.DefaultConfig()
+	cfg := &configFlags{
+		Seed:                 defaultConfig.Seed,
+		BlockRate:            defaultConfig.BlockRate,
+		Width:                defaultConfig.Width,
+		TransactionsPerBlock: defaultConfig.TransactionsPerBlock,
+		UTXOSetSize:          defaultConfig.UTXOSetSize,
+		ReorgInterval:        defaultConfig.ReorgInterval,
+		MaxReorgDepth:        defaultConfig.MaxReorgDepth,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (cfg *configFlags) generatorConfig() *chaingenerator.Config {
+	return &chaingenerator.Config{
+		Seed:                 cfg.Seed,
+		NumberOfBlocks:       cfg.NumberOfBlocks,
+		BlockRate:            cfg.BlockRate,
+		Width:                cfg.Width,
+		TransactionsPerBlock: cfg.TransactionsPerBlock,
+		UTXOSetSize:          cfg.UTXOSetSize,
+		ReorgInterval:        cfg.ReorgInterval,
+		MaxReorgDepth:        cfg.MaxReorgDepth,
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kaspanet/kaspad/domain/blockrecorder"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/chaingenerator"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error parsing command-line arguments"))
+	}
+
+	err = generate(cfg)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+}
+
+// generate generates the blocks into a fresh consensus, and records each of
+// them, along with the outcome of its insertion, in the output file
+func generate(cfg *configFlags) error {
+	// Don't overwrite an existing recording
+	_, err := os.Stat(cfg.Output)
+	if err == nil {
+		return errors.Errorf("%s already exists", cfg.Output)
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	params := cfg.NetParams()
+	tc, teardown, err := consensus.NewFactory().NewTestConsensus(&consensus.Config{Params: *params}, "kaspachaingen")
+	if err != nil {
+		return errors.Wrap(err, "error creating the consensus")
+	}
+	defer teardown(false)
+
+	generator, err := chaingenerator.New(tc, cfg.generatorConfig())
+	if err != nil {
+		return err
+	}
+
+	// The blocks are written into a temporary file that's only renamed to the
+	// output path once all of them were generated, so that a failure doesn't
+	// leave a partial recording behind
+	temporaryFile, err := ioutil.TempFile(filepath.Dir(cfg.Output), filepath.Base(cfg.Output)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	temporaryPath := temporaryFile.Name()
+	err = temporaryFile.Close()
+	if err != nil {
+		return err
+	}
+	isRenamed := false
+	defer func() {
+		if !isRenamed {
+			os.Remove(temporaryPath)
+		}
+	}()
+
+	writer, err := blockrecorder.OpenWriter(temporaryPath, params.Name)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	generatedBlocks := 0
+	err = generator.Generate(func(block *externalapi.DomainBlock, _ *externalapi.BlockInsertionResult) error {
+		record, err := blockrecorder.NewRecord(tc, block, nil)
+		if err != nil {
+			return err
+		}
+		err = writer.Write(record)
+		if err != nil {
+			return err
+		}
+
+		generatedBlocks++
+		if generatedBlocks%progressInterval == 0 {
+			fmt.Printf("Generated %d blocks\n", generatedBlocks)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(temporaryPath, cfg.Output)
+	if err != nil {
+		return err
+	}
+	isRenamed = true
+
+	fmt.Printf("Generated %d blocks with %d transactions into %s\n",
+		generatedBlocks, generator.GeneratedTransactionCount(), cfg.Output)
+	return nil
+}
+
+const progressInterval = 1000
+
+func printErrorAndExit(err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", err)
+	os.Exit(1)
+}
@@ -20,6 +20,7 @@ var commandTypes = []reflect.Type{
 	reflect.TypeOf(protowire.KaspadMessage_GetHeadersRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetBlockCountRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetBlockDagInfoRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetSyncStatusRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetSelectedTipHashRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetVirtualSelectedParentBlueScoreRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetVirtualSelectedParentChainFromBlockRequest{}),
@@ -36,6 +37,16 @@ var commandTypes = []reflect.Type{
 
 	reflect.TypeOf(protowire.KaspadMessage_BanRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_UnbanRequest{}),
+
+	reflect.TypeOf(protowire.KaspadMessage_GetDatabaseStatsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_CompactDatabaseRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_CreateSnapshotRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetSnapshotStatusRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_SetLogLevelRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ReloadConfigRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetCacheStatsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GenerateBlocksRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ExportDagRequest{}),
 }
 
 type commandDescription struct {
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	jsonFormat    = "json"
+	graphMLFormat = "graphml"
+)
+
+var (
+	defaultRPCServer        = "localhost"
+	defaultTimeout   uint64 = 60
+)
+
+type configFlags struct {
+	RPCServer string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	Timeout   uint64 `short:"t" long:"timeout" description:"Timeout for the request (in seconds)"`
+	Depth     uint64 `short:"d" long:"depth" description:"How far below the virtual, in blue score, the exported window reaches (default: 100, at most 10000)"`
+	Format    string `short:"f" long:"format" description:"The format of the export {json, graphml}" default:"json"`
+	Output    string `short:"o" long:"output" description:"The file to write the export to. If omitted, the export is written to stdout"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		RPCServer: defaultRPCServer,
+		Timeout:   defaultTimeout,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Format != jsonFormat && cfg.Format != graphMLFormat {
+		return nil, errors.Errorf("unknown format '%s'. The format must be either %s or %s",
+			cfg.Format, jsonFormat, graphMLFormat)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Data        []graphMLData `xml:"data"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// graphMLKeys declares every attribute that appears in the GraphML output,
+// as GraphML requires
+var graphMLKeys = []graphMLKey{
+	{ID: "virtualParentHashes", For: "graph", AttrName: "virtualParentHashes", AttrType: "string"},
+	{ID: "virtualBlueScore", For: "graph", AttrName: "virtualBlueScore", AttrType: "long"},
+	{ID: "virtualDaaScore", For: "graph", AttrName: "virtualDaaScore", AttrType: "long"},
+	{ID: "pruningPointHash", For: "graph", AttrName: "pruningPointHash", AttrType: "string"},
+	{ID: "finalityPointHash", For: "graph", AttrName: "finalityPointHash", AttrType: "string"},
+	{ID: "status", For: "node", AttrName: "status", AttrType: "string"},
+	{ID: "blueScore", For: "node", AttrName: "blueScore", AttrType: "long"},
+	{ID: "blueWork", For: "node", AttrName: "blueWork", AttrType: "string"},
+	{ID: "daaScore", For: "node", AttrName: "daaScore", AttrType: "long"},
+	{ID: "timestamp", For: "node", AttrName: "timestamp", AttrType: "long"},
+	{ID: "color", For: "node", AttrName: "color", AttrType: "string"},
+	{ID: "mergeSetBluesHashes", For: "node", AttrName: "mergeSetBluesHashes", AttrType: "string"},
+	{ID: "mergeSetRedsHashes", For: "node", AttrName: "mergeSetRedsHashes", AttrType: "string"},
+	{ID: "isInVirtualSelectedParentChain", For: "node", AttrName: "isInVirtualSelectedParentChain", AttrType: "boolean"},
+	{ID: "isInVirtualDaaWindow", For: "node", AttrName: "isInVirtualDaaWindow", AttrType: "boolean"},
+	{ID: "isPruningPoint", For: "node", AttrName: "isPruningPoint", AttrType: "boolean"},
+	{ID: "isFinalityPoint", For: "node", AttrName: "isFinalityPoint", AttrType: "boolean"},
+	{ID: "isSelectedParent", For: "edge", AttrName: "isSelectedParent", AttrType: "boolean"},
+}
+
+// writeGraphML writes the exported DAG as a GraphML graph, where every block is a
+// node and every block points to each of its parents with an edge. Since GraphML
+// doesn't allow dangling edges, edges to parents outside of the exported window
+// are omitted. Lists of hashes are written as space-separated strings.
+func writeGraphML(writer io.Writer, response *appmessage.ExportDAGResponseMessage) error {
+	graph := graphMLGraph{
+		ID:          "dag",
+		EdgeDefault: "directed",
+		Data: []graphMLData{
+			{Key: "virtualParentHashes", Value: strings.Join(response.VirtualParentHashes, " ")},
+			{Key: "virtualBlueScore", Value: strconv.FormatUint(response.VirtualBlueScore, 10)},
+			{Key: "virtualDaaScore", Value: strconv.FormatUint(response.VirtualDAAScore, 10)},
+			{Key: "pruningPointHash", Value: response.PruningPointHash},
+			{Key: "finalityPointHash", Value: response.FinalityPointHash},
+		},
+	}
+
+	exportedBlocks := make(map[string]struct{}, len(response.Blocks))
+	for _, block := range response.Blocks {
+		exportedBlocks[block.Hash] = struct{}{}
+	}
+
+	for _, block := range response.Blocks {
+		graph.Nodes = append(graph.Nodes, graphMLNode{
+			ID: block.Hash,
+			Data: []graphMLData{
+				{Key: "status", Value: block.Status},
+				{Key: "blueScore", Value: strconv.FormatUint(block.BlueScore, 10)},
+				{Key: "blueWork", Value: block.BlueWork},
+				{Key: "daaScore", Value: strconv.FormatUint(block.DAAScore, 10)},
+				{Key: "timestamp", Value: strconv.FormatInt(block.Timestamp, 10)},
+				{Key: "color", Value: block.Color},
+				{Key: "mergeSetBluesHashes", Value: strings.Join(block.MergeSetBluesHashes, " ")},
+				{Key: "mergeSetRedsHashes", Value: strings.Join(block.MergeSetRedsHashes, " ")},
+				{Key: "isInVirtualSelectedParentChain", Value: strconv.FormatBool(block.IsInVirtualSelectedParentChain)},
+				{Key: "isInVirtualDaaWindow", Value: strconv.FormatBool(block.IsInVirtualDAAWindow)},
+				{Key: "isPruningPoint", Value: strconv.FormatBool(block.Hash == response.PruningPointHash)},
+				{Key: "isFinalityPoint", Value: strconv.FormatBool(block.Hash == response.FinalityPointHash)},
+			},
+		})
+
+		for _, parentHash := range block.ParentHashes {
+			if _, ok := exportedBlocks[parentHash]; !ok {
+				continue
+			}
+			graph.Edges = append(graph.Edges, graphMLEdge{
+				Source: block.Hash,
+				Target: parentHash,
+				Data: []graphMLData{
+					{Key: "isSelectedParent", Value: strconv.FormatBool(parentHash == block.SelectedParentHash)},
+				},
+			})
+		}
+	}
+
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(&graphMLDocument{
+		XMLNS: graphMLNamespace,
+		Keys:  graphMLKeys,
+		Graph: graph,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
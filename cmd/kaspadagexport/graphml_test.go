@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestWriteGraphML(t *testing.T) {
+	response := appmessage.NewExportDAGResponseMessage([]string{"c"}, 3, 3, "a", "a", []*appmessage.DAGExportBlock{
+		{Hash: "a", ParentHashes: []string{"outside"}, SelectedParentHash: "outside", Color: "blue"},
+		{Hash: "b", ParentHashes: []string{"a"}, SelectedParentHash: "a", Color: "blue"},
+		{Hash: "c", ParentHashes: []string{"a", "b"}, SelectedParentHash: "b", Color: "red"},
+	})
+
+	var buffer bytes.Buffer
+	err := writeGraphML(&buffer, response)
+	if err != nil {
+		t.Fatalf("writeGraphML: %+v", err)
+	}
+
+	document := &graphMLDocument{}
+	err = xml.Unmarshal(buffer.Bytes(), document)
+	if err != nil {
+		t.Fatalf("The output is not valid XML: %+v\n%s", err, buffer.String())
+	}
+	if document.XMLNS != graphMLNamespace {
+		t.Fatalf("Unexpected namespace %s", document.XMLNS)
+	}
+	if len(document.Graph.Nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", len(document.Graph.Nodes))
+	}
+
+	// The edge from a to its parent outside of the window is omitted
+	type edge struct{ source, target, isSelectedParent string }
+	expectedEdges := []edge{{"b", "a", "true"}, {"c", "a", "false"}, {"c", "b", "true"}}
+	if len(document.Graph.Edges) != len(expectedEdges) {
+		t.Fatalf("Expected %d edges, got %d", len(expectedEdges), len(document.Graph.Edges))
+	}
+	for i, expectedEdge := range expectedEdges {
+		graphMLEdge := document.Graph.Edges[i]
+		actualEdge := edge{graphMLEdge.Source, graphMLEdge.Target, graphMLEdge.Data[0].Value}
+		if actualEdge != expectedEdge {
+			t.Errorf("Expected edge %d to be %v, got %v", i, expectedEdge, actualEdge)
+		}
+	}
+
+	keys := make(map[string]struct{}, len(document.Keys))
+	for _, key := range document.Keys {
+		keys[key.ID] = struct{}{}
+	}
+	allData := document.Graph.Data
+	for _, node := range document.Graph.Nodes {
+		allData = append(allData, node.Data...)
+	}
+	for _, graphMLEdge := range document.Graph.Edges {
+		allData = append(allData, graphMLEdge.Data...)
+	}
+	for _, data := range allData {
+		if _, ok := keys[data.Key]; !ok {
+			t.Errorf("Data key %s is not declared", data.Key)
+		}
+	}
+}
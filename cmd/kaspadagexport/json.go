@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+type jsonDAGExport struct {
+	VirtualParentHashes []string        `json:"virtualParentHashes"`
+	VirtualBlueScore    uint64          `json:"virtualBlueScore"`
+	VirtualDAAScore     uint64          `json:"virtualDaaScore"`
+	PruningPointHash    string          `json:"pruningPointHash"`
+	FinalityPointHash   string          `json:"finalityPointHash"`
+	Blocks              []*jsonDAGBlock `json:"blocks"`
+}
+
+type jsonDAGBlock struct {
+	Hash                           string   `json:"hash"`
+	ParentHashes                   []string `json:"parentHashes"`
+	SelectedParentHash             string   `json:"selectedParentHash"`
+	MergeSetBluesHashes            []string `json:"mergeSetBluesHashes"`
+	MergeSetRedsHashes             []string `json:"mergeSetRedsHashes"`
+	Status                         string   `json:"status"`
+	BlueScore                      uint64   `json:"blueScore"`
+	BlueWork                       string   `json:"blueWork"`
+	DAAScore                       uint64   `json:"daaScore"`
+	Timestamp                      int64    `json:"timestamp"`
+	Color                          string   `json:"color"`
+	IsInVirtualSelectedParentChain bool     `json:"isInVirtualSelectedParentChain"`
+	IsInVirtualDAAWindow           bool     `json:"isInVirtualDaaWindow"`
+}
+
+// writeJSON writes the exported DAG as a single indented JSON object
+func writeJSON(writer io.Writer, response *appmessage.ExportDAGResponseMessage) error {
+	dagExport := &jsonDAGExport{
+		VirtualParentHashes: nonNilHashes(response.VirtualParentHashes),
+		VirtualBlueScore:    response.VirtualBlueScore,
+		VirtualDAAScore:     response.VirtualDAAScore,
+		PruningPointHash:    response.PruningPointHash,
+		FinalityPointHash:   response.FinalityPointHash,
+		Blocks:              make([]*jsonDAGBlock, len(response.Blocks)),
+	}
+	for i, block := range response.Blocks {
+		dagExport.Blocks[i] = &jsonDAGBlock{
+			Hash:                           block.Hash,
+			ParentHashes:                   nonNilHashes(block.ParentHashes),
+			SelectedParentHash:             block.SelectedParentHash,
+			MergeSetBluesHashes:            nonNilHashes(block.MergeSetBluesHashes),
+			MergeSetRedsHashes:             nonNilHashes(block.MergeSetRedsHashes),
+			Status:                         block.Status,
+			BlueScore:                      block.BlueScore,
+			BlueWork:                       block.BlueWork,
+			DAAScore:                       block.DAAScore,
+			Timestamp:                      block.Timestamp,
+			Color:                          block.Color,
+			IsInVirtualSelectedParentChain: block.IsInVirtualSelectedParentChain,
+			IsInVirtualDAAWindow:           block.IsInVirtualDAAWindow,
+		}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dagExport)
+}
+
+// nonNilHashes makes empty hash lists, which come over the wire as nil,
+// encode as [] rather than null
+func nonNilHashes(hashes []string) []string {
+	if hashes == nil {
+		return []string{}
+	}
+	return hashes
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error parsing command-line arguments"))
+	}
+
+	rpcAddress, err := cfg.NetParams().NormalizeRPCServerAddress(cfg.RPCServer)
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error parsing RPC server address"))
+	}
+	client, err := rpcclient.NewRPCClient(rpcAddress)
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error connecting to the RPC server"))
+	}
+	defer client.Close()
+	client.SetTimeout(time.Duration(cfg.Timeout) * time.Second)
+
+	response, err := client.ExportDAG(cfg.Depth)
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error exporting the DAG"))
+	}
+
+	var output io.Writer = os.Stdout
+	if cfg.Output != "" {
+		file, err := os.Create(cfg.Output)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	switch cfg.Format {
+	case jsonFormat:
+		err = writeJSON(output, response)
+	case graphMLFormat:
+		err = writeGraphML(output, response)
+	}
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error writing the export"))
+	}
+}
+
+func printErrorAndExit(err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/migration"
+)
+
+type bucketStats struct {
+	name       string
+	isBucket   bool
+	keyCount   int
+	keysSize   int
+	valuesSize int
+}
+
+func buckets(conf *bucketsConfig) error {
+	db, err := openDatabase(&conf.databaseFlags, true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	version, found, err := migration.StoredVersion(db)
+	if err != nil {
+		return err
+	}
+	if found {
+		fmt.Printf("Database version: %d\n\n", version)
+	} else {
+		fmt.Printf("Database version: unknown\n\n")
+	}
+
+	allStats, err := collectBucketStats(db)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "Name\tKeys\tKey bytes\tValue bytes\t\n")
+	total := &bucketStats{}
+	for _, stats := range allStats {
+		name := stats.name
+		if stats.isBucket {
+			name += string(bucketSeparator)
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t\n", name, stats.keyCount, stats.keysSize, stats.valuesSize)
+		total.keyCount += stats.keyCount
+		total.keysSize += stats.keysSize
+		total.valuesSize += stats.valuesSize
+	}
+	fmt.Fprintf(writer, "Total\t%d\t%d\t%d\t\n", total.keyCount, total.keysSize, total.valuesSize)
+	return writer.Flush()
+}
+
+// collectBucketStats goes over the whole database, and returns the stats of
+// every top-level bucket and of every key that isn't in any bucket, sorted
+// by name
+func collectBucketStats(db database.Database) ([]*bucketStats, error) {
+	cursor, err := db.Cursor(database.MakeBucket(nil))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	statsByName := make(map[string]*bucketStats)
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		keyBytes := key.Bytes()
+		name, _, isInBucket := splitKey(keyBytes)
+		stats, ok := statsByName[name]
+		if !ok {
+			stats = &bucketStats{name: name, isBucket: isInBucket}
+			statsByName[name] = stats
+		}
+		stats.keyCount++
+		stats.keysSize += len(keyBytes)
+		stats.valuesSize += len(value)
+	}
+
+	allStats := make([]*bucketStats, 0, len(statsByName))
+	for _, stats := range statsByName {
+		allStats = append(allStats, stats)
+	}
+	sort.Slice(allStats, func(i, j int) bool { return allStats[i].name < allStats[j].name })
+	return allStats, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+const (
+	bucketsSubCmd          = "buckets"
+	dumpSubCmd             = "dump"
+	verifySubCmd           = "verify"
+	rebuildUTXOIndexSubCmd = "rebuild-utxoindex"
+)
+
+var defaultAppDir = config.DefaultAppDir
+
+type configFlags struct {
+	config.NetworkFlags
+}
+
+type databaseFlags struct {
+	AppDir string `long:"appdir" short:"b" description:"The directory kaspad stores its data in (default: ~/.kaspad (*nix), %LOCALAPPDATA%\\Kaspad (Windows))"`
+	DbType string `long:"dbtype" description:"The database backend kaspad uses {leveldb, logdb}"`
+	config.NetworkFlags
+}
+
+// databasePath returns the path of the database of the selected
+// network, in the same way kaspad resolves it
+func (dbFlags *databaseFlags) databasePath() string {
+	return filepath.Join(dbFlags.AppDir, dbFlags.NetParams().Name, "data")
+}
+
+type bucketsConfig struct {
+	databaseFlags
+}
+
+type dumpConfig struct {
+	Bucket string `long:"bucket" short:"n" description:"The bucket or key to dump, as listed by the buckets command" required:"true"`
+	Key    string `long:"key" short:"k" description:"Only dump the entry whose key is the given hex, without the bucket name"`
+	Limit  int    `long:"limit" short:"l" description:"The maximum number of entries to dump" default:"10"`
+	Raw    bool   `long:"raw" description:"Print values in hex instead of decoding them"`
+	databaseFlags
+}
+
+type verifyConfig struct {
+	databaseFlags
+}
+
+type rebuildUTXOIndexConfig struct {
+	databaseFlags
+}
+
+func parseCommandLine() (subCommand string, config interface{}) {
+	cfg := &configFlags{}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+
+	bucketsConf := &bucketsConfig{}
+	parser.AddCommand(bucketsSubCmd, "Lists the buckets in the database",
+		"Lists the buckets and keys in the database, with their number of keys and their sizes", bucketsConf)
+
+	dumpConf := &dumpConfig{}
+	parser.AddCommand(dumpSubCmd, "Prints the entries of a bucket",
+		"Prints the entries of a bucket or the value of a key, decoded as JSON", dumpConf)
+
+	verifyConf := &verifyConfig{}
+	parser.AddCommand(verifySubCmd, "Verifies the consistency of the database",
+		"Verifies that the consensus stores and the UTXO index are consistent with each other", verifyConf)
+
+	rebuildUTXOIndexConf := &rebuildUTXOIndexConfig{}
+	parser.AddCommand(rebuildUTXOIndexSubCmd, "Rebuilds the UTXO index",
+		"Deletes the UTXO index and rebuilds it from the virtual UTXO set. "+
+			"Unlike the other commands, this one writes to the database", rebuildUTXOIndexConf)
+
+	_, err := parser.Parse()
+
+	if err != nil {
+		var flagsErr *flags.Error
+		if ok := errors.As(err, &flagsErr); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		} else {
+			os.Exit(1)
+		}
+		return "", nil
+	}
+
+	var activeDatabaseFlags *databaseFlags
+	switch parser.Command.Active.Name {
+	case bucketsSubCmd:
+		activeDatabaseFlags = &bucketsConf.databaseFlags
+		config = bucketsConf
+	case dumpSubCmd:
+		activeDatabaseFlags = &dumpConf.databaseFlags
+		config = dumpConf
+	case verifySubCmd:
+		activeDatabaseFlags = &verifyConf.databaseFlags
+		config = verifyConf
+	case rebuildUTXOIndexSubCmd:
+		activeDatabaseFlags = &rebuildUTXOIndexConf.databaseFlags
+		config = rebuildUTXOIndexConf
+	}
+
+	combineNetworkFlags(&activeDatabaseFlags.NetworkFlags, &cfg.NetworkFlags)
+	err = activeDatabaseFlags.ResolveNetwork(parser)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+	if activeDatabaseFlags.AppDir == "" {
+		activeDatabaseFlags.AppDir = defaultAppDir
+	}
+	if activeDatabaseFlags.DbType == "" {
+		activeDatabaseFlags.DbType = ldb.BackendName
+	}
+
+	return parser.Command.Active.Name, config
+}
+
+func combineNetworkFlags(dst, src *config.NetworkFlags) {
+	dst.Testnet = dst.Testnet || src.Testnet
+	dst.Simnet = dst.Simnet || src.Simnet
+	dst.Devnet = dst.Devnet || src.Devnet
+	if dst.OverrideDAGParamsFile == "" {
+		dst.OverrideDAGParamsFile = src.OverrideDAGParamsFile
+	}
+	if dst.NetworkDefinitionFile == "" {
+		dst.NetworkDefinitionFile = src.NetworkDefinitionFile
+	}
+}
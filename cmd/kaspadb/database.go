@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+
+	// Register the database backends kaspad may use
+	_ "github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	_ "github.com/kaspanet/kaspad/infrastructure/db/database/logdb"
+)
+
+const databaseCacheSizeMiB = 64
+
+// openDatabase opens the database kaspad uses for the network in dbFlags.
+// Databases that are opened as read-only are never modified.
+func openDatabase(dbFlags *databaseFlags, readOnly bool) (database.Database, error) {
+	options := database.DefaultOptions(databaseCacheSizeMiB)
+	options.ReadOnly = readOnly
+	db, err := database.Open(dbFlags.DbType, dbFlags.databasePath(), options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open the database in %s. "+
+			"Note that kaspad must not be running while using kaspadb", dbFlags.databasePath())
+	}
+	return db, nil
+}
+
+// splitKey splits the given database key into the name of the bucket it's
+// in and the rest of the key. Keys that are not in any bucket have no
+// separator, and isInBucket is false for them.
+func splitKey(key []byte) (bucketName string, suffix []byte, isInBucket bool) {
+	for i, b := range key {
+		if b == bucketSeparator {
+			return string(key[:i]), key[i+1:], true
+		}
+	}
+	return string(key), nil, false
+}
+
+const bucketSeparator = '/'
@@ -0,0 +1,463 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/kaspanet/kaspad/domain/consensus/database/binaryserialization"
+	"github.com/kaspanet/kaspad/domain/consensus/database/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// entryDecoder decodes an entry of a bucket, given the suffix of its key
+// and its value, into values that can be printed as JSON
+type entryDecoder func(keySuffix []byte, value []byte) (key interface{}, decodedValue interface{}, err error)
+
+// bucketDecoders are the decoders of the entries in each of the buckets
+// that kaspad stores, by bucket name
+var bucketDecoders = map[string]entryDecoder{
+	"blocks":                       hashKey(decodeBlock),
+	"block-headers":                hashKey(decodeBlockHeader),
+	"block-statuses":               hashKey(decodeBlockStatus),
+	"block-relations":              hashKey(decodeBlockRelations),
+	"block-ghostdag-data":          hashKey(decodeGHOSTDAGData),
+	"reachability-data":            hashKey(decodeReachabilityData),
+	"utxo-diffs":                   hashKey(decodeUTXODiff),
+	"utxo-diff-children":           hashKey(decodeDBHash),
+	"chain-block-index-by-hash":    hashKey(decodeUint64),
+	"chain-block-hash-by-index":    decodeChainBlockHashByIndex,
+	"virtual-utxo-set":             decodeUTXOSetEntry,
+	"pruning-point-utxo-set":       decodeUTXOSetEntry,
+	"imported-pruning-point-utxos": decodeUTXOSetEntry,
+	"utxo-index":                   decodeUTXOIndexEntry,
+}
+
+// keyDecoders are the decoders of the values of the keys that are
+// stored outside of any bucket, by key
+var keyDecoders = map[string]func(value []byte) (interface{}, error){
+	"tips":                         decodeTips,
+	"blocks-count":                 decodeBlockCount,
+	"block-headers-count":          decodeBlockHeaderCount,
+	"headers-selected-tip":         decodeDBHash,
+	"highest-chain-block-index":    decodeUint64,
+	"pruning-block-hash":           decodeDBHash,
+	"previous-pruning-block-hash":  decodeDBHash,
+	"candidate-pruning-point-hash": decodeDBHash,
+	"reachability-reindex-root":    decodeDBHash,
+	"utxo-index-virtual-parents":   decodeUTXOIndexVirtualParents,
+	"database-version":             decodeUint64,
+}
+
+// hashKey returns a decoder of entries that are keyed by block hash
+// and whose values are decoded by decodeValue
+func hashKey(decodeValue func(value []byte) (interface{}, error)) entryDecoder {
+	return func(keySuffix []byte, value []byte) (interface{}, interface{}, error) {
+		hash, err := externalapi.NewDomainHashFromByteSlice(keySuffix)
+		if err != nil {
+			return nil, nil, err
+		}
+		decodedValue, err := decodeValue(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		return hash.String(), decodedValue, nil
+	}
+}
+
+type blockHeader struct {
+	Version              uint16
+	ParentHashes         []string
+	HashMerkleRoot       string
+	AcceptedIDMerkleRoot string
+	UTXOCommitment       string
+	TimeInMilliseconds   int64
+	Bits                 uint32
+	Nonce                uint64
+}
+
+type block struct {
+	Header         *blockHeader
+	TransactionIDs []string
+}
+
+type blockRelations struct {
+	Parents  []string
+	Children []string
+}
+
+type ghostdagData struct {
+	BlueScore          uint64
+	BlueWork           string
+	SelectedParent     string
+	MergeSetBlues      []string
+	MergeSetReds       []string
+	BluesAnticoneSizes map[string]model.KType
+}
+
+type reachabilityData struct {
+	Parent            string
+	Children          []string
+	IntervalStart     uint64
+	IntervalEnd       uint64
+	FutureCoveringSet []string
+}
+
+type utxoEntry struct {
+	Outpoint               string `json:",omitempty"`
+	Amount                 uint64
+	ScriptPublicKey        string
+	ScriptPublicKeyVersion uint16
+	BlockDAAScore          uint64
+	IsCoinbase             bool
+}
+
+type utxoDiff struct {
+	ToAdd    []*utxoEntry
+	ToRemove []*utxoEntry
+}
+
+type utxoIndexKey struct {
+	ScriptPublicKey        string
+	ScriptPublicKeyVersion uint16
+	Outpoint               string
+}
+
+func hashStrings(hashes []*externalapi.DomainHash) []string {
+	hashStrings := make([]string, len(hashes))
+	for i, hash := range hashes {
+		hashStrings[i] = hash.String()
+	}
+	return hashStrings
+}
+
+func hashString(hash *externalapi.DomainHash) string {
+	if hash == nil {
+		return ""
+	}
+	return hash.String()
+}
+
+func toBlockHeader(header externalapi.BlockHeader) *blockHeader {
+	return &blockHeader{
+		Version:              header.Version(),
+		ParentHashes:         hashStrings(header.ParentHashes()),
+		HashMerkleRoot:       header.HashMerkleRoot().String(),
+		AcceptedIDMerkleRoot: header.AcceptedIDMerkleRoot().String(),
+		UTXOCommitment:       header.UTXOCommitment().String(),
+		TimeInMilliseconds:   header.TimeInMilliseconds(),
+		Bits:                 header.Bits(),
+		Nonce:                header.Nonce(),
+	}
+}
+
+func toUTXOEntry(outpoint *externalapi.DomainOutpoint, entry externalapi.UTXOEntry) *utxoEntry {
+	decodedEntry := &utxoEntry{
+		Amount:                 entry.Amount(),
+		ScriptPublicKey:        hex.EncodeToString(entry.ScriptPublicKey().Script),
+		ScriptPublicKeyVersion: entry.ScriptPublicKey().Version,
+		BlockDAAScore:          entry.BlockDAAScore(),
+		IsCoinbase:             entry.IsCoinbase(),
+	}
+	if outpoint != nil {
+		decodedEntry.Outpoint = outpoint.String()
+	}
+	return decodedEntry
+}
+
+func toUTXOEntries(collection externalapi.UTXOCollection) ([]*utxoEntry, error) {
+	entries := make([]*utxoEntry, 0, collection.Len())
+	iterator := collection.Iterator()
+	defer iterator.Close()
+	for ok := iterator.First(); ok; ok = iterator.Next() {
+		outpoint, entry, err := iterator.Get()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, toUTXOEntry(outpoint, entry))
+	}
+	return entries, nil
+}
+
+func decodeBlock(value []byte) (interface{}, error) {
+	dbBlock := &serialization.DbBlock{}
+	err := proto.Unmarshal(value, dbBlock)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	domainBlock, err := serialization.DbBlockToDomainBlock(dbBlock)
+	if err != nil {
+		return nil, err
+	}
+	transactionIDs := make([]string, len(domainBlock.Transactions))
+	for i, transaction := range domainBlock.Transactions {
+		transactionIDs[i] = consensushashing.TransactionID(transaction).String()
+	}
+	return &block{
+		Header:         toBlockHeader(domainBlock.Header),
+		TransactionIDs: transactionIDs,
+	}, nil
+}
+
+func decodeBlockHeader(value []byte) (interface{}, error) {
+	dbBlockHeader := &serialization.DbBlockHeader{}
+	err := proto.Unmarshal(value, dbBlockHeader)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	header, err := serialization.DbBlockHeaderToDomainBlockHeader(dbBlockHeader)
+	if err != nil {
+		return nil, err
+	}
+	return toBlockHeader(header), nil
+}
+
+func decodeBlockStatus(value []byte) (interface{}, error) {
+	dbBlockStatus := &serialization.DbBlockStatus{}
+	err := proto.Unmarshal(value, dbBlockStatus)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return serialization.DbBlockStatusToDomainBlockStatus(dbBlockStatus).String(), nil
+}
+
+func decodeBlockRelations(value []byte) (interface{}, error) {
+	dbBlockRelations := &serialization.DbBlockRelations{}
+	err := proto.Unmarshal(value, dbBlockRelations)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	relations, err := serialization.DbBlockRelationsToDomainBlockRelations(dbBlockRelations)
+	if err != nil {
+		return nil, err
+	}
+	return &blockRelations{
+		Parents:  hashStrings(relations.Parents),
+		Children: hashStrings(relations.Children),
+	}, nil
+}
+
+func decodeGHOSTDAGData(value []byte) (interface{}, error) {
+	dbGHOSTDAGData := &serialization.DbBlockGhostdagData{}
+	err := proto.Unmarshal(value, dbGHOSTDAGData)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	data, err := serialization.DBBlockGHOSTDAGDataToBlockGHOSTDAGData(dbGHOSTDAGData)
+	if err != nil {
+		return nil, err
+	}
+	bluesAnticoneSizes := make(map[string]model.KType, len(data.BluesAnticoneSizes()))
+	for hash, size := range data.BluesAnticoneSizes() {
+		bluesAnticoneSizes[hash.String()] = size
+	}
+	return &ghostdagData{
+		BlueScore:          data.BlueScore(),
+		BlueWork:           data.BlueWork().Text(16),
+		SelectedParent:     hashString(data.SelectedParent()),
+		MergeSetBlues:      hashStrings(data.MergeSetBlues()),
+		MergeSetReds:       hashStrings(data.MergeSetReds()),
+		BluesAnticoneSizes: bluesAnticoneSizes,
+	}, nil
+}
+
+func decodeReachabilityData(value []byte) (interface{}, error) {
+	dbReachabilityData := &serialization.DbReachabilityData{}
+	err := proto.Unmarshal(value, dbReachabilityData)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	data, err := serialization.DBReachablityDataToReachablityData(dbReachabilityData)
+	if err != nil {
+		return nil, err
+	}
+	return &reachabilityData{
+		Parent:            hashString(data.Parent()),
+		Children:          hashStrings(data.Children()),
+		IntervalStart:     data.Interval().Start,
+		IntervalEnd:       data.Interval().End,
+		FutureCoveringSet: hashStrings(data.FutureCoveringSet()),
+	}, nil
+}
+
+func decodeUTXODiff(value []byte) (interface{}, error) {
+	dbUTXODiff := &serialization.DbUtxoDiff{}
+	err := proto.Unmarshal(value, dbUTXODiff)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	diff, err := serialization.DBUTXODiffToUTXODiff(dbUTXODiff)
+	if err != nil {
+		return nil, err
+	}
+	toAdd, err := toUTXOEntries(diff.ToAdd())
+	if err != nil {
+		return nil, err
+	}
+	toRemove, err := toUTXOEntries(diff.ToRemove())
+	if err != nil {
+		return nil, err
+	}
+	return &utxoDiff{ToAdd: toAdd, ToRemove: toRemove}, nil
+}
+
+func decodeDBHash(value []byte) (interface{}, error) {
+	dbHash := &serialization.DbHash{}
+	err := proto.Unmarshal(value, dbHash)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	hash, err := serialization.DbHashToDomainHash(dbHash)
+	if err != nil {
+		return nil, err
+	}
+	return hash.String(), nil
+}
+
+func decodeUint64(value []byte) (interface{}, error) {
+	return binaryserialization.DeserializeUint64(value)
+}
+
+func decodeTips(value []byte) (interface{}, error) {
+	dbTips := &serialization.DbTips{}
+	err := proto.Unmarshal(value, dbTips)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	tips, err := serialization.DBTipsToTips(dbTips)
+	if err != nil {
+		return nil, err
+	}
+	return hashStrings(tips), nil
+}
+
+func decodeBlockCount(value []byte) (interface{}, error) {
+	dbBlockCount := &serialization.DbBlockCount{}
+	err := proto.Unmarshal(value, dbBlockCount)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return dbBlockCount.Count, nil
+}
+
+func decodeBlockHeaderCount(value []byte) (interface{}, error) {
+	dbBlockHeaderCount := &serialization.DbBlockHeaderCount{}
+	err := proto.Unmarshal(value, dbBlockHeaderCount)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return dbBlockHeaderCount.Count, nil
+}
+
+func decodeChainBlockHashByIndex(keySuffix []byte, value []byte) (interface{}, interface{}, error) {
+	if len(keySuffix) != 8 {
+		return nil, nil, errors.Errorf("chain block index has an unexpected length %d", len(keySuffix))
+	}
+	hash, err := binaryserialization.DeserializeHash(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return binary.BigEndian.Uint64(keySuffix), hash.String(), nil
+}
+
+func deserializeOutpoint(serializedOutpoint []byte) (*externalapi.DomainOutpoint, error) {
+	dbOutpoint := &serialization.DbOutpoint{}
+	err := proto.Unmarshal(serializedOutpoint, dbOutpoint)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return serialization.DbOutpointToDomainOutpoint(dbOutpoint)
+}
+
+func deserializeUTXOEntry(serializedUTXOEntry []byte) (externalapi.UTXOEntry, error) {
+	dbUTXOEntry := &serialization.DbUtxoEntry{}
+	err := proto.Unmarshal(serializedUTXOEntry, dbUTXOEntry)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return serialization.DBUTXOEntryToUTXOEntry(dbUTXOEntry)
+}
+
+func decodeUTXOSetEntry(keySuffix []byte, value []byte) (interface{}, interface{}, error) {
+	outpoint, err := deserializeOutpoint(keySuffix)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry, err := deserializeUTXOEntry(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outpoint.String(), toUTXOEntry(nil, entry), nil
+}
+
+// splitUTXOIndexKey returns the outpoint in the suffix of a key in the
+// utxo-index bucket, along with its serialized form. The key is made of the
+// script public key version, the script, a bucket separator and the outpoint.
+// Since the script may contain separators itself, its length is taken from
+// the UTXO entry the key points to.
+func splitUTXOIndexKey(keySuffix []byte, entry externalapi.UTXOEntry) (
+	outpoint *externalapi.DomainOutpoint, serializedOutpoint []byte, err error) {
+
+	scriptPublicKeyLength := 2 + len(entry.ScriptPublicKey().Script)
+	if len(keySuffix) <= scriptPublicKeyLength {
+		return nil, nil, errors.Errorf("utxo-index key is too short for its script public key")
+	}
+	scriptPublicKeyVersion := binary.LittleEndian.Uint16(keySuffix[:2])
+	if scriptPublicKeyVersion != entry.ScriptPublicKey().Version ||
+		string(keySuffix[2:scriptPublicKeyLength]) != string(entry.ScriptPublicKey().Script) {
+
+		return nil, nil, errors.Errorf("utxo-index key doesn't match the script public key of its UTXO entry")
+	}
+	serializedOutpoint = keySuffix[scriptPublicKeyLength+1:]
+	outpoint, err = deserializeOutpoint(serializedOutpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return outpoint, serializedOutpoint, nil
+}
+
+func decodeUTXOIndexEntry(keySuffix []byte, value []byte) (interface{}, interface{}, error) {
+	entry, err := deserializeUTXOEntry(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	outpoint, _, err := splitUTXOIndexKey(keySuffix, entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	key := &utxoIndexKey{
+		ScriptPublicKey:        hex.EncodeToString(entry.ScriptPublicKey().Script),
+		ScriptPublicKeyVersion: entry.ScriptPublicKey().Version,
+		Outpoint:               outpoint.String(),
+	}
+	return key, toUTXOEntry(nil, entry), nil
+}
+
+// deserializeUTXOIndexVirtualParents deserializes the virtual parents the
+// UTXO index was last synced with. They are stored as a little-endian
+// uint64 count followed by the hashes.
+func deserializeUTXOIndexVirtualParents(value []byte) ([]*externalapi.DomainHash, error) {
+	if len(value) < 8 {
+		return nil, errors.Errorf("utxo-index virtual parents have an unexpected length %d", len(value))
+	}
+	count := binary.LittleEndian.Uint64(value[:8])
+	hashes, err := binaryserialization.DeserializeHashes(value[8:])
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(hashes)) != count {
+		return nil, errors.Errorf("expected %d utxo-index virtual parents but found %d", count, len(hashes))
+	}
+	return hashes, nil
+}
+
+func decodeUTXOIndexVirtualParents(value []byte) (interface{}, error) {
+	hashes, err := deserializeUTXOIndexVirtualParents(value)
+	if err != nil {
+		return nil, err
+	}
+	return hashStrings(hashes), nil
+}
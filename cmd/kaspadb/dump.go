@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// dumpedEntry is a single dumped database entry. Entries that can't be
+// decoded are dumped in hex, along with the error that decoding them
+// returned.
+type dumpedEntry struct {
+	Key   interface{}
+	Value interface{}
+	Error string `json:",omitempty"`
+}
+
+func dump(conf *dumpConfig) error {
+	db, err := openDatabase(&conf.databaseFlags, true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	name := strings.TrimSuffix(conf.Bucket, string(bucketSeparator))
+	singleKey := database.MakeBucket(nil).Key([]byte(name))
+	isSingleKey, err := db.Has(singleKey)
+	if err != nil {
+		return err
+	}
+	if isSingleKey {
+		value, err := db.Get(singleKey)
+		if err != nil {
+			return err
+		}
+		return printEntry(dumpKey(name, value, conf.Raw))
+	}
+
+	bucket := database.MakeBucket([]byte(name))
+	if conf.Key != "" {
+		keySuffix, err := hex.DecodeString(conf.Key)
+		if err != nil {
+			return errors.Wrapf(err, "key %s is not in hex", conf.Key)
+		}
+		value, err := db.Get(bucket.Key(keySuffix))
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return errors.Errorf("key %s was not found in bucket %s", conf.Key, name)
+			}
+			return err
+		}
+		return printEntry(dumpBucketEntry(name, keySuffix, value, conf.Raw))
+	}
+
+	cursor, err := db.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	dumpedCount := 0
+	for dumpedCount < conf.Limit && cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		err = printEntry(dumpBucketEntry(name, key.Suffix(), value, conf.Raw))
+		if err != nil {
+			return err
+		}
+		dumpedCount++
+	}
+	if dumpedCount == 0 {
+		return errors.Errorf("bucket %s is empty or doesn't exist", name)
+	}
+	return nil
+}
+
+func dumpKey(name string, value []byte, raw bool) *dumpedEntry {
+	decode, ok := keyDecoders[name]
+	if raw || !ok {
+		return &dumpedEntry{Key: name, Value: hex.EncodeToString(value)}
+	}
+	decodedValue, err := decode(value)
+	if err != nil {
+		return &dumpedEntry{Key: name, Value: hex.EncodeToString(value), Error: err.Error()}
+	}
+	return &dumpedEntry{Key: name, Value: decodedValue}
+}
+
+func dumpBucketEntry(bucketName string, keySuffix []byte, value []byte, raw bool) *dumpedEntry {
+	decode, ok := bucketDecoders[bucketName]
+	if raw || !ok {
+		return &dumpedEntry{Key: hex.EncodeToString(keySuffix), Value: hex.EncodeToString(value)}
+	}
+	decodedKey, decodedValue, err := decode(keySuffix, value)
+	if err != nil {
+		return &dumpedEntry{
+			Key:   hex.EncodeToString(keySuffix),
+			Value: hex.EncodeToString(value),
+			Error: err.Error(),
+		}
+	}
+	return &dumpedEntry{Key: decodedKey, Value: decodedValue}
+}
+
+func printEntry(entry *dumpedEntry) error {
+	entryJSON, err := json.MarshalIndent(entry, "", "    ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	fmt.Println(string(entryJSON))
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/utxoindex"
+)
+
+func rebuildUTXOIndex(conf *rebuildUTXOIndexConfig) error {
+	db, err := openDatabase(&conf.databaseFlags, false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	consensusConfig := &consensus.Config{Params: *conf.NetParams()}
+	consensusInstance, err := consensus.NewFactory().NewConsensus(consensusConfig, db)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Rebuilding the UTXO index. This may take a while...\n")
+	err = utxoindex.Rebuild(consensusInstance, db)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The UTXO index was rebuilt\n")
+	return nil
+}
@@ -0,0 +1,563 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/database/binaryserialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// maxReportedInconsistencies is the number of inconsistencies that
+// are printed for every check. The rest are only counted.
+const maxReportedInconsistencies = 10
+
+// verification holds the state of verifying a database
+type verification struct {
+	db                   database.Database
+	inconsistencies      int
+	checkInconsistencies int
+}
+
+type check struct {
+	description string
+	run         func(v *verification) error
+}
+
+var checks = []*check{
+	{"Every entry can be decoded", checkEntriesDecode},
+	{"The block counts match the stored blocks and headers", checkCounts},
+	{"Every block has a header and a status of a block with transactions", checkBlocks},
+	{"Every header has a status", checkHeaders},
+	{"Every block with GHOSTDAG data has a header, and so does its selected parent", checkGHOSTDAGData},
+	{"The tips and the virtual parents have valid statuses", checkTips},
+	{"The pruning point has a header", checkPruningPoint},
+	{"The headers selected chain is consistent", checkHeadersSelectedChain},
+	{"The UTXO index matches the virtual UTXO set", checkUTXOIndex},
+}
+
+func verify(conf *verifyConfig) error {
+	db, err := openDatabase(&conf.databaseFlags, true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	v := &verification{db: db}
+	for _, check := range checks {
+		fmt.Printf("%s...\n", check.description)
+		v.checkInconsistencies = 0
+		err := check.run(v)
+		if err != nil {
+			return err
+		}
+		if v.checkInconsistencies == 0 {
+			fmt.Printf("  OK\n")
+			continue
+		}
+		if v.checkInconsistencies > maxReportedInconsistencies {
+			fmt.Printf("  ...and %d more\n", v.checkInconsistencies-maxReportedInconsistencies)
+		}
+	}
+
+	if v.inconsistencies > 0 {
+		return errors.Errorf("Found %d inconsistencies", v.inconsistencies)
+	}
+	fmt.Printf("No inconsistencies found\n")
+	return nil
+}
+
+func (v *verification) reportf(format string, args ...interface{}) {
+	v.inconsistencies++
+	v.checkInconsistencies++
+	if v.checkInconsistencies <= maxReportedInconsistencies {
+		fmt.Printf("  "+format+"\n", args...)
+	}
+}
+
+func (v *verification) notef(format string, args ...interface{}) {
+	fmt.Printf("  "+format+"\n", args...)
+}
+
+// forEachEntry calls f with the key suffix and value of every entry in
+// the given bucket
+func (v *verification) forEachEntry(bucketName string, f func(keySuffix []byte, value []byte) error) error {
+	cursor, err := v.db.Cursor(database.MakeBucket([]byte(bucketName)))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		keySuffix := make([]byte, len(key.Suffix()))
+		copy(keySuffix, key.Suffix())
+		err = f(keySuffix, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *verification) countEntries(bucketName string) (uint64, error) {
+	count := uint64(0)
+	err := v.forEachEntry(bucketName, func(_ []byte, _ []byte) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+func (v *verification) get(bucketName string, keySuffix []byte) (value []byte, found bool, err error) {
+	value, err = v.db.Get(database.MakeBucket([]byte(bucketName)).Key(keySuffix))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (v *verification) has(bucketName string, hash *externalapi.DomainHash) (bool, error) {
+	return v.db.Has(database.MakeBucket([]byte(bucketName)).Key(hash.ByteSlice()))
+}
+
+// getKey returns the value of a key that isn't in any bucket
+func (v *verification) getKey(name string) (value []byte, found bool, err error) {
+	value, err = v.db.Get(database.MakeBucket(nil).Key([]byte(name)))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (v *verification) blockStatus(hash *externalapi.DomainHash) (status string, found bool, err error) {
+	statusBytes, found, err := v.get("block-statuses", hash.ByteSlice())
+	if err != nil || !found {
+		return "", found, err
+	}
+	decodedStatus, err := decodeBlockStatus(statusBytes)
+	if err != nil {
+		return "", false, err
+	}
+	return decodedStatus.(string), true, nil
+}
+
+func (v *verification) virtualParents() ([]*externalapi.DomainHash, bool, error) {
+	relationsBytes, found, err := v.get("block-relations", model.VirtualBlockHash.ByteSlice())
+	if err != nil || !found {
+		return nil, found, err
+	}
+	relations, err := decodeBlockRelations(relationsBytes)
+	if err != nil {
+		return nil, false, err
+	}
+	parents := make([]*externalapi.DomainHash, len(relations.(*blockRelations).Parents))
+	for i, parent := range relations.(*blockRelations).Parents {
+		parents[i], err = externalapi.NewDomainHashFromString(parent)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return parents, true, nil
+}
+
+func checkEntriesDecode(v *verification) error {
+	for bucketName, decode := range bucketDecoders {
+		err := v.forEachEntry(bucketName, func(keySuffix []byte, value []byte) error {
+			_, _, err := decode(keySuffix, value)
+			if err != nil {
+				v.reportf("Entry %x in %s can't be decoded: %s", keySuffix, bucketName, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for name, decode := range keyDecoders {
+		value, found, err := v.getKey(name)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		_, err = decode(value)
+		if err != nil {
+			v.reportf("Key %s can't be decoded: %s", name, err)
+		}
+	}
+	return nil
+}
+
+func checkCounts(v *verification) error {
+	counts := []struct {
+		bucketName string
+		countKey   string
+		decode     func(value []byte) (interface{}, error)
+	}{
+		{"blocks", "blocks-count", decodeBlockCount},
+		{"block-headers", "block-headers-count", decodeBlockHeaderCount},
+	}
+	for _, count := range counts {
+		entryCount, err := v.countEntries(count.bucketName)
+		if err != nil {
+			return err
+		}
+		countBytes, found, err := v.getKey(count.countKey)
+		if err != nil {
+			return err
+		}
+		storedCount := uint64(0)
+		if found {
+			decodedCount, err := count.decode(countBytes)
+			if err != nil {
+				// Already reported by checkEntriesDecode
+				continue
+			}
+			storedCount = decodedCount.(uint64)
+		}
+		if storedCount != entryCount {
+			v.reportf("%s is %d, but %s has %d entries", count.countKey, storedCount, count.bucketName, entryCount)
+		}
+	}
+	return nil
+}
+
+func checkBlocks(v *verification) error {
+	return v.forEachEntry("blocks", func(keySuffix []byte, _ []byte) error {
+		hash, err := externalapi.NewDomainHashFromByteSlice(keySuffix)
+		if err != nil {
+			v.reportf("Block key %x is not a hash", keySuffix)
+			return nil
+		}
+		hasHeader, err := v.has("block-headers", hash)
+		if err != nil {
+			return err
+		}
+		if !hasHeader {
+			v.reportf("Block %s has no header", hash)
+		}
+		status, found, err := v.blockStatus(hash)
+		if err != nil {
+			return err
+		}
+		if !found {
+			v.reportf("Block %s has no status", hash)
+			return nil
+		}
+		if status == externalapi.StatusHeaderOnly.String() || status == externalapi.StatusInvalid.String() {
+			v.reportf("Block %s is stored with its transactions, but its status is %s", hash, status)
+		}
+		return nil
+	})
+}
+
+func checkHeaders(v *verification) error {
+	return v.forEachEntry("block-headers", func(keySuffix []byte, _ []byte) error {
+		hash, err := externalapi.NewDomainHashFromByteSlice(keySuffix)
+		if err != nil {
+			v.reportf("Header key %x is not a hash", keySuffix)
+			return nil
+		}
+		hasStatus, err := v.has("block-statuses", hash)
+		if err != nil {
+			return err
+		}
+		if !hasStatus {
+			v.reportf("Header %s has no status", hash)
+		}
+		return nil
+	})
+}
+
+func checkGHOSTDAGData(v *verification) error {
+	return v.forEachEntry("block-ghostdag-data", func(keySuffix []byte, value []byte) error {
+		hash, err := externalapi.NewDomainHashFromByteSlice(keySuffix)
+		if err != nil {
+			v.reportf("GHOSTDAG data key %x is not a hash", keySuffix)
+			return nil
+		}
+		// The virtual block has GHOSTDAG data, but no header
+		if hash.Equal(model.VirtualBlockHash) {
+			return nil
+		}
+		hasHeader, err := v.has("block-headers", hash)
+		if err != nil {
+			return err
+		}
+		if !hasHeader {
+			v.reportf("Block %s has GHOSTDAG data but no header", hash)
+		}
+
+		decodedData, err := decodeGHOSTDAGData(value)
+		if err != nil {
+			// Already reported by checkEntriesDecode
+			return nil
+		}
+		selectedParent := decodedData.(*ghostdagData).SelectedParent
+		if selectedParent == "" {
+			return nil
+		}
+		selectedParentHash, err := externalapi.NewDomainHashFromString(selectedParent)
+		if err != nil {
+			return err
+		}
+		hasSelectedParentHeader, err := v.has("block-headers", selectedParentHash)
+		if err != nil {
+			return err
+		}
+		if !hasSelectedParentHeader {
+			v.reportf("The selected parent %s of block %s has no header", selectedParentHash, hash)
+		}
+		return nil
+	})
+}
+
+func checkTips(v *verification) error {
+	tipsBytes, found, err := v.getKey("tips")
+	if err != nil {
+		return err
+	}
+	if found {
+		tips, err := decodeTips(tipsBytes)
+		if err == nil {
+			for _, tip := range tips.([]string) {
+				tipHash, err := externalapi.NewDomainHashFromString(tip)
+				if err != nil {
+					return err
+				}
+				status, found, err := v.blockStatus(tipHash)
+				if err != nil {
+					return err
+				}
+				if !found {
+					v.reportf("Tip %s has no status", tipHash)
+				} else if status == externalapi.StatusInvalid.String() {
+					v.reportf("Tip %s is invalid", tipHash)
+				}
+			}
+		}
+	}
+
+	virtualParents, found, err := v.virtualParents()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+	for _, parent := range virtualParents {
+		status, found, err := v.blockStatus(parent)
+		if err != nil {
+			return err
+		}
+		if !found {
+			v.reportf("Virtual parent %s has no status", parent)
+		} else if status != externalapi.StatusUTXOValid.String() {
+			v.reportf("Virtual parent %s has status %s", parent, status)
+		}
+	}
+	return nil
+}
+
+func checkPruningPoint(v *verification) error {
+	pruningPointBytes, found, err := v.getKey("pruning-block-hash")
+	if err != nil {
+		return err
+	}
+	if !found {
+		v.notef("No pruning point is stored")
+		return nil
+	}
+	pruningPoint, err := decodeDBHash(pruningPointBytes)
+	if err != nil {
+		// Already reported by checkEntriesDecode
+		return nil
+	}
+	pruningPointHash, err := externalapi.NewDomainHashFromString(pruningPoint.(string))
+	if err != nil {
+		return err
+	}
+	hasHeader, err := v.has("block-headers", pruningPointHash)
+	if err != nil {
+		return err
+	}
+	if !hasHeader {
+		v.reportf("The pruning point %s has no header", pruningPointHash)
+	}
+	return nil
+}
+
+func checkHeadersSelectedChain(v *verification) error {
+	var highestIndex uint64
+	var highestHash *externalapi.DomainHash
+	entryCount := uint64(0)
+	err := v.forEachEntry("chain-block-hash-by-index", func(keySuffix []byte, value []byte) error {
+		entryCount++
+		if len(keySuffix) != 8 {
+			v.reportf("Chain block index %x has an unexpected length", keySuffix)
+			return nil
+		}
+		index := binary.BigEndian.Uint64(keySuffix)
+		hash, err := binaryserialization.DeserializeHash(value)
+		if err != nil {
+			v.reportf("Chain block %d is not a hash", index)
+			return nil
+		}
+		if highestHash == nil || index > highestIndex {
+			highestIndex = index
+			highestHash = hash
+		}
+
+		indexBytes, found, err := v.get("chain-block-index-by-hash", hash.ByteSlice())
+		if err != nil {
+			return err
+		}
+		if !found {
+			v.reportf("Chain block %d (%s) has no index by hash", index, hash)
+			return nil
+		}
+		indexByHash, err := binaryserialization.DeserializeUint64(indexBytes)
+		if err != nil {
+			v.reportf("The index of chain block %s can't be decoded: %s", hash, err)
+			return nil
+		}
+		if indexByHash != index {
+			v.reportf("Chain block %s is at index %d, but its index by hash is %d", hash, index, indexByHash)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	indexByHashCount, err := v.countEntries("chain-block-index-by-hash")
+	if err != nil {
+		return err
+	}
+	if indexByHashCount != entryCount {
+		v.reportf("There are %d chain blocks by index, but %d by hash", entryCount, indexByHashCount)
+	}
+	if highestHash == nil {
+		return nil
+	}
+	if highestIndex != entryCount-1 {
+		v.reportf("The highest chain block index is %d, but there are %d chain blocks", highestIndex, entryCount)
+	}
+
+	storedHighestIndexBytes, found, err := v.getKey("highest-chain-block-index")
+	if err != nil {
+		return err
+	}
+	if found {
+		storedHighestIndex, err := binaryserialization.DeserializeUint64(storedHighestIndexBytes)
+		if err == nil && storedHighestIndex != highestIndex {
+			v.reportf("highest-chain-block-index is %d, but the highest chain block index is %d",
+				storedHighestIndex, highestIndex)
+		}
+	}
+
+	selectedTipBytes, found, err := v.getKey("headers-selected-tip")
+	if err != nil {
+		return err
+	}
+	if found {
+		selectedTip, err := decodeDBHash(selectedTipBytes)
+		if err == nil && selectedTip.(string) != highestHash.String() {
+			v.reportf("The headers selected tip is %s, but the last chain block is %s", selectedTip, highestHash)
+		}
+	}
+	return nil
+}
+
+func checkUTXOIndex(v *verification) error {
+	indexVirtualParentsBytes, found, err := v.getKey("utxo-index-virtual-parents")
+	if err != nil {
+		return err
+	}
+	if !found {
+		v.notef("The UTXO index is missing or incomplete. kaspad rebuilds it when started with --utxoindex")
+		return nil
+	}
+	indexVirtualParents, err := deserializeUTXOIndexVirtualParents(indexVirtualParentsBytes)
+	if err != nil {
+		// Already reported by checkEntriesDecode
+		return nil
+	}
+	virtualParents, found, err := v.virtualParents()
+	if err != nil {
+		return err
+	}
+	if !found || !externalapi.HashesEqual(indexVirtualParents, virtualParents) {
+		v.notef("The UTXO index isn't synced with the virtual. kaspad resyncs it when started with --utxoindex")
+		return nil
+	}
+
+	indexEntryCount := uint64(0)
+	err = v.forEachEntry("utxo-index", func(keySuffix []byte, value []byte) error {
+		indexEntryCount++
+		entry, err := deserializeUTXOEntry(value)
+		if err != nil {
+			// Already reported by checkEntriesDecode
+			return nil
+		}
+		outpoint, serializedOutpoint, err := splitUTXOIndexKey(keySuffix, entry)
+		if err != nil {
+			// Already reported by checkEntriesDecode
+			return nil
+		}
+		virtualEntryBytes, found, err := v.get("virtual-utxo-set", serializedOutpoint)
+		if err != nil {
+			return err
+		}
+		if !found {
+			v.reportf("UTXO %s is in the UTXO index but not in the virtual UTXO set", outpoint)
+			return nil
+		}
+		virtualEntry, err := deserializeUTXOEntry(virtualEntryBytes)
+		if err != nil {
+			// Already reported by checkEntriesDecode
+			return nil
+		}
+		// The UTXO index may record a different block DAA score than the virtual UTXO
+		// set for the same UTXO, so it isn't compared
+		if virtualEntry.Amount() != entry.Amount() ||
+			virtualEntry.ScriptPublicKey().Version != entry.ScriptPublicKey().Version ||
+			!bytes.Equal(virtualEntry.ScriptPublicKey().Script, entry.ScriptPublicKey().Script) ||
+			virtualEntry.IsCoinbase() != entry.IsCoinbase() {
+			v.reportf("UTXO %s differs between the UTXO index and the virtual UTXO set", outpoint)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	virtualEntryCount, err := v.countEntries("virtual-utxo-set")
+	if err != nil {
+		return err
+	}
+	if virtualEntryCount != indexEntryCount {
+		v.reportf("The virtual UTXO set has %d UTXOs, but the UTXO index has %d",
+			virtualEntryCount, indexEntryCount)
+	}
+	return nil
+}
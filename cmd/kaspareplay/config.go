@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+type configFlags struct {
+	Recording string `short:"r" long:"recording" description:"The block recording to replay, as written by kaspad --recordblocks" required:"true"`
+	RelayTo   string `long:"relay-to" description:"Instead of replaying into a fresh consensus, relay the recorded blocks to the kaspad listening on the given P2P address (e.g. localhost:16511)"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Recording == "" {
+		return nil, errors.New("--recording is required")
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kaspanet/kaspad/domain/blockrecorder"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		printErrorAndExit(errors.Wrap(err, "error parsing command-line arguments"))
+	}
+
+	reader, err := blockrecorder.OpenReader(cfg.Recording)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	params := cfg.NetParams()
+	if reader.NetworkName != params.Name {
+		printErrorAndExit(errors.Errorf("%s was recorded on %s, but the replay is on %s",
+			cfg.Recording, reader.NetworkName, params.Name))
+	}
+
+	var exitCode int
+	if cfg.RelayTo != "" {
+		exitCode = relayRecording(cfg, reader)
+	} else {
+		exitCode = replayRecording(cfg, reader)
+	}
+	reader.Close()
+	os.Exit(exitCode)
+}
+
+// replayRecording replays the recording into a fresh consensus, and returns
+// the exit code of kaspareplay
+func replayRecording(cfg *configFlags, reader *blockrecorder.Reader) int {
+	tc, teardown, err := consensus.NewFactory().NewTestConsensus(&consensus.Config{Params: *cfg.NetParams()}, "kaspareplay")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error creating the consensus: %s\n", err)
+		return 1
+	}
+	defer teardown(false)
+
+	result, err := replay(tc, reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+	if result.divergence != nil {
+		fmt.Printf("Replay diverged at record %d (block %s): %s\n", result.divergence.recordIndex,
+			result.divergence.blockHash, result.divergence.description)
+		return 2
+	}
+	fmt.Printf("Replayed %d records with no divergence\n", result.replayedRecords)
+	return 0
+}
+
+type divergence struct {
+	recordIndex int
+	blockHash   *externalapi.DomainHash
+	description string
+}
+
+type replayResult struct {
+	replayedRecords int
+	divergence      *divergence
+}
+
+// replay inserts every recorded block into the given consensus, in order, and
+// stops at the first record whose outcome differs from the recorded one
+func replay(consensus externalapi.Consensus, reader *blockrecorder.Reader) (*replayResult, error) {
+	result := &replayResult{}
+	for recordIndex := 0; ; recordIndex++ {
+		expected, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return result, nil
+			}
+			return nil, errors.Wrapf(err, "error reading record %d", recordIndex)
+		}
+
+		_, insertionErr := consensus.ValidateAndInsertBlock(expected.Block)
+		actual, err := blockrecorder.NewRecord(consensus, expected.Block, insertionErr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting the outcome of record %d", recordIndex)
+		}
+		description := blockrecorder.Divergence(expected, actual)
+		if description != "" {
+			result.divergence = &divergence{
+				recordIndex: recordIndex,
+				blockHash:   consensushashing.BlockHash(expected.Block),
+				description: description,
+			}
+			return result, nil
+		}
+
+		result.replayedRecords++
+		if result.replayedRecords%progressInterval == 0 {
+			fmt.Printf("Replayed %d records\n", result.replayedRecords)
+		}
+	}
+}
+
+const progressInterval = 1000
+
+func printErrorAndExit(err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/blockrecorder"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/standalone"
+	"github.com/pkg/errors"
+)
+
+// relayTimeout is how long the node is given to request a relayed block, and
+// to relay back the last one
+const relayTimeout = time.Minute
+
+// relayRecording relays the recorded blocks to the node at cfg.RelayTo, and
+// returns the exit code of kaspareplay
+func relayRecording(cfg *configFlags, reader *blockrecorder.Reader) int {
+	kaspadConfig := config.DefaultConfig()
+	kaspadConfig.NetworkFlags = cfg.NetworkFlags
+	minimalNetAdapter, err := standalone.NewMinimalNetAdapter(kaspadConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error creating the net adapter: %s\n", err)
+		return 1
+	}
+	routes, err := minimalNetAdapter.Connect(cfg.RelayTo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error connecting to %s: %s\n", cfg.RelayTo, err)
+		return 1
+	}
+	defer routes.Disconnect()
+
+	relayedBlocks, err := relay(routes, reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+	fmt.Printf("Relayed %d blocks to %s\n", relayedBlocks, cfg.RelayTo)
+	return 0
+}
+
+// relay sends every recorded block that was inserted successfully to the node
+// behind routes, in order, the way a peer relays newly mined blocks. Blocks
+// whose insertion failed are skipped, since a node bans peers that relay
+// invalid blocks.
+func relay(routes *standalone.Routes, reader *blockrecorder.Reader) (int, error) {
+	relayedBlocks := 0
+	var lastBlockHash *externalapi.DomainHash
+	for recordIndex := 0; ; recordIndex++ {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return 0, errors.Wrapf(err, "error reading record %d", recordIndex)
+		}
+		if record.InsertionError != "" {
+			continue
+		}
+
+		blockHash := consensushashing.BlockHash(record.Block)
+		err = routes.OutgoingRoute.Enqueue(&appmessage.MsgInvRelayBlock{Hash: blockHash})
+		if err != nil {
+			return 0, err
+		}
+		_, err = routes.WaitForMessageOfType(appmessage.CmdRequestRelayBlocks, relayTimeout)
+		if err != nil {
+			return 0, errors.Wrapf(err, "the node didn't request block %s of record %d. Was it started "+
+				"from an empty data directory?", blockHash, recordIndex)
+		}
+		err = routes.OutgoingRoute.Enqueue(appmessage.DomainBlockToMsgBlock(record.Block))
+		if err != nil {
+			return 0, err
+		}
+
+		lastBlockHash = blockHash
+		relayedBlocks++
+		if relayedBlocks%progressInterval == 0 {
+			fmt.Printf("Relayed %d blocks\n", relayedBlocks)
+		}
+	}
+
+	if lastBlockHash != nil {
+		err := waitForRelayBack(routes, lastBlockHash)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return relayedBlocks, nil
+}
+
+// waitForRelayBack waits until the node relays the given block back, which it
+// does once the block is accepted
+func waitForRelayBack(routes *standalone.Routes, blockHash *externalapi.DomainHash) error {
+	for {
+		message, err := routes.WaitForMessageOfType(appmessage.CmdInvRelayBlock, relayTimeout)
+		if err != nil {
+			return errors.Wrapf(err, "the node didn't accept the last relayed block %s", blockHash)
+		}
+		if message.(*appmessage.MsgInvRelayBlock).Hash.Equal(blockHash) {
+			return nil
+		}
+	}
+}
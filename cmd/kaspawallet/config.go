@@ -195,4 +195,7 @@ func combineNetworkFlags(dst, src *config.NetworkFlags) {
 	if dst.OverrideDAGParamsFile == "" {
 		dst.OverrideDAGParamsFile = src.OverrideDAGParamsFile
 	}
+	if dst.NetworkDefinitionFile == "" {
+		dst.NetworkDefinitionFile = src.NetworkDefinitionFile
+	}
 }
@@ -0,0 +1,71 @@
+package benchmarks
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/pkg/errors"
+)
+
+// mempoolSize is the number of transactions in the mempool the block
+// templates are built from
+const mempoolSize = 500
+
+var (
+	sharedMiningManager     miningmanager.MiningManager
+	sharedMiningManagerErr  error
+	sharedMiningManagerOnce sync.Once
+)
+
+// getMiningManager returns a mining manager on top of the dataset whose
+// mempool holds mempoolSize transactions, and creates it on first use
+func getMiningManager(b *testing.B, generated *dataset) miningmanager.MiningManager {
+	b.StopTimer()
+	defer b.StartTimer()
+
+	sharedMiningManagerOnce.Do(func() {
+		sharedMiningManager, sharedMiningManagerErr = newMiningManager(generated)
+	})
+	if sharedMiningManagerErr != nil {
+		b.Fatalf("Error creating the mining manager: %+v", sharedMiningManagerErr)
+	}
+	return sharedMiningManager
+}
+
+func newMiningManager(generated *dataset) (miningmanager.MiningManager, error) {
+	miningManager := miningmanager.NewFactory().NewMiningManager(generated.tc, generated.tc.DAGParams())
+	transactions, err := generated.generator.BuildTransactions(mempoolSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) < mempoolSize {
+		return nil, errors.Errorf("the dataset has outputs for only %d of the %d mempool transactions",
+			len(transactions), mempoolSize)
+	}
+	for i, transaction := range transactions {
+		err := miningManager.ValidateAndInsertTransaction(transaction, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error inserting transaction %d into the mempool", i)
+		}
+	}
+	return miningManager, nil
+}
+
+// BenchmarkGetBlockTemplate builds block templates on top of the dataset,
+// from a full mempool
+func BenchmarkGetBlockTemplate(b *testing.B) {
+	generated := getDataset(b)
+	miningManager := getMiningManager(b, generated)
+
+	scriptPublicKey, _ := testutils.OpTrueScript()
+	coinbaseData := &externalapi.DomainCoinbaseData{ScriptPublicKey: scriptPublicKey, ExtraData: []byte{}}
+	for i := 0; i < b.N; i++ {
+		_, err := miningManager.GetBlockTemplate(coinbaseData)
+		if err != nil {
+			b.Fatalf("GetBlockTemplate: %+v", err)
+		}
+	}
+}
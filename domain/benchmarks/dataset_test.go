@@ -0,0 +1,109 @@
+package benchmarks
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/chaingenerator"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+// datasetConfig is the shape of the DAG the benchmarks run on. Changing it
+// makes results incomparable with results of earlier commits.
+func datasetConfig() *chaingenerator.Config {
+	return &chaingenerator.Config{
+		Seed:                 1,
+		NumberOfBlocks:       300,
+		BlockRate:            1,
+		Width:                3,
+		TransactionsPerBlock: 20,
+		UTXOSetSize:          2000,
+		ReorgInterval:        30,
+		MaxReorgDepth:        3,
+	}
+}
+
+// datasetConsensusConfig returns the config of the consensus the dataset is
+// generated into. The coinbase maturity is lowered so that transactions
+// spend coinbase outputs from early on.
+func datasetConsensusConfig() *consensus.Config {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.BlockCoinbaseMaturity = 10
+	return consensusConfig
+}
+
+// dataset is a generated DAG, along with the consensus it was generated into
+type dataset struct {
+	tc        testapi.TestConsensus
+	teardown  func(keepDataDir bool)
+	generator *chaingenerator.Generator
+
+	// blocks are the generated blocks, in insertion order
+	blocks      []*externalapi.DomainBlock
+	blockHashes []*externalapi.DomainHash
+
+	// virtualUTXODiffs are the changes of the virtual UTXO set caused by
+	// the insertion of each of the blocks
+	virtualUTXODiffs []externalapi.UTXODiff
+}
+
+var (
+	sharedDataset     *dataset
+	sharedDatasetErr  error
+	sharedDatasetOnce sync.Once
+)
+
+// getDataset returns the dataset, and generates it on first use. The
+// dataset is shared by all the benchmarks, which must not modify its
+// consensus.
+func getDataset(b *testing.B) *dataset {
+	b.StopTimer()
+	defer b.StartTimer()
+
+	sharedDatasetOnce.Do(func() {
+		sharedDataset, sharedDatasetErr = generateDataset()
+	})
+	if sharedDatasetErr != nil {
+		b.Fatalf("Error generating the dataset: %+v", sharedDatasetErr)
+	}
+	return sharedDataset
+}
+
+func generateDataset() (*dataset, error) {
+	factory := consensus.NewFactory()
+	tc, teardown, err := factory.NewTestConsensus(datasetConsensusConfig(), "benchmarks")
+	if err != nil {
+		return nil, err
+	}
+	generator, err := chaingenerator.New(tc, datasetConfig())
+	if err != nil {
+		teardown(false)
+		return nil, err
+	}
+
+	generated := &dataset{tc: tc, teardown: teardown, generator: generator}
+	err = generator.Generate(func(block *externalapi.DomainBlock, blockInsertionResult *externalapi.BlockInsertionResult) error {
+		generated.blocks = append(generated.blocks, block)
+		generated.blockHashes = append(generated.blockHashes, consensushashing.BlockHash(block))
+		generated.virtualUTXODiffs = append(generated.virtualUTXODiffs, blockInsertionResult.VirtualUTXODiff)
+		return nil
+	})
+	if err != nil {
+		teardown(false)
+		return nil, err
+	}
+	return generated, nil
+}
+
+func TestMain(m *testing.M) {
+	exitCode := m.Run()
+	if sharedDataset != nil {
+		sharedDataset.teardown(false)
+	}
+	os.Exit(exitCode)
+}
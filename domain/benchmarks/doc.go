@@ -0,0 +1,17 @@
+/*
+Package benchmarks holds the standard benchmark suite of the consensus hot
+paths:
+
+  - ValidateAndInsertBlock
+  - GHOSTDAG, of both ghostdagmanager and ghostdag2
+  - Reachability queries
+  - UTXO diff application
+  - txscript signature verification
+  - Block template building
+
+The benchmarks run on a DAG generated with chaingenerator from a fixed seed,
+so their results depend only on the code under test and on the machine they
+run on. Use kaspabench to run the suite and to compare the results of two
+commits.
+*/
+package benchmarks
@@ -0,0 +1,39 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/processes/ghostdag2"
+	"github.com/kaspanet/kaspad/domain/consensus/processes/ghostdagmanager"
+)
+
+// BenchmarkGHOSTDAG runs GHOSTDAG on the blocks of the dataset, with each of
+// the GHOSTDAG implementations. The results are staged and discarded.
+func BenchmarkGHOSTDAG(b *testing.B) {
+	implementations := []struct {
+		name        string
+		constructor consensus.GHOSTDAGManagerConstructor
+	}{
+		{name: "ghostdagmanager", constructor: ghostdagmanager.New},
+		{name: "ghostdag2", constructor: ghostdag2.New},
+	}
+
+	generated := getDataset(b)
+	tc := generated.tc
+	for _, implementation := range implementations {
+		ghostdagManager := implementation.constructor(tc.DatabaseContext(), tc.DAGTopologyManager(),
+			tc.GHOSTDAGDataStore(), tc.BlockHeaderStore(), tc.DAGParams().K)
+
+		b.Run(implementation.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				blockHash := generated.blockHashes[i%len(generated.blockHashes)]
+				err := ghostdagManager.GHOSTDAG(model.NewStagingArea(), blockHash)
+				if err != nil {
+					b.Fatalf("GHOSTDAG: %+v", err)
+				}
+			}
+		})
+	}
+}
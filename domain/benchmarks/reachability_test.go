@@ -0,0 +1,44 @@
+package benchmarks
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// BenchmarkReachability queries the reachability of random pairs of blocks
+// of the dataset
+func BenchmarkReachability(b *testing.B) {
+	generated := getDataset(b)
+	reachabilityManager := generated.tc.ReachabilityManager()
+
+	const pairCount = 1000
+	random := rand.New(rand.NewSource(0))
+	pairs := make([][2]*externalapi.DomainHash, pairCount)
+	for i := range pairs {
+		pairs[i][0] = generated.blockHashes[random.Intn(len(generated.blockHashes))]
+		pairs[i][1] = generated.blockHashes[random.Intn(len(generated.blockHashes))]
+	}
+
+	queries := []struct {
+		name  string
+		query func(stagingArea *model.StagingArea, blockHashA, blockHashB *externalapi.DomainHash) (bool, error)
+	}{
+		{name: "IsDAGAncestorOf", query: reachabilityManager.IsDAGAncestorOf},
+		{name: "IsReachabilityTreeAncestorOf", query: reachabilityManager.IsReachabilityTreeAncestorOf},
+	}
+	for _, query := range queries {
+		b.Run(query.name, func(b *testing.B) {
+			stagingArea := model.NewStagingArea()
+			for i := 0; i < b.N; i++ {
+				pair := pairs[i%pairCount]
+				_, err := query.query(stagingArea, pair[0], pair[1])
+				if err != nil {
+					b.Fatalf("%s: %+v", query.name, err)
+				}
+			}
+		})
+	}
+}
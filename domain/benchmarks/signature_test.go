@@ -0,0 +1,117 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// BenchmarkSignatureVerification executes the script of a pay-to-pubkey input,
+// with no signature cache, so that every execution verifies the signature
+func BenchmarkSignatureVerification(b *testing.B) {
+	privateKey := make([]byte, 32)
+	for i := range privateKey {
+		privateKey[i] = byte(i + 1)
+	}
+
+	b.Run("Schnorr", func(b *testing.B) {
+		keyPair, err := secp256k1.DeserializeSchnorrPrivateKeyFromSlice(privateKey)
+		if err != nil {
+			b.Fatalf("DeserializeSchnorrPrivateKeyFromSlice: %+v", err)
+		}
+		publicKey, err := keyPair.SchnorrPublicKey()
+		if err != nil {
+			b.Fatalf("SchnorrPublicKey: %+v", err)
+		}
+		serializedPublicKey, err := publicKey.Serialize()
+		if err != nil {
+			b.Fatalf("Serialize: %+v", err)
+		}
+		address, err := util.NewAddressPublicKey(serializedPublicKey[:], dagconfig.SimnetParams.Prefix)
+		if err != nil {
+			b.Fatalf("NewAddressPublicKey: %+v", err)
+		}
+
+		transaction := paymentTransaction(b, address)
+		transaction.Inputs[0].SignatureScript, err = txscript.SignatureScript(transaction, 0,
+			consensushashing.SigHashAll, keyPair, &consensushashing.SighashReusedValues{})
+		if err != nil {
+			b.Fatalf("SignatureScript: %+v", err)
+		}
+
+		b.ResetTimer()
+		verifySignature(b, transaction)
+	})
+
+	b.Run("ECDSA", func(b *testing.B) {
+		privateKey, err := secp256k1.DeserializeECDSAPrivateKeyFromSlice(privateKey)
+		if err != nil {
+			b.Fatalf("DeserializeECDSAPrivateKeyFromSlice: %+v", err)
+		}
+		publicKey, err := privateKey.ECDSAPublicKey()
+		if err != nil {
+			b.Fatalf("ECDSAPublicKey: %+v", err)
+		}
+		serializedPublicKey, err := publicKey.Serialize()
+		if err != nil {
+			b.Fatalf("Serialize: %+v", err)
+		}
+		address, err := util.NewAddressPublicKeyECDSA(serializedPublicKey[:], dagconfig.SimnetParams.Prefix)
+		if err != nil {
+			b.Fatalf("NewAddressPublicKeyECDSA: %+v", err)
+		}
+
+		transaction := paymentTransaction(b, address)
+		transaction.Inputs[0].SignatureScript, err = txscript.SignatureScriptECDSA(transaction, 0,
+			consensushashing.SigHashAll, privateKey, &consensushashing.SighashReusedValues{})
+		if err != nil {
+			b.Fatalf("SignatureScriptECDSA: %+v", err)
+		}
+
+		b.ResetTimer()
+		verifySignature(b, transaction)
+	})
+}
+
+// paymentTransaction returns an unsigned transaction that spends an output
+// paying to the given address
+func paymentTransaction(b *testing.B, address util.Address) *externalapi.DomainTransaction {
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		b.Fatalf("PayToAddrScript: %+v", err)
+	}
+	return &externalapi.DomainTransaction{
+		Version: constants.MaxTransactionVersion,
+		Inputs: []*externalapi.DomainTransactionInput{{
+			PreviousOutpoint: externalapi.DomainOutpoint{Index: 0},
+			Sequence:         constants.MaxTxInSequenceNum,
+			UTXOEntry:        utxo.NewUTXOEntry(100000000, scriptPublicKey, false, 0),
+		}},
+		Outputs:      []*externalapi.DomainTransactionOutput{{Value: 99999000, ScriptPublicKey: scriptPublicKey}},
+		SubnetworkID: subnetworks.SubnetworkIDNative,
+		Payload:      []byte{},
+	}
+}
+
+func verifySignature(b *testing.B, transaction *externalapi.DomainTransaction) {
+	scriptPublicKey := transaction.Inputs[0].UTXOEntry.ScriptPublicKey()
+	for i := 0; i < b.N; i++ {
+		vm, err := txscript.NewEngine(scriptPublicKey, transaction, 0, txscript.ScriptNoFlags, nil, nil,
+			&consensushashing.SighashReusedValues{})
+		if err != nil {
+			b.Fatalf("NewEngine: %+v", err)
+		}
+		err = vm.Execute()
+		if err != nil {
+			b.Fatalf("Execute: %+v", err)
+		}
+	}
+}
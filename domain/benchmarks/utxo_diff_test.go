@@ -0,0 +1,38 @@
+package benchmarks
+
+import (
+	"testing"
+)
+
+// BenchmarkUTXODiff applies the changes to the virtual UTXO set caused by each
+// of the blocks of the dataset, in order, to the changes caused by the first
+// block
+func BenchmarkUTXODiff(b *testing.B) {
+	generated := getDataset(b)
+	diffs := generated.virtualUTXODiffs
+
+	b.Run("WithDiff", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var err error
+			diff := diffs[0]
+			for diffIndex := 1; diffIndex < len(diffs); diffIndex++ {
+				diff, err = diff.WithDiff(diffs[diffIndex])
+				if err != nil {
+					b.Fatalf("WithDiff of diff %d: %+v", diffIndex, err)
+				}
+			}
+		}
+	})
+
+	b.Run("WithDiffInPlace", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			diff := diffs[0].CloneMutable()
+			for diffIndex := 1; diffIndex < len(diffs); diffIndex++ {
+				err := diff.WithDiffInPlace(diffs[diffIndex])
+				if err != nil {
+					b.Fatalf("WithDiffInPlace of diff %d: %+v", diffIndex, err)
+				}
+			}
+		}
+	})
+}
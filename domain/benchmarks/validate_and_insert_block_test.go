@@ -0,0 +1,39 @@
+package benchmarks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+)
+
+// BenchmarkValidateAndInsertBlock inserts all the blocks of the dataset, in
+// order, into a fresh consensus
+func BenchmarkValidateAndInsertBlock(b *testing.B) {
+	generated := getDataset(b)
+
+	var insertionDuration time.Duration
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(datasetConsensusConfig(), "BenchmarkValidateAndInsertBlock")
+		if err != nil {
+			b.Fatalf("Error setting up consensus: %+v", err)
+		}
+		b.StartTimer()
+
+		start := time.Now()
+		for blockIndex, block := range generated.blocks {
+			_, err := tc.ValidateAndInsertBlock(block)
+			if err != nil {
+				b.Fatalf("Error inserting block %d: %+v", blockIndex, err)
+			}
+		}
+		insertionDuration += time.Since(start)
+
+		b.StopTimer()
+		teardown(false)
+		b.StartTimer()
+	}
+	b.ReportMetric(float64(insertionDuration.Nanoseconds())/float64(b.N*len(generated.blocks)), "ns/block")
+}
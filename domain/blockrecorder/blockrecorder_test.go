@@ -0,0 +1,266 @@
+package blockrecorder_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/blockrecorder"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/pkg/errors"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		recordingDir, err := ioutil.TempDir("", "TestRecordAndReplay")
+		if err != nil {
+			t.Fatalf("TempDir: %+v", err)
+		}
+		defer os.RemoveAll(recordingDir)
+		recordingPath := filepath.Join(recordingDir, "recording")
+
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestRecordAndReplay")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		recordingConsensus, err := blockrecorder.New(tc, recordingPath, consensusConfig.Name)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+
+		// Record the DAG:
+		// genesis <- A <- C <- D (UTXO invalid)
+		//         <- B <-
+		// and a block with a missing parent
+		insertBlock := func(block *externalapi.DomainBlock) *externalapi.DomainHash {
+			_, _ = recordingConsensus.ValidateAndInsertBlock(block)
+			return consensushashing.BlockHash(block)
+		}
+		buildBlock := func(parentHashes ...*externalapi.DomainHash) *externalapi.DomainBlock {
+			block, _, err := tc.BuildBlockWithParents(parentHashes, nil, nil)
+			if err != nil {
+				t.Fatalf("BuildBlockWithParents: %+v", err)
+			}
+			return block
+		}
+		blockAHash := insertBlock(buildBlock(consensusConfig.GenesisHash))
+		blockBHash := insertBlock(buildBlock(consensusConfig.GenesisHash))
+		blockCHash := insertBlock(buildBlock(blockAHash, blockBHash))
+		blockD, err := tc.BuildUTXOInvalidBlock([]*externalapi.DomainHash{blockCHash})
+		if err != nil {
+			t.Fatalf("BuildUTXOInvalidBlock: %+v", err)
+		}
+		insertBlock(blockD)
+		orphanBlock := buildBlock(blockCHash)
+		header := orphanBlock.Header
+		orphanBlock.Header = blockheader.NewImmutableBlockHeader(header.Version(),
+			[]*externalapi.DomainHash{externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})},
+			header.HashMerkleRoot(), header.AcceptedIDMerkleRoot(), header.UTXOCommitment(),
+			header.TimeInMilliseconds(), header.Bits(), header.Nonce())
+		insertBlock(orphanBlock)
+
+		replayTC, replayTeardown, err := factory.NewTestConsensus(consensusConfig, "TestRecordAndReplay_replay")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer replayTeardown(false)
+
+		reader, err := blockrecorder.OpenReader(recordingPath)
+		if err != nil {
+			t.Fatalf("OpenReader: %+v", err)
+		}
+		defer reader.Close()
+		if reader.NetworkName != consensusConfig.Name {
+			t.Fatalf("Expected the recording to be of %s, got %s", consensusConfig.Name, reader.NetworkName)
+		}
+
+		var records []*blockrecorder.Record
+		for {
+			expected, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Read: %+v", err)
+			}
+			records = append(records, expected)
+
+			_, insertionErr := replayTC.ValidateAndInsertBlock(expected.Block)
+			actual, err := blockrecorder.NewRecord(replayTC, expected.Block, insertionErr)
+			if err != nil {
+				t.Fatalf("NewRecord: %+v", err)
+			}
+			divergence := blockrecorder.Divergence(expected, actual)
+			if divergence != "" {
+				t.Fatalf("Record %d diverged: %s", len(records)-1, divergence)
+			}
+		}
+
+		if len(records) != 5 {
+			t.Fatalf("Expected 5 records, got %d", len(records))
+		}
+		if records[3].BlockStatus != externalapi.StatusDisqualifiedFromChain {
+			t.Fatalf("Expected the UTXO invalid block to be disqualified from chain, got %s", records[3].BlockStatus)
+		}
+		if records[4].BlockExists || records[4].InsertionError == "" {
+			t.Fatalf("Expected the block with a missing parent to fail insertion")
+		}
+
+		// A different outcome should be reported as a divergence
+		changedRecord := *records[2]
+		changedVirtual := *changedRecord.Virtual
+		changedVirtual.BlueScore++
+		changedRecord.Virtual = &changedVirtual
+		if blockrecorder.Divergence(records[2], &changedRecord) == "" {
+			t.Fatalf("Expected a different virtual blue score to be a divergence")
+		}
+
+		// Recording to the same file on another network should fail
+		_, err = blockrecorder.OpenWriter(recordingPath, "another-network")
+		if err == nil {
+			t.Fatalf("Expected recording on another network to fail")
+		}
+	})
+}
+
+func TestReadTruncatedRecording(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		recordingDir, err := ioutil.TempDir("", "TestReadTruncatedRecording")
+		if err != nil {
+			t.Fatalf("TempDir: %+v", err)
+		}
+		defer os.RemoveAll(recordingDir)
+		recordingPath := filepath.Join(recordingDir, "recording")
+
+		tc, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig, "TestReadTruncatedRecording")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		recordingConsensus, err := blockrecorder.New(tc, recordingPath, consensusConfig.Name)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		parentHash := consensusConfig.GenesisHash
+		for i := 0; i < 2; i++ {
+			block, _, err := tc.BuildBlockWithParents([]*externalapi.DomainHash{parentHash}, nil, nil)
+			if err != nil {
+				t.Fatalf("BuildBlockWithParents: %+v", err)
+			}
+			_, err = recordingConsensus.ValidateAndInsertBlock(block)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+			parentHash = consensushashing.BlockHash(block)
+		}
+
+		// Simulate a crash in the middle of writing the last record
+		fileInfo, err := os.Stat(recordingPath)
+		if err != nil {
+			t.Fatalf("Stat: %+v", err)
+		}
+		err = os.Truncate(recordingPath, fileInfo.Size()-3)
+		if err != nil {
+			t.Fatalf("Truncate: %+v", err)
+		}
+
+		reader, err := blockrecorder.OpenReader(recordingPath)
+		if err != nil {
+			t.Fatalf("OpenReader: %+v", err)
+		}
+		defer reader.Close()
+		_, err = reader.Read()
+		if err != nil {
+			t.Fatalf("Read: %+v", err)
+		}
+		_, err = reader.Read()
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("Expected the truncated record to return io.ErrUnexpectedEOF, got %+v", err)
+		}
+	})
+}
+
+func TestAppendAfterTruncatedRecord(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		recordingDir, err := ioutil.TempDir("", "TestAppendAfterTruncatedRecord")
+		if err != nil {
+			t.Fatalf("TempDir: %+v", err)
+		}
+		defer os.RemoveAll(recordingDir)
+		recordingPath := filepath.Join(recordingDir, "recording")
+
+		tc, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig, "TestAppendAfterTruncatedRecord")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		parentHash := consensusConfig.GenesisHash
+		var blockHashes []*externalapi.DomainHash
+		insertBlock := func(recordingConsensus externalapi.Consensus) {
+			block, _, err := tc.BuildBlockWithParents([]*externalapi.DomainHash{parentHash}, nil, nil)
+			if err != nil {
+				t.Fatalf("BuildBlockWithParents: %+v", err)
+			}
+			_, err = recordingConsensus.ValidateAndInsertBlock(block)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertBlock: %+v", err)
+			}
+			parentHash = consensushashing.BlockHash(block)
+			blockHashes = append(blockHashes, parentHash)
+		}
+
+		recordingConsensus, err := blockrecorder.New(tc, recordingPath, consensusConfig.Name)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		insertBlock(recordingConsensus)
+		insertBlock(recordingConsensus)
+
+		// Simulate a crash in the middle of writing the last record,
+		// and a restart that keeps recording to the same file
+		fileInfo, err := os.Stat(recordingPath)
+		if err != nil {
+			t.Fatalf("Stat: %+v", err)
+		}
+		err = os.Truncate(recordingPath, fileInfo.Size()-3)
+		if err != nil {
+			t.Fatalf("Truncate: %+v", err)
+		}
+		recordingConsensus, err = blockrecorder.New(tc, recordingPath, consensusConfig.Name)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		insertBlock(recordingConsensus)
+
+		reader, err := blockrecorder.OpenReader(recordingPath)
+		if err != nil {
+			t.Fatalf("OpenReader: %+v", err)
+		}
+		defer reader.Close()
+		for _, expectedBlockHash := range []*externalapi.DomainHash{blockHashes[0], blockHashes[2]} {
+			record, err := reader.Read()
+			if err != nil {
+				t.Fatalf("Read: %+v", err)
+			}
+			if !consensushashing.BlockHash(record.Block).Equal(expectedBlockHash) {
+				t.Fatalf("Expected a record of block %s, but got one of %s",
+					expectedBlockHash, consensushashing.BlockHash(record.Block))
+			}
+		}
+		_, err = reader.Read()
+		if !errors.Is(err, io.EOF) {
+			t.Fatalf("Expected the recording to end after the appended record, got %+v", err)
+		}
+	})
+}
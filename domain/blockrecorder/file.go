@@ -0,0 +1,360 @@
+package blockrecorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/kaspanet/kaspad/domain/consensus/database/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// A recording starts with a header made of magic, the format version and the
+// name of the network it was recorded on. It's followed by the records, each
+// prefixed by its length as a uvarint. A record is made of:
+//   - The block, serialized as in the database, prefixed by its length
+//   - The insertion error, prefixed by its length
+//   - Whether the block exists, and its status, a byte each
+//   - The virtual parents, prefixed by their count, followed by the virtual's
+//     blue score, DAA score, bits and past median time as varints, and its UTXO commitment
+var magic = []byte("kasparec")
+
+const formatVersion = 1
+
+// maxRecordSize bounds the memory used to read a single record, so that a
+// corrupted length doesn't make the reader allocate an arbitrary amount of memory
+const maxRecordSize = 64 * 1024 * 1024
+
+// Writer appends records to a recording
+type Writer struct {
+	file *os.File
+}
+
+// OpenWriter opens the recording at the given path for appending, or creates
+// it if it doesn't exist. An existing recording must have been recorded on the
+// given network. If it ends with a truncated record, left by a crash while it
+// was being written, the truncated record is removed before appending.
+func OpenWriter(path string, networkName string) (*Writer, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if fileInfo.Size() == 0 {
+		err = writeHeader(file, networkName)
+	} else {
+		err = prepareForAppending(file, fileInfo.Size(), networkName)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &Writer{file: file}, nil
+}
+
+// prepareForAppending checks that the recording in file was recorded on
+// the given network, and truncates it right after its last complete record
+func prepareForAppending(file *os.File, fileSize int64, networkName string) error {
+	reader := bufio.NewReader(io.NewSectionReader(file, 0, fileSize))
+	recordingNetworkName, err := readHeader(reader)
+	if err != nil {
+		return err
+	}
+	if recordingNetworkName != networkName {
+		return errors.Errorf("%s was recorded on %s, not on %s", file.Name(), recordingNetworkName, networkName)
+	}
+
+	offset := int64(len(magic) + len(appendUvarint(nil, formatVersion)) +
+		len(appendBytes(nil, []byte(recordingNetworkName))))
+	for {
+		recordSize, err := binary.ReadUvarint(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+		isTruncated := err != nil
+		recordEnd := offset + int64(len(appendUvarint(nil, recordSize))) + int64(recordSize)
+		if isTruncated || recordEnd > fileSize {
+			log.Warnf("Removing a truncated record of %d bytes from the end of %s",
+				fileSize-offset, file.Name())
+			return file.Truncate(offset)
+		}
+		if recordSize > maxRecordSize {
+			return errors.Errorf("record size %d at offset %d of %s is larger than the maximum of %d",
+				recordSize, offset, file.Name(), maxRecordSize)
+		}
+		_, err = reader.Discard(int(recordSize))
+		if err != nil {
+			return err
+		}
+		offset = recordEnd
+	}
+}
+
+// Write appends the given record to the recording. The record is written with
+// a single write, so that a crash leaves at most one truncated record at the
+// end of the recording.
+func (w *Writer) Write(record *Record) error {
+	serializedRecord, err := serializeRecord(record)
+	if err != nil {
+		return err
+	}
+	buffer := appendUvarint(nil, uint64(len(serializedRecord)))
+	buffer = append(buffer, serializedRecord...)
+	_, err = w.file.Write(buffer)
+	return err
+}
+
+// Close closes the recording
+func (w *Writer) Close() error {
+	return w.file.Close()
+}
+
+// Reader reads the records of a recording in order
+type Reader struct {
+	file   *os.File
+	reader *bufio.Reader
+
+	// NetworkName is the name of the network the recording was recorded on
+	NetworkName string
+}
+
+// OpenReader opens the recording at the given path for reading
+func OpenReader(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(file)
+	networkName, err := readHeader(reader)
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "error reading the header of %s", path)
+	}
+	return &Reader{
+		file:        file,
+		reader:      reader,
+		NetworkName: networkName,
+	}, nil
+}
+
+// Read returns the next record of the recording. It returns io.EOF once all
+// the records were read, and an error wrapping io.ErrUnexpectedEOF if the last
+// record was truncated.
+func (r *Reader) Read() (*Record, error) {
+	recordSize, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errors.Wrap(err, "the recording ends with a truncated record")
+		}
+		return nil, err
+	}
+	if recordSize > maxRecordSize {
+		return nil, errors.Errorf("record size %d is larger than the maximum of %d", recordSize, maxRecordSize)
+	}
+	serializedRecord := make([]byte, recordSize)
+	_, err = io.ReadFull(r.reader, serializedRecord)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errors.Wrap(io.ErrUnexpectedEOF, "the recording ends with a truncated record")
+		}
+		return nil, err
+	}
+	return deserializeRecord(serializedRecord)
+}
+
+// Close closes the recording
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
+
+func writeHeader(writer io.Writer, networkName string) error {
+	header := append([]byte{}, magic...)
+	header = appendUvarint(header, formatVersion)
+	header = appendBytes(header, []byte(networkName))
+	_, err := writer.Write(header)
+	return err
+}
+
+func readHeader(reader *bufio.Reader) (networkName string, err error) {
+	fileMagic := make([]byte, len(magic))
+	_, err = io.ReadFull(reader, fileMagic)
+	if err != nil || !bytes.Equal(fileMagic, magic) {
+		return "", errors.New("not a block recording")
+	}
+	version, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return "", err
+	}
+	if version != formatVersion {
+		return "", errors.Errorf("unsupported recording format version %d", version)
+	}
+	networkNameBytes, err := readBytes(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(networkNameBytes), nil
+}
+
+func serializeRecord(record *Record) ([]byte, error) {
+	serializedBlock, err := proto.Marshal(serialization.DomainBlockToDbBlock(record.Block))
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := appendBytes(nil, serializedBlock)
+	buffer = appendBytes(buffer, []byte(record.InsertionError))
+	blockExists := byte(0)
+	if record.BlockExists {
+		blockExists = 1
+	}
+	buffer = append(buffer, blockExists, byte(record.BlockStatus))
+
+	virtual := record.Virtual
+	buffer = appendUvarint(buffer, uint64(len(virtual.ParentHashes)))
+	for _, parentHash := range virtual.ParentHashes {
+		buffer = append(buffer, parentHash.ByteSlice()...)
+	}
+	buffer = appendUvarint(buffer, virtual.BlueScore)
+	buffer = appendUvarint(buffer, virtual.DAAScore)
+	buffer = appendUvarint(buffer, uint64(virtual.Bits))
+	buffer = appendVarint(buffer, virtual.PastMedianTime)
+	buffer = append(buffer, virtual.UTXOCommitment.ByteSlice()...)
+
+	return buffer, nil
+}
+
+func deserializeRecord(serializedRecord []byte) (*Record, error) {
+	reader := bytes.NewReader(serializedRecord)
+
+	serializedBlock, err := readBytes(reader)
+	if err != nil {
+		return nil, err
+	}
+	dbBlock := &serialization.DbBlock{}
+	err = proto.Unmarshal(serializedBlock, dbBlock)
+	if err != nil {
+		return nil, err
+	}
+	block, err := serialization.DbBlockToDomainBlock(dbBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	insertionError, err := readBytes(reader)
+	if err != nil {
+		return nil, err
+	}
+	blockExists, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	blockStatus, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	virtual := &externalapi.VirtualInfo{}
+	parentCount, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if parentCount > uint64(reader.Len()/externalapi.DomainHashSize) {
+		return nil, errors.Errorf("virtual parent count %d is larger than the rest of the record", parentCount)
+	}
+	virtual.ParentHashes = make([]*externalapi.DomainHash, parentCount)
+	for i := range virtual.ParentHashes {
+		virtual.ParentHashes[i], err = readHash(reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	virtual.BlueScore, err = binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	virtual.DAAScore, err = binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	bits, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	virtual.Bits = uint32(bits)
+	virtual.PastMedianTime, err = binary.ReadVarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	virtual.UTXOCommitment, err = readHash(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Record{
+		Block:          block,
+		InsertionError: string(insertionError),
+		BlockExists:    blockExists != 0,
+		BlockStatus:    externalapi.BlockStatus(blockStatus),
+		Virtual:        virtual,
+	}, nil
+}
+
+func appendBytes(buffer []byte, data []byte) []byte {
+	buffer = appendUvarint(buffer, uint64(len(data)))
+	return append(buffer, data...)
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func readBytes(reader byteReader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxRecordSize {
+		return nil, errors.Errorf("length %d is larger than the maximum of %d", length, maxRecordSize)
+	}
+	data := make([]byte, length)
+	_, err = io.ReadFull(reader, data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func readHash(reader io.Reader) (*externalapi.DomainHash, error) {
+	var hashBytes [externalapi.DomainHashSize]byte
+	_, err := io.ReadFull(reader, hashBytes[:])
+	if err != nil {
+		return nil, err
+	}
+	return externalapi.NewDomainHashFromByteArray(&hashBytes), nil
+}
+
+func appendUvarint(buffer []byte, value uint64) []byte {
+	var varintBytes [binary.MaxVarintLen64]byte
+	length := binary.PutUvarint(varintBytes[:], value)
+	return append(buffer, varintBytes[:length]...)
+}
+
+func appendVarint(buffer []byte, value int64) []byte {
+	var varintBytes [binary.MaxVarintLen64]byte
+	length := binary.PutVarint(varintBytes[:], value)
+	return append(buffer, varintBytes[:length]...)
+}
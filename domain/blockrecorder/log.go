@@ -0,0 +1,7 @@
+package blockrecorder
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("BREC")
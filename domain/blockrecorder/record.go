@@ -0,0 +1,93 @@
+package blockrecorder
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+// Record is a single block that was passed to ValidateAndInsertBlock,
+// together with the outcome of its insertion
+type Record struct {
+	Block *externalapi.DomainBlock
+
+	// InsertionError is the error ValidateAndInsertBlock returned,
+	// or an empty string if it succeeded
+	InsertionError string
+
+	// BlockExists and BlockStatus describe the block right after its insertion
+	BlockExists bool
+	BlockStatus externalapi.BlockStatus
+
+	// Virtual is the state of the virtual right after the insertion
+	Virtual *externalapi.VirtualInfo
+}
+
+// NewRecord builds the record of passing the given block to the
+// ValidateAndInsertBlock of the given consensus, which returned insertionErr
+func NewRecord(consensus externalapi.Consensus, block *externalapi.DomainBlock, insertionErr error) (*Record, error) {
+	record := &Record{Block: block}
+	if insertionErr != nil {
+		record.InsertionError = insertionErr.Error()
+	}
+
+	blockInfo, err := consensus.GetBlockInfo(consensushashing.BlockHash(block))
+	if err != nil {
+		return nil, err
+	}
+	record.BlockExists = blockInfo.Exists
+	record.BlockStatus = blockInfo.BlockStatus
+
+	record.Virtual, err = consensus.GetVirtualInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Divergence describes the first difference between the outcomes of the
+// expected and the actual records, or returns an empty string if they have
+// the same outcome
+func Divergence(expected *Record, actual *Record) string {
+	expectedStatus := blockStatusString(expected.BlockExists, expected.BlockStatus)
+	actualStatus := blockStatusString(actual.BlockExists, actual.BlockStatus)
+	if expectedStatus != actualStatus {
+		return fmt.Sprintf("block status: expected %s, got %s", expectedStatus, actualStatus)
+	}
+	if expected.InsertionError != actual.InsertionError {
+		return fmt.Sprintf("insertion error: expected '%s', got '%s'", expected.InsertionError, actual.InsertionError)
+	}
+
+	expectedVirtual := expected.Virtual
+	actualVirtual := actual.Virtual
+	switch {
+	case !expectedVirtual.UTXOCommitment.Equal(actualVirtual.UTXOCommitment):
+		return fmt.Sprintf("virtual UTXO commitment: expected %s, got %s",
+			expectedVirtual.UTXOCommitment, actualVirtual.UTXOCommitment)
+	case !externalapi.HashesEqual(expectedVirtual.ParentHashes, actualVirtual.ParentHashes):
+		return fmt.Sprintf("virtual parents: expected %s, got %s",
+			expectedVirtual.ParentHashes, actualVirtual.ParentHashes)
+	case expectedVirtual.BlueScore != actualVirtual.BlueScore:
+		return fmt.Sprintf("virtual blue score: expected %d, got %d",
+			expectedVirtual.BlueScore, actualVirtual.BlueScore)
+	case expectedVirtual.DAAScore != actualVirtual.DAAScore:
+		return fmt.Sprintf("virtual DAA score: expected %d, got %d",
+			expectedVirtual.DAAScore, actualVirtual.DAAScore)
+	case expectedVirtual.Bits != actualVirtual.Bits:
+		return fmt.Sprintf("virtual bits: expected %d, got %d", expectedVirtual.Bits, actualVirtual.Bits)
+	case expectedVirtual.PastMedianTime != actualVirtual.PastMedianTime:
+		return fmt.Sprintf("virtual past median time: expected %d, got %d",
+			expectedVirtual.PastMedianTime, actualVirtual.PastMedianTime)
+	}
+
+	return ""
+}
+
+func blockStatusString(exists bool, status externalapi.BlockStatus) string {
+	if !exists {
+		return "nonexistent"
+	}
+	return status.String()
+}
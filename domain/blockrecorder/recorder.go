@@ -0,0 +1,61 @@
+package blockrecorder
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// recordingConsensus is a consensus that records every block passed to its
+// ValidateAndInsertBlock, together with the outcome of its insertion
+type recordingConsensus struct {
+	externalapi.Consensus
+
+	writer *Writer
+
+	// lock makes sure that the recorded outcome of an insertion isn't
+	// affected by a concurrent insertion
+	lock sync.Mutex
+}
+
+// New returns a consensus that passes everything to the given consensus,
+// and records every block passed to ValidateAndInsertBlock in the recording
+// at the given path. A failure to record a block never fails its insertion:
+// it's logged, and recording stops.
+func New(consensus externalapi.Consensus, path string, networkName string) (externalapi.Consensus, error) {
+	writer, err := OpenWriter(path, networkName)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Recording inserted blocks to %s", path)
+	return &recordingConsensus{
+		Consensus: consensus,
+		writer:    writer,
+	}, nil
+}
+
+func (rc *recordingConsensus) ValidateAndInsertBlock(block *externalapi.DomainBlock) (
+	*externalapi.BlockInsertionResult, error) {
+
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	blockInsertionResult, insertionErr := rc.Consensus.ValidateAndInsertBlock(block)
+	if rc.writer != nil {
+		err := rc.record(block, insertionErr)
+		if err != nil {
+			log.Errorf("Stopped recording blocks: %+v", err)
+			rc.writer.Close()
+			rc.writer = nil
+		}
+	}
+	return blockInsertionResult, insertionErr
+}
+
+func (rc *recordingConsensus) record(block *externalapi.DomainBlock, insertionErr error) error {
+	record, err := NewRecord(rc.Consensus, block, insertionErr)
+	if err != nil {
+		return err
+	}
+	return rc.writer.Write(record)
+}
@@ -0,0 +1,150 @@
+package consensus
+
+// The estimated memory, in bytes, that a single entry takes in each of the
+// consensus caches. These are only used to divide the cache memory budget
+// between the caches, so they don't have to be accurate
+const (
+	acceptanceDataEntrySize       = 10_000
+	blockEntrySize                = 50_000
+	blockHeaderEntrySize          = 500
+	blockRelationEntrySize        = 500
+	blockStatusEntrySize          = 50
+	multisetEntrySize             = 150
+	reachabilityDataEntrySize     = 300
+	utxoDiffEntrySize             = 5_000
+	ghostdagDataEntrySize         = 1_000
+	finalityEntrySize             = 100
+	headersSelectedChainEntrySize = 150
+	daaScoreEntrySize             = 60
+	daaAddedBlocksEntrySize       = 400
+)
+
+const (
+	// defaultUTXOSetCacheSize is the size of the virtual UTXO set cache when
+	// no cache memory budget is set. It's about 10,000 UTXO entries
+	defaultUTXOSetCacheSize = 2 * 1024 * 1024
+
+	// minUTXOSetCacheSize is the smallest the virtual UTXO set cache gets,
+	// however small the cache memory budget is
+	minUTXOSetCacheSize = 1024 * 1024
+
+	// minEntryCacheSize is the smallest, in entries, that any of the other
+	// consensus caches gets, however small the cache memory budget is
+	minEntryCacheSize = 100
+)
+
+// cacheSizes holds the capacities of the consensus caches. All of them are
+// measured in entries, except for utxoSet, which is measured in bytes
+type cacheSizes struct {
+	acceptanceData       int
+	block                int
+	blockHeader          int
+	blockRelation        int
+	blockStatus          int
+	multiset             int
+	reachabilityData     int
+	utxoDiff             int
+	ghostdagData         int
+	finality             int
+	headersSelectedChain int
+	daaScore             int
+	daaAddedBlocks       int
+
+	utxoSet uint64
+}
+
+type entryCacheSize struct {
+	size               *int
+	estimatedEntrySize uint64
+}
+
+// newCacheSizes returns the capacities of the consensus caches for the given config.
+//
+// When config.MaxCacheSize is set, at most half of it goes to the caches that are
+// measured in entries, which are shrunk proportionally if their default sizes don't
+// fit, and the rest goes to the virtual UTXO set cache. Since no cache is shrunk
+// below its minimum size, very small budgets may be exceeded
+func newCacheSizes(config *Config) *cacheSizes {
+	pruningWindowSize := int(config.PruningDepth())
+
+	// This is used for caches that are used as part of deletePastBlocks that need to traverse until
+	// the previous pruning point.
+	pruningWindowSizePlusFinalityDepth := int(config.PruningDepth() + config.FinalityDepth())
+
+	sizes := &cacheSizes{
+		acceptanceData:       200,
+		block:                200,
+		blockHeader:          10_000,
+		blockRelation:        pruningWindowSizePlusFinalityDepth,
+		blockStatus:          pruningWindowSizePlusFinalityDepth,
+		multiset:             200,
+		reachabilityData:     pruningWindowSizePlusFinalityDepth,
+		utxoDiff:             200,
+		ghostdagData:         pruningWindowSize,
+		finality:             200,
+		headersSelectedChain: pruningWindowSize,
+		daaScore:             pruningWindowSize,
+		daaAddedBlocks:       int(config.FinalityDepth()),
+		utxoSet:              defaultUTXOSetCacheSize,
+	}
+	if config.MaxCacheSize > 0 {
+		sizes.fitToBudget(config.MaxCacheSize)
+	}
+
+	// Some tests artificially decrease the pruningWindowSize, thus making the GhostDagStore cache too small for a
+	// a single DifficultyAdjustmentWindow. To alleviate this problem we make sure that the cache size is at least
+	// dagParams.DifficultyAdjustmentWindowSize
+	if sizes.ghostdagData < config.DifficultyAdjustmentWindowSize {
+		sizes.ghostdagData = config.DifficultyAdjustmentWindowSize
+	}
+
+	return sizes
+}
+
+func (cs *cacheSizes) fitToBudget(budget uint64) {
+	entryCachesBudget := budget / 2
+	entryCachesSize := cs.estimatedEntryCachesSize()
+	if entryCachesSize > entryCachesBudget {
+		ratio := float64(entryCachesBudget) / float64(entryCachesSize)
+		for _, entryCache := range cs.entryCaches() {
+			*entryCache.size = int(float64(*entryCache.size) * ratio)
+			if *entryCache.size < minEntryCacheSize {
+				*entryCache.size = minEntryCacheSize
+			}
+		}
+		entryCachesSize = cs.estimatedEntryCachesSize()
+	}
+
+	cs.utxoSet = minUTXOSetCacheSize
+	if budget > entryCachesSize+minUTXOSetCacheSize {
+		cs.utxoSet = budget - entryCachesSize
+	}
+}
+
+// estimatedEntryCachesSize returns the estimated memory, in bytes, that the
+// caches that are measured in entries take when they're full
+func (cs *cacheSizes) estimatedEntryCachesSize() uint64 {
+	size := uint64(0)
+	for _, entryCache := range cs.entryCaches() {
+		size += uint64(*entryCache.size) * entryCache.estimatedEntrySize
+	}
+	return size
+}
+
+func (cs *cacheSizes) entryCaches() []entryCacheSize {
+	return []entryCacheSize{
+		{&cs.acceptanceData, acceptanceDataEntrySize},
+		{&cs.block, blockEntrySize},
+		{&cs.blockHeader, blockHeaderEntrySize},
+		{&cs.blockRelation, blockRelationEntrySize},
+		{&cs.blockStatus, blockStatusEntrySize},
+		{&cs.multiset, multisetEntrySize},
+		{&cs.reachabilityData, reachabilityDataEntrySize},
+		{&cs.utxoDiff, utxoDiffEntrySize},
+		{&cs.ghostdagData, ghostdagDataEntrySize},
+		{&cs.finality, finalityEntrySize},
+		{&cs.headersSelectedChain, headersSelectedChainEntrySize},
+		{&cs.daaScore, daaScoreEntrySize},
+		{&cs.daaAddedBlocks, daaAddedBlocksEntrySize},
+	}
+}
@@ -0,0 +1,53 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestNewCacheSizes(t *testing.T) {
+	defaultSizes := newCacheSizes(&Config{Params: dagconfig.MainnetParams})
+	if defaultSizes.utxoSet != defaultUTXOSetCacheSize {
+		t.Fatalf("unexpected default UTXO set cache size: want %d, got %d",
+			defaultUTXOSetCacheSize, defaultSizes.utxoSet)
+	}
+	defaultEntryCachesSize := defaultSizes.estimatedEntryCachesSize()
+
+	tests := []struct {
+		name   string
+		budget uint64
+	}{
+		{name: "large budget", budget: 4 * defaultEntryCachesSize},
+		{name: "budget that requires shrinking", budget: defaultEntryCachesSize},
+	}
+	for _, test := range tests {
+		sizes := newCacheSizes(&Config{Params: dagconfig.MainnetParams, MaxCacheSize: test.budget})
+		entryCachesSize := sizes.estimatedEntryCachesSize()
+
+		if entryCachesSize > test.budget/2 {
+			t.Errorf("%s: entry caches take %d bytes, which is more than half of the budget of %d bytes",
+				test.name, entryCachesSize, test.budget)
+		}
+		if entryCachesSize+sizes.utxoSet != test.budget {
+			t.Errorf("%s: caches take %d bytes, but the budget is %d bytes",
+				test.name, entryCachesSize+sizes.utxoSet, test.budget)
+		}
+		if entryCachesSize > defaultEntryCachesSize {
+			t.Errorf("%s: entry caches grew beyond their default sizes", test.name)
+		}
+		if sizes.ghostdagData < dagconfig.MainnetParams.DifficultyAdjustmentWindowSize {
+			t.Errorf("%s: the GHOSTDAG data cache is smaller than the difficulty adjustment window", test.name)
+		}
+	}
+
+	tinySizes := newCacheSizes(&Config{Params: dagconfig.MainnetParams, MaxCacheSize: 1})
+	if tinySizes.utxoSet != minUTXOSetCacheSize {
+		t.Errorf("unexpected UTXO set cache size for a tiny budget: want %d, got %d",
+			minUTXOSetCacheSize, tinySizes.utxoSet)
+	}
+	if tinySizes.block != minEntryCacheSize {
+		t.Errorf("unexpected block cache size for a tiny budget: want %d, got %d",
+			minEntryCacheSize, tinySizes.block)
+	}
+}
@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"sync"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/database"
 	"github.com/kaspanet/kaspad/domain/consensus/model"
@@ -60,13 +61,38 @@ func (s *consensus) BuildBlock(coinbaseData *externalapi.DomainCoinbaseData,
 	return s.blockBuilder.BuildBlock(coinbaseData, transactions)
 }
 
+// BuildBlockWithParents builds a block over the given parents, with the given
+// coinbaseData and the given transactions, and returns it together with its
+// past UTXO-diff from the virtual. The transactions are not validated
+func (s *consensus) BuildBlockWithParents(parentHashes []*externalapi.DomainHash,
+	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
+	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
+
+	// Require write lock because BuildBlockWithParents stages temporary data
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.blockBuilder.BuildBlockWithParents(parentHashes, coinbaseData, transactions)
+}
+
 // ValidateAndInsertBlock validates the given block and, if valid, applies it
 // to the current state
 func (s *consensus) ValidateAndInsertBlock(block *externalapi.DomainBlock) (*externalapi.BlockInsertionResult, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	return s.blockProcessor.ValidateAndInsertBlock(block)
+	start := time.Now()
+	blockInsertionResult, err := s.blockProcessor.ValidateAndInsertBlock(block)
+	validateAndInsertBlockDuration.ObserveDuration(start)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(block.Transactions) > 0 {
+		insertedBlocks.Inc()
+		insertedBlocksRate.Mark(1)
+	}
+	return blockInsertionResult, nil
 }
 
 // ValidateTransactionAndPopulateWithConsensusData validates the given transaction
@@ -391,6 +417,10 @@ func (s *consensus) GetVirtualInfo() (*externalapi.VirtualInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	virtualMultiset, err := s.multisetStore.Get(s.databaseContext, stagingArea, model.VirtualBlockHash)
+	if err != nil {
+		return nil, err
+	}
 
 	return &externalapi.VirtualInfo{
 		ParentHashes:   blockRelations.Parents,
@@ -398,6 +428,7 @@ func (s *consensus) GetVirtualInfo() (*externalapi.VirtualInfo, error) {
 		PastMedianTime: pastMedianTime,
 		BlueScore:      virtualGHOSTDAGData.BlueScore(),
 		DAAScore:       daaScore,
+		UTXOCommitment: virtualMultiset.Hash(),
 	}, nil
 }
 
@@ -535,3 +566,33 @@ func (s *consensus) Anticone(blockHash *externalapi.DomainHash) ([]*externalapi.
 
 	return s.dagTraversalManager.Anticone(stagingArea, blockHash)
 }
+
+// CacheStats returns the usage statistics of the consensus caches
+func (s *consensus) CacheStats() []*externalapi.CacheStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stores := []model.Store{
+		s.acceptanceDataStore,
+		s.blockStore,
+		s.blockHeaderStore,
+		s.pruningStore,
+		s.ghostdagDataStore,
+		s.blockRelationStore,
+		s.blockStatusStore,
+		s.consensusStateStore,
+		s.headersSelectedTipStore,
+		s.multisetStore,
+		s.reachabilityDataStore,
+		s.utxoDiffStore,
+		s.finalityStore,
+		s.headersSelectedChainStore,
+		s.daaBlocksStore,
+	}
+
+	var cacheStats []*externalapi.CacheStats
+	for _, store := range stores {
+		cacheStats = append(cacheStats, store.CacheStats()...)
+	}
+	return cacheStats
+}
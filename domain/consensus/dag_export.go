@@ -0,0 +1,194 @@
+package consensus
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// ExportDAG returns the blocks in the past of the DAG tips whose blue score is
+// at most depth below the blue score of the virtual, along with their GHOSTDAG
+// data, ordered by blue work. Blocks that are only known by their headers and
+// aren't in the past of any of the tips are not exported.
+func (s *consensus) ExportDAG(depth uint64) (*externalapi.DAGExport, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	virtualGHOSTDAGData, err := s.ghostdagDataStore.Get(s.databaseContext, stagingArea, model.VirtualBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	minBlueScore := uint64(0)
+	if virtualGHOSTDAGData.BlueScore() > depth {
+		minBlueScore = virtualGHOSTDAGData.BlueScore() - depth
+	}
+
+	colors, virtualSelectedParentChain, err := s.exportedColorsAndVirtualSelectedParentChain(
+		stagingArea, virtualGHOSTDAGData, minBlueScore)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualDAAWindow, err := s.difficultyManager.DAAWindow(stagingArea, model.VirtualBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	virtualDAAWindowSet := make(map[externalapi.DomainHash]struct{}, len(virtualDAAWindow))
+	for _, blockHash := range virtualDAAWindow {
+		virtualDAAWindowSet[*blockHash] = struct{}{}
+	}
+
+	tips, err := s.consensusStateStore.Tips(stagingArea, s.databaseContext)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []*externalapi.DAGExportBlock
+	ghostdagDataByBlock := make(map[externalapi.DomainHash]*model.BlockGHOSTDAGData)
+	queue := append([]*externalapi.DomainHash{}, tips...)
+	for len(queue) > 0 {
+		blockHash := queue[0]
+		queue = queue[1:]
+		if _, ok := ghostdagDataByBlock[*blockHash]; ok {
+			continue
+		}
+
+		// Parents of blocks near the pruning point might have already been pruned
+		exists, err := s.blockStatusStore.Exists(s.databaseContext, stagingArea, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		ghostdagData, err := s.ghostdagDataStore.Get(s.databaseContext, stagingArea, blockHash)
+		if err != nil {
+			return nil, err
+		}
+		if ghostdagData.BlueScore() < minBlueScore {
+			continue
+		}
+		ghostdagDataByBlock[*blockHash] = ghostdagData
+
+		block, err := s.exportBlock(stagingArea, blockHash, ghostdagData)
+		if err != nil {
+			return nil, err
+		}
+		block.Color = colors[*blockHash]
+		_, block.IsInVirtualSelectedParentChain = virtualSelectedParentChain[*blockHash]
+		_, block.IsInVirtualDAAWindow = virtualDAAWindowSet[*blockHash]
+		blocks = append(blocks, block)
+
+		queue = append(queue, block.Parents...)
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return s.ghostdagManager.Less(blocks[i].Hash, ghostdagDataByBlock[*blocks[i].Hash],
+			blocks[j].Hash, ghostdagDataByBlock[*blocks[j].Hash])
+	})
+
+	virtualParents, err := s.dagTopologyManager.Parents(stagingArea, model.VirtualBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	virtualDAAScore, err := s.daaBlocksStore.DAAScore(s.databaseContext, stagingArea, model.VirtualBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	pruningPoint, err := s.pruningStore.PruningPoint(s.databaseContext, stagingArea)
+	if err != nil {
+		return nil, err
+	}
+	finalityPoint, err := s.finalityManager.VirtualFinalityPoint(stagingArea)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalapi.DAGExport{
+		VirtualParents:   virtualParents,
+		VirtualBlueScore: virtualGHOSTDAGData.BlueScore(),
+		VirtualDAAScore:  virtualDAAScore,
+		PruningPoint:     pruningPoint,
+		FinalityPoint:    finalityPoint,
+		Blocks:           blocks,
+	}, nil
+}
+
+// exportedColorsAndVirtualSelectedParentChain walks down the virtual selected parent
+// chain until minBlueScore, and returns the colors of the blocks merged by it and the
+// set of the blocks in it
+func (s *consensus) exportedColorsAndVirtualSelectedParentChain(stagingArea *model.StagingArea,
+	virtualGHOSTDAGData *model.BlockGHOSTDAGData, minBlueScore uint64) (
+	map[externalapi.DomainHash]externalapi.DAGExportBlockColor, map[externalapi.DomainHash]struct{}, error) {
+
+	colors := make(map[externalapi.DomainHash]externalapi.DAGExportBlockColor)
+	virtualSelectedParentChain := make(map[externalapi.DomainHash]struct{})
+	ghostdagData := virtualGHOSTDAGData
+	for {
+		for _, blueHash := range ghostdagData.MergeSetBlues() {
+			colors[*blueHash] = externalapi.DAGExportBlockColorBlue
+		}
+		for _, redHash := range ghostdagData.MergeSetReds() {
+			colors[*redHash] = externalapi.DAGExportBlockColorRed
+		}
+
+		selectedParent := ghostdagData.SelectedParent()
+		if selectedParent == nil {
+			break
+		}
+		exists, err := s.blockStatusStore.Exists(s.databaseContext, stagingArea, selectedParent)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			break
+		}
+		ghostdagData, err = s.ghostdagDataStore.Get(s.databaseContext, stagingArea, selectedParent)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ghostdagData.BlueScore() < minBlueScore {
+			break
+		}
+		virtualSelectedParentChain[*selectedParent] = struct{}{}
+	}
+
+	return colors, virtualSelectedParentChain, nil
+}
+
+func (s *consensus) exportBlock(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	ghostdagData *model.BlockGHOSTDAGData) (*externalapi.DAGExportBlock, error) {
+
+	parents, err := s.dagTopologyManager.Parents(stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	status, err := s.blockStatusStore.Get(s.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.blockHeaderStore.BlockHeader(s.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	daaScore, err := s.daaBlocksStore.DAAScore(s.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalapi.DAGExportBlock{
+		Hash:           blockHash,
+		Parents:        parents,
+		SelectedParent: ghostdagData.SelectedParent(),
+		MergeSetBlues:  ghostdagData.MergeSetBlues(),
+		MergeSetReds:   ghostdagData.MergeSetReds(),
+		Status:         status,
+		BlueScore:      ghostdagData.BlueScore(),
+		BlueWork:       ghostdagData.BlueWork(),
+		DAAScore:       daaScore,
+		Timestamp:      header.TimeInMilliseconds(),
+	}, nil
+}
@@ -0,0 +1,90 @@
+package consensus_test
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+)
+
+func TestConsensus_ExportDAG(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestConsensus_ExportDAG")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		// Build the DAG:
+		// genesis <- A <- C
+		//         <- B <-
+		blockAHash, _, err := tc.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		blockBHash, _, err := tc.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		blockCHash, _, err := tc.AddBlock([]*externalapi.DomainHash{blockAHash, blockBHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+
+		dagExport, err := tc.ExportDAG(100)
+		if err != nil {
+			t.Fatalf("ExportDAG: %+v", err)
+		}
+		if len(dagExport.Blocks) != 4 {
+			t.Fatalf("Expected 4 exported blocks, got %d", len(dagExport.Blocks))
+		}
+		if !dagExport.Blocks[0].Hash.Equal(consensusConfig.GenesisHash) {
+			t.Fatalf("Expected the genesis to be exported first, got %s", dagExport.Blocks[0].Hash)
+		}
+		if !dagExport.Blocks[3].Hash.Equal(blockCHash) {
+			t.Fatalf("Expected block C to be exported last, got %s", dagExport.Blocks[3].Hash)
+		}
+		if !externalapi.HashesEqual(dagExport.VirtualParents, []*externalapi.DomainHash{blockCHash}) {
+			t.Fatalf("Unexpected virtual parents %s", dagExport.VirtualParents)
+		}
+		if !dagExport.PruningPoint.Equal(consensusConfig.GenesisHash) {
+			t.Fatalf("Expected the pruning point to be the genesis, got %s", dagExport.PruningPoint)
+		}
+		if !dagExport.FinalityPoint.Equal(consensusConfig.GenesisHash) {
+			t.Fatalf("Expected the finality point to be the genesis, got %s", dagExport.FinalityPoint)
+		}
+
+		blockC := dagExport.Blocks[3]
+		if len(blockC.MergeSetBlues) != 2 || len(blockC.MergeSetReds) != 0 {
+			t.Fatalf("Expected block C to merge 2 blue blocks and no red ones, got %d and %d",
+				len(blockC.MergeSetBlues), len(blockC.MergeSetReds))
+		}
+		for _, block := range dagExport.Blocks {
+			if block.Color != externalapi.DAGExportBlockColorBlue {
+				t.Errorf("Expected block %s to be blue, got %s", block.Hash, block.Color)
+			}
+			// The genesis is never part of a DAA window
+			isInVirtualDAAWindow := !block.Hash.Equal(consensusConfig.GenesisHash)
+			if block.IsInVirtualDAAWindow != isInVirtualDAAWindow {
+				t.Errorf("Expected IsInVirtualDAAWindow of block %s to be %t", block.Hash, isInVirtualDAAWindow)
+			}
+			isInVirtualSelectedParentChain := block.Hash.Equal(consensusConfig.GenesisHash) ||
+				block.Hash.Equal(blockC.SelectedParent) || block.Hash.Equal(blockCHash)
+			if block.IsInVirtualSelectedParentChain != isInVirtualSelectedParentChain {
+				t.Errorf("Expected IsInVirtualSelectedParentChain of block %s to be %t",
+					block.Hash, isInVirtualSelectedParentChain)
+			}
+		}
+
+		shallowDAGExport, err := tc.ExportDAG(1)
+		if err != nil {
+			t.Fatalf("ExportDAG: %+v", err)
+		}
+		if len(shallowDAGExport.Blocks) != 1 || !shallowDAGExport.Blocks[0].Hash.Equal(blockCHash) {
+			t.Fatalf("Expected only block C to be exported with a depth of 1, got %d blocks",
+				len(shallowDAGExport.Blocks))
+		}
+	})
+}
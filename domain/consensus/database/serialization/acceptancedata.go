@@ -2,6 +2,7 @@ package serialization
 
 import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
 )
 
 // DomainAcceptanceDataToDbAcceptanceData converts model.AcceptanceData to DbAcceptanceData
@@ -53,7 +54,15 @@ func DbAcceptanceDataToDomainAcceptanceData(dbAcceptanceData *DbAcceptanceData)
 				return nil, err
 			}
 
-			domainTransactionInputUTXOEntries := make([]externalapi.UTXOEntry, len(dbTransactionAcceptanceData.TransactionInputUtxoEntries))
+			// The UTXO entries are stored only for accepted transactions, in
+			// which case there's one for every input
+			utxoEntryCount := len(dbTransactionAcceptanceData.TransactionInputUtxoEntries)
+			if utxoEntryCount != 0 && utxoEntryCount != len(domainTransaction.Inputs) {
+				return nil, errors.Errorf("transaction acceptance data has %d UTXO entries for %d inputs",
+					utxoEntryCount, len(domainTransaction.Inputs))
+			}
+
+			domainTransactionInputUTXOEntries := make([]externalapi.UTXOEntry, utxoEntryCount)
 			for k, transactionInputUTXOEntry := range dbTransactionAcceptanceData.TransactionInputUtxoEntries {
 				domainTransactionInputUTXOEntry, err := DBUTXOEntryToUTXOEntry(transactionInputUTXOEntry)
 				if err != nil {
@@ -23,34 +23,34 @@ func DomainBlockHeaderToDbBlockHeader(domainBlockHeader externalapi.BlockHeader)
 
 // DbBlockHeaderToDomainBlockHeader converts DbBlockHeader to BlockHeader
 func DbBlockHeaderToDomainBlockHeader(dbBlockHeader *DbBlockHeader) (externalapi.BlockHeader, error) {
-	parentHashes, err := DbHashesToDomainHashes(dbBlockHeader.ParentHashes)
+	parentHashes, err := DbHashesToDomainHashes(dbBlockHeader.GetParentHashes())
 	if err != nil {
 		return nil, err
 	}
-	hashMerkleRoot, err := DbHashToDomainHash(dbBlockHeader.HashMerkleRoot)
+	hashMerkleRoot, err := DbHashToDomainHash(dbBlockHeader.GetHashMerkleRoot())
 	if err != nil {
 		return nil, err
 	}
-	acceptedIDMerkleRoot, err := DbHashToDomainHash(dbBlockHeader.AcceptedIDMerkleRoot)
+	acceptedIDMerkleRoot, err := DbHashToDomainHash(dbBlockHeader.GetAcceptedIDMerkleRoot())
 	if err != nil {
 		return nil, err
 	}
-	utxoCommitment, err := DbHashToDomainHash(dbBlockHeader.UtxoCommitment)
+	utxoCommitment, err := DbHashToDomainHash(dbBlockHeader.GetUtxoCommitment())
 	if err != nil {
 		return nil, err
 	}
-	if dbBlockHeader.Version > math.MaxUint16 {
+	if dbBlockHeader.GetVersion() > math.MaxUint16 {
 		return nil, errors.Errorf("Invalid header version - bigger then uint16")
 	}
 
 	return blockheader.NewImmutableBlockHeader(
-		uint16(dbBlockHeader.Version),
+		uint16(dbBlockHeader.GetVersion()),
 		parentHashes,
 		hashMerkleRoot,
 		acceptedIDMerkleRoot,
 		utxoCommitment,
-		dbBlockHeader.TimeInMilliseconds,
-		dbBlockHeader.Bits,
-		dbBlockHeader.Nonce,
+		dbBlockHeader.GetTimeInMilliseconds(),
+		dbBlockHeader.GetBits(),
+		dbBlockHeader.GetNonce(),
 	), nil
 }
@@ -0,0 +1,270 @@
+//go:build go1.18
+// +build go1.18
+
+package serialization
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/multiset"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"google.golang.org/protobuf/proto"
+)
+
+// dbObjectType describes how a database object is converted to its domain
+// representation and back
+type dbObjectType struct {
+	name        string
+	newDbObject func() proto.Message
+	toDomain    func(dbObject proto.Message) (interface{}, error)
+	fromDomain  func(domainObject interface{}) (proto.Message, error)
+
+	// sortRepeatedFields is set for objects that are backed by maps, and
+	// whose serialization therefore depends on the map's iteration order
+	sortRepeatedFields func(dbObject proto.Message)
+
+	seed interface{}
+}
+
+func dbObjectTypes() []*dbObjectType {
+	genesis := dagconfig.MainnetParams.GenesisBlock
+	genesisHash := consensushashing.BlockHash(genesis)
+	coinbase := genesis.Transactions[0]
+	outpoint := externalapi.DomainOutpoint{TransactionID: *consensushashing.TransactionID(coinbase), Index: 1}
+	utxoEntry := utxo.NewUTXOEntry(1000, &externalapi.ScriptPublicKey{Script: []byte{0x51}, Version: 0}, true, 1)
+	utxoDiff, err := utxo.NewUTXODiffFromCollections(
+		utxo.NewUTXOCollection(map[externalapi.DomainOutpoint]externalapi.UTXOEntry{outpoint: utxoEntry}),
+		utxo.NewUTXOCollection(map[externalapi.DomainOutpoint]externalapi.UTXOEntry{}))
+	if err != nil {
+		panic(err)
+	}
+
+	return []*dbObjectType{
+		{
+			name:        "DbBlock",
+			newDbObject: func() proto.Message { return &DbBlock{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DbBlockToDomainBlock(dbObject.(*DbBlock))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return DomainBlockToDbBlock(domainObject.(*externalapi.DomainBlock)), nil
+			},
+			seed: genesis,
+		},
+		{
+			name:        "DbBlockHeader",
+			newDbObject: func() proto.Message { return &DbBlockHeader{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DbBlockHeaderToDomainBlockHeader(dbObject.(*DbBlockHeader))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return DomainBlockHeaderToDbBlockHeader(domainObject.(externalapi.BlockHeader)), nil
+			},
+			seed: genesis.Header,
+		},
+		{
+			name:        "DbTransaction",
+			newDbObject: func() proto.Message { return &DbTransaction{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DbTransactionToDomainTransaction(dbObject.(*DbTransaction))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return DomainTransactionToDbTransaction(domainObject.(*externalapi.DomainTransaction)), nil
+			},
+			seed: coinbase,
+		},
+		{
+			name:        "DbAcceptanceData",
+			newDbObject: func() proto.Message { return &DbAcceptanceData{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DbAcceptanceDataToDomainAcceptanceData(dbObject.(*DbAcceptanceData))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return DomainAcceptanceDataToDbAcceptanceData(domainObject.(externalapi.AcceptanceData)), nil
+			},
+			seed: externalapi.AcceptanceData{{
+				BlockHash: genesisHash,
+				TransactionAcceptanceData: []*externalapi.TransactionAcceptanceData{{
+					Transaction: coinbase, Fee: 0, IsAccepted: true, TransactionInputUTXOEntries: []externalapi.UTXOEntry{},
+				}},
+			}},
+		},
+		{
+			name:        "DbBlockRelations",
+			newDbObject: func() proto.Message { return &DbBlockRelations{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DbBlockRelationsToDomainBlockRelations(dbObject.(*DbBlockRelations))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return DomainBlockRelationsToDbBlockRelations(domainObject.(*model.BlockRelations)), nil
+			},
+			seed: &model.BlockRelations{Parents: []*externalapi.DomainHash{genesisHash}, Children: []*externalapi.DomainHash{}},
+		},
+		{
+			name:        "DbBlockGhostdagData",
+			newDbObject: func() proto.Message { return &DbBlockGhostdagData{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DBBlockGHOSTDAGDataToBlockGHOSTDAGData(dbObject.(*DbBlockGhostdagData))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return BlockGHOSTDAGDataToDBBlockGHOSTDAGData(domainObject.(*model.BlockGHOSTDAGData)), nil
+			},
+			sortRepeatedFields: func(dbObject proto.Message) {
+				bluesAnticoneSizes := dbObject.(*DbBlockGhostdagData).BluesAnticoneSizes
+				sort.Slice(bluesAnticoneSizes, func(i, j int) bool {
+					return bytes.Compare(marshalForSort(bluesAnticoneSizes[i]), marshalForSort(bluesAnticoneSizes[j])) < 0
+				})
+			},
+			seed: model.NewBlockGHOSTDAGData(1, big.NewInt(2), genesisHash, []*externalapi.DomainHash{genesisHash},
+				[]*externalapi.DomainHash{}, map[externalapi.DomainHash]model.KType{*genesisHash: 0}),
+		},
+		{
+			name:        "DbReachabilityData",
+			newDbObject: func() proto.Message { return &DbReachabilityData{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DBReachablityDataToReachablityData(dbObject.(*DbReachabilityData))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return ReachablityDataToDBReachablityData(domainObject.(model.ReachabilityData)), nil
+			},
+		},
+		{
+			name:        "DbMultiset",
+			newDbObject: func() proto.Message { return &DbMultiset{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DBMultisetToMultiset(dbObject.(*DbMultiset))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return MultisetToDBMultiset(domainObject.(model.Multiset)), nil
+			},
+			seed: multiset.New(),
+		},
+		{
+			name:        "DbUtxoEntry",
+			newDbObject: func() proto.Message { return &DbUtxoEntry{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DBUTXOEntryToUTXOEntry(dbObject.(*DbUtxoEntry))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return UTXOEntryToDBUTXOEntry(domainObject.(externalapi.UTXOEntry)), nil
+			},
+			seed: utxoEntry,
+		},
+		{
+			name:        "DbUtxoDiff",
+			newDbObject: func() proto.Message { return &DbUtxoDiff{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DBUTXODiffToUTXODiff(dbObject.(*DbUtxoDiff))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return UTXODiffToDBUTXODiff(domainObject.(externalapi.UTXODiff))
+			},
+			sortRepeatedFields: func(dbObject proto.Message) {
+				dbUTXODiff := dbObject.(*DbUtxoDiff)
+				for _, items := range [][]*DbUtxoCollectionItem{dbUTXODiff.ToAdd, dbUTXODiff.ToRemove} {
+					sort.Slice(items, func(i, j int) bool {
+						return bytes.Compare(marshalForSort(items[i]), marshalForSort(items[j])) < 0
+					})
+				}
+			},
+			seed: utxoDiff,
+		},
+		{
+			name:        "DbTips",
+			newDbObject: func() proto.Message { return &DbTips{} },
+			toDomain: func(dbObject proto.Message) (interface{}, error) {
+				return DBTipsToTips(dbObject.(*DbTips))
+			},
+			fromDomain: func(domainObject interface{}) (proto.Message, error) {
+				return TipsToDBTips(domainObject.([]*externalapi.DomainHash)), nil
+			},
+			seed: []*externalapi.DomainHash{genesisHash},
+		},
+	}
+}
+
+// FuzzDbObjects makes sure that decoding arbitrary database objects never
+// panics, and that every object that decodes successfully survives the
+// round-trip back to its database representation. typeIndex selects the
+// type of the object out of dbObjectTypes.
+func FuzzDbObjects(f *testing.F) {
+	types := dbObjectTypes()
+	for i, objectType := range types {
+		f.Add(uint8(i), []byte{})
+		if objectType.seed == nil {
+			continue
+		}
+		dbObject, err := objectType.fromDomain(objectType.seed)
+		if err != nil {
+			f.Fatalf("%s: %+v", objectType.name, err)
+		}
+		seed, err := proto.Marshal(dbObject)
+		if err != nil {
+			f.Fatalf("%s: %+v", objectType.name, err)
+		}
+		f.Add(uint8(i), seed)
+	}
+
+	f.Fuzz(func(t *testing.T, typeIndex uint8, data []byte) {
+		objectType := types[int(typeIndex)%len(types)]
+		dbObject := objectType.newDbObject()
+		err := proto.Unmarshal(data, dbObject)
+		if err != nil {
+			return
+		}
+		domainObject, err := objectType.toDomain(dbObject)
+		if err != nil {
+			return
+		}
+
+		serialized := serializeDomainObject(t, objectType, domainObject)
+		roundTripDomainObject := deserializeDomainObject(t, objectType, serialized)
+		reserialized := serializeDomainObject(t, objectType, roundTripDomainObject)
+		if !bytes.Equal(serialized, reserialized) {
+			t.Fatalf("%s changed after a round-trip:\n%x\n%x", objectType.name, serialized, reserialized)
+		}
+	})
+}
+
+func serializeDomainObject(t *testing.T, objectType *dbObjectType, domainObject interface{}) []byte {
+	dbObject, err := objectType.fromDomain(domainObject)
+	if err != nil {
+		t.Fatalf("%s doesn't convert back to a database object: %+v", objectType.name, err)
+	}
+	if objectType.sortRepeatedFields != nil {
+		objectType.sortRepeatedFields(dbObject)
+	}
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(dbObject)
+	if err != nil {
+		t.Fatalf("Marshal: %+v", err)
+	}
+	return serialized
+}
+
+func deserializeDomainObject(t *testing.T, objectType *dbObjectType, serialized []byte) interface{} {
+	dbObject := objectType.newDbObject()
+	err := proto.Unmarshal(serialized, dbObject)
+	if err != nil {
+		t.Fatalf("Unmarshal: %+v", err)
+	}
+	domainObject, err := objectType.toDomain(dbObject)
+	if err != nil {
+		t.Fatalf("%s doesn't convert back to a domain object: %+v", objectType.name, err)
+	}
+	return domainObject
+}
+
+func marshalForSort(message proto.Message) []byte {
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		panic(err)
+	}
+	return serialized
+}
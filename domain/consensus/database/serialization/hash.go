@@ -6,7 +6,7 @@ import (
 
 // DbHashToDomainHash converts a DbHash to a DomainHash
 func DbHashToDomainHash(dbHash *DbHash) (*externalapi.DomainHash, error) {
-	return externalapi.NewDomainHashFromByteSlice(dbHash.Hash)
+	return externalapi.NewDomainHashFromByteSlice(dbHash.GetHash())
 }
 
 // DomainHashToDbHash converts a DomainHash to a DbHash
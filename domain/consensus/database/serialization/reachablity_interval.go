@@ -13,7 +13,7 @@ func reachablityIntervalToDBReachablityInterval(reachabilityInterval *model.Reac
 
 func dbReachablityIntervalToReachablityInterval(dbReachabilityInterval *DbReachabilityInterval) *model.ReachabilityInterval {
 	return &model.ReachabilityInterval{
-		Start: dbReachabilityInterval.Start,
-		End:   dbReachabilityInterval.End,
+		Start: dbReachabilityInterval.GetStart(),
+		End:   dbReachabilityInterval.GetEnd(),
 	}
 }
@@ -7,7 +7,7 @@ import (
 
 // DbSubnetworkIDToDomainSubnetworkID converts DbSubnetworkId to DomainSubnetworkID
 func DbSubnetworkIDToDomainSubnetworkID(dbSubnetworkID *DbSubnetworkId) (*externalapi.DomainSubnetworkID, error) {
-	return subnetworks.FromBytes(dbSubnetworkID.SubnetworkId)
+	return subnetworks.FromBytes(dbSubnetworkID.GetSubnetworkId())
 }
 
 // DomainSubnetworkIDToDbSubnetworkID converts DomainSubnetworkID to DbSubnetworkId
@@ -39,13 +39,13 @@ func DomainTransactionToDbTransaction(domainTransaction *externalapi.DomainTrans
 
 // DbTransactionToDomainTransaction converts DbTransaction to DomainTransaction
 func DbTransactionToDomainTransaction(dbTransaction *DbTransaction) (*externalapi.DomainTransaction, error) {
-	domainSubnetworkID, err := DbSubnetworkIDToDomainSubnetworkID(dbTransaction.SubnetworkID)
+	domainSubnetworkID, err := DbSubnetworkIDToDomainSubnetworkID(dbTransaction.GetSubnetworkID())
 	if err != nil {
 		return nil, err
 	}
 
-	domainInputs := make([]*externalapi.DomainTransactionInput, len(dbTransaction.Inputs))
-	for i, dbTransactionInput := range dbTransaction.Inputs {
+	domainInputs := make([]*externalapi.DomainTransactionInput, len(dbTransaction.GetInputs()))
+	for i, dbTransactionInput := range dbTransaction.GetInputs() {
 		domainPreviousOutpoint, err := DbOutpointToDomainOutpoint(dbTransactionInput.PreviousOutpoint)
 		if err != nil {
 			return nil, err
@@ -57,8 +57,8 @@ func DbTransactionToDomainTransaction(dbTransaction *DbTransaction) (*externalap
 		}
 	}
 
-	domainOutputs := make([]*externalapi.DomainTransactionOutput, len(dbTransaction.Outputs))
-	for i, dbTransactionOutput := range dbTransaction.Outputs {
+	domainOutputs := make([]*externalapi.DomainTransactionOutput, len(dbTransaction.GetOutputs()))
+	for i, dbTransactionOutput := range dbTransaction.GetOutputs() {
 		scriptPublicKey, err := DBScriptPublicKeyToScriptPublicKey(dbTransactionOutput.ScriptPublicKey)
 		if err != nil {
 			return nil, err
@@ -69,16 +69,16 @@ func DbTransactionToDomainTransaction(dbTransaction *DbTransaction) (*externalap
 		}
 	}
 
-	if dbTransaction.Version > math.MaxUint16 {
+	if dbTransaction.GetVersion() > math.MaxUint16 {
 		return nil, errors.Errorf("The transaction version is bigger then uint16.")
 	}
 	return &externalapi.DomainTransaction{
-		Version:      uint16(dbTransaction.Version),
+		Version:      uint16(dbTransaction.GetVersion()),
 		Inputs:       domainInputs,
 		Outputs:      domainOutputs,
-		LockTime:     dbTransaction.LockTime,
+		LockTime:     dbTransaction.GetLockTime(),
 		SubnetworkID: *domainSubnetworkID,
-		Gas:          dbTransaction.Gas,
-		Payload:      dbTransaction.Payload,
+		Gas:          dbTransaction.GetGas(),
+		Payload:      dbTransaction.GetPayload(),
 	}, nil
 }
@@ -14,10 +14,10 @@ func ScriptPublicKeyToDBScriptPublicKey(scriptPublicKey *externalapi.ScriptPubli
 
 // DBScriptPublicKeyToScriptPublicKey convert DbScriptPublicKey ro ScriptPublicKey
 func DBScriptPublicKeyToScriptPublicKey(dbScriptPublicKey *DbScriptPublicKey) (*externalapi.ScriptPublicKey, error) {
-	if dbScriptPublicKey.Version > math.MaxUint16 {
+	if dbScriptPublicKey.GetVersion() > math.MaxUint16 {
 		return nil, errors.Errorf("The version on ScriptPublicKey is bigger then uint16.")
 	}
-	return &externalapi.ScriptPublicKey{Script: dbScriptPublicKey.Script, Version: uint16(dbScriptPublicKey.Version)}, nil
+	return &externalapi.ScriptPublicKey{Script: dbScriptPublicKey.GetScript(), Version: uint16(dbScriptPublicKey.GetVersion())}, nil
 }
 
 // UTXOEntryToDBUTXOEntry converts UTXOEntry to DbUtxoEntry
@@ -33,9 +33,9 @@ func UTXOEntryToDBUTXOEntry(utxoEntry externalapi.UTXOEntry) *DbUtxoEntry {
 
 // DBUTXOEntryToUTXOEntry convert DbUtxoEntry ro UTXOEntry
 func DBUTXOEntryToUTXOEntry(dbUtxoEntry *DbUtxoEntry) (externalapi.UTXOEntry, error) {
-	scriptPublicKey, err := DBScriptPublicKeyToScriptPublicKey(dbUtxoEntry.ScriptPublicKey)
+	scriptPublicKey, err := DBScriptPublicKeyToScriptPublicKey(dbUtxoEntry.GetScriptPublicKey())
 	if err != nil {
 		return nil, err
 	}
-	return utxo.NewUTXOEntry(dbUtxoEntry.Amount, scriptPublicKey, dbUtxoEntry.IsCoinbase, dbUtxoEntry.BlockDaaScore), nil
+	return utxo.NewUTXOEntry(dbUtxoEntry.GetAmount(), scriptPublicKey, dbUtxoEntry.GetIsCoinbase(), dbUtxoEntry.GetBlockDaaScore()), nil
 }
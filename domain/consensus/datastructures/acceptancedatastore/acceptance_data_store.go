@@ -23,6 +23,13 @@ func New(cacheSize int, preallocate bool) model.AcceptanceDataStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (ads *acceptanceDataStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		ads.cache.Stats("acceptance-data"),
+	}
+}
+
 // Stage stages the given acceptanceData for the given blockHash
 func (ads *acceptanceDataStore) Stage(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, acceptanceData externalapi.AcceptanceData) {
 	stagingShard := ads.stagingShard(stagingArea)
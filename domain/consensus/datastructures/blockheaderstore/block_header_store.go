@@ -32,6 +32,13 @@ func New(dbContext model.DBReader, cacheSize int, preallocate bool) (model.Block
 	return blockHeaderStore, nil
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (bhs *blockHeaderStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		bhs.cache.Stats("block-headers"),
+	}
+}
+
 func (bhs *blockHeaderStore) initializeCount(dbContext model.DBReader) error {
 	count := uint64(0)
 	hasCountBytes, err := dbContext.Has(countKey)
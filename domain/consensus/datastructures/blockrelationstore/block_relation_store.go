@@ -23,6 +23,13 @@ func New(cacheSize int, preallocate bool) model.BlockRelationStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (brs *blockRelationStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		brs.cache.Stats("block-relations"),
+	}
+}
+
 func (brs *blockRelationStore) StageBlockRelation(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, blockRelations *model.BlockRelations) {
 	stagingShard := brs.stagingShard(stagingArea)
 
@@ -23,6 +23,13 @@ func New(cacheSize int, preallocate bool) model.BlockStatusStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (bss *blockStatusStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		bss.cache.Stats("block-statuses"),
+	}
+}
+
 // Stage stages the given blockStatus for the given blockHash
 func (bss *blockStatusStore) Stage(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, blockStatus externalapi.BlockStatus) {
 	stagingShard := bss.stagingShard(stagingArea)
@@ -33,6 +33,13 @@ func New(dbContext model.DBReader, cacheSize int, preallocate bool) (model.Block
 	return blockStore, nil
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (bs *blockStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		bs.cache.Stats("blocks"),
+	}
+}
+
 func (bs *blockStore) initializeCount(dbContext model.DBReader) error {
 	count := uint64(0)
 	hasCountBytes, err := dbContext.Has(countKey)
@@ -13,10 +13,18 @@ type consensusStateStore struct {
 	tipsCache []*externalapi.DomainHash
 }
 
-// New instantiates a new ConsensusStateStore
-func New(utxoSetCacheSize int, preallocate bool) model.ConsensusStateStore {
+// New instantiates a new ConsensusStateStore whose virtual UTXO set
+// cache holds up to utxoSetCacheMaxSize bytes of UTXO entries
+func New(utxoSetCacheMaxSize uint64) model.ConsensusStateStore {
 	return &consensusStateStore{
-		virtualUTXOSetCache: utxolrucache.New(utxoSetCacheSize, preallocate),
+		virtualUTXOSetCache: utxolrucache.New(utxoSetCacheMaxSize),
+	}
+}
+
+// CacheStats returns the usage statistics of the store's caches
+func (css *consensusStateStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		css.virtualUTXOSetCache.Stats("virtual-utxo-set"),
 	}
 }
 
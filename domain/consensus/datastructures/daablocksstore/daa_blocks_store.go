@@ -25,6 +25,14 @@ func New(daaScoreCacheSize int, daaAddedBlocksCacheSize int, preallocate bool) m
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (daas *daaBlocksStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		daas.daaScoreLRUCache.Stats("daa-score"),
+		daas.daaAddedBlocksLRUCache.Stats("daa-added-blocks"),
+	}
+}
+
 func (daas *daaBlocksStore) StageDAAScore(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, daaScore uint64) {
 	stagingShard := daas.stagingShard(stagingArea)
 
@@ -20,6 +20,13 @@ func New(cacheSize int, preallocate bool) model.FinalityStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (fs *finalityStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		fs.cache.Stats("finality-points"),
+	}
+}
+
 func (fs *finalityStore) StageFinalityPoint(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, finalityPointHash *externalapi.DomainHash) {
 	stagingShard := fs.stagingShard(stagingArea)
 
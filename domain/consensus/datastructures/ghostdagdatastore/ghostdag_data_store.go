@@ -23,6 +23,13 @@ func New(cacheSize int, preallocate bool) model.GHOSTDAGDataStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (gds *ghostdagDataStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		gds.cache.Stats("block-ghostdag-data"),
+	}
+}
+
 // Stage stages the given blockGHOSTDAGData for the given blockHash
 func (gds *ghostdagDataStore) Stage(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, blockGHOSTDAGData *model.BlockGHOSTDAGData) {
 	stagingShard := gds.stagingShard(stagingArea)
@@ -30,6 +30,14 @@ func New(cacheSize int, preallocate bool) model.HeadersSelectedChainStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (hscs *headersSelectedChainStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		hscs.cacheByIndex.Stats("chain-block-hash-by-index"),
+		hscs.cacheByHash.Stats("chain-block-index-by-hash"),
+	}
+}
+
 // Stage stages the given chain changes
 func (hscs *headersSelectedChainStore) Stage(dbContext model.DBReader, stagingArea *model.StagingArea, chainChanges *externalapi.SelectedChainPath) error {
 	stagingShard := hscs.stagingShard(stagingArea)
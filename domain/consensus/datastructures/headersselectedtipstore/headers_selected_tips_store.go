@@ -19,6 +19,11 @@ func New() model.HeaderSelectedTipStore {
 	return &headerSelectedTipStore{}
 }
 
+// CacheStats returns nil, since this store doesn't have any bounded caches
+func (hsts *headerSelectedTipStore) CacheStats() []*externalapi.CacheStats {
+	return nil
+}
+
 func (hsts *headerSelectedTipStore) Has(dbContext model.DBReader, stagingArea *model.StagingArea) (bool, error) {
 	stagingShard := hsts.stagingShard(stagingArea)
 
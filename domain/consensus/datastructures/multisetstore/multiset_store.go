@@ -23,6 +23,13 @@ func New(cacheSize int, preallocate bool) model.MultisetStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (ms *multisetStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		ms.cache.Stats("multisets"),
+	}
+}
+
 // Stage stages the given multiset for the given blockHash
 func (ms *multisetStore) Stage(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, multiset model.Multiset) {
 	stagingShard := ms.stagingShard(stagingArea)
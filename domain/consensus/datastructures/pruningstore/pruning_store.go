@@ -26,6 +26,11 @@ func New() model.PruningStore {
 	return &pruningStore{}
 }
 
+// CacheStats returns nil, since this store doesn't have any bounded caches
+func (ps *pruningStore) CacheStats() []*externalapi.CacheStats {
+	return nil
+}
+
 func (ps *pruningStore) StagePruningPointCandidate(stagingArea *model.StagingArea, candidate *externalapi.DomainHash) {
 	stagingShard := ps.stagingShard(stagingArea)
 
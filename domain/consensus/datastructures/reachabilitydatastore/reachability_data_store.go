@@ -25,6 +25,13 @@ func New(cacheSize int, preallocate bool) model.ReachabilityDataStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (rds *reachabilityDataStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		rds.reachabilityDataCache.Stats("reachability-data"),
+	}
+}
+
 // StageReachabilityData stages the given reachabilityData for the given blockHash
 func (rds *reachabilityDataStore) StageReachabilityData(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, reachabilityData model.ReachabilityData) {
 	stagingShard := rds.stagingShard(stagingArea)
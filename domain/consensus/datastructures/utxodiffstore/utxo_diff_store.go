@@ -27,6 +27,14 @@ func New(cacheSize int, preallocate bool) model.UTXODiffStore {
 	}
 }
 
+// CacheStats returns the usage statistics of the store's caches
+func (uds *utxoDiffStore) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		uds.utxoDiffCache.Stats("utxo-diffs"),
+		uds.utxoDiffChildCache.Stats("utxo-diff-children"),
+	}
+}
+
 // Stage stages the given utxoDiff for the given blockHash
 func (uds *utxoDiffStore) Stage(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
 	utxoDiff externalapi.UTXODiff, utxoDiffChild *externalapi.DomainHash) {
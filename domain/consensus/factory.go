@@ -43,6 +43,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 	infrastructuredatabase "github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/util/mstime"
 )
 
 const (
@@ -58,6 +59,9 @@ type Config struct {
 	IsArchival bool
 	// EnableSanityCheckPruningUTXOSet checks the full pruning point utxo set against the commitment at every pruning movement
 	EnableSanityCheckPruningUTXOSet bool
+	// MaxCacheSize is the estimated memory, in bytes, that the consensus caches may use, including
+	// the virtual UTXO set cache. If it's 0, the default cache sizes are used
+	MaxCacheSize uint64
 }
 
 // Factory instantiates new Consensuses
@@ -67,6 +71,8 @@ type Factory interface {
 	NewTestConsensus(config *Config, testName string) (
 		tc testapi.TestConsensus, teardown func(keepDataDir bool), err error)
 
+	SetClock(clock mstime.Clock)
+
 	SetTestDataDir(dataDir string)
 	SetTestGHOSTDAGManager(ghostdagConstructor GHOSTDAGManagerConstructor)
 	SetTestLevelDBCacheSize(cacheSizeMiB int)
@@ -76,6 +82,7 @@ type Factory interface {
 }
 
 type factory struct {
+	clock                    mstime.Clock
 	dataDir                  string
 	ghostdagConstructor      GHOSTDAGManagerConstructor
 	pastMedianTimeConsructor PastMedianTimeManagerConstructor
@@ -87,6 +94,7 @@ type factory struct {
 // NewFactory creates a new Consensus factory
 func NewFactory() Factory {
 	return &factory{
+		clock:                    mstime.SystemClock,
 		ghostdagConstructor:      ghostdagmanager.New,
 		pastMedianTimeConsructor: pastmediantimemanager.New,
 		difficultyConstructor:    difficultymanager.New,
@@ -99,8 +107,6 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 
 	dbManager := consensusdatabase.New(db)
 
-	pruningWindowSizeForCaches := int(config.PruningDepth())
-
 	var preallocateCaches bool
 	if f.preallocateCaches != nil {
 		preallocateCaches = *f.preallocateCaches
@@ -108,42 +114,32 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		preallocateCaches = defaultPreallocateCaches
 	}
 
-	// This is used for caches that are used as part of deletePastBlocks that need to traverse until
-	// the previous pruning point.
-	pruningWindowSizePlusFinalityDepthForCache := int(config.PruningDepth() + config.FinalityDepth())
+	cacheSizes := newCacheSizes(config)
+	log.Debugf("Consensus cache sizes: %+v", *cacheSizes)
 
 	// Data Structures
-	acceptanceDataStore := acceptancedatastore.New(200, preallocateCaches)
-	blockStore, err := blockstore.New(dbManager, 200, preallocateCaches)
+	acceptanceDataStore := acceptancedatastore.New(cacheSizes.acceptanceData, preallocateCaches)
+	blockStore, err := blockstore.New(dbManager, cacheSizes.block, preallocateCaches)
 	if err != nil {
 		return nil, err
 	}
-	blockHeaderStore, err := blockheaderstore.New(dbManager, 10_000, preallocateCaches)
+	blockHeaderStore, err := blockheaderstore.New(dbManager, cacheSizes.blockHeader, preallocateCaches)
 	if err != nil {
 		return nil, err
 	}
-	blockRelationStore := blockrelationstore.New(pruningWindowSizePlusFinalityDepthForCache, preallocateCaches)
+	blockRelationStore := blockrelationstore.New(cacheSizes.blockRelation, preallocateCaches)
 
-	blockStatusStore := blockstatusstore.New(pruningWindowSizePlusFinalityDepthForCache, preallocateCaches)
-	multisetStore := multisetstore.New(200, preallocateCaches)
+	blockStatusStore := blockstatusstore.New(cacheSizes.blockStatus, preallocateCaches)
+	multisetStore := multisetstore.New(cacheSizes.multiset, preallocateCaches)
 	pruningStore := pruningstore.New()
-	reachabilityDataStore := reachabilitydatastore.New(pruningWindowSizePlusFinalityDepthForCache, preallocateCaches)
-	utxoDiffStore := utxodiffstore.New(200, preallocateCaches)
-	consensusStateStore := consensusstatestore.New(10_000, preallocateCaches)
-
-	// Some tests artificially decrease the pruningWindowSize, thus making the GhostDagStore cache too small for a
-	// a single DifficultyAdjustmentWindow. To alleviate this problem we make sure that the cache size is at least
-	// dagParams.DifficultyAdjustmentWindowSize
-	ghostdagDataCacheSize := pruningWindowSizeForCaches
-	if ghostdagDataCacheSize < config.DifficultyAdjustmentWindowSize {
-		ghostdagDataCacheSize = config.DifficultyAdjustmentWindowSize
-	}
-	ghostdagDataStore := ghostdagdatastore.New(ghostdagDataCacheSize, preallocateCaches)
-
+	reachabilityDataStore := reachabilitydatastore.New(cacheSizes.reachabilityData, preallocateCaches)
+	utxoDiffStore := utxodiffstore.New(cacheSizes.utxoDiff, preallocateCaches)
+	consensusStateStore := consensusstatestore.New(cacheSizes.utxoSet)
+	ghostdagDataStore := ghostdagdatastore.New(cacheSizes.ghostdagData, preallocateCaches)
 	headersSelectedTipStore := headersselectedtipstore.New()
-	finalityStore := finalitystore.New(200, preallocateCaches)
-	headersSelectedChainStore := headersselectedchainstore.New(pruningWindowSizeForCaches, preallocateCaches)
-	daaBlocksStore := daablocksstore.New(pruningWindowSizeForCaches, int(config.FinalityDepth()), preallocateCaches)
+	finalityStore := finalitystore.New(cacheSizes.finality, preallocateCaches)
+	headersSelectedChainStore := headersselectedchainstore.New(cacheSizes.headersSelectedChain, preallocateCaches)
+	daaBlocksStore := daablocksstore.New(cacheSizes.daaScore, cacheSizes.daaAddedBlocks, preallocateCaches)
 
 	// Processes
 	reachabilityManager := reachabilitymanager.New(
@@ -235,6 +231,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		config.TargetTimePerBlock,
 
 		dbManager,
+		f.clock,
+
 		difficultyManager,
 		pastMedianTimeManager,
 		transactionValidator,
@@ -329,6 +327,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 
 	blockBuilder := blockbuilder.New(
 		dbManager,
+		f.clock,
+
 		difficultyManager,
 		pastMedianTimeManager,
 		coinbaseManager,
@@ -494,6 +494,12 @@ func (f *factory) NewTestConsensus(config *Config, testName string) (
 	return tstConsensus, teardown, nil
 }
 
+// SetClock sets the clock the consensuses created by the factory read the current
+// time from. It defaults to the system clock.
+func (f *factory) SetClock(clock mstime.Clock) {
+	f.clock = clock
+}
+
 func (f *factory) SetTestDataDir(dataDir string) {
 	f.dataDir = dataDir
 }
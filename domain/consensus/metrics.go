@@ -0,0 +1,17 @@
+package consensus
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var (
+	validateAndInsertBlockDuration = metrics.NewHistogram("kaspad_consensus_validate_and_insert_block_duration_seconds",
+		"The time it takes ValidateAndInsertBlock to validate and insert a block or a block header",
+		metrics.DurationBuckets)
+
+	insertedBlocks = metrics.NewCounter("kaspad_dag_inserted_blocks_total",
+		"The number of blocks with bodies that were inserted into the DAG")
+
+	insertedBlocksRate = metrics.NewMeter("kaspad_dag_blocks_per_second",
+		"The average number of blocks with bodies inserted into the DAG per second over the last minute")
+)
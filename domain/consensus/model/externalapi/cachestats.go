@@ -0,0 +1,14 @@
+package externalapi
+
+// CacheStats holds the usage statistics of a single consensus cache.
+// Size and Capacity are measured in entries, except for caches that
+// are bounded by memory, where they are measured in estimated bytes
+type CacheStats struct {
+	Name      string
+	Entries   uint64
+	Size      uint64
+	Capacity  uint64
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
@@ -3,6 +3,8 @@ package externalapi
 // Consensus maintains the current core state of the node
 type Consensus interface {
 	BuildBlock(coinbaseData *DomainCoinbaseData, transactions []*DomainTransaction) (*DomainBlock, error)
+	BuildBlockWithParents(parentHashes []*DomainHash, coinbaseData *DomainCoinbaseData,
+		transactions []*DomainTransaction) (*DomainBlock, UTXODiff, error)
 	ValidateAndInsertBlock(block *DomainBlock) (*BlockInsertionResult, error)
 	ValidateTransactionAndPopulateWithConsensusData(transaction *DomainTransaction) error
 
@@ -33,4 +35,6 @@ type Consensus interface {
 	IsInSelectedParentChainOf(blockHashA *DomainHash, blockHashB *DomainHash) (bool, error)
 	GetHeadersSelectedTip() (*DomainHash, error)
 	Anticone(blockHash *DomainHash) ([]*DomainHash, error)
+	CacheStats() []*CacheStats
+	ExportDAG(depth uint64) (*DAGExport, error)
 }
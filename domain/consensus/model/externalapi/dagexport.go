@@ -0,0 +1,58 @@
+package externalapi
+
+import "math/big"
+
+// DAGExport is a snapshot of a window of the DAG, together with the
+// GHOSTDAG data that's needed to visualize it
+type DAGExport struct {
+	VirtualParents   []*DomainHash
+	VirtualBlueScore uint64
+	VirtualDAAScore  uint64
+	PruningPoint     *DomainHash
+	FinalityPoint    *DomainHash
+	Blocks           []*DAGExportBlock
+}
+
+// DAGExportBlock is a single block of a DAGExport
+type DAGExportBlock struct {
+	Hash           *DomainHash
+	Parents        []*DomainHash
+	SelectedParent *DomainHash
+	MergeSetBlues  []*DomainHash
+	MergeSetReds   []*DomainHash
+	Status         BlockStatus
+	BlueScore      uint64
+	BlueWork       *big.Int
+	DAAScore       uint64
+	Timestamp      int64
+	Color          DAGExportBlockColor
+
+	IsInVirtualSelectedParentChain bool
+	IsInVirtualDAAWindow           bool
+}
+
+// DAGExportBlockColor is the color GHOSTDAG gave a block in the merge set
+// of the virtual selected parent chain block that merged it
+type DAGExportBlockColor uint8
+
+const (
+	// DAGExportBlockColorNone means the block wasn't merged by any block in the
+	// window of the virtual selected parent chain
+	DAGExportBlockColorNone DAGExportBlockColor = iota
+
+	// DAGExportBlockColorBlue means the block was merged as a blue block
+	DAGExportBlockColorBlue
+
+	// DAGExportBlockColorRed means the block was merged as a red block
+	DAGExportBlockColorRed
+)
+
+var dagExportBlockColorStrings = map[DAGExportBlockColor]string{
+	DAGExportBlockColorNone: "none",
+	DAGExportBlockColorBlue: "blue",
+	DAGExportBlockColorRed:  "red",
+}
+
+func (c DAGExportBlockColor) String() string {
+	return dagExportBlockColorStrings[c]
+}
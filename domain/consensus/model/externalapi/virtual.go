@@ -7,4 +7,5 @@ type VirtualInfo struct {
 	PastMedianTime int64
 	BlueScore      uint64
 	DAAScore       uint64
+	UTXOCommitment *DomainHash
 }
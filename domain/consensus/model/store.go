@@ -1,5 +1,8 @@
 package model
 
+import "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+
 // Store is a common interface for data stores
 type Store interface {
+	CacheStats() []*externalapi.CacheStats
 }
@@ -10,6 +10,4 @@ type TestConsensusStateManager interface {
 	model.ConsensusStateManager
 	AddUTXOToMultiset(multiset model.Multiset, entry externalapi.UTXOEntry,
 		outpoint *externalapi.DomainOutpoint) error
-	ResolveBlockStatus(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
-		useSeparateStagingAreaPerBlock bool) (externalapi.BlockStatus, error)
 }
@@ -18,6 +18,7 @@ import (
 
 type blockBuilder struct {
 	databaseContext model.DBManager
+	clock           mstime.Clock
 
 	difficultyManager     model.DifficultyManager
 	pastMedianTimeManager model.PastMedianTimeManager
@@ -35,6 +36,7 @@ type blockBuilder struct {
 // New creates a new instance of a BlockBuilder
 func New(
 	databaseContext model.DBManager,
+	clock mstime.Clock,
 
 	difficultyManager model.DifficultyManager,
 	pastMedianTimeManager model.PastMedianTimeManager,
@@ -51,6 +53,7 @@ func New(
 
 	return &blockBuilder{
 		databaseContext:       databaseContext,
+		clock:                 clock,
 		difficultyManager:     difficultyManager,
 		pastMedianTimeManager: pastMedianTimeManager,
 		coinbaseManager:       coinbaseManager,
@@ -167,7 +170,7 @@ func (bb *blockBuilder) buildHeader(stagingArea *model.StagingArea, transactions
 	if err != nil {
 		return nil, err
 	}
-	timeInMilliseconds, err := bb.newBlockTime(stagingArea)
+	timeInMilliseconds, err := bb.newBlockTime(stagingArea, model.VirtualBlockHash)
 	if err != nil {
 		return nil, err
 	}
@@ -206,15 +209,15 @@ func (bb *blockBuilder) newBlockParentHashes(stagingArea *model.StagingArea) ([]
 	return virtualBlockRelations.Parents, nil
 }
 
-func (bb *blockBuilder) newBlockTime(stagingArea *model.StagingArea) (int64, error) {
+func (bb *blockBuilder) newBlockTime(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (int64, error) {
 	// The timestamp for the block must not be before the median timestamp
 	// of the last several blocks. Thus, choose the maximum between the
 	// current time and one second after the past median time. The current
 	// timestamp is truncated to a millisecond boundary before comparison since a
 	// block timestamp does not supported a precision greater than one
 	// millisecond.
-	newTimestamp := mstime.Now().UnixMilliseconds()
-	minTimestamp, err := bb.minBlockTime(stagingArea, model.VirtualBlockHash)
+	newTimestamp := bb.clock.Now().UnixMilliseconds()
+	minTimestamp, err := bb.minBlockTime(stagingArea, blockHash)
 	if err != nil {
 		return 0, err
 	}
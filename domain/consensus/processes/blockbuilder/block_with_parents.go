@@ -0,0 +1,103 @@
+package blockbuilder
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+// tempBlockHash is the hash under which the data of a block that's built
+// over given parents is staged while the block is being built
+var tempBlockHash = externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+
+// BuildBlockWithParents builds a block with provided parents, coinbaseData and transactions,
+// and returns the block together with its past UTXO-diff from the virtual.
+// Unlike BuildBlock, the transactions are not validated
+func (bb *blockBuilder) BuildBlockWithParents(parentHashes []*externalapi.DomainHash,
+	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
+	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "BuildBlockWithParents")
+	defer onEnd()
+
+	stagingArea := model.NewStagingArea()
+
+	return bb.buildBlockWithParents(stagingArea, parentHashes, coinbaseData, transactions)
+}
+
+func (bb *blockBuilder) buildBlockWithParents(stagingArea *model.StagingArea, parentHashes []*externalapi.DomainHash,
+	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
+	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
+
+	bb.blockRelationStore.StageBlockRelation(stagingArea, tempBlockHash, &model.BlockRelations{Parents: parentHashes})
+
+	err := bb.ghostdagManager.GHOSTDAG(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bits, err := bb.difficultyManager.StageDAADataAndReturnRequiredDifficulty(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ghostdagData, err := bb.ghostdagDataStore.Get(bb.databaseContext, stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selectedParentStatus, err := bb.consensusStateManager.ResolveBlockStatus(
+		stagingArea, ghostdagData.SelectedParent(), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if selectedParentStatus == externalapi.StatusDisqualifiedFromChain {
+		return nil, nil, errors.Errorf("Error building block with selectedParent %s with status DisqualifiedFromChain",
+			ghostdagData.SelectedParent())
+	}
+
+	pastUTXO, acceptanceData, multiset, err :=
+		bb.consensusStateManager.CalculatePastUTXOAndAcceptanceData(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bb.acceptanceDataStore.Stage(stagingArea, tempBlockHash, acceptanceData)
+
+	coinbase, err := bb.coinbaseManager.ExpectedCoinbaseTransaction(stagingArea, tempBlockHash, coinbaseData)
+	if err != nil {
+		return nil, nil, err
+	}
+	transactionsWithCoinbase := append([]*externalapi.DomainTransaction{coinbase}, transactions...)
+
+	timeInMilliseconds, err := bb.newBlockTime(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashMerkleRoot := bb.newBlockHashMerkleRoot(transactionsWithCoinbase)
+	acceptedIDMerkleRoot, err := bb.calculateAcceptedIDMerkleRoot(acceptanceData)
+	if err != nil {
+		return nil, nil, err
+	}
+	utxoCommitment := multiset.Hash()
+
+	header := blockheader.NewImmutableBlockHeader(
+		constants.MaxBlockVersion,
+		parentHashes,
+		hashMerkleRoot,
+		acceptedIDMerkleRoot,
+		utxoCommitment,
+		timeInMilliseconds,
+		bits,
+		0,
+	)
+
+	return &externalapi.DomainBlock{
+		Header:       header,
+		Transactions: transactionsWithCoinbase,
+	}, pastUTXO, nil
+}
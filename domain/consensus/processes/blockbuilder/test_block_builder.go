@@ -17,9 +17,6 @@ type testBlockBuilder struct {
 	nonceCounter  uint64
 }
 
-var tempBlockHash = externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{
-	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
-
 // NewTestBlockBuilder creates an instance of a TestBlockBuilder
 func NewTestBlockBuilder(baseBlockBuilder model.BlockBuilder, testConsensus testapi.TestConsensus) testapi.TestBlockBuilder {
 	return &testBlockBuilder{
@@ -86,34 +83,6 @@ func (bb *testBlockBuilder) buildUTXOInvalidHeader(stagingArea *model.StagingAre
 	), nil
 }
 
-func (bb *testBlockBuilder) buildHeaderWithParents(stagingArea *model.StagingArea, parentHashes []*externalapi.DomainHash,
-	bits uint32, transactions []*externalapi.DomainTransaction, acceptanceData externalapi.AcceptanceData, multiset model.Multiset) (
-	externalapi.BlockHeader, error) {
-
-	header, err := bb.buildUTXOInvalidHeader(stagingArea, parentHashes, bits, transactions)
-	if err != nil {
-		return nil, err
-	}
-
-	hashMerkleRoot := bb.newBlockHashMerkleRoot(transactions)
-	acceptedIDMerkleRoot, err := bb.calculateAcceptedIDMerkleRoot(acceptanceData)
-	if err != nil {
-		return nil, err
-	}
-	utxoCommitment := multiset.Hash()
-
-	return blockheader.NewImmutableBlockHeader(
-		header.Version(),
-		header.ParentHashes(),
-		hashMerkleRoot,
-		acceptedIDMerkleRoot,
-		utxoCommitment,
-		header.TimeInMilliseconds(),
-		header.Bits(),
-		header.Nonce(),
-	), nil
-}
-
 func (bb *testBlockBuilder) buildBlockWithParents(stagingArea *model.StagingArea, parentHashes []*externalapi.DomainHash,
 	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
 	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
@@ -130,57 +99,31 @@ func (bb *testBlockBuilder) buildBlockWithParents(stagingArea *model.StagingArea
 		}
 	}
 
-	bb.blockRelationStore.StageBlockRelation(stagingArea, tempBlockHash, &model.BlockRelations{Parents: parentHashes})
-
-	err := bb.ghostdagManager.GHOSTDAG(stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	bits, err := bb.difficultyManager.StageDAADataAndReturnRequiredDifficulty(stagingArea, tempBlockHash)
+	block, pastUTXO, err := bb.blockBuilder.buildBlockWithParents(stagingArea, parentHashes, coinbaseData, transactions)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	ghostdagData, err := bb.ghostdagDataStore.Get(bb.databaseContext, stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	selectedParentStatus, err := bb.testConsensus.ConsensusStateManager().ResolveBlockStatus(
-		stagingArea, ghostdagData.SelectedParent(), false)
-	if err != nil {
-		return nil, nil, err
-	}
-	if selectedParentStatus == externalapi.StatusDisqualifiedFromChain {
-		return nil, nil, errors.Errorf("Error building block with selectedParent %s with status DisqualifiedFromChain",
-			ghostdagData.SelectedParent())
-	}
-
-	pastUTXO, acceptanceData, multiset, err :=
-		bb.consensusStateManager.CalculatePastUTXOAndAcceptanceData(stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	bb.acceptanceDataStore.Stage(stagingArea, tempBlockHash, acceptanceData)
-
-	coinbase, err := bb.coinbaseManager.ExpectedCoinbaseTransaction(stagingArea, tempBlockHash, coinbaseData)
-	if err != nil {
-		return nil, nil, err
-	}
-	transactionsWithCoinbase := append([]*externalapi.DomainTransaction{coinbase}, transactions...)
-
-	header, err := bb.buildHeaderWithParents(
-		stagingArea, parentHashes, bits, transactionsWithCoinbase, acceptanceData, multiset)
+	// Test blocks get the earliest valid timestamp, so that they're deterministic,
+	// and a unique nonce, so that blocks with the same parents get different hashes
+	timeInMilliseconds, err := bb.minBlockTime(stagingArea, tempBlockHash)
 	if err != nil {
 		return nil, nil, err
 	}
+	bb.nonceCounter++
+	header := block.Header
+	block.Header = blockheader.NewImmutableBlockHeader(
+		header.Version(),
+		header.ParentHashes(),
+		header.HashMerkleRoot(),
+		header.AcceptedIDMerkleRoot(),
+		header.UTXOCommitment(),
+		timeInMilliseconds,
+		header.Bits(),
+		bb.nonceCounter,
+	)
 
-	return &externalapi.DomainBlock{
-		Header:       header,
-		Transactions: transactionsWithCoinbase,
-	}, pastUTXO, nil
+	return block, pastUTXO, nil
 }
 
 func (bb *testBlockBuilder) BuildUTXOInvalidHeader(parentHashes []*externalapi.DomainHash) (externalapi.BlockHeader,
@@ -7,7 +7,6 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
-	"github.com/kaspanet/kaspad/util/mstime"
 	"github.com/pkg/errors"
 )
 
@@ -63,7 +62,7 @@ func (v *blockValidator) checkBlockVersion(header externalapi.BlockHeader) error
 
 func (v *blockValidator) checkBlockTimestampInIsolation(header externalapi.BlockHeader) error {
 	blockTimestamp := header.TimeInMilliseconds()
-	now := mstime.Now().UnixMilliseconds()
+	now := v.clock.Now().UnixMilliseconds()
 	maxCurrentTime := now + int64(v.timestampDeviationTolerance)*v.targetTimePerBlock.Milliseconds()
 	if blockTimestamp > maxCurrentTime {
 		return errors.Wrapf(
@@ -2,6 +2,7 @@ package blockvalidator_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus"
 	"github.com/kaspanet/kaspad/domain/consensus/model"
@@ -112,3 +113,45 @@ func TestCheckBlockTimestampInIsolation(t *testing.T) {
 		}
 	})
 }
+
+func TestCheckBlockTimestampInIsolationWithFakeClock(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		clock := mstime.NewFakeClock(mstime.Now())
+		factory := consensus.NewFactory()
+		factory.SetClock(clock)
+
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestCheckBlockTimestampInIsolationWithFakeClock")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		maxDeviation := time.Duration(consensusConfig.TimestampDeviationTolerance) * consensusConfig.TargetTimePerBlock
+		buildBlockAt := func(timestamp mstime.Time, extraData byte) *externalapi.DomainBlock {
+			coinbaseData := &externalapi.DomainCoinbaseData{ScriptPublicKey: &externalapi.ScriptPublicKey{}, ExtraData: []byte{extraData}}
+			block, _, err := tc.BuildBlockWithParents([]*externalapi.DomainHash{consensusConfig.GenesisHash}, coinbaseData, nil)
+			if err != nil {
+				t.Fatalf("BuildBlockWithParents: %+v", err)
+			}
+			header := block.Header.ToMutable()
+			header.SetTimeInMilliseconds(timestamp.UnixMilliseconds())
+			block.Header = header.ToImmutable()
+			return block
+		}
+
+		// A block that's too far in the future is valid once the clock reaches it
+		futureBlock := buildBlockAt(clock.Now().Add(2*maxDeviation), 1)
+		clock.Advance(2 * maxDeviation)
+		_, err = tc.ValidateAndInsertBlock(futureBlock)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+
+		// The wall clock has no effect: the deviation is measured from the fake clock
+		tooFarBlock := buildBlockAt(clock.Now().Add(maxDeviation+time.Second), 2)
+		_, err = tc.ValidateAndInsertBlock(tooFarBlock)
+		if !errors.Is(err, ruleerrors.ErrTimeTooMuchInTheFuture) {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+	})
+}
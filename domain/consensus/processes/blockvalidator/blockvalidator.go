@@ -2,6 +2,7 @@ package blockvalidator
 
 import (
 	"github.com/kaspanet/kaspad/util/difficulty"
+	"github.com/kaspanet/kaspad/util/mstime"
 	"math/big"
 	"time"
 
@@ -24,6 +25,7 @@ type blockValidator struct {
 	targetTimePerBlock          time.Duration
 
 	databaseContext       model.DBReader
+	clock                 mstime.Clock
 	difficultyManager     model.DifficultyManager
 	pastMedianTimeManager model.PastMedianTimeManager
 	transactionValidator  model.TransactionValidator
@@ -55,6 +57,7 @@ func New(powMax *big.Int,
 	targetTimePerBlock time.Duration,
 
 	databaseContext model.DBReader,
+	clock mstime.Clock,
 
 	difficultyManager model.DifficultyManager,
 	pastMedianTimeManager model.PastMedianTimeManager,
@@ -89,6 +92,7 @@ func New(powMax *big.Int,
 		timestampDeviationTolerance: timestampDeviationTolerance,
 		targetTimePerBlock:          targetTimePerBlock,
 		databaseContext:             databaseContext,
+		clock:                       clock,
 		difficultyManager:           difficultyManager,
 		pastMedianTimeManager:       pastMedianTimeManager,
 		transactionValidator:        transactionValidator,
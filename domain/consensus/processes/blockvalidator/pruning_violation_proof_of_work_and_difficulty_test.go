@@ -312,3 +312,8 @@ func (dm *mocDifficultyManager) StageDAADataAndReturnRequiredDifficulty(stagingA
 
 	return dm.testDifficulty, nil
 }
+
+// DAAWindow returns an empty window for the test
+func (dm *mocDifficultyManager) DAAWindow(*model.StagingArea, *externalapi.DomainHash) ([]*externalapi.DomainHash, error) {
+	return nil, nil
+}
@@ -14,6 +14,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ResolveBlockStatus resolves the UTXO status of the given block and of
+// the unverified blocks in its selected parent chain, and returns it
+func (csm *consensusStateManager) ResolveBlockStatus(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	useSeparateStagingAreaPerBlock bool) (externalapi.BlockStatus, error) {
+
+	status, _, err := csm.resolveBlockStatus(stagingArea, blockHash, useSeparateStagingAreaPerBlock)
+	return status, err
+}
+
 func (csm *consensusStateManager) resolveBlockStatus(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
 	useSeparateStagingAreaPerBlock bool) (externalapi.BlockStatus, *model.UTXODiffReversalData, error) {
 
@@ -36,14 +36,18 @@ func (csm *consensusStateManager) verifyUTXO(stagingArea *model.StagingArea, blo
 	}
 	log.Debugf("AcceptedIDMerkleRoot validation passed for block %s", blockHash)
 
-	coinbaseTransaction := block.Transactions[0]
-	log.Debugf("Validating coinbase transaction %s for block %s",
-		consensushashing.TransactionID(coinbaseTransaction), blockHash)
-	err = csm.validateCoinbaseTransaction(stagingArea, blockHash, coinbaseTransaction)
-	if err != nil {
-		return err
+	// The genesis is trusted by its hash, and its coinbase may pay a premine
+	// that no expected coinbase transaction would
+	if !blockHash.Equal(csm.genesisHash) {
+		coinbaseTransaction := block.Transactions[0]
+		log.Debugf("Validating coinbase transaction %s for block %s",
+			consensushashing.TransactionID(coinbaseTransaction), blockHash)
+		err = csm.validateCoinbaseTransaction(stagingArea, blockHash, coinbaseTransaction)
+		if err != nil {
+			return err
+		}
+		log.Debugf("Coinbase transaction validation passed for block %s", blockHash)
 	}
-	log.Debugf("Coinbase transaction validation passed for block %s", blockHash)
 
 	log.Debugf("Validating transactions against past UTXO for block %s", blockHash)
 	err = csm.validateBlockTransactionsAgainstPastUTXO(stagingArea, block, pastUTXODiff)
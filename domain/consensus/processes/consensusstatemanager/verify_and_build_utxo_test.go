@@ -0,0 +1,67 @@
+package consensusstatemanager_test
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestGenesisPremine(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+
+		// Replace the genesis with one whose coinbase pays a premine
+		scriptPublicKey, _ := testutils.OpTrueScript()
+		premine := []*externalapi.DomainTransactionOutput{{Value: 1000000, ScriptPublicKey: scriptPublicKey}}
+		genesisHeader := consensusConfig.GenesisBlock.Header
+		consensusConfig.GenesisBlock = dagconfig.NewGenesisBlock(genesisHeader.Version(),
+			genesisHeader.TimeInMilliseconds(), genesisHeader.Bits(), genesisHeader.Nonce(),
+			consensusConfig.GenesisBlock.Transactions[0].Payload, premine)
+		consensusConfig.GenesisHash = consensushashing.BlockHash(consensusConfig.GenesisBlock)
+
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestGenesisPremine")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		stagingArea := model.NewStagingArea()
+		genesisStatus, err := tc.BlockStatusStore().Get(tc.DatabaseContext(), stagingArea, consensusConfig.GenesisHash)
+		if err != nil {
+			t.Fatalf("Error getting the status of the genesis: %+v", err)
+		}
+		if genesisStatus != externalapi.StatusUTXOValid {
+			t.Fatalf("Unexpected genesis status. Want: %s, got: %s", externalapi.StatusUTXOValid, genesisStatus)
+		}
+
+		blockAHash, _, err := tc.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error adding block A: %+v", err)
+		}
+
+		// The premine is accepted by A, so a child of A may spend it
+		spendingTransaction, err := testutils.CreateTransaction(consensusConfig.GenesisBlock.Transactions[0], 1)
+		if err != nil {
+			t.Fatalf("Error creating transaction: %+v", err)
+		}
+		blockBHash, _, err := tc.AddBlock([]*externalapi.DomainHash{blockAHash}, nil,
+			[]*externalapi.DomainTransaction{spendingTransaction})
+		if err != nil {
+			t.Fatalf("Error adding block B: %+v", err)
+		}
+
+		blockBStatus, err := tc.BlockStatusStore().Get(tc.DatabaseContext(), stagingArea, blockBHash)
+		if err != nil {
+			t.Fatalf("Error getting the status of block B: %+v", err)
+		}
+		if blockBStatus != externalapi.StatusUTXOValid {
+			t.Fatalf("Unexpected status of block B. Want: %s, got: %s", externalapi.StatusUTXOValid, blockBStatus)
+		}
+	})
+}
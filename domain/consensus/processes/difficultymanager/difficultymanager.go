@@ -103,6 +103,14 @@ func (dm *difficultyManager) RequiredDifficulty(stagingArea *model.StagingArea,
 	return dm.requiredDifficultyFromTargetsWindow(stagingArea, targetsWindow)
 }
 
+// DAAWindow returns the hashes of the blocks in the DAA window of the given block,
+// which are the blocks its required difficulty is calculated from
+func (dm *difficultyManager) DAAWindow(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (
+	[]*externalapi.DomainHash, error) {
+
+	return dm.dagTraversalManager.BlockWindow(stagingArea, blockHash, dm.difficultyAdjustmentWindowSize+1)
+}
+
 func (dm *difficultyManager) requiredDifficultyFromTargetsWindow(
 	stagingArea *model.StagingArea, targetsWindow blockWindow) (uint32, error) {
 	if dm.disableDifficultyAdjustment {
@@ -307,6 +307,10 @@ func (ds *GHOSTDAGDataStoreImpl) IsStaged(*model.StagingArea) bool {
 	panic("implement me")
 }
 
+func (ds *GHOSTDAGDataStoreImpl) CacheStats() []*externalapi.CacheStats {
+	panic("implement me")
+}
+
 func (ds *GHOSTDAGDataStoreImpl) Discard() {
 	panic("implement me")
 }
@@ -413,6 +417,8 @@ func (b *blockHeadersStore) Stage(stagingArea *model.StagingArea, blockHash *ext
 
 func (b *blockHeadersStore) IsStaged(*model.StagingArea) bool { panic("unimplemented") }
 
+func (b *blockHeadersStore) CacheStats() []*externalapi.CacheStats { panic("unimplemented") }
+
 func (b *blockHeadersStore) BlockHeader(dbContext model.DBReader, stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (externalapi.BlockHeader, error) {
 	header, ok := b.dagMap[*blockHash]
 	if ok {
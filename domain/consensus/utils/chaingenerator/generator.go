@@ -0,0 +1,290 @@
+// Package chaingenerator generates large, reproducible DAGs with transactions,
+// for benchmarking sync and UTXO handling on realistic data.
+//
+// Blocks are generated in rounds. All the blocks of a round point at the
+// virtual parents at the beginning of the round, so a round is as wide as the
+// number of blocks in it. Every output, coinbase outputs included, pays to an
+// anyone-can-spend script, so that generated transactions need no signatures.
+// A reorg is generated by building a branch that forks from the tips of an
+// earlier round, and that is heavier than the blocks generated since.
+//
+// The generated DAG depends only on the params of the network and on the
+// Config, the seed included.
+package chaingenerator
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// Config defines the shape of a generated DAG
+type Config struct {
+	// Seed seeds the random decisions of the generator
+	Seed int64
+
+	// NumberOfBlocks is the number of blocks to generate, not including the genesis
+	NumberOfBlocks uint64
+
+	// BlockRate is the number of blocks per second, which determines the
+	// timestamps of the generated blocks
+	BlockRate float64
+
+	// Width is the number of blocks in each round
+	Width int
+
+	// TransactionsPerBlock is the average number of non-coinbase transactions in a block
+	TransactionsPerBlock int
+
+	// UTXOSetSize is the number of spendable outputs the transactions aim to keep
+	UTXOSetSize int
+
+	// ReorgInterval is the average number of rounds between reorgs. If it's
+	// 0, no reorgs are generated
+	ReorgInterval uint64
+
+	// MaxReorgDepth is the maximum number of rounds a reorg reverts
+	MaxReorgDepth uint64
+}
+
+// DefaultConfig returns a Config with moderate values for everything but
+// NumberOfBlocks
+func DefaultConfig() *Config {
+	return &Config{
+		Seed:                 0,
+		BlockRate:            1,
+		Width:                2,
+		TransactionsPerBlock: 10,
+		UTXOSetSize:          1000,
+		ReorgInterval:        0,
+		MaxReorgDepth:        3,
+	}
+}
+
+// BlockHandler is called with every generated block, right after it's
+// inserted into the consensus
+type BlockHandler func(block *externalapi.DomainBlock, blockInsertionResult *externalapi.BlockInsertionResult) error
+
+// Generator generates a DAG into a consensus
+type Generator struct {
+	tc     testapi.TestConsensus
+	config *Config
+	random *rand.Rand
+
+	scriptPublicKey *externalapi.ScriptPublicKey
+	signatureScript []byte
+	pool            *utxoPool
+
+	millisecondsPerBlock      float64
+	generatedBlocks           uint64
+	generatedTransactionCount uint64
+
+	// roundTips holds the virtual parents at the beginning of recent
+	// rounds, most recent last. Reorgs fork from them
+	roundTips [][]*externalapi.DomainHash
+}
+
+// New returns a Generator that generates a DAG with the given config on top
+// of the genesis of the given consensus. The consensus should contain nothing
+// but its genesis.
+func New(tc testapi.TestConsensus, config *Config) (*Generator, error) {
+	if config.BlockRate <= 0 {
+		return nil, errors.Errorf("the block rate must be positive")
+	}
+	if config.Width <= 0 {
+		return nil, errors.Errorf("the width must be positive")
+	}
+	if config.TransactionsPerBlock < 0 || config.UTXOSetSize < 0 {
+		return nil, errors.Errorf("the number of transactions per block and the UTXO set size can't be negative")
+	}
+	if config.ReorgInterval > 0 && config.MaxReorgDepth == 0 {
+		return nil, errors.Errorf("the maximum reorg depth must be positive when reorgs are enabled")
+	}
+
+	params := tc.DAGParams()
+	millisecondsPerBlock := 1000 / config.BlockRate
+	lastTimeInMilliseconds := params.GenesisBlock.Header.TimeInMilliseconds() +
+		int64(float64(config.NumberOfBlocks)*millisecondsPerBlock)
+	if lastTimeInMilliseconds > mstime.Now().UnixMilliseconds() {
+		return nil, errors.Errorf("generating %d blocks at %f blocks per second from the genesis of %s "+
+			"reaches %s, which is in the future", config.NumberOfBlocks, config.BlockRate, params.Name,
+			mstime.UnixMilliseconds(lastTimeInMilliseconds).ToNativeTime().Format(time.RFC3339))
+	}
+
+	scriptPublicKey, redeemScript := testutils.OpTrueScript()
+	signatureScript, err := txscript.PayToScriptHashSignatureScript(redeemScript, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Generator{
+		tc:                   tc,
+		config:               config,
+		random:               rand.New(rand.NewSource(config.Seed)),
+		scriptPublicKey:      scriptPublicKey,
+		signatureScript:      signatureScript,
+		pool:                 newUTXOPool(tc),
+		millisecondsPerBlock: millisecondsPerBlock,
+	}, nil
+}
+
+// Generate generates the DAG, and calls handler with every generated block
+func (g *Generator) Generate(handler BlockHandler) error {
+	for g.generatedBlocks < g.config.NumberOfBlocks {
+		if g.shouldReorg() {
+			err := g.generateReorg(handler)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err := g.generateRound(handler)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GeneratedTransactionCount returns the number of non-coinbase transactions
+// generated so far
+func (g *Generator) GeneratedTransactionCount() uint64 {
+	return g.generatedTransactionCount
+}
+
+// BuildTransactions builds up to count transactions that spend different
+// outputs of the virtual UTXO set, without inserting them into a block. It's
+// meant for filling a mempool after the DAG is generated.
+func (g *Generator) BuildTransactions(count int) ([]*externalapi.DomainTransaction, error) {
+	virtualInfo, err := g.tc.GetVirtualInfo()
+	if err != nil {
+		return nil, err
+	}
+	g.pool.round++
+	return g.buildTransactions(count, virtualInfo.DAAScore)
+}
+
+func (g *Generator) shouldReorg() bool {
+	if g.config.ReorgInterval == 0 || len(g.roundTips) == 0 {
+		return false
+	}
+	return g.random.Int63n(int64(g.config.ReorgInterval)) == 0
+}
+
+// generateRound generates a round of blocks that point at the current
+// virtual parents, with transactions that spend outputs of the virtual UTXO set
+func (g *Generator) generateRound(handler BlockHandler) error {
+	virtualInfo, err := g.tc.GetVirtualInfo()
+	if err != nil {
+		return err
+	}
+	g.rememberRoundTips(virtualInfo.ParentHashes)
+	g.pool.round++
+
+	for i := 0; i < g.config.Width && g.generatedBlocks < g.config.NumberOfBlocks; i++ {
+		transactionCount := 0
+		if g.config.TransactionsPerBlock > 0 {
+			transactionCount = g.random.Intn(2*g.config.TransactionsPerBlock + 1)
+		}
+		transactions, err := g.buildTransactions(transactionCount, virtualInfo.DAAScore)
+		if err != nil {
+			return err
+		}
+		_, err = g.generateBlock(virtualInfo.ParentHashes, transactions, handler)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateReorg generates a branch that forks from the beginning of one of the
+// recent rounds, and that is one round longer than the rounds it reorgs. The
+// branch has no transactions, so that it doesn't conflict with the
+// transactions it reorgs.
+func (g *Generator) generateReorg(handler BlockHandler) error {
+	maxDepth := g.config.MaxReorgDepth
+	if maxDepth > uint64(len(g.roundTips)) {
+		maxDepth = uint64(len(g.roundTips))
+	}
+	depth := 1 + uint64(g.random.Int63n(int64(maxDepth)))
+	parents := g.roundTips[uint64(len(g.roundTips))-depth]
+
+	for round := uint64(0); round < depth+1; round++ {
+		g.pool.round++
+		roundBlockHashes := make([]*externalapi.DomainHash, 0, g.config.Width)
+		for i := 0; i < g.config.Width && g.generatedBlocks < g.config.NumberOfBlocks; i++ {
+			blockHash, err := g.generateBlock(parents, nil, handler)
+			if err != nil {
+				return err
+			}
+			roundBlockHashes = append(roundBlockHashes, blockHash)
+		}
+		if len(roundBlockHashes) == 0 {
+			break
+		}
+		parents = roundBlockHashes
+	}
+
+	// Reorgs don't fork from before an earlier reorg, so that branches don't
+	// need to outweigh each other's forks
+	g.roundTips = nil
+	return nil
+}
+
+func (g *Generator) rememberRoundTips(tips []*externalapi.DomainHash) {
+	g.roundTips = append(g.roundTips, tips)
+	maxRememberedRounds := int(g.config.MaxReorgDepth)
+	if len(g.roundTips) > maxRememberedRounds {
+		g.roundTips = g.roundTips[len(g.roundTips)-maxRememberedRounds:]
+	}
+}
+
+// generateBlock builds a block with the given parents and transactions,
+// timestamps and solves it, and inserts it into the consensus
+func (g *Generator) generateBlock(parents []*externalapi.DomainHash, transactions []*externalapi.DomainTransaction,
+	handler BlockHandler) (*externalapi.DomainHash, error) {
+
+	// The extra data makes blocks with the same parents and transactions differ
+	extraData := make([]byte, 8)
+	binary.LittleEndian.PutUint64(extraData, g.generatedBlocks)
+	coinbaseData := &externalapi.DomainCoinbaseData{ScriptPublicKey: g.scriptPublicKey, ExtraData: extraData}
+
+	block, _, err := g.tc.BuildBlockWithParents(parents, coinbaseData, transactions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error building block %d", g.generatedBlocks)
+	}
+
+	g.generatedBlocks++
+	timeInMilliseconds := g.tc.DAGParams().GenesisBlock.Header.TimeInMilliseconds() +
+		int64(float64(g.generatedBlocks)*g.millisecondsPerBlock)
+	mutableHeader := block.Header.ToMutable()
+	mutableHeader.SetTimeInMilliseconds(timeInMilliseconds)
+	block.Header = mutableHeader.ToImmutable()
+	if !g.tc.DAGParams().SkipProofOfWork {
+		mining.SolveBlock(block, g.random)
+	}
+
+	blockInsertionResult, err := g.tc.ValidateAndInsertBlock(block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error inserting generated block %d", g.generatedBlocks-1)
+	}
+
+	g.pool.addTransactionOutputs(block.Transactions)
+	g.generatedTransactionCount += uint64(len(block.Transactions) - 1)
+
+	err = handler(block, blockInsertionResult)
+	if err != nil {
+		return nil, err
+	}
+	return consensushashing.BlockHash(block), nil
+}
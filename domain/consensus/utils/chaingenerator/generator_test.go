@@ -0,0 +1,92 @@
+package chaingenerator
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+// generate generates a DAG with the given config on simnet, and returns the
+// hashes of the generated blocks and the number of reorged blocks
+func generate(t *testing.T, config *Config, testName string) ([]*externalapi.DomainHash, int) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.BlockCoinbaseMaturity = 10
+
+	factory := consensus.NewFactory()
+	tc, teardown, err := factory.NewTestConsensus(consensusConfig, testName)
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardown(false)
+
+	generator, err := New(tc, config)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+
+	var blockHashes []*externalapi.DomainHash
+	reorgedBlockCount := 0
+	err = generator.Generate(func(block *externalapi.DomainBlock, blockInsertionResult *externalapi.BlockInsertionResult) error {
+		blockHashes = append(blockHashes, consensushashing.BlockHash(block))
+		reorgedBlockCount += len(blockInsertionResult.VirtualSelectedParentChainChanges.Removed)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Generate: %+v", err)
+	}
+	if uint64(len(blockHashes)) != config.NumberOfBlocks {
+		t.Fatalf("Unexpected number of generated blocks. Want: %d, got: %d", config.NumberOfBlocks, len(blockHashes))
+	}
+
+	stagingArea := model.NewStagingArea()
+	for i, blockHash := range blockHashes {
+		blockStatus, err := tc.BlockStatusStore().Get(tc.DatabaseContext(), stagingArea, blockHash)
+		if err != nil {
+			t.Fatalf("Error getting the status of block %d: %+v", i, err)
+		}
+		if blockStatus == externalapi.StatusDisqualifiedFromChain {
+			t.Fatalf("Generated block %d is disqualified from chain", i)
+		}
+	}
+	if config.TransactionsPerBlock > 0 && generator.GeneratedTransactionCount() == 0 {
+		t.Fatalf("No transactions were generated")
+	}
+	return blockHashes, reorgedBlockCount
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	config := DefaultConfig()
+	config.NumberOfBlocks = 60
+	config.ReorgInterval = 5
+
+	blockHashes, _ := generate(t, config, "TestGenerateIsDeterministic")
+	sameSeedBlockHashes, _ := generate(t, config, "TestGenerateIsDeterministic")
+	for i := range blockHashes {
+		if !blockHashes[i].Equal(sameSeedBlockHashes[i]) {
+			t.Fatalf("Block %d differs between runs with the same seed: %s != %s",
+				i, blockHashes[i], sameSeedBlockHashes[i])
+		}
+	}
+
+	config.Seed = 1
+	otherSeedBlockHashes, _ := generate(t, config, "TestGenerateIsDeterministic")
+	if blockHashes[len(blockHashes)-1].Equal(otherSeedBlockHashes[len(otherSeedBlockHashes)-1]) {
+		t.Fatalf("Runs with different seeds generated the same DAG")
+	}
+}
+
+func TestGenerateReorgs(t *testing.T) {
+	config := DefaultConfig()
+	config.NumberOfBlocks = 100
+	config.Width = 3
+	config.ReorgInterval = 3
+
+	_, reorgedBlockCount := generate(t, config, "TestGenerateReorgs")
+	if reorgedBlockCount == 0 {
+		t.Fatalf("No selected chain blocks were reorged")
+	}
+}
@@ -0,0 +1,171 @@
+package chaingenerator
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+)
+
+// maxMissingOutpointAge is the number of rounds after which an outpoint that
+// isn't in the virtual UTXO set is dropped. An outpoint is missing from the
+// virtual UTXO set until the transaction that created it is accepted, and
+// while it's reorged out.
+const maxMissingOutpointAge = 10
+
+const (
+	// minTransactionFee is the minimum fee of a generated transaction. It's
+	// the default minimum relay fee of a 1KB transaction, so that generated
+	// transactions are accepted to the mempool
+	minTransactionFee = 1000
+
+	// maxTransactionFee is the maximum fee of a generated transaction
+	maxTransactionFee = 2000
+)
+
+// pooledOutpoint is an output of a generated transaction that may be spent
+// by later generated transactions
+type pooledOutpoint struct {
+	outpoint     externalapi.DomainOutpoint
+	createdRound uint64
+}
+
+// utxoPool holds the outputs of generated transactions that weren't spent
+// yet, in the order they were created
+type utxoPool struct {
+	tc        testapi.TestConsensus
+	outpoints []*pooledOutpoint
+
+	// round is the number of the current round
+	round uint64
+}
+
+func newUTXOPool(tc testapi.TestConsensus) *utxoPool {
+	return &utxoPool{tc: tc}
+}
+
+func (pool *utxoPool) size() int {
+	return len(pool.outpoints)
+}
+
+func (pool *utxoPool) addTransactionOutputs(transactions []*externalapi.DomainTransaction) {
+	for _, transaction := range transactions {
+		transactionID := consensushashing.TransactionID(transaction)
+		for i := range transaction.Outputs {
+			pool.outpoints = append(pool.outpoints, &pooledOutpoint{
+				outpoint:     externalapi.DomainOutpoint{TransactionID: *transactionID, Index: uint32(i)},
+				createdRound: pool.round,
+			})
+		}
+	}
+}
+
+// take removes and returns the oldest outpoint that a block of the current
+// round whose DAA score is daaScore may spend, along with its UTXO entry. It returns false if no
+// such outpoint exists. Outpoints that can't be spent yet are moved to the
+// end of the pool.
+func (pool *utxoPool) take(daaScore uint64) (*externalapi.DomainOutpoint, externalapi.UTXOEntry, bool, error) {
+	stagingArea := model.NewStagingArea()
+	consensusStateStore := pool.tc.ConsensusStateStore()
+	coinbaseMaturity := pool.tc.DAGParams().BlockCoinbaseMaturity
+
+	for checked, initialSize := 0, len(pool.outpoints); checked < initialSize; checked++ {
+		pooled := pool.outpoints[0]
+		pool.outpoints = pool.outpoints[1:]
+
+		// The virtual UTXO set changes as the blocks of the current round are
+		// inserted, but the blocks of a round only see the outputs of earlier
+		// rounds
+		if pooled.createdRound == pool.round {
+			pool.outpoints = append(pool.outpoints, pooled)
+			continue
+		}
+		exists, err := consensusStateStore.HasUTXOByOutpoint(pool.tc.DatabaseContext(), stagingArea, &pooled.outpoint)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if !exists {
+			if pool.round-pooled.createdRound <= maxMissingOutpointAge {
+				pool.outpoints = append(pool.outpoints, pooled)
+			}
+			continue
+		}
+		utxoEntry, err := consensusStateStore.UTXOByOutpoint(pool.tc.DatabaseContext(), stagingArea, &pooled.outpoint)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if utxoEntry.IsCoinbase() && utxoEntry.BlockDAAScore()+coinbaseMaturity > daaScore {
+			pool.outpoints = append(pool.outpoints, pooled)
+			continue
+		}
+		return &pooled.outpoint, utxoEntry, true, nil
+	}
+	return nil, nil, false, nil
+}
+
+// buildTransactions builds up to count transactions for a block whose DAA
+// score is daaScore. The transactions spend different outputs of the virtual
+// UTXO set, and split or merge outputs so that the pool size approaches the
+// configured UTXO set size.
+func (g *Generator) buildTransactions(count int, daaScore uint64) ([]*externalapi.DomainTransaction, error) {
+	transactions := make([]*externalapi.DomainTransaction, 0, count)
+	for i := 0; i < count; i++ {
+		inputCount, outputCount := 1, 1
+		poolSize := g.pool.size()
+		if poolSize < g.config.UTXOSetSize {
+			outputCount = 2 + g.random.Intn(2)
+		} else if poolSize > g.config.UTXOSetSize {
+			inputCount = 2
+		}
+
+		inputs := make([]*externalapi.DomainTransactionInput, 0, inputCount)
+		inputsValue := uint64(0)
+		for len(inputs) < inputCount {
+			outpoint, utxoEntry, ok, err := g.pool.take(daaScore)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			inputs = append(inputs, &externalapi.DomainTransactionInput{
+				PreviousOutpoint: *outpoint,
+				SignatureScript:  g.signatureScript,
+				Sequence:         constants.MaxTxInSequenceNum,
+			})
+			inputsValue += utxoEntry.Amount()
+		}
+		if len(inputs) == 0 {
+			break
+		}
+
+		fee := uint64(minTransactionFee + g.random.Int63n(maxTransactionFee-minTransactionFee+1))
+		if fee > inputsValue {
+			fee = inputsValue
+		}
+		outputsValue := inputsValue - fee
+		if outputsValue < uint64(outputCount) {
+			outputCount = 1
+		}
+		outputs := make([]*externalapi.DomainTransactionOutput, outputCount)
+		for j := range outputs {
+			value := outputsValue / uint64(outputCount)
+			if j == 0 {
+				value += outputsValue % uint64(outputCount)
+			}
+			outputs[j] = &externalapi.DomainTransactionOutput{
+				ScriptPublicKey: g.scriptPublicKey,
+				Value:           value,
+			}
+		}
+
+		transactions = append(transactions, &externalapi.DomainTransaction{
+			Version: constants.MaxTransactionVersion,
+			Inputs:  inputs,
+			Outputs: outputs,
+			Payload: []byte{},
+		})
+	}
+	return transactions, nil
+}
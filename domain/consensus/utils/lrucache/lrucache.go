@@ -9,6 +9,10 @@ import (
 type LRUCache struct {
 	cache    map[externalapi.DomainHash]interface{}
 	capacity int
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
 }
 
 // New creates a new LRUCache
@@ -38,15 +42,22 @@ func (c *LRUCache) Add(key *externalapi.DomainHash, value interface{}) {
 func (c *LRUCache) Get(key *externalapi.DomainHash) (interface{}, bool) {
 	value, ok := c.cache[*key]
 	if !ok {
+		c.misses++
 		return nil, false
 	}
+	c.hits++
 	return value, true
 }
 
 // Has returns whether the LRUCache contains the given key
 func (c *LRUCache) Has(key *externalapi.DomainHash) bool {
 	_, ok := c.cache[*key]
-	return ok
+	if !ok {
+		c.misses++
+		return false
+	}
+	c.hits++
+	return true
 }
 
 // Remove removes the entry for the the given key. Does nothing if
@@ -55,6 +66,19 @@ func (c *LRUCache) Remove(key *externalapi.DomainHash) {
 	delete(c.cache, *key)
 }
 
+// Stats returns the usage statistics of the LRUCache under the given name
+func (c *LRUCache) Stats(name string) *externalapi.CacheStats {
+	return &externalapi.CacheStats{
+		Name:      name,
+		Entries:   uint64(len(c.cache)),
+		Size:      uint64(len(c.cache)),
+		Capacity:  uint64(c.capacity),
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
 func (c *LRUCache) evictRandom() {
 	var keyToEvict externalapi.DomainHash
 	for key := range c.cache {
@@ -62,4 +86,5 @@ func (c *LRUCache) evictRandom() {
 		break
 	}
 	c.Remove(&keyToEvict)
+	c.evictions++
 }
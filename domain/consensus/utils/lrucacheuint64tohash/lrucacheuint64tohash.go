@@ -7,6 +7,10 @@ import "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 type LRUCache struct {
 	cache    map[uint64]*externalapi.DomainHash
 	capacity int
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
 }
 
 // New creates a new LRUCache
@@ -36,15 +40,22 @@ func (c *LRUCache) Add(key uint64, value *externalapi.DomainHash) {
 func (c *LRUCache) Get(key uint64) (*externalapi.DomainHash, bool) {
 	value, ok := c.cache[key]
 	if !ok {
+		c.misses++
 		return nil, false
 	}
+	c.hits++
 	return value, true
 }
 
 // Has returns whether the LRUCache contains the given key
 func (c *LRUCache) Has(key uint64) bool {
 	_, ok := c.cache[key]
-	return ok
+	if !ok {
+		c.misses++
+		return false
+	}
+	c.hits++
+	return true
 }
 
 // Remove removes the entry for the the given key. Does nothing if
@@ -53,6 +64,19 @@ func (c *LRUCache) Remove(key uint64) {
 	delete(c.cache, key)
 }
 
+// Stats returns the usage statistics of the LRUCache under the given name
+func (c *LRUCache) Stats(name string) *externalapi.CacheStats {
+	return &externalapi.CacheStats{
+		Name:      name,
+		Entries:   uint64(len(c.cache)),
+		Size:      uint64(len(c.cache)),
+		Capacity:  uint64(c.capacity),
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
 func (c *LRUCache) evictRandom() {
 	var keyToEvict uint64
 	for key := range c.cache {
@@ -60,4 +84,5 @@ func (c *LRUCache) evictRandom() {
 		break
 	}
 	c.Remove(keyToEvict)
+	c.evictions++
 }
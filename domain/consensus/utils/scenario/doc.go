@@ -0,0 +1,79 @@
+/*
+Package scenario implements a declarative format for consensus tests, and a
+runner that plays such scenarios on a testapi.TestConsensus.
+
+A scenario is a JSON object with a list of blocks, which are built and
+inserted in order, and optional expectations about the virtual once all the
+blocks are inserted:
+
+	{
+	  "blocks": [
+	    {"id": "A", "parents": ["genesis"]},
+	    {"id": "B", "parents": ["A"]},
+	    {"id": "C", "parents": ["B"], "transactions": [{"id": "tx1", "inputs": ["B.coinbase:0"], "fee": 1}]},
+	    {"id": "D", "parents": ["B"], "transactions": [{"id": "tx1"}]},
+	    {"id": "E", "parents": ["C", "D"], "timeOffset": -3600000, "expectedError": "ErrTimeTooOld"}
+	  ],
+	  "expectedVirtual": {
+	    "parents": ["C", "D"],
+	    "utxos": [{"outpoint": "tx1:0"}],
+	    "spentOutputs": ["B.coinbase:0"]
+	  }
+	}
+
+Blocks
+
+Every block has a unique id, which is used to refer to it in later blocks and
+in expectations. The id "genesis" refers to the genesis block of the network.
+
+A block can have the following fields:
+
+	id                     The name of the block
+	parents                The ids of the parents of the block
+	transactions           The transactions of the block, except for its coinbase
+	timeOffset             Milliseconds to add to the timestamp the block builder chose
+	invalidUtxoCommitment  Replaces the UTXO commitment of the block with a wrong one
+	headerOnly             Inserts only the header of the block
+	expectedStatus         The status the block is expected to have after its insertion:
+	                       Valid, UTXOPendingVerification, DisqualifiedFromChain, HeaderOnly or Invalid
+	expectedError          The name of the rule error the insertion is expected to fail with,
+	                       e.g. ErrTimeTooOld. If omitted, the insertion is expected to succeed.
+	                       A block that can't even be built fails with the error of building it,
+	                       and its id can't be referred to
+	expectedVirtual        Expectations about the virtual right after the insertion of the block
+
+Transactions
+
+Every transaction has a unique id. Outputs are referred to as "<transaction id>:<index>",
+and the coinbase transaction of a block as "<block id>.coinbase".
+
+A transaction can have the following fields:
+
+	id       The name of the transaction
+	inputs   The outputs the transaction spends
+	outputs  The values of the outputs of the transaction. If omitted, the transaction
+	         has a single output worth the value of its inputs minus its fee
+	fee      The fee of the transaction, used when outputs is omitted
+
+A transaction that only has an id refers to a transaction that was already
+defined, which allows including the same transaction in several blocks.
+Transactions with the same inputs and outputs are identical, so transactions
+that double spend each other should have different fees.
+
+All outputs, including coinbase outputs, pay to an anyone-can-spend script,
+so that any output can be spent without signatures. Note that coinbase outputs
+can only be spent once they mature, so scenarios that spend them are usually
+run with BlockCoinbaseMaturity set to 0.
+
+Virtual expectations
+
+	parents       The ids of the parents of the virtual, in any order
+	utxos         Outputs expected to be in the virtual UTXO set, as objects with an
+	              outpoint and an optional amount
+	spentOutputs  Outputs expected not to be in the virtual UTXO set
+
+Blocks are built with testapi.TestConsensus.BuildBlockWithParents, and their
+headers aren't solved, so scenarios are expected to run on a consensus with
+SkipProofOfWork set.
+*/
+package scenario
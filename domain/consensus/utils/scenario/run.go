@@ -0,0 +1,368 @@
+package scenario
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/pkg/errors"
+)
+
+const (
+	genesisID      = "genesis"
+	coinbaseSuffix = ".coinbase"
+)
+
+// Result maps the ids of a scenario to the blocks and transactions they describe
+type Result struct {
+	BlockHashes  map[string]*externalapi.DomainHash
+	Transactions map[string]*externalapi.DomainTransaction
+}
+
+type runner struct {
+	tc           testapi.TestConsensus
+	blockHashes  map[string]*externalapi.DomainHash
+	blockIDs     map[externalapi.DomainHash]string
+	transactions map[string]*externalapi.DomainTransaction
+}
+
+// Run inserts the blocks of the given scenario into the given consensus, and
+// returns an error describing the first outcome that differs from the expected one
+func Run(tc testapi.TestConsensus, scenario *Scenario) (*Result, error) {
+	genesisHash := tc.DAGParams().GenesisHash
+	genesis, err := tc.GetBlock(genesisHash)
+	if err != nil {
+		return nil, err
+	}
+	r := &runner{
+		tc:          tc,
+		blockHashes: map[string]*externalapi.DomainHash{genesisID: genesisHash},
+		blockIDs:    map[externalapi.DomainHash]string{*genesisHash: genesisID},
+		transactions: map[string]*externalapi.DomainTransaction{
+			genesisID + coinbaseSuffix: genesis.Transactions[transactionhelper.CoinbaseTransactionIndex],
+		},
+	}
+
+	for i, block := range scenario.Blocks {
+		err := r.runBlock(block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "block %d (%s)", i, block.ID)
+		}
+	}
+	if scenario.ExpectedVirtual != nil {
+		err := r.checkVirtual(scenario.ExpectedVirtual)
+		if err != nil {
+			return nil, errors.Wrap(err, "expected virtual")
+		}
+	}
+
+	return &Result{
+		BlockHashes:  r.blockHashes,
+		Transactions: r.transactions,
+	}, nil
+}
+
+func (r *runner) runBlock(block *Block) error {
+	if block.ID == "" {
+		return errors.New("the block has no id")
+	}
+	if _, ok := r.blockHashes[block.ID]; ok {
+		return errors.Errorf("the block id %s is used more than once", block.ID)
+	}
+
+	parentHashes := make([]*externalapi.DomainHash, len(block.Parents))
+	for i, parentID := range block.Parents {
+		parentHash, ok := r.blockHashes[parentID]
+		if !ok {
+			return errors.Errorf("unknown parent %s", parentID)
+		}
+		parentHashes[i] = parentHash
+	}
+	transactions := make([]*externalapi.DomainTransaction, len(block.Transactions))
+	for i, transaction := range block.Transactions {
+		var err error
+		transactions[i], err = r.buildTransaction(transaction)
+		if err != nil {
+			return errors.Wrapf(err, "transaction %s", transaction.ID)
+		}
+	}
+
+	domainBlock, _, err := r.tc.BuildBlockWithParents(parentHashes, nil, transactions)
+	if err != nil {
+		// Some blocks break the rules badly enough not to be buildable. If their
+		// building fails with the expected error, the block is never inserted
+		if block.ExpectedError != "" && checkInsertionError(block.ExpectedError, err) == nil {
+			return nil
+		}
+		return errors.Wrap(err, "error building the block")
+	}
+	if block.TimeOffset != 0 || block.InvalidUTXOCommitment {
+		domainBlock.Header = modifiedHeader(domainBlock.Header, block.TimeOffset, block.InvalidUTXOCommitment)
+	}
+	if block.HeaderOnly {
+		domainBlock = &externalapi.DomainBlock{Header: domainBlock.Header}
+	}
+
+	_, insertionErr := r.tc.ValidateAndInsertBlock(domainBlock)
+
+	blockHash := consensushashing.BlockHash(domainBlock)
+	r.blockHashes[block.ID] = blockHash
+	r.blockIDs[*blockHash] = block.ID
+	if !block.HeaderOnly {
+		err := r.addTransaction(block.ID+coinbaseSuffix, domainBlock.Transactions[transactionhelper.CoinbaseTransactionIndex])
+		if err != nil {
+			return err
+		}
+	}
+
+	err = checkInsertionError(block.ExpectedError, insertionErr)
+	if err != nil {
+		return err
+	}
+	if block.ExpectedStatus != "" {
+		err := r.checkBlockStatus(blockHash, block.ExpectedStatus)
+		if err != nil {
+			return err
+		}
+	}
+	if block.ExpectedVirtual != nil {
+		err := r.checkVirtual(block.ExpectedVirtual)
+		if err != nil {
+			return errors.Wrap(err, "expected virtual")
+		}
+	}
+	return nil
+}
+
+// modifiedHeader returns a copy of the given header, with timeOffset added to
+// its timestamp, and with a wrong UTXO commitment if invalidUTXOCommitment is set
+func modifiedHeader(header externalapi.BlockHeader, timeOffset int64,
+	invalidUTXOCommitment bool) externalapi.BlockHeader {
+
+	utxoCommitment := header.UTXOCommitment()
+	if invalidUTXOCommitment {
+		utxoCommitmentBytes := utxoCommitment.ByteArray()
+		utxoCommitmentBytes[0] ^= 0xff
+		utxoCommitment = externalapi.NewDomainHashFromByteArray(utxoCommitmentBytes)
+	}
+	return blockheader.NewImmutableBlockHeader(
+		header.Version(),
+		header.ParentHashes(),
+		header.HashMerkleRoot(),
+		header.AcceptedIDMerkleRoot(),
+		utxoCommitment,
+		header.TimeInMilliseconds()+timeOffset,
+		header.Bits(),
+		header.Nonce(),
+	)
+}
+
+func (r *runner) buildTransaction(transaction *Transaction) (*externalapi.DomainTransaction, error) {
+	if len(transaction.Inputs) == 0 && len(transaction.Outputs) == 0 && transaction.Fee == 0 {
+		domainTransaction, ok := r.transactions[transaction.ID]
+		if !ok {
+			return nil, errors.New("the transaction has no inputs and isn't defined in an earlier block")
+		}
+		return domainTransaction, nil
+	}
+
+	scriptPublicKey, redeemScript := testutils.OpTrueScript()
+	signatureScript, err := txscript.PayToScriptHashSignatureScript(redeemScript, nil)
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([]*externalapi.DomainTransactionInput, len(transaction.Inputs))
+	inputsValue := uint64(0)
+	for i, outputID := range transaction.Inputs {
+		outpoint, output, err := r.resolveOutput(outputID)
+		if err != nil {
+			return nil, err
+		}
+		inputs[i] = &externalapi.DomainTransactionInput{
+			PreviousOutpoint: *outpoint,
+			SignatureScript:  signatureScript,
+			Sequence:         constants.MaxTxInSequenceNum,
+		}
+		inputsValue += output.Value
+	}
+
+	outputValues := transaction.Outputs
+	if len(outputValues) == 0 {
+		if inputsValue < transaction.Fee {
+			return nil, errors.Errorf("the fee %d is larger than the inputs value %d", transaction.Fee, inputsValue)
+		}
+		outputValues = []uint64{inputsValue - transaction.Fee}
+	}
+	outputs := make([]*externalapi.DomainTransactionOutput, len(outputValues))
+	for i, value := range outputValues {
+		outputs[i] = &externalapi.DomainTransactionOutput{
+			ScriptPublicKey: scriptPublicKey,
+			Value:           value,
+		}
+	}
+
+	domainTransaction := &externalapi.DomainTransaction{
+		Version: constants.MaxTransactionVersion,
+		Inputs:  inputs,
+		Outputs: outputs,
+		Payload: []byte{},
+	}
+	err = r.addTransaction(transaction.ID, domainTransaction)
+	if err != nil {
+		return nil, err
+	}
+	return domainTransaction, nil
+}
+
+func (r *runner) addTransaction(id string, transaction *externalapi.DomainTransaction) error {
+	if id == "" {
+		return errors.New("the transaction has no id")
+	}
+	if _, ok := r.transactions[id]; ok {
+		return errors.Errorf("the transaction id %s is used more than once", id)
+	}
+	r.transactions[id] = transaction
+	return nil
+}
+
+// resolveOutput returns the outpoint and the output that the given
+// "<transaction id>:<index>" refers to
+func (r *runner) resolveOutput(outputID string) (*externalapi.DomainOutpoint, *externalapi.DomainTransactionOutput, error) {
+	separatorIndex := strings.LastIndex(outputID, ":")
+	if separatorIndex == -1 {
+		return nil, nil, errors.Errorf("the output %s isn't of the form <transaction id>:<index>", outputID)
+	}
+	transactionID := outputID[:separatorIndex]
+	index, err := strconv.ParseUint(outputID[separatorIndex+1:], 10, 32)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "the output %s has an invalid index", outputID)
+	}
+
+	transaction, ok := r.transactions[transactionID]
+	if !ok {
+		return nil, nil, errors.Errorf("unknown transaction %s", transactionID)
+	}
+	if index >= uint64(len(transaction.Outputs)) {
+		return nil, nil, errors.Errorf("the transaction %s has no output %d", transactionID, index)
+	}
+
+	outpoint := &externalapi.DomainOutpoint{
+		TransactionID: *consensushashing.TransactionID(transaction),
+		Index:         uint32(index),
+	}
+	return outpoint, transaction.Outputs[index], nil
+}
+
+func checkInsertionError(expectedError string, insertionErr error) error {
+	if expectedError == "" {
+		if insertionErr != nil {
+			return errors.Wrap(insertionErr, "unexpected insertion error")
+		}
+		return nil
+	}
+
+	if insertionErr == nil {
+		return errors.Errorf("expected the insertion to fail with %s, but it succeeded", expectedError)
+	}
+	var ruleError ruleerrors.RuleError
+	if !errors.As(insertionErr, &ruleError) {
+		return errors.Wrapf(insertionErr, "expected the insertion to fail with %s, but it failed with a non-rule error",
+			expectedError)
+	}
+	// The message of a rule error is its name, optionally followed by its inner error
+	ruleErrorName := strings.SplitN(ruleError.Error(), ":", 2)[0]
+	if ruleErrorName != expectedError {
+		return errors.Errorf("expected the insertion to fail with %s, but it failed with %s", expectedError, ruleError)
+	}
+	return nil
+}
+
+func (r *runner) checkBlockStatus(blockHash *externalapi.DomainHash, expectedStatus string) error {
+	blockInfo, err := r.tc.GetBlockInfo(blockHash)
+	if err != nil {
+		return err
+	}
+	if !blockInfo.Exists {
+		return errors.Errorf("expected the block status to be %s, but the block doesn't exist", expectedStatus)
+	}
+	if blockInfo.BlockStatus.String() != expectedStatus {
+		return errors.Errorf("expected the block status to be %s, but it's %s", expectedStatus, blockInfo.BlockStatus)
+	}
+	return nil
+}
+
+func (r *runner) checkVirtual(expectedVirtual *Virtual) error {
+	if expectedVirtual.Parents != nil {
+		virtualInfo, err := r.tc.GetVirtualInfo()
+		if err != nil {
+			return err
+		}
+		parentIDs := make([]string, len(virtualInfo.ParentHashes))
+		for i, parentHash := range virtualInfo.ParentHashes {
+			parentID, ok := r.blockIDs[*parentHash]
+			if !ok {
+				parentID = parentHash.String()
+			}
+			parentIDs[i] = parentID
+		}
+		expectedParentIDs := append([]string{}, expectedVirtual.Parents...)
+		sort.Strings(parentIDs)
+		sort.Strings(expectedParentIDs)
+		if strings.Join(parentIDs, ",") != strings.Join(expectedParentIDs, ",") {
+			return errors.Errorf("expected the virtual parents to be %v, but they're %v", expectedParentIDs, parentIDs)
+		}
+	}
+
+	stagingArea := model.NewStagingArea()
+	consensusStateStore := r.tc.ConsensusStateStore()
+	for _, expectedUTXO := range expectedVirtual.UTXOs {
+		outpoint, _, err := r.resolveOutput(expectedUTXO.Outpoint)
+		if err != nil {
+			return err
+		}
+		exists, err := consensusStateStore.HasUTXOByOutpoint(r.tc.DatabaseContext(), stagingArea, outpoint)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errors.Errorf("expected %s to be in the virtual UTXO set, but it isn't", expectedUTXO.Outpoint)
+		}
+		if expectedUTXO.Amount == 0 {
+			continue
+		}
+		utxoEntry, err := consensusStateStore.UTXOByOutpoint(r.tc.DatabaseContext(), stagingArea, outpoint)
+		if err != nil {
+			return err
+		}
+		if utxoEntry.Amount() != expectedUTXO.Amount {
+			return errors.Errorf("expected the amount of %s to be %d, but it's %d",
+				expectedUTXO.Outpoint, expectedUTXO.Amount, utxoEntry.Amount())
+		}
+	}
+
+	for _, spentOutput := range expectedVirtual.SpentOutputs {
+		outpoint, _, err := r.resolveOutput(spentOutput)
+		if err != nil {
+			return err
+		}
+		exists, err := consensusStateStore.HasUTXOByOutpoint(r.tc.DatabaseContext(), stagingArea, outpoint)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.Errorf("expected %s not to be in the virtual UTXO set, but it is", spentOutput)
+		}
+	}
+
+	return nil
+}
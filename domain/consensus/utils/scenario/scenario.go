@@ -0,0 +1,80 @@
+package scenario
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Scenario is a sequence of blocks to insert into a consensus, along with the
+// expected outcome of every insertion
+type Scenario struct {
+	Blocks          []*Block `json:"blocks"`
+	ExpectedVirtual *Virtual `json:"expectedVirtual,omitempty"`
+}
+
+// Block describes a block of a scenario, and the expected outcome of its insertion
+type Block struct {
+	ID                    string         `json:"id"`
+	Parents               []string       `json:"parents"`
+	Transactions          []*Transaction `json:"transactions,omitempty"`
+	TimeOffset            int64          `json:"timeOffset,omitempty"`
+	InvalidUTXOCommitment bool           `json:"invalidUtxoCommitment,omitempty"`
+	HeaderOnly            bool           `json:"headerOnly,omitempty"`
+	ExpectedStatus        string         `json:"expectedStatus,omitempty"`
+	ExpectedError         string         `json:"expectedError,omitempty"`
+	ExpectedVirtual       *Virtual       `json:"expectedVirtual,omitempty"`
+}
+
+// Transaction describes a transaction of a scenario. A transaction with only
+// an ID refers to a transaction that was already described.
+type Transaction struct {
+	ID      string   `json:"id"`
+	Inputs  []string `json:"inputs,omitempty"`
+	Outputs []uint64 `json:"outputs,omitempty"`
+	Fee     uint64   `json:"fee,omitempty"`
+}
+
+// Virtual describes the expected state of the virtual
+type Virtual struct {
+	Parents      []string `json:"parents,omitempty"`
+	UTXOs        []*UTXO  `json:"utxos,omitempty"`
+	SpentOutputs []string `json:"spentOutputs,omitempty"`
+}
+
+// UTXO describes an output that is expected to be in the virtual UTXO set.
+// If Amount is 0, the amount of the output isn't checked.
+type UTXO struct {
+	Outpoint string `json:"outpoint"`
+	Amount   uint64 `json:"amount,omitempty"`
+}
+
+// Parse parses a scenario from its JSON representation. Unknown fields are
+// rejected, so that a typo doesn't silently drop an expectation.
+func Parse(reader io.Reader) (*Scenario, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	scenario := &Scenario{}
+	err := decoder.Decode(scenario)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing scenario")
+	}
+	return scenario, nil
+}
+
+// Load parses the scenario in the file at the given path
+func Load(path string) (*Scenario, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scenario, err := Parse(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading %s", path)
+	}
+	return scenario, nil
+}
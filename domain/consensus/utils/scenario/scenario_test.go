@@ -0,0 +1,114 @@
+package scenario_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/scenario"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+)
+
+func TestScenarios(t *testing.T) {
+	paths, err := filepath.Glob("testdata/*.json")
+	if err != nil {
+		t.Fatalf("Glob: %+v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("No scenarios found in testdata")
+	}
+
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			testScenario, err := scenario.Load(path)
+			if err != nil {
+				t.Fatalf("Load: %+v", err)
+			}
+			testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+				consensusConfig.BlockCoinbaseMaturity = 0
+
+				tc, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig, "TestScenarios")
+				if err != nil {
+					t.Fatalf("Error setting up consensus: %+v", err)
+				}
+				defer teardown(false)
+
+				_, err = scenario.Run(tc, testScenario)
+				if err != nil {
+					t.Fatalf("Run: %+v", err)
+				}
+			})
+		})
+	}
+}
+
+func TestRunReportsUnexpectedOutcomes(t *testing.T) {
+	tests := []struct {
+		name          string
+		scenario      string
+		expectedError string
+	}{
+		{
+			name:          "wrong status",
+			scenario:      `{"blocks": [{"id": "A", "parents": ["genesis"], "expectedStatus": "DisqualifiedFromChain"}]}`,
+			expectedError: "expected the block status to be DisqualifiedFromChain, but it's Valid",
+		},
+		{
+			name:          "missing error",
+			scenario:      `{"blocks": [{"id": "A", "parents": ["genesis"], "expectedError": "ErrTimeTooOld"}]}`,
+			expectedError: "expected the insertion to fail with ErrTimeTooOld, but it succeeded",
+		},
+		{
+			name:          "wrong virtual parents",
+			scenario:      `{"blocks": [{"id": "A", "parents": ["genesis"]}], "expectedVirtual": {"parents": ["genesis"]}}`,
+			expectedError: "expected the virtual parents to be [genesis], but they're [A]",
+		},
+		{
+			name: "unspent output",
+			scenario: `{"blocks": [{"id": "A", "parents": ["genesis"]}, {"id": "B", "parents": ["A"]}],
+				"expectedVirtual": {"spentOutputs": ["B.coinbase:0"]}}`,
+			expectedError: "expected B.coinbase:0 not to be in the virtual UTXO set, but it is",
+		},
+		{
+			name:          "unknown parent",
+			scenario:      `{"blocks": [{"id": "A", "parents": ["B"]}]}`,
+			expectedError: "unknown parent B",
+		},
+		{
+			name:          "unknown field",
+			scenario:      `{"blocks": [{"id": "A", "parents": ["genesis"], "expectedStatuss": "Valid"}]}`,
+			expectedError: "unknown field",
+		},
+	}
+
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		for _, test := range tests {
+			tc, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig, "TestRunReportsUnexpectedOutcomes")
+			if err != nil {
+				t.Fatalf("Error setting up consensus: %+v", err)
+			}
+
+			testScenario, err := scenario.Parse(strings.NewReader(test.scenario))
+			if err == nil {
+				_, err = scenario.Run(tc, testScenario)
+			}
+			teardown(false)
+			if err == nil {
+				t.Fatalf("%s: expected the scenario to fail", test.name)
+			}
+			if !strings.Contains(err.Error(), test.expectedError) {
+				t.Fatalf("%s: expected an error containing '%s', got: %s", test.name, test.expectedError, err)
+			}
+		}
+	})
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	_, err := scenario.Load(filepath.Join(os.TempDir(), "nonexistent-scenario.json"))
+	if err == nil {
+		t.Fatalf("Expected loading a missing file to fail")
+	}
+}
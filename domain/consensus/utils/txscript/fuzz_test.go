@@ -0,0 +1,88 @@
+//go:build go1.18
+// +build go1.18
+
+package txscript
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// FuzzScript makes sure that parsing, analyzing and executing arbitrary scripts
+// never panics, and that every script that parses successfully is unparsed back
+// to the same bytes. The seeds are the scripts in script_tests.json.
+func FuzzScript(f *testing.F) {
+	file, err := ioutil.ReadFile("data/script_tests.json")
+	if err != nil {
+		f.Fatalf("ReadFile: %+v", err)
+	}
+	var tests [][]interface{}
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		f.Fatalf("Unmarshal: %+v", err)
+	}
+	for _, test := range tests {
+		// Skip single line comments, and tests that don't parse (these are
+		// tests of the test data format itself)
+		if len(test) < 2 {
+			continue
+		}
+		signatureScriptString, ok1 := test[0].(string)
+		scriptPublicKeyString, ok2 := test[1].(string)
+		if !ok1 || !ok2 {
+			continue
+		}
+		signatureScript, err1 := parseShortForm(signatureScriptString, 0)
+		scriptPublicKey, err2 := parseShortForm(scriptPublicKeyString, 0)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		f.Add(signatureScript, scriptPublicKey)
+	}
+
+	logLevel := log.Level()
+	log.SetLevel(logger.LevelOff)
+	defer log.SetLevel(logLevel)
+
+	sigCache := NewSigCache(10)
+	sigCacheECDSA := NewSigCacheECDSA(10)
+	f.Fuzz(func(t *testing.T, signatureScript []byte, scriptPublicKey []byte) {
+		for _, script := range [][]byte{signatureScript, scriptPublicKey} {
+			_, _ = DisasmString(0, script)
+			_ = GetSigOpCount(script)
+			_ = GetScriptClass(script)
+			_ = IsUnspendable(script)
+			_, _ = PushedData(script)
+
+			parsedScript, err := parseScript(script)
+			if err != nil {
+				continue
+			}
+			unparsedScript, err := unparseScript(parsedScript)
+			if err != nil {
+				t.Fatalf("unparseScript: %+v", err)
+			}
+			if !bytes.Equal(unparsedScript, script) {
+				t.Fatalf("script %x was unparsed to %x", script, unparsedScript)
+			}
+		}
+
+		domainScriptPublicKey := &externalapi.ScriptPublicKey{Script: scriptPublicKey, Version: 0}
+		_ = GetPreciseSigOpCount(signatureScript, domainScriptPublicKey, true)
+		_, _ = CalcScriptInfo(signatureScript, scriptPublicKey, true)
+
+		tx := createSpendingTx(signatureScript, domainScriptPublicKey)
+		vm, err := NewEngine(domainScriptPublicKey, tx, 0, ScriptNoFlags, sigCache, sigCacheECDSA,
+			&consensushashing.SighashReusedValues{})
+		if err != nil {
+			return
+		}
+		_ = vm.Execute()
+	})
+}
@@ -0,0 +1,50 @@
+//go:build go1.18
+// +build go1.18
+
+package utxo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// FuzzDeserializeUTXO makes sure that deserializing arbitrary UTXOs never
+// panics, and that every UTXO that deserializes successfully is serialized
+// back to the same bytes.
+func FuzzDeserializeUTXO(f *testing.F) {
+	script, err := hex.DecodeString("76a914ad06dd6ddee55cbca9a9e3713bd7587509a3056488ac")
+	if err != nil {
+		f.Fatalf("Error decoding scriptPublicKey script string: %s", err)
+	}
+	for _, isCoinbase := range []bool{false, true} {
+		entry := NewUTXOEntry(5000000000, &externalapi.ScriptPublicKey{Script: script, Version: 0}, isCoinbase, 1432432)
+		outpoint := &externalapi.DomainOutpoint{
+			TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0x16, 0x5e}),
+			Index:         0xffffffff,
+		}
+		serialized, err := SerializeUTXO(entry, outpoint)
+		if err != nil {
+			f.Fatalf("SerializeUTXO: %+v", err)
+		}
+		f.Add(serialized)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		entry, outpoint, err := DeserializeUTXO(data)
+		if err != nil {
+			return
+		}
+		serialized, err := SerializeUTXO(entry, outpoint)
+		if err != nil {
+			t.Fatalf("SerializeUTXO: %+v", err)
+		}
+		// DeserializeUTXO ignores trailing bytes, so only the part of data it
+		// consumed is expected to be serialized back
+		if !bytes.HasPrefix(data, serialized) {
+			t.Fatalf("UTXO %x was serialized back to %x", data, serialized)
+		}
+	})
+}
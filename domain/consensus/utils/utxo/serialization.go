@@ -104,7 +104,7 @@ func serializeUTXOEntry(w io.Writer, entry externalapi.UTXOEntry) error {
 	return nil
 }
 
-func deserializeUTXOEntry(r io.Reader) (externalapi.UTXOEntry, error) {
+func deserializeUTXOEntry(r *bytes.Reader) (externalapi.UTXOEntry, error) {
 	var blockDAAScore uint64
 	var amount uint64
 	var isCoinbase bool
@@ -125,6 +125,13 @@ func deserializeUTXOEntry(r io.Reader) (externalapi.UTXOEntry, error) {
 		return nil, err
 	}
 
+	// scriptPubKeyLen isn't trusted before it's checked against the remaining
+	// data, so that corrupt data can't trigger an arbitrarily large allocation
+	if scriptPubKeyLen > uint64(r.Len()) {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "script public key length %d exceeds the remaining %d bytes",
+			scriptPubKeyLen, r.Len())
+	}
+
 	scriptPubKeyScript := make([]byte, scriptPubKeyLen)
 	_, err = io.ReadFull(r, scriptPubKeyScript)
 	if err != nil {
@@ -4,32 +4,43 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 )
 
+// entryOverhead is the estimated memory, in bytes, that a cached UTXO entry
+// takes besides its script: the outpoint key, the map bucket slot, the
+// UTXOEntry struct and the ScriptPublicKey struct.
+// Measured on amd64 it's between 160 and 215 bytes, depending on how full the
+// map's buckets are, so it's rounded up to not overrun the cache's budget
+const entryOverhead = 250
+
 // LRUCache is a least-recently-used cache for UTXO entries
-// indexed by DomainOutpoint
+// indexed by DomainOutpoint. Its capacity is measured in
+// the estimated memory its entries take, in bytes
 type LRUCache struct {
-	cache    map[externalapi.DomainOutpoint]externalapi.UTXOEntry
-	capacity int
+	cache   map[externalapi.DomainOutpoint]externalapi.UTXOEntry
+	size    uint64
+	maxSize uint64
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
 }
 
-// New creates a new LRUCache
-func New(capacity int, preallocate bool) *LRUCache {
-	var cache map[externalapi.DomainOutpoint]externalapi.UTXOEntry
-	if preallocate {
-		cache = make(map[externalapi.DomainOutpoint]externalapi.UTXOEntry, capacity+1)
-	} else {
-		cache = make(map[externalapi.DomainOutpoint]externalapi.UTXOEntry)
-	}
+// New creates a new LRUCache that holds up to maxSize bytes of UTXO entries
+func New(maxSize uint64) *LRUCache {
 	return &LRUCache{
-		cache:    cache,
-		capacity: capacity,
+		cache:   make(map[externalapi.DomainOutpoint]externalapi.UTXOEntry),
+		maxSize: maxSize,
 	}
 }
 
 // Add adds an entry to the LRUCache
 func (c *LRUCache) Add(key *externalapi.DomainOutpoint, value externalapi.UTXOEntry) {
+	if oldValue, ok := c.cache[*key]; ok {
+		c.size -= estimatedSize(oldValue)
+	}
 	c.cache[*key] = value
+	c.size += estimatedSize(value)
 
-	if len(c.cache) > c.capacity {
+	for c.size > c.maxSize && len(c.cache) > 0 {
 		c.evictRandom()
 	}
 }
@@ -38,32 +49,51 @@ func (c *LRUCache) Add(key *externalapi.DomainOutpoint, value externalapi.UTXOEn
 func (c *LRUCache) Get(key *externalapi.DomainOutpoint) (externalapi.UTXOEntry, bool) {
 	value, ok := c.cache[*key]
 	if !ok {
+		c.misses++
 		return nil, false
 	}
+	c.hits++
 	return value, true
 }
 
 // Has returns whether the LRUCache contains the given key
 func (c *LRUCache) Has(key *externalapi.DomainOutpoint) bool {
 	_, ok := c.cache[*key]
-	return ok
+	if !ok {
+		c.misses++
+		return false
+	}
+	c.hits++
+	return true
 }
 
 // Remove removes the entry for the the given key. Does nothing if
 // the entry does not exist
 func (c *LRUCache) Remove(key *externalapi.DomainOutpoint) {
+	value, ok := c.cache[*key]
+	if !ok {
+		return
+	}
 	delete(c.cache, *key)
+	c.size -= estimatedSize(value)
 }
 
 // Clear clears the cache
 func (c *LRUCache) Clear() {
-	keys := make([]externalapi.DomainOutpoint, len(c.cache))
-	for outpoint := range c.cache {
-		keys = append(keys, outpoint)
-	}
+	c.cache = make(map[externalapi.DomainOutpoint]externalapi.UTXOEntry)
+	c.size = 0
+}
 
-	for _, key := range keys {
-		delete(c.cache, key)
+// Stats returns the usage statistics of the LRUCache under the given name
+func (c *LRUCache) Stats(name string) *externalapi.CacheStats {
+	return &externalapi.CacheStats{
+		Name:      name,
+		Entries:   uint64(len(c.cache)),
+		Size:      c.size,
+		Capacity:  c.maxSize,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
 	}
 }
 
@@ -74,4 +104,9 @@ func (c *LRUCache) evictRandom() {
 		break
 	}
 	c.Remove(&keyToEvict)
+	c.evictions++
+}
+
+func estimatedSize(entry externalapi.UTXOEntry) uint64 {
+	return entryOverhead + uint64(len(entry.ScriptPublicKey().Script))
 }
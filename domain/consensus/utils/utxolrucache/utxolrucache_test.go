@@ -0,0 +1,87 @@
+package utxolrucache
+
+import (
+	"encoding/binary"
+	"runtime"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+)
+
+func TestLRUCacheMaxSize(t *testing.T) {
+	entry := utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: make([]byte, 40)}, false, 0)
+	entrySize := estimatedSize(entry)
+	cache := New(10 * entrySize)
+
+	for i := uint32(0); i < 20; i++ {
+		cache.Add(&externalapi.DomainOutpoint{Index: i}, entry)
+	}
+	stats := cache.Stats("test")
+	if stats.Entries != 10 {
+		t.Fatalf("unexpected number of entries: want 10, got %d", stats.Entries)
+	}
+	if stats.Size != 10*entrySize {
+		t.Fatalf("unexpected size: want %d, got %d", 10*entrySize, stats.Size)
+	}
+	if stats.Evictions != 10 {
+		t.Fatalf("unexpected number of evictions: want 10, got %d", stats.Evictions)
+	}
+
+	// Re-adding an existing entry must not change the size
+	for outpoint := range cache.cache {
+		cache.Add(&outpoint, entry)
+		break
+	}
+	if cache.size != 10*entrySize {
+		t.Fatalf("re-adding an entry changed the size: want %d, got %d", 10*entrySize, cache.size)
+	}
+
+	hits := 0
+	for i := uint32(0); i < 20; i++ {
+		if _, ok := cache.Get(&externalapi.DomainOutpoint{Index: i}); ok {
+			hits++
+		}
+	}
+	stats = cache.Stats("test")
+	if stats.Hits != 10 || stats.Misses != 10 || hits != 10 {
+		t.Fatalf("unexpected hits and misses: got %d hits and %d misses", stats.Hits, stats.Misses)
+	}
+
+	cache.Remove(&externalapi.DomainOutpoint{Index: 100})
+	for outpoint := range cache.cache {
+		cache.Remove(&outpoint)
+	}
+	if cache.size != 0 {
+		t.Fatalf("the size of an empty cache is %d", cache.size)
+	}
+}
+
+func TestEstimatedSizeCoversHeapUsage(t *testing.T) {
+	entry := utxo.NewUTXOEntry(1, &externalapi.ScriptPublicKey{Script: make([]byte, 34)}, false, 0)
+
+	for _, entryCount := range []int{10_000, 30_000, 100_000} {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		cache := New(^uint64(0))
+		for i := 0; i < entryCount; i++ {
+			var transactionID [externalapi.DomainHashSize]byte
+			binary.LittleEndian.PutUint64(transactionID[:], uint64(i))
+			outpoint := &externalapi.DomainOutpoint{
+				TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&transactionID),
+			}
+			cache.Add(outpoint, utxo.NewUTXOEntry(entry.Amount(), entry.ScriptPublicKey(), false, 0))
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		heapUsage := after.HeapAlloc - before.HeapAlloc
+		if heapUsage > cache.size {
+			t.Errorf("%d entries take %d bytes of heap, more than their estimated size of %d bytes",
+				entryCount, heapUsage, cache.size)
+		}
+		runtime.KeepAlive(cache)
+	}
+}
@@ -0,0 +1,328 @@
+package dagconfig
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/kaspanet/go-muhash"
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/difficulty"
+	"github.com/pkg/errors"
+)
+
+// NetworkDefinition is the JSON representation of the parameters of a custom
+// network, including its genesis block. Durations are in milliseconds, and
+// byte strings and PowMax are hex encoded.
+type NetworkDefinition struct {
+	Name        string   `json:"name"`
+	Net         uint32   `json:"net"`
+	RPCPort     string   `json:"rpcPort"`
+	DefaultPort string   `json:"defaultPort"`
+	DNSSeeds    []string `json:"dnsSeeds"`
+	GRPCSeeds   []string `json:"grpcSeeds"`
+
+	Prefix       string `json:"prefix"`
+	PrivateKeyID byte   `json:"privateKeyID"`
+
+	Genesis *GenesisDefinition `json:"genesis"`
+
+	K                                       model.KType `json:"k"`
+	PowMax                                  string      `json:"powMax"`
+	BlockCoinbaseMaturity                   uint64      `json:"blockCoinbaseMaturity"`
+	SubsidyReductionInterval                uint64      `json:"subsidyReductionInterval"`
+	BaseSubsidy                             uint64      `json:"baseSubsidy"`
+	TargetTimePerBlockInMilliSeconds        int64       `json:"targetTimePerBlockInMilliSeconds"`
+	FinalityDuration                        int64       `json:"finalityDuration"`
+	TimestampDeviationTolerance             int         `json:"timestampDeviationTolerance"`
+	DifficultyAdjustmentWindowSize          int         `json:"difficultyAdjustmentWindowSize"`
+	RuleChangeActivationThreshold           uint64      `json:"ruleChangeActivationThreshold"`
+	MinerConfirmationWindow                 uint64      `json:"minerConfirmationWindow"`
+	RelayNonStdTxs                          bool        `json:"relayNonStdTxs"`
+	AcceptUnroutable                        bool        `json:"acceptUnroutable"`
+	EnableNonNativeSubnetworks              bool        `json:"enableNonNativeSubnetworks"`
+	DisableDifficultyAdjustment             bool        `json:"disableDifficultyAdjustment"`
+	SkipProofOfWork                         bool        `json:"skipProofOfWork"`
+	MaxCoinbasePayloadLength                uint64      `json:"maxCoinbasePayloadLength"`
+	MaxBlockSize                            uint64      `json:"maxBlockSize"`
+	MaxBlockParents                         model.KType `json:"maxBlockParents"`
+	MassPerTxByte                           uint64      `json:"massPerTxByte"`
+	MassPerScriptPubKeyByte                 uint64      `json:"massPerScriptPubKeyByte"`
+	MassPerSigOp                            uint64      `json:"massPerSigOp"`
+	MergeSetSizeLimit                       uint64      `json:"mergeSetSizeLimit"`
+	MaxMassAcceptedByBlock                  uint64      `json:"maxMassAcceptedByBlock"`
+	CoinbasePayloadScriptPublicKeyMaxLength uint8       `json:"coinbasePayloadScriptPublicKeyMaxLength"`
+}
+
+// GenesisDefinition is the JSON representation of a genesis block. The hash
+// of the block isn't needed to rebuild it, and is kept to verify that the
+// block was rebuilt correctly.
+type GenesisDefinition struct {
+	Hash               string                     `json:"hash"`
+	Version            uint16                     `json:"version"`
+	TimeInMilliseconds int64                      `json:"timeInMilliseconds"`
+	Bits               uint32                     `json:"bits"`
+	Nonce              uint64                     `json:"nonce"`
+	CoinbasePayload    string                     `json:"coinbasePayload"`
+	Outputs            []*GenesisOutputDefinition `json:"outputs"`
+}
+
+// GenesisOutputDefinition is the JSON representation of an output of the coinbase
+// transaction of a genesis block
+type GenesisOutputDefinition struct {
+	Amount                 uint64 `json:"amount"`
+	ScriptPublicKey        string `json:"scriptPublicKey"`
+	ScriptPublicKeyVersion uint16 `json:"scriptPublicKeyVersion"`
+}
+
+// standardNetworks are the networks whose name and magic a custom network
+// may not reuse
+var standardNetworks = []*Params{&MainnetParams, &TestnetParams, &SimnetParams, &DevnetParams}
+
+// GenesisCoinbasePayload returns the payload of the coinbase transaction of a
+// genesis block, with the given extra data appended to it
+func GenesisCoinbasePayload(extraData []byte) []byte {
+	payload := []byte{
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Blue score
+		0x00, 0x00, // Script version
+		0x01, // Varint
+		0x00, // OP-FALSE
+	}
+	return append(payload, extraData...)
+}
+
+// NewGenesisBlock builds a genesis block whose coinbase transaction has the
+// given payload and outputs
+func NewGenesisBlock(version uint16, timeInMilliseconds int64, bits uint32, nonce uint64,
+	coinbasePayload []byte, outputs []*externalapi.DomainTransactionOutput) *externalapi.DomainBlock {
+
+	coinbaseTx := transactionhelper.NewSubnetworkTransaction(0, []*externalapi.DomainTransactionInput{}, outputs,
+		&subnetworks.SubnetworkIDCoinbase, 0, coinbasePayload)
+	transactions := []*externalapi.DomainTransaction{coinbaseTx}
+
+	return &externalapi.DomainBlock{
+		Header: blockheader.NewImmutableBlockHeader(
+			version,
+			[]*externalapi.DomainHash{},
+			merkle.CalculateHashMerkleRoot(transactions),
+			&externalapi.DomainHash{},
+			externalapi.NewDomainHashFromByteArray(muhash.EmptyMuHashHash.AsArray()),
+			timeInMilliseconds,
+			bits,
+			nonce,
+		),
+		Transactions: transactions,
+	}
+}
+
+// NewNetworkDefinition returns the definition of the network with the given
+// params
+func NewNetworkDefinition(params *Params) *NetworkDefinition {
+	header := params.GenesisBlock.Header
+	coinbaseTx := params.GenesisBlock.Transactions[0]
+	outputs := make([]*GenesisOutputDefinition, len(coinbaseTx.Outputs))
+	for i, output := range coinbaseTx.Outputs {
+		outputs[i] = &GenesisOutputDefinition{
+			Amount:                 output.Value,
+			ScriptPublicKey:        hex.EncodeToString(output.ScriptPublicKey.Script),
+			ScriptPublicKeyVersion: output.ScriptPublicKey.Version,
+		}
+	}
+
+	return &NetworkDefinition{
+		Name:         params.Name,
+		Net:          uint32(params.Net),
+		RPCPort:      params.RPCPort,
+		DefaultPort:  params.DefaultPort,
+		DNSSeeds:     params.DNSSeeds,
+		GRPCSeeds:    params.GRPCSeeds,
+		Prefix:       params.Prefix.String(),
+		PrivateKeyID: params.PrivateKeyID,
+		Genesis: &GenesisDefinition{
+			Hash:               consensushashing.BlockHash(params.GenesisBlock).String(),
+			Version:            header.Version(),
+			TimeInMilliseconds: header.TimeInMilliseconds(),
+			Bits:               header.Bits(),
+			Nonce:              header.Nonce(),
+			CoinbasePayload:    hex.EncodeToString(coinbaseTx.Payload),
+			Outputs:            outputs,
+		},
+		K:                                       params.K,
+		PowMax:                                  params.PowMax.Text(16),
+		BlockCoinbaseMaturity:                   params.BlockCoinbaseMaturity,
+		SubsidyReductionInterval:                params.SubsidyReductionInterval,
+		BaseSubsidy:                             params.BaseSubsidy,
+		TargetTimePerBlockInMilliSeconds:        params.TargetTimePerBlock.Milliseconds(),
+		FinalityDuration:                        params.FinalityDuration.Milliseconds(),
+		TimestampDeviationTolerance:             params.TimestampDeviationTolerance,
+		DifficultyAdjustmentWindowSize:          params.DifficultyAdjustmentWindowSize,
+		RuleChangeActivationThreshold:           params.RuleChangeActivationThreshold,
+		MinerConfirmationWindow:                 params.MinerConfirmationWindow,
+		RelayNonStdTxs:                          params.RelayNonStdTxs,
+		AcceptUnroutable:                        params.AcceptUnroutable,
+		EnableNonNativeSubnetworks:              params.EnableNonNativeSubnetworks,
+		DisableDifficultyAdjustment:             params.DisableDifficultyAdjustment,
+		SkipProofOfWork:                         params.SkipProofOfWork,
+		MaxCoinbasePayloadLength:                params.MaxCoinbasePayloadLength,
+		MaxBlockSize:                            params.MaxBlockSize,
+		MaxBlockParents:                         params.MaxBlockParents,
+		MassPerTxByte:                           params.MassPerTxByte,
+		MassPerScriptPubKeyByte:                 params.MassPerScriptPubKeyByte,
+		MassPerSigOp:                            params.MassPerSigOp,
+		MergeSetSizeLimit:                       params.MergeSetSizeLimit,
+		MaxMassAcceptedByBlock:                  params.MaxMassAcceptedByBlock,
+		CoinbasePayloadScriptPublicKeyMaxLength: params.CoinbasePayloadScriptPublicKeyMaxLength,
+	}
+}
+
+// GenesisBlock rebuilds the genesis block of the network. It doesn't verify
+// the block against the hash in the definition.
+func (definition *NetworkDefinition) GenesisBlock() (*externalapi.DomainBlock, error) {
+	genesis := definition.Genesis
+	if genesis == nil {
+		return nil, errors.Errorf("the network definition has no genesis block")
+	}
+
+	coinbasePayload, err := hex.DecodeString(genesis.CoinbasePayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid genesis coinbase payload")
+	}
+	outputs := make([]*externalapi.DomainTransactionOutput, len(genesis.Outputs))
+	for i, output := range genesis.Outputs {
+		script, err := hex.DecodeString(output.ScriptPublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid script public key in genesis output %d", i)
+		}
+		outputs[i] = &externalapi.DomainTransactionOutput{
+			Value:           output.Amount,
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: script, Version: output.ScriptPublicKeyVersion},
+		}
+	}
+
+	return NewGenesisBlock(genesis.Version, genesis.TimeInMilliseconds, genesis.Bits, genesis.Nonce,
+		coinbasePayload, outputs), nil
+}
+
+// Params validates the network definition, and returns the params of the
+// network it defines. As a side effect, the address prefix of the network
+// is registered in the util package.
+func (definition *NetworkDefinition) Params() (*Params, error) {
+	if definition.Name == "" {
+		return nil, errors.Errorf("the network definition has no name")
+	}
+	for _, standardNetwork := range standardNetworks {
+		if definition.Name == standardNetwork.Name {
+			return nil, errors.Errorf("the name %s is taken by a standard network", definition.Name)
+		}
+		if appmessage.KaspaNet(definition.Net) == standardNetwork.Net {
+			return nil, errors.Errorf("the net magic %#08x is taken by %s", definition.Net, standardNetwork.Name)
+		}
+	}
+	if definition.TargetTimePerBlockInMilliSeconds <= 0 {
+		return nil, errors.Errorf("targetTimePerBlockInMilliSeconds must be positive")
+	}
+	if definition.K == 0 {
+		return nil, errors.Errorf("k must be positive")
+	}
+
+	powMax, ok := big.NewInt(0).SetString(definition.PowMax, 16)
+	if !ok {
+		return nil, errors.Errorf("couldn't convert %s to big int", definition.PowMax)
+	}
+
+	genesisBlock, err := definition.GenesisBlock()
+	if err != nil {
+		return nil, err
+	}
+	genesisHash := consensushashing.BlockHash(genesisBlock)
+	if genesisHash.String() != definition.Genesis.Hash {
+		return nil, errors.Errorf("the genesis block hashes to %s, but the network definition says %s",
+			genesisHash, definition.Genesis.Hash)
+	}
+	genesisTarget := difficulty.CompactToBig(genesisBlock.Header.Bits())
+	if genesisTarget.Cmp(powMax) > 0 {
+		return nil, errors.Errorf("the target of the genesis block (%s) is higher than powMax (%s)",
+			genesisTarget.Text(16), powMax.Text(16))
+	}
+	if !definition.SkipProofOfWork && !pow.CheckProofOfWorkByBits(genesisBlock.Header.ToMutable()) {
+		return nil, errors.Errorf("the genesis block has invalid proof of work")
+	}
+
+	prefix, err := util.RegisterBech32Prefix(definition.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Params{
+		K:                                       definition.K,
+		Name:                                    definition.Name,
+		Net:                                     appmessage.KaspaNet(definition.Net),
+		RPCPort:                                 definition.RPCPort,
+		DefaultPort:                             definition.DefaultPort,
+		DNSSeeds:                                definition.DNSSeeds,
+		GRPCSeeds:                               definition.GRPCSeeds,
+		GenesisBlock:                            genesisBlock,
+		GenesisHash:                             genesisHash,
+		PowMax:                                  powMax,
+		BlockCoinbaseMaturity:                   definition.BlockCoinbaseMaturity,
+		SubsidyReductionInterval:                definition.SubsidyReductionInterval,
+		TargetTimePerBlock:                      time.Duration(definition.TargetTimePerBlockInMilliSeconds) * time.Millisecond,
+		FinalityDuration:                        time.Duration(definition.FinalityDuration) * time.Millisecond,
+		TimestampDeviationTolerance:             definition.TimestampDeviationTolerance,
+		DifficultyAdjustmentWindowSize:          definition.DifficultyAdjustmentWindowSize,
+		RuleChangeActivationThreshold:           definition.RuleChangeActivationThreshold,
+		MinerConfirmationWindow:                 definition.MinerConfirmationWindow,
+		RelayNonStdTxs:                          definition.RelayNonStdTxs,
+		AcceptUnroutable:                        definition.AcceptUnroutable,
+		Prefix:                                  prefix,
+		PrivateKeyID:                            definition.PrivateKeyID,
+		EnableNonNativeSubnetworks:              definition.EnableNonNativeSubnetworks,
+		DisableDifficultyAdjustment:             definition.DisableDifficultyAdjustment,
+		SkipProofOfWork:                         definition.SkipProofOfWork,
+		MaxCoinbasePayloadLength:                definition.MaxCoinbasePayloadLength,
+		MaxBlockSize:                            definition.MaxBlockSize,
+		MaxBlockParents:                         definition.MaxBlockParents,
+		MassPerTxByte:                           definition.MassPerTxByte,
+		MassPerScriptPubKeyByte:                 definition.MassPerScriptPubKeyByte,
+		MassPerSigOp:                            definition.MassPerSigOp,
+		MergeSetSizeLimit:                       definition.MergeSetSizeLimit,
+		MaxMassAcceptedByBlock:                  definition.MaxMassAcceptedByBlock,
+		CoinbasePayloadScriptPublicKeyMaxLength: definition.CoinbasePayloadScriptPublicKeyMaxLength,
+		BaseSubsidy:                             definition.BaseSubsidy,
+	}, nil
+}
+
+// LoadNetworkDefinition reads the network definition in the file at the given
+// path, and returns the params of the network it defines
+func LoadNetworkDefinition(path string) (*Params, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	definition := &NetworkDefinition{}
+	err = decoder.Decode(definition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing the network definition in %s", path)
+	}
+
+	params, err := definition.Params()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid network definition in %s", path)
+	}
+	return params, nil
+}
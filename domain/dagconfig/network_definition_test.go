@@ -0,0 +1,127 @@
+package dagconfig_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	. "github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+// customNetworkDefinition returns the definition of a custom network that
+// shares everything with devnet except for what identifies it
+func customNetworkDefinition() *NetworkDefinition {
+	definition := NewNetworkDefinition(&DevnetParams)
+	definition.Name = "kaspa-customnet"
+	definition.Net = 0x0badf00d
+	definition.Prefix = "kaspacustomnet"
+	return definition
+}
+
+func TestNetworkDefinitionRoundTrip(t *testing.T) {
+	params, err := customNetworkDefinition().Params()
+	if err != nil {
+		t.Fatalf("Params: %+v", err)
+	}
+	if !params.GenesisHash.Equal(DevnetParams.GenesisHash) {
+		t.Fatalf("Unexpected genesis hash. Want: %s, got: %s", DevnetParams.GenesisHash, params.GenesisHash)
+	}
+	if params.Prefix.String() != "kaspacustomnet" {
+		t.Fatalf("Unexpected prefix. Want: kaspacustomnet, got: %s", params.Prefix)
+	}
+
+	// Apart from what identifies the network, the params should be identical to devnet's
+	expectedParams := DevnetParams
+	expectedParams.Name = params.Name
+	expectedParams.Net = params.Net
+	expectedParams.Prefix = params.Prefix
+	expectedParams.GenesisBlock = params.GenesisBlock
+	expectedParams.GenesisHash = params.GenesisHash
+	expectedParams.GRPCSeeds = params.GRPCSeeds
+	if !reflect.DeepEqual(*params, expectedParams) {
+		t.Fatalf("The params don't match devnet's params.\nWant: %+v\nGot: %+v", expectedParams, *params)
+	}
+}
+
+func TestLoadNetworkDefinition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "network.json")
+	serializedDefinition, err := json.Marshal(customNetworkDefinition())
+	if err != nil {
+		t.Fatalf("Marshal: %+v", err)
+	}
+	err = os.WriteFile(path, serializedDefinition, 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+
+	params, err := LoadNetworkDefinition(path)
+	if err != nil {
+		t.Fatalf("LoadNetworkDefinition: %+v", err)
+	}
+	if params.Name != "kaspa-customnet" {
+		t.Fatalf("Unexpected name. Want: kaspa-customnet, got: %s", params.Name)
+	}
+}
+
+func TestInvalidNetworkDefinitions(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(definition *NetworkDefinition)
+		expectedError string
+	}{
+		{
+			name:          "standard name",
+			modify:        func(definition *NetworkDefinition) { definition.Name = DevnetParams.Name },
+			expectedError: "taken by a standard network",
+		},
+		{
+			name:          "standard net",
+			modify:        func(definition *NetworkDefinition) { definition.Net = uint32(TestnetParams.Net) },
+			expectedError: "is taken by kaspa-testnet",
+		},
+		{
+			name:          "wrong genesis hash",
+			modify:        func(definition *NetworkDefinition) { definition.Genesis.TimeInMilliseconds++ },
+			expectedError: "the genesis block hashes to",
+		},
+		{
+			name: "unsolved genesis",
+			modify: func(definition *NetworkDefinition) {
+				definition.Genesis.Nonce = 0
+				genesisBlock, err := definition.GenesisBlock()
+				if err != nil {
+					panic(err)
+				}
+				definition.Genesis.Hash = consensushashing.BlockHash(genesisBlock).String()
+			},
+			expectedError: "invalid proof of work",
+		},
+		{
+			name:          "powMax below the genesis target",
+			modify:        func(definition *NetworkDefinition) { definition.PowMax = "1" },
+			expectedError: "is higher than powMax",
+		},
+		{
+			name:          "invalid prefix",
+			modify:        func(definition *NetworkDefinition) { definition.Prefix = "kaspa:custom" },
+			expectedError: "invalid prefix",
+		},
+	}
+
+	for _, test := range tests {
+		definition := customNetworkDefinition()
+		test.modify(definition)
+		_, err := definition.Params()
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.expectedError) {
+			t.Errorf("%s: expected an error containing '%s', but got: %s", test.name, test.expectedError, err)
+		}
+	}
+}
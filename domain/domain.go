@@ -1,10 +1,12 @@
 package domain
 
 import (
+	"github.com/kaspanet/kaspad/domain/blockrecorder"
 	"github.com/kaspanet/kaspad/domain/consensus"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/miningmanager"
 	infrastructuredatabase "github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/util/mstime"
 )
 
 // Domain provides a reference to the domain's external aps
@@ -26,15 +28,28 @@ func (d domain) MiningManager() miningmanager.MiningManager {
 	return d.miningManager
 }
 
-// New instantiates a new instance of a Domain object
-func New(consensusConfig *consensus.Config, db infrastructuredatabase.Database) (Domain, error) {
+// New instantiates a new instance of a Domain object. If recordBlocksPath
+// isn't empty, every block passed to the consensus's ValidateAndInsertBlock is
+// recorded in the recording at that path. The consensus and the mining manager
+// read the current time from clock.
+func New(consensusConfig *consensus.Config, db infrastructuredatabase.Database,
+	recordBlocksPath string, clock mstime.Clock) (Domain, error) {
+
 	consensusFactory := consensus.NewFactory()
+	consensusFactory.SetClock(clock)
 	consensusInstance, err := consensusFactory.NewConsensus(consensusConfig, db)
 	if err != nil {
 		return nil, err
 	}
+	if recordBlocksPath != "" {
+		consensusInstance, err = blockrecorder.New(consensusInstance, recordBlocksPath, consensusConfig.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	miningManagerFactory := miningmanager.NewFactory()
+	miningManagerFactory.SetClock(clock)
 	miningManager := miningManagerFactory.NewMiningManager(consensusInstance, &consensusConfig.Params)
 
 	return &domain{
@@ -5,18 +5,23 @@ import (
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 	"github.com/kaspanet/kaspad/domain/miningmanager/blocktemplatebuilder"
 	mempoolpkg "github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/util/mstime"
 )
 
 // Factory instantiates new mining managers
 type Factory interface {
 	NewMiningManager(consensus externalapi.Consensus, params *dagconfig.Params) MiningManager
+
+	SetClock(clock mstime.Clock)
 }
 
-type factory struct{}
+type factory struct {
+	clock mstime.Clock
+}
 
 // NewMiningManager instantiate a new mining manager
 func (f *factory) NewMiningManager(consensus externalapi.Consensus, params *dagconfig.Params) MiningManager {
-	mempool := mempoolpkg.New(consensus, params)
+	mempool := mempoolpkg.New(consensus, params, f.clock)
 	blockTemplateBuilder := blocktemplatebuilder.New(consensus, mempool, params.MaxMassAcceptedByBlock)
 
 	return &miningManager{
@@ -25,7 +30,15 @@ func (f *factory) NewMiningManager(consensus externalapi.Consensus, params *dagc
 	}
 }
 
+// SetClock sets the clock the mining managers created by the factory read the
+// current time from. It defaults to the system clock.
+func (f *factory) SetClock(clock mstime.Clock) {
+	f.clock = clock
+}
+
 // NewFactory creates a new mining manager factory
 func NewFactory() Factory {
-	return &factory{}
+	return &factory{
+		clock: mstime.SystemClock,
+	}
 }
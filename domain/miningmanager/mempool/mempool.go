@@ -92,11 +92,15 @@ type mempool struct {
 	mtx       sync.RWMutex
 	policy    policy
 	dagParams *dagconfig.Params
+	clock     mstime.Clock
 }
 
 // New returns a new memory pool for validating and storing standalone
-// transactions until they are mined into a block.
-func New(consensus consensusexternalapi.Consensus, dagParams *dagconfig.Params) miningmanagermodel.Mempool {
+// transactions until they are mined into a block. Orphan transactions
+// expire according to the time read from clock.
+func New(consensus consensusexternalapi.Consensus, dagParams *dagconfig.Params,
+	clock mstime.Clock) miningmanagermodel.Mempool {
+
 	policy := policy{
 		MaxTxVersion:    constants.MaxTransactionVersion,
 		AcceptNonStd:    dagParams.RelayNonStdTxs,
@@ -114,8 +118,9 @@ func New(consensus consensusexternalapi.Consensus, dagParams *dagconfig.Params)
 		orphansByPrev:                        make(map[consensusexternalapi.DomainOutpoint]map[consensusexternalapi.DomainTransactionID]*consensusexternalapi.DomainTransaction),
 		mempoolUTXOSet:                       newMempoolUTXOSet(),
 		consensus:                            consensus,
-		nextExpireScan:                       mstime.Now().Add(orphanExpireScanInterval),
+		nextExpireScan:                       clock.Now().Add(orphanExpireScanInterval),
 		dagParams:                            dagParams,
+		clock:                                clock,
 	}
 }
 
@@ -155,6 +160,24 @@ func (mp *mempool) TransactionCount() int {
 	return len(mp.pool) + len(mp.chainedTransactions)
 }
 
+func (mp *mempool) OrphanCount() int {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return len(mp.orphans)
+}
+
+// SetPolicy sets the minimum fee of relayed transactions and the maximum
+// number of orphan transactions. Orphans above the new maximum are evicted
+// the next time an orphan is added.
+func (mp *mempool) SetPolicy(minRelayTxFee util.Amount, maxOrphanTxs int) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	mp.policy.MinRelayTxFee = minRelayTxFee
+	mp.policy.MaxOrphanTxs = maxOrphanTxs
+}
+
 // txDescriptor is a descriptor containing a transaction in the mempool along with
 // additional metadata.
 type txDescriptor struct {
@@ -223,7 +246,7 @@ func (mp *mempool) limitNumOrphans() error {
 	// Scan through the orphan pool and remove any expired orphans when it's
 	// time. This is done for efficiency so the scan only happens
 	// periodically instead of on every orphan added to the pool.
-	if now := mstime.Now(); now.After(mp.nextExpireScan) {
+	if now := mp.clock.Now(); now.After(mp.nextExpireScan) {
 		origNumOrphans := len(mp.orphans)
 		for _, otx := range mp.orphans {
 			if now.After(otx.expiration) {
@@ -282,7 +305,7 @@ func (mp *mempool) addOrphan(tx *consensusexternalapi.DomainTransaction) {
 	txID := consensushashing.TransactionID(tx)
 	mp.orphans[*txID] = &orphanTx{
 		tx:         tx,
-		expiration: mstime.Now().Add(orphanTTL),
+		expiration: mp.clock.Now().Add(orphanTTL),
 	}
 	for _, txIn := range tx.Inputs {
 		if _, exists := mp.orphansByPrev[txIn.PreviousOutpoint]; !exists {
@@ -0,0 +1,44 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	consensusexternalapi "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func createOrphan(i byte) *consensusexternalapi.DomainTransaction {
+	previousTransactionID := consensusexternalapi.NewDomainTransactionIDFromByteArray(
+		&[consensusexternalapi.DomainHashSize]byte{i})
+	return &consensusexternalapi.DomainTransaction{
+		Inputs: []*consensusexternalapi.DomainTransactionInput{{
+			PreviousOutpoint: *consensusexternalapi.NewDomainOutpoint(previousTransactionID, 0),
+		}},
+		SubnetworkID: subnetworks.SubnetworkIDNative,
+	}
+}
+
+// TestOrphanExpiry checks that orphans expire according to the mempool's clock
+func TestOrphanExpiry(t *testing.T) {
+	clock := mstime.NewFakeClock(mstime.Now().Add(-30 * 24 * time.Hour))
+	mp := New(nil, &dagconfig.SimnetParams, clock).(*mempool)
+
+	expiringOrphan := createOrphan(1)
+	mp.addOrphan(expiringOrphan)
+
+	// The scan that expires orphans only happens when an orphan is added
+	clock.Advance(orphanTTL + orphanExpireScanInterval)
+	mp.addOrphan(createOrphan(2))
+
+	_, exists := mp.orphans[*consensushashing.TransactionID(expiringOrphan)]
+	if exists {
+		t.Fatalf("Expected the orphan to expire after %s", orphanTTL)
+	}
+	if len(mp.orphans) != 1 {
+		t.Fatalf("Expected only the new orphan to remain, but got %d orphans", len(mp.orphans))
+	}
+}
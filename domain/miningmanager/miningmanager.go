@@ -3,6 +3,7 @@ package miningmanager
 import (
 	consensusexternalapi "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/util"
 )
 
 // MiningManager creates block templates for mining as well as maintaining
@@ -12,8 +13,10 @@ type MiningManager interface {
 	GetTransaction(transactionID *consensusexternalapi.DomainTransactionID) (*consensusexternalapi.DomainTransaction, bool)
 	AllTransactions() []*consensusexternalapi.DomainTransaction
 	TransactionCount() int
+	OrphanCount() int
 	HandleNewBlockTransactions(txs []*consensusexternalapi.DomainTransaction) ([]*consensusexternalapi.DomainTransaction, error)
 	ValidateAndInsertTransaction(transaction *consensusexternalapi.DomainTransaction, allowOrphan bool) error
+	SetMempoolPolicy(minRelayTxFee util.Amount, maxOrphanTxs int)
 }
 
 type miningManager struct {
@@ -21,6 +24,20 @@ type miningManager struct {
 	blockTemplateBuilder miningmanagermodel.BlockTemplateBuilder
 }
 
+// BlockFromTemplate returns a copy of the given block template that can be added
+// to the DAG. The template's transactions are shared with the mempool, and their
+// inputs are populated with UTXO entries, which a block that's added to the DAG
+// may not have
+func BlockFromTemplate(templateBlock *consensusexternalapi.DomainBlock) *consensusexternalapi.DomainBlock {
+	block := templateBlock.Clone()
+	for _, transaction := range block.Transactions {
+		for _, input := range transaction.Inputs {
+			input.UTXOEntry = nil
+		}
+	}
+	return block
+}
+
 // GetBlockTemplate creates a block template for a miner to consume
 func (mm *miningManager) GetBlockTemplate(coinbaseData *consensusexternalapi.DomainCoinbaseData) (*consensusexternalapi.DomainBlock, error) {
 	return mm.blockTemplateBuilder.GetBlockTemplate(coinbaseData)
@@ -51,3 +68,13 @@ func (mm *miningManager) AllTransactions() []*consensusexternalapi.DomainTransac
 func (mm *miningManager) TransactionCount() int {
 	return mm.mempool.TransactionCount()
 }
+
+func (mm *miningManager) OrphanCount() int {
+	return mm.mempool.OrphanCount()
+}
+
+// SetMempoolPolicy sets the minimum fee of transactions relayed by the
+// mempool and the maximum number of orphan transactions it keeps
+func (mm *miningManager) SetMempoolPolicy(minRelayTxFee util.Amount, maxOrphanTxs int) {
+	mm.mempool.SetPolicy(minRelayTxFee, maxOrphanTxs)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
@@ -318,6 +319,29 @@ func TestOrphanTransactions(t *testing.T) {
 	})
 }
 
+func TestBlockFromTemplate(t *testing.T) {
+	transaction := createTransactionWithUTXOEntry(t, 0)
+	templateBlock := &externalapi.DomainBlock{
+		Header:       blockheader.NewImmutableBlockHeader(0, nil, &externalapi.DomainHash{}, &externalapi.DomainHash{}, &externalapi.DomainHash{}, 0, 0, 0),
+		Transactions: []*externalapi.DomainTransaction{transaction},
+	}
+
+	block := miningmanager.BlockFromTemplate(templateBlock)
+	if !consensushashing.BlockHash(block).Equal(consensushashing.BlockHash(templateBlock)) {
+		t.Fatalf("The block has a different hash than its template")
+	}
+	for _, input := range block.Transactions[0].Inputs {
+		if input.UTXOEntry != nil {
+			t.Fatalf("Expected the UTXO entries of the block's inputs to be removed")
+		}
+	}
+	for _, input := range transaction.Inputs {
+		if input.UTXOEntry == nil {
+			t.Fatalf("Expected the UTXO entries of the template's transactions to be kept")
+		}
+	}
+}
+
 func createTransactionWithUTXOEntry(t *testing.T, i int) *externalapi.DomainTransaction {
 	prevOutTxID := externalapi.DomainTransactionID{}
 	prevOutPoint := externalapi.DomainOutpoint{TransactionID: prevOutTxID, Index: uint32(i)}
@@ -2,6 +2,7 @@ package model
 
 import (
 	consensusexternalapi "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/util"
 )
 
 // Mempool maintains a set of known transactions that
@@ -14,4 +15,6 @@ type Mempool interface {
 	GetTransaction(transactionID *consensusexternalapi.DomainTransactionID) (*consensusexternalapi.DomainTransaction, bool)
 	AllTransactions() []*consensusexternalapi.DomainTransaction
 	TransactionCount() int
+	OrphanCount() int
+	SetPolicy(minRelayTxFee util.Amount, maxOrphanTxs int)
 }
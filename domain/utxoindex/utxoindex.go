@@ -40,6 +40,18 @@ func New(consensus externalapi.Consensus, database database.Database) (*UTXOInde
 	return utxoIndex, nil
 }
 
+// Rebuild deletes the UTXO index in the given database and resyncs it
+// from consensus, whether or not it's synced.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func Rebuild(consensus externalapi.Consensus, database database.Database) error {
+	utxoIndex := &UTXOIndex{
+		consensus: consensus,
+		store:     newUTXOIndexStore(database),
+	}
+	return utxoIndex.Reset()
+}
+
 // Reset deletes the whole UTXO index and resyncs it from consensus.
 func (ui *UTXOIndex) Reset() error {
 	err := ui.store.deleteAll()
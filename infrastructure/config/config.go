@@ -31,6 +31,7 @@ const (
 	defaultConfigFilename      = "kaspad.conf"
 	defaultDataDirname         = "data"
 	defaultLogLevel            = "info"
+	defaultLogFormat           = "text"
 	defaultLogDirname          = "logs"
 	defaultLogFilename         = "kaspad.log"
 	defaultErrLogFilename      = "kaspad_err.log"
@@ -49,10 +50,13 @@ const (
 	defaultMinRelayTxFee         = 1e-5 // 1 sompi per byte
 	defaultMaxOrphanTransactions = 100
 	//DefaultMaxOrphanTxSize is the default maximum size for an orphan transaction
-	DefaultMaxOrphanTxSize  = 100000
-	defaultSigCacheMaxSize  = 100000
-	sampleConfigFilename    = "sample-kaspad.conf"
-	defaultMaxUTXOCacheSize = 5000000000
+	DefaultMaxOrphanTxSize      = 100000
+	defaultSigCacheMaxSize      = 100000
+	sampleConfigFilename        = "sample-kaspad.conf"
+	defaultMaxUTXOCacheSize     = 0
+	defaultDbType               = "leveldb"
+	defaultDbCacheSizeMiB       = 256
+	defaultDbWriteBufferSizeMiB = 128
 )
 
 var (
@@ -104,10 +108,18 @@ type Flags struct {
 	Proxy                           string        `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser                       string        `long:"proxyuser" description:"Username for proxy server"`
 	ProxyPass                       string        `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
-	DbType                          string        `long:"dbtype" description:"Database backend to use for the Block DAG"`
+	DbType                          string        `long:"dbtype" description:"Database backend to use for the Block DAG {leveldb, logdb, memory}"`
+	DbCacheSizeMiB                  int           `long:"dbcachesize" description:"Size of the database block cache in MiB"`
+	DbWriteBufferSizeMiB            int           `long:"dbwritebuffersize" description:"Amount of written data in MiB that the database keeps in memory before flushing it to disk"`
+	DbCompression                   bool          `long:"dbcompression" description:"Compress the database using Snappy"`
+	DbSync                          bool          `long:"dbsync" description:"Sync every database write to disk. Slower, but keeps the database intact after a power loss or an operating system crash"`
+	DbSeeksCompaction               bool          `long:"dbseekscompaction" description:"Compact database files that are often read through while seeking other files"`
 	Profile                         string        `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	MetricsListen                   string        `long:"metricslisten" description:"Serve Prometheus metrics at /metrics on the given interface/port (eg. 127.0.0.1:16120)"`
+	HealthListen                    string        `long:"healthlisten" description:"Serve the health and the readiness of the node at /health and /ready on the given interface/port (eg. 127.0.0.1:16121)"`
 	LogLevel                        string        `short:"d" long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
-	Upnp                            bool          `long:"upnp" description:"Use UPnP to map our listening port outside of NAT"`
+	LogFormat                       string        `long:"logformat" description:"Format of the log output {text, json} -- The json format writes every log entry as a JSON object with structured fields"`
+	Upnp                            bool          `long:"upnp" description:"Use UPnP or NAT-PMP to map our listening port outside of NAT"`
 	MinRelayTxFee                   float64       `long:"minrelaytxfee" description:"The minimum transaction fee in KAS/kB to be considered a non-zero fee."`
 	MaxOrphanTxs                    int           `long:"maxorphantx" description:"Max number of orphan transactions to keep in memory"`
 	BlockMaxMass                    uint64        `long:"blockmaxmass" description:"Maximum transaction mass to be used when creating a block"`
@@ -118,9 +130,13 @@ type Flags struct {
 	RelayNonStd                     bool          `long:"relaynonstd" description:"Relay non-standard transactions regardless of the default settings for the active network."`
 	RejectNonStd                    bool          `long:"rejectnonstd" description:"Reject non-standard transactions regardless of the default settings for the active network."`
 	ResetDatabase                   bool          `long:"reset-db" description:"Reset database before starting node. It's needed when switching between subnetworks."`
-	MaxUTXOCacheSize                uint64        `long:"maxutxocachesize" description:"Max size of loaded UTXO into ram from the disk in bytes"`
+	MigrationBackup                 bool          `long:"migrationbackup" description:"Back up the database before migrating it to the database version of this kaspad"`
+	MigrationDryRun                 bool          `long:"migrationdryrun" description:"Run the pending database migrations on a temporary copy of the database and exit"`
+	RestoreSnapshot                 string        `long:"restoresnapshot" description:"Restore the database from the snapshot in the given directory, which was created by the CreateSnapshot RPC. The database must not already exist -- Use --reset-db to remove it"`
+	MaxUTXOCacheSize                uint64        `long:"maxutxocachesize" description:"Max memory, in bytes, used by the consensus caches, including the UTXO set cache (0 to use the built-in cache sizes)"`
 	UTXOIndex                       bool          `long:"utxoindex" description:"Enable the UTXO index"`
 	IsArchivalNode                  bool          `long:"archival" description:"Run as an archival node: don't delete old block data when moving the pruning point (Warning: heavy disk usage)'"`
+	RecordBlocks                    string        `long:"recordblocks" description:"Record every block inserted into the DAG, along with the outcome of its insertion, in the given file, for replaying with kaspareplay -- Start recording from an empty data directory, since blocks received before the pruning point during IBD can't be replayed"`
 	EnableSanityCheckPruningUTXOSet bool          `long:"enable-sanity-check-pruning-utxo" hidden:"true" description:"When moving the pruning point - check that the utxo set matches the utxo commitment"`
 	NetworkFlags
 	ServiceOptions *ServiceOptions
@@ -172,6 +188,7 @@ func defaultFlags() *Flags {
 	return &Flags{
 		ConfigFile:           defaultConfigFile,
 		LogLevel:             defaultLogLevel,
+		LogFormat:            defaultLogFormat,
 		TargetOutboundPeers:  defaultTargetOutboundPeers,
 		MaxInboundPeers:      defaultMaxInboundPeers,
 		BanDuration:          defaultBanDuration,
@@ -187,6 +204,9 @@ func defaultFlags() *Flags {
 		SigCacheMaxSize:      defaultSigCacheMaxSize,
 		MinRelayTxFee:        defaultMinRelayTxFee,
 		MaxUTXOCacheSize:     defaultMaxUTXOCacheSize,
+		DbType:               defaultDbType,
+		DbCacheSizeMiB:       defaultDbCacheSizeMiB,
+		DbWriteBufferSizeMiB: defaultDbWriteBufferSizeMiB,
 		ServiceOptions:       &ServiceOptions{},
 	}
 }
@@ -329,12 +349,28 @@ func LoadConfig() (*Config, error) {
 	}
 	cfg.LogDir = cleanAndExpandPath(cfg.LogDir)
 
+	if cfg.RestoreSnapshot != "" {
+		cfg.RestoreSnapshot = cleanAndExpandPath(cfg.RestoreSnapshot)
+	}
+
+	if cfg.RecordBlocks != "" {
+		cfg.RecordBlocks = cleanAndExpandPath(cfg.RecordBlocks)
+	}
+
 	// Special show command to list supported subsystems and exit.
 	if cfg.LogLevel == "show" {
 		fmt.Println("Supported subsystems", logger.SupportedSubsystems())
 		os.Exit(0)
 	}
 
+	// Set the log format before the logger starts running.
+	if err := logger.SetLogFormat(cfg.LogFormat); err != nil {
+		err := errors.Errorf("%s: %s", funcName, err.Error())
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
 	// Initialize log rotation. After log rotation has been initialized, the
 	// logger variables may be used.
 	logger.InitLog(filepath.Join(cfg.LogDir, defaultLogFilename), filepath.Join(cfg.LogDir, defaultErrLogFilename))
@@ -359,6 +395,46 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// Validate the database cache and write buffer sizes
+	if cfg.DbCacheSizeMiB < 1 {
+		str := "%s: The dbcachesize option must be at least 1 -- parsed [%d]"
+		err := errors.Errorf(str, funcName, cfg.DbCacheSizeMiB)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.DbWriteBufferSizeMiB < 1 {
+		str := "%s: The dbwritebuffersize option must be at least 1 -- parsed [%d]"
+		err := errors.Errorf(str, funcName, cfg.DbWriteBufferSizeMiB)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Validate the metrics listen address
+	if cfg.MetricsListen != "" {
+		_, _, err := net.SplitHostPort(cfg.MetricsListen)
+		if err != nil {
+			str := "%s: The metricslisten option must be of the form host:port -- parsed [%s]"
+			err := errors.Errorf(str, funcName, cfg.MetricsListen)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
+	// Validate the health listen address
+	if cfg.HealthListen != "" {
+		_, _, err := net.SplitHostPort(cfg.HealthListen)
+		if err != nil {
+			str := "%s: The healthlisten option must be of the form host:port -- parsed [%s]"
+			err := errors.Errorf(str, funcName, cfg.HealthListen)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Don't allow ban durations that are too short.
 	if cfg.BanDuration < time.Second {
 		str := "%s: The banduration option may not be less than 1s -- parsed [%s]"
@@ -369,35 +445,12 @@ func LoadConfig() (*Config, error) {
 	}
 
 	// Validate any given whitelisted IP addresses and networks.
-	if len(cfg.Whitelists) > 0 {
-		var ip net.IP
-		cfg.Whitelists = make([]*net.IPNet, 0, len(cfg.Flags.Whitelists))
-
-		for _, addr := range cfg.Flags.Whitelists {
-			_, ipnet, err := net.ParseCIDR(addr)
-			if err != nil {
-				ip = net.ParseIP(addr)
-				if ip == nil {
-					str := "%s: The whitelist value of '%s' is invalid"
-					err = errors.Errorf(str, funcName, addr)
-					fmt.Fprintln(os.Stderr, err)
-					fmt.Fprintln(os.Stderr, usageMessage)
-					return nil, err
-				}
-				var bits int
-				if ip.To4() == nil {
-					// IPv6
-					bits = 128
-				} else {
-					bits = 32
-				}
-				ipnet = &net.IPNet{
-					IP:   ip,
-					Mask: net.CIDRMask(bits, bits),
-				}
-			}
-			cfg.Whitelists = append(cfg.Whitelists, ipnet)
-		}
+	cfg.Whitelists, err = parseWhitelists(cfg.Flags.Whitelists)
+	if err != nil {
+		err := errors.Errorf("%s: %s", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
 	}
 
 	// --addPeer and --connect do not mix.
@@ -580,6 +633,37 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// parseWhitelists parses the given whitelisted IP addresses and networks
+func parseWhitelists(whitelists []string) ([]*net.IPNet, error) {
+	if len(whitelists) == 0 {
+		return nil, nil
+	}
+
+	parsedWhitelists := make([]*net.IPNet, 0, len(whitelists))
+	for _, addr := range whitelists {
+		_, ipnet, err := net.ParseCIDR(addr)
+		if err != nil {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return nil, errors.Errorf("The whitelist value of '%s' is invalid", addr)
+			}
+			var bits int
+			if ip.To4() == nil {
+				// IPv6
+				bits = 128
+			} else {
+				bits = 32
+			}
+			ipnet = &net.IPNet{
+				IP:   ip,
+				Mask: net.CIDRMask(bits, bits),
+			}
+		}
+		parsedWhitelists = append(parsedWhitelists, ipnet)
+	}
+	return parsedWhitelists, nil
+}
+
 // createDefaultConfig copies the file sample-kaspad.conf to the given destination path,
 // and populates it with some randomly generated RPC username and password.
 func createDefaultConfigFile(destinationPath string) error {
@@ -20,6 +20,7 @@ type NetworkFlags struct {
 	Simnet                bool   `long:"simnet" description:"Use the simulation test network"`
 	Devnet                bool   `long:"devnet" description:"Use the development test network"`
 	OverrideDAGParamsFile string `long:"override-dag-params-file" description:"Overrides DAG params (allowed only on devnet)"`
+	NetworkDefinitionFile string `long:"network-definition-file" description:"Use the custom network defined in the given file, as generated by kaspa-netgen"`
 
 	ActiveNetParams *dagconfig.Params
 }
@@ -71,6 +72,14 @@ func (networkFlags *NetworkFlags) ResolveNetwork(parser *flags.Parser) error {
 		numNets++
 		networkFlags.ActiveNetParams = &dagconfig.DevnetParams
 	}
+	if networkFlags.NetworkDefinitionFile != "" {
+		numNets++
+		params, err := dagconfig.LoadNetworkDefinition(networkFlags.NetworkDefinitionFile)
+		if err != nil {
+			return err
+		}
+		networkFlags.ActiveNetParams = params
+	}
 	if numNets > 1 {
 		message := "Multiple networks parameters (testnet, simnet, devnet, etc.) cannot be used" +
 			"together. Please choose only one network"
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"net"
+	"os"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/network"
+	"github.com/pkg/errors"
+)
+
+// ReloadableConfig is the part of the configuration that can change
+// while kaspad is running
+type ReloadableConfig struct {
+	LogLevel       string
+	AddPeers       []string
+	ConnectPeers   []string
+	DisableBanning bool
+	BanDuration    time.Duration
+	Whitelists     []*net.IPNet
+	MinRelayTxFee  util.Amount
+	MaxOrphanTxs   int
+}
+
+// Reloadable returns the part of cfg that can change while kaspad is running
+func (cfg *Config) Reloadable() *ReloadableConfig {
+	return &ReloadableConfig{
+		LogLevel:       cfg.LogLevel,
+		AddPeers:       cfg.AddPeers,
+		ConnectPeers:   cfg.ConnectPeers,
+		DisableBanning: cfg.DisableBanning,
+		BanDuration:    cfg.BanDuration,
+		Whitelists:     cfg.Whitelists,
+		MinRelayTxFee:  cfg.MinRelayTxFee,
+		MaxOrphanTxs:   cfg.MaxOrphanTxs,
+	}
+}
+
+// ReloadConfig parses the configuration file and the command line options of
+// cfg again, and returns the part of the resulting configuration that can
+// change while kaspad is running. cfg itself isn't changed.
+//
+// An error is returned if any of the reloadable options is invalid, or if the
+// node would have to switch between --addpeer and --connect, which requires
+// a restart. Options that can't change while kaspad is running are ignored.
+func ReloadConfig(cfg *Config) (*ReloadableConfig, error) {
+	return reloadConfig(cfg, os.Args[1:])
+}
+
+func reloadConfig(cfg *Config, args []string) (*ReloadableConfig, error) {
+	cfgFlags := defaultFlags()
+	parser := newConfigParser(cfgFlags, flags.None)
+	if !cfg.Simnet || cfg.ConfigFile != defaultConfigFile {
+		err := flags.NewIniParser(parser).ParseFile(cfg.ConfigFile)
+		if err != nil {
+			if pErr := &(os.PathError{}); !errors.As(err, &pErr) {
+				return nil, errors.Wrapf(err, "Error parsing config file")
+			}
+		}
+	}
+
+	// Parse command line options again to ensure they take precedence.
+	_, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error parsing command line arguments")
+	}
+
+	reloadableConfig, err := parseReloadableConfig(cfgFlags, cfg.NetParams().DefaultPort)
+	if err != nil {
+		return nil, err
+	}
+
+	if (len(cfg.ConnectPeers) > 0) != (len(reloadableConfig.ConnectPeers) > 0) {
+		return nil, errors.New("switching between --addpeer and --connect requires a restart")
+	}
+	return reloadableConfig, nil
+}
+
+// parseReloadableConfig validates the reloadable options in cfgFlags, and
+// returns them parsed and normalized the same way LoadConfig does
+func parseReloadableConfig(cfgFlags *Flags, defaultPort string) (*ReloadableConfig, error) {
+	err := logger.ValidateLogLevels(cfgFlags.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfgFlags.BanDuration < time.Second {
+		return nil, errors.Errorf("The banduration option may not be less than 1s -- parsed [%s]",
+			cfgFlags.BanDuration)
+	}
+
+	whitelists, err := parseWhitelists(cfgFlags.Whitelists)
+	if err != nil {
+		return nil, err
+	}
+
+	minRelayTxFee, err := util.NewAmount(cfgFlags.MinRelayTxFee)
+	if err != nil {
+		return nil, errors.Errorf("invalid minrelaytxfee: %s", err)
+	}
+	if minRelayTxFee == 0 {
+		return nil, errors.Errorf("The minrelaytxfee option must be greater than 0 -- parsed [%d]",
+			minRelayTxFee)
+	}
+
+	if cfgFlags.MaxOrphanTxs < 0 {
+		return nil, errors.Errorf("The maxorphantx option may not be less than 0 -- parsed [%d]",
+			cfgFlags.MaxOrphanTxs)
+	}
+
+	if len(cfgFlags.AddPeers) > 0 && len(cfgFlags.ConnectPeers) > 0 {
+		return nil, errors.New("--addpeer and --connect can not be used together")
+	}
+	addPeers, err := network.NormalizeAddresses(cfgFlags.AddPeers, defaultPort)
+	if err != nil {
+		return nil, err
+	}
+	connectPeers, err := network.NormalizeAddresses(cfgFlags.ConnectPeers, defaultPort)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReloadableConfig{
+		LogLevel:       cfgFlags.LogLevel,
+		AddPeers:       addPeers,
+		ConnectPeers:   connectPeers,
+		DisableBanning: cfgFlags.DisableBanning,
+		BanDuration:    cfgFlags.BanDuration,
+		Whitelists:     whitelists,
+		MinRelayTxFee:  minRelayTxFee,
+		MaxOrphanTxs:   cfgFlags.MaxOrphanTxs,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/util"
+)
+
+func TestReloadConfig(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "TestReloadConfig")
+	if err != nil {
+		t.Fatalf("Failed creating a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tests := []struct {
+		name                string
+		configFile          string
+		args                []string
+		runningConnectPeers []string
+		expectedError       string
+		expected            *ReloadableConfig
+	}{
+		{
+			name: "valid config file",
+			configFile: "loglevel=CNFG=trace\naddpeer=1.2.3.4\nnobanning=1\nbanduration=1h\n" +
+				"whitelist=10.0.0.0/8\nwhitelist=::1\nminrelaytxfee=0.0001\nmaxorphantx=7\n",
+			expected: &ReloadableConfig{
+				LogLevel:       "CNFG=trace",
+				AddPeers:       []string{"1.2.3.4:16111"},
+				ConnectPeers:   []string{},
+				DisableBanning: true,
+				BanDuration:    time.Hour,
+				Whitelists:     mustParseWhitelists(t, "10.0.0.0/8", "::1"),
+				MinRelayTxFee:  util.Amount(10000),
+				MaxOrphanTxs:   7,
+			},
+		},
+		{
+			name:                "command line options take precedence",
+			configFile:          "maxorphantx=7\n",
+			args:                []string{"--maxorphantx=9", "--connect=5.6.7.8:1234"},
+			runningConnectPeers: []string{"1.2.3.4:16111"},
+			expected: &ReloadableConfig{
+				LogLevel:      defaultLogLevel,
+				AddPeers:      []string{},
+				ConnectPeers:  []string{"5.6.7.8:1234"},
+				BanDuration:   defaultBanDuration,
+				MinRelayTxFee: util.Amount(1000),
+				MaxOrphanTxs:  9,
+			},
+		},
+		{
+			name:          "invalid log level",
+			configFile:    "loglevel=NOPE=trace\n",
+			expectedError: "The specified subsystem [NOPE] is invalid",
+		},
+		{
+			name:          "invalid whitelist",
+			configFile:    "whitelist=not-an-ip\n",
+			expectedError: "The whitelist value of 'not-an-ip' is invalid",
+		},
+		{
+			name:          "too short ban duration",
+			configFile:    "banduration=1ms\n",
+			expectedError: "The banduration option may not be less than 1s",
+		},
+		{
+			name:          "zero min relay fee",
+			configFile:    "minrelaytxfee=0\n",
+			expectedError: "The minrelaytxfee option must be greater than 0",
+		},
+		{
+			name:                "switching from --connect to --addpeer",
+			configFile:          "addpeer=1.2.3.4\n",
+			runningConnectPeers: []string{"1.2.3.4:16111"},
+			expectedError:       "switching between --addpeer and --connect requires a restart",
+		},
+	}
+
+	for _, test := range tests {
+		configFile := filepath.Join(tmpDir, "kaspad.conf")
+		err := ioutil.WriteFile(configFile, []byte(test.configFile), 0600)
+		if err != nil {
+			t.Fatalf("Failed writing the config file: %v", err)
+		}
+
+		cfg := DefaultConfig()
+		cfg.ConfigFile = configFile
+		cfg.ConnectPeers = test.runningConnectPeers
+
+		reloadableConfig, err := reloadConfig(cfg, test.args)
+		if test.expectedError != "" {
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf("%s: expected error containing '%s', got: %v", test.name, test.expectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: reloadConfig: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(reloadableConfig, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, reloadableConfig)
+		}
+	}
+}
+
+func mustParseWhitelists(t *testing.T, whitelists ...string) []*net.IPNet {
+	parsedWhitelists, err := parseWhitelists(whitelists)
+	if err != nil {
+		t.Fatalf("parseWhitelists: %v", err)
+	}
+	return parsedWhitelists
+}
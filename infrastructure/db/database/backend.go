@@ -0,0 +1,58 @@
+package database
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// OpenFunc opens the database in the given path, creating it
+// if it doesn't exist
+type OpenFunc func(path string, options *Options) (Database, error)
+
+var (
+	backends     = make(map[string]OpenFunc)
+	backendsLock sync.RWMutex
+)
+
+// RegisterBackend makes a database backend available under the given
+// name. It is meant to be called from the init function of the package
+// that implements the backend, and panics if the name is already taken.
+func RegisterBackend(name string, open OpenFunc) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	if _, ok := backends[name]; ok {
+		panic(errors.Errorf("database backend %s is already registered", name))
+	}
+	backends[name] = open
+}
+
+// Open opens the database in the given path using the backend
+// that was registered under backendName
+func Open(backendName string, path string, options *Options) (Database, error) {
+	backendsLock.RLock()
+	open, ok := backends[backendName]
+	backendsLock.RUnlock()
+
+	if !ok {
+		return nil, errors.Errorf("unknown database backend %s. Available backends: %s",
+			backendName, strings.Join(Backends(), ", "))
+	}
+	return open(path, options)
+}
+
+// Backends returns the names of all the registered backends, sorted
+func Backends() []string {
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,40 @@
+package database_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestOpen(t *testing.T) {
+	expectedBackends := []string{"leveldb", "logdb", "memory"}
+	if !reflect.DeepEqual(database.Backends(), expectedBackends) {
+		t.Fatalf("TestOpen: unexpected backends. Want: %s, got: %s", expectedBackends, database.Backends())
+	}
+
+	for _, backend := range database.Backends() {
+		path, err := ioutil.TempDir("", "TestOpen")
+		if err != nil {
+			t.Fatalf("TestOpen: TempDir unexpectedly "+
+				"failed: %s", err)
+		}
+		db, err := database.Open(backend, path, database.DefaultOptions(8))
+		if err != nil {
+			t.Fatalf("TestOpen: Open %s unexpectedly "+
+				"failed: %s", backend, err)
+		}
+		err = db.Close()
+		if err != nil {
+			t.Fatalf("TestOpen: Close %s unexpectedly "+
+				"failed: %s", backend, err)
+		}
+	}
+
+	_, err := database.Open("doesn't exist", "", database.DefaultOptions(8))
+	if err == nil {
+		t.Fatalf("TestOpen: Open unexpectedly " +
+			"succeeded for an unknown backend")
+	}
+}
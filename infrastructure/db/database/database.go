@@ -14,6 +14,10 @@ type Database interface {
 	// Begin begins a new database transaction.
 	Begin() (Transaction, error)
 
+	// Snapshot takes a consistent snapshot of the database,
+	// which remains readable while the database keeps changing.
+	Snapshot() (Snapshot, error)
+
 	// Close closes the database.
 	Close() error
 }
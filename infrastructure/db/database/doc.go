@@ -6,8 +6,10 @@ Overview
 This package provides a database layer to store and retrieve data in a simple
 and efficient manner.
 
-The current backend is ffldb, which makes use of leveldb, flat files, and strict
-checksums in key areas to ensure data integrity.
+Backends register themselves under a name using RegisterBackend, and databases
+are opened by backend name using Open. The available backends are leveldb (see
+package ldb), memory (see package memdb), which keeps all its data in memory,
+and logdb (see package logdb), an append-only log with an in-memory index.
 
 Implementors of additional backends are required to implement the following interfaces:
 
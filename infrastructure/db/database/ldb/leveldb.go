@@ -8,25 +8,43 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/opt"
 )
 
+// BackendName is the name under which the LevelDB backend is registered
+const BackendName = "leveldb"
+
+func init() {
+	database.RegisterBackend(BackendName, func(path string, options *database.Options) (database.Database, error) {
+		return NewLevelDBWithOptions(path, options)
+	})
+}
+
 // LevelDB defines a thin wrapper around leveldb.
 type LevelDB struct {
-	ldb *leveldb.DB
+	ldb          *leveldb.DB
+	writeOptions *opt.WriteOptions
+	isCompacting uint32
 }
 
-// NewLevelDB opens a leveldb instance defined by the given path.
+// NewLevelDB opens a leveldb instance defined by the given path,
+// with the default options and a cache of the given size.
 func NewLevelDB(path string, cacheSizeMiB int) (*LevelDB, error) {
+	return NewLevelDBWithOptions(path, database.DefaultOptions(cacheSizeMiB))
+}
+
+// NewLevelDBWithOptions opens a leveldb instance defined by the given path,
+// with the given options.
+func NewLevelDBWithOptions(path string, databaseOptions *database.Options) (*LevelDB, error) {
 	// Open leveldb. If it doesn't exist, create it.
-	options := Options()
-	options.BlockCacheCapacity = cacheSizeMiB * opt.MiB
-	options.WriteBuffer = (cacheSizeMiB * opt.MiB) / 2
+	options := Options(databaseOptions)
 	ldb, err := leveldb.OpenFile(path, &options)
 
 	// If the database is corrupted, attempt to recover.
-	if _, corrupted := err.(*ldbErrors.ErrCorrupted); corrupted {
+	// Recovering rewrites the database, so it's never
+	// attempted on a read-only database.
+	if _, corrupted := err.(*ldbErrors.ErrCorrupted); corrupted && !databaseOptions.ReadOnly {
 		log.Warnf("LevelDB corruption detected for path %s: %s",
 			path, err)
 		var recoverErr error
-		ldb, recoverErr = leveldb.RecoverFile(path, nil)
+		ldb, recoverErr = leveldb.RecoverFile(path, &options)
 		if recoverErr != nil {
 			return nil, errors.Wrapf(err, "failed recovering from "+
 				"database corruption: %s", recoverErr)
@@ -42,7 +60,8 @@ func NewLevelDB(path string, cacheSizeMiB int) (*LevelDB, error) {
 	}
 
 	db := &LevelDB{
-		ldb: ldb,
+		ldb:          ldb,
+		writeOptions: writeOptions(databaseOptions),
 	}
 	return db, nil
 }
@@ -56,7 +75,7 @@ func (db *LevelDB) Close() error {
 // Put sets the value for the given key. It overwrites
 // any previous value for that key.
 func (db *LevelDB) Put(key *database.Key, value []byte) error {
-	err := db.ldb.Put(key.Bytes(), value, nil)
+	err := db.ldb.Put(key.Bytes(), value, db.writeOptions)
 	return errors.WithStack(err)
 }
 
@@ -87,6 +106,6 @@ func (db *LevelDB) Has(key *database.Key) (bool, error) {
 // Delete deletes the value for the given key. Will not
 // return an error if the key doesn't exist.
 func (db *LevelDB) Delete(key *database.Key) error {
-	err := db.ldb.Delete(key.Bytes(), nil)
+	err := db.ldb.Delete(key.Bytes(), db.writeOptions)
 	return errors.WithStack(err)
 }
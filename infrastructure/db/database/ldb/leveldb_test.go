@@ -151,3 +151,59 @@ func TestLevelDBTransactionSanity(t *testing.T) {
 			"returned unexpected error: %s", err)
 	}
 }
+
+func TestLevelDBReadOnly(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestLevelDBReadOnly")
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: TempDir unexpectedly "+
+			"failed: %s", err)
+	}
+	readOnlyOptions := database.DefaultOptions(8)
+	readOnlyOptions.ReadOnly = true
+
+	_, err = NewLevelDBWithOptions(path, readOnlyOptions)
+	if err == nil {
+		t.Fatalf("TestLevelDBReadOnly: opening a missing database " +
+			"as read-only unexpectedly succeeded")
+	}
+
+	ldb, err := NewLevelDB(path, 8)
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: NewLevelDB unexpectedly "+
+			"failed: %s", err)
+	}
+	key := database.MakeBucket(nil).Key([]byte("key"))
+	putData := []byte("Hello world!")
+	err = ldb.Put(key, putData)
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: Put returned "+
+			"unexpected error: %s", err)
+	}
+	err = ldb.Close()
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: Close unexpectedly "+
+			"failed: %s", err)
+	}
+
+	ldb, err = NewLevelDBWithOptions(path, readOnlyOptions)
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: NewLevelDBWithOptions unexpectedly "+
+			"failed: %s", err)
+	}
+	defer ldb.Close()
+	getData, err := ldb.Get(key)
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: Get returned "+
+			"unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(getData, putData) {
+		t.Fatalf("TestLevelDBReadOnly: get data and "+
+			"put data are not equal. Put: %s, got: %s",
+			string(putData), string(getData))
+	}
+	err = ldb.Put(key, []byte("Goodbye world!"))
+	if err == nil {
+		t.Fatalf("TestLevelDBReadOnly: Put to a read-only " +
+			"database unexpectedly succeeded")
+	}
+}
@@ -1,13 +1,33 @@
 package ldb
 
-import "github.com/syndtr/goleveldb/leveldb/opt"
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
 
 // Options is a function that returns a leveldb
-// opt.Options struct for opening a database.
-func Options() opt.Options {
+// opt.Options struct for opening a database
+// with the given options.
+func Options(databaseOptions *database.Options) opt.Options {
+	compression := opt.NoCompression
+	if databaseOptions.Compression {
+		compression = opt.SnappyCompression
+	}
 	return opt.Options{
-		Compression:            opt.NoCompression,
-		DisableSeeksCompaction: true,
-		NoSync:                 true,
+		Compression:            compression,
+		DisableSeeksCompaction: !databaseOptions.SeeksCompaction,
+		NoSync:                 !databaseOptions.Sync,
+		BlockCacheCapacity:     databaseOptions.CacheSizeMiB * opt.MiB,
+		WriteBuffer:            databaseOptions.WriteBufferSizeMiB * opt.MiB,
+		ReadOnly:               databaseOptions.ReadOnly,
+		ErrorIfMissing:         databaseOptions.ReadOnly,
+	}
+}
+
+// writeOptions returns the leveldb opt.WriteOptions
+// struct for writing with the given options
+func writeOptions(databaseOptions *database.Options) *opt.WriteOptions {
+	return &opt.WriteOptions{
+		Sync: databaseOptions.Sync,
 	}
 }
@@ -0,0 +1,95 @@
+package ldb
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// LevelStats are the statistics of a single level of a LevelDB instance
+type LevelStats struct {
+	Level              int
+	TableCount         int
+	Size               int64
+	ReadBytes          int64
+	WrittenBytes       int64
+	CompactionDuration time.Duration
+}
+
+// Stats are the statistics of a LevelDB instance
+type Stats struct {
+	Levels                   []*LevelStats
+	IOReadBytes              uint64
+	IOWrittenBytes           uint64
+	WriteDelayCount          int32
+	WriteDelayDuration       time.Duration
+	BlockCacheSize           int
+	OpenedTableCount         int
+	MemoryCompactionCount    uint32
+	Level0CompactionCount    uint32
+	NonLevel0CompactionCount uint32
+	SeekCompactionCount      uint32
+}
+
+// Stats returns the current statistics of the database.
+func (db *LevelDB) Stats() (*Stats, error) {
+	var dbStats leveldb.DBStats
+	err := db.ldb.Stats(&dbStats)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	levels := make([]*LevelStats, len(dbStats.LevelTablesCounts))
+	for level := range levels {
+		levels[level] = &LevelStats{
+			Level:              level,
+			TableCount:         dbStats.LevelTablesCounts[level],
+			Size:               dbStats.LevelSizes[level],
+			ReadBytes:          dbStats.LevelRead[level],
+			WrittenBytes:       dbStats.LevelWrite[level],
+			CompactionDuration: dbStats.LevelDurations[level],
+		}
+	}
+
+	return &Stats{
+		Levels:                   levels,
+		IOReadBytes:              dbStats.IORead,
+		IOWrittenBytes:           dbStats.IOWrite,
+		WriteDelayCount:          dbStats.WriteDelayCount,
+		WriteDelayDuration:       dbStats.WriteDelayDuration,
+		BlockCacheSize:           dbStats.BlockCacheSize,
+		OpenedTableCount:         dbStats.OpenedTablesCount,
+		MemoryCompactionCount:    dbStats.MemComp,
+		Level0CompactionCount:    dbStats.Level0Comp,
+		NonLevel0CompactionCount: dbStats.NonLevel0Comp,
+		SeekCompactionCount:      dbStats.SeekComp,
+	}, nil
+}
+
+// Compact compacts the whole database, reclaiming the space taken
+// by deleted and overwritten data. This may take a long time on big
+// databases, and the database remains usable while it runs.
+// Only one compaction may run at a time.
+func (db *LevelDB) Compact() error {
+	if !atomic.CompareAndSwapUint32(&db.isCompacting, 0, 1) {
+		return errors.New("a database compaction is already running")
+	}
+	defer atomic.StoreUint32(&db.isCompacting, 0)
+
+	log.Infof("Compacting the database")
+	start := time.Now()
+	err := db.ldb.CompactRange(util.Range{})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	log.Infof("Compacted the database in %s", time.Since(start))
+	return nil
+}
+
+// IsCompacting returns whether a compaction started by Compact is running
+func (db *LevelDB) IsCompacting() bool {
+	return atomic.LoadUint32(&db.isCompacting) == 1
+}
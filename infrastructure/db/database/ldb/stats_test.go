@@ -0,0 +1,46 @@
+package ldb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestLevelDBCompact(t *testing.T) {
+	ldb, teardownFunc := prepareDatabaseForTest(t, "TestLevelDBCompact")
+	defer teardownFunc()
+
+	bucket := database.MakeBucket([]byte("bucket"))
+	for i := 0; i < 1000; i++ {
+		err := ldb.Put(bucket.Key([]byte(fmt.Sprintf("key%d", i))), make([]byte, 100))
+		if err != nil {
+			t.Fatalf("TestLevelDBCompact: Put unexpectedly failed: %s", err)
+		}
+	}
+
+	err := ldb.Compact()
+	if err != nil {
+		t.Fatalf("TestLevelDBCompact: Compact unexpectedly failed: %s", err)
+	}
+	if ldb.IsCompacting() {
+		t.Fatalf("TestLevelDBCompact: IsCompacting unexpectedly returned true after Compact returned")
+	}
+
+	stats, err := ldb.Stats()
+	if err != nil {
+		t.Fatalf("TestLevelDBCompact: Stats unexpectedly failed: %s", err)
+	}
+	// Compacting the whole database flushes the memory table to disk,
+	// so some level is expected to hold data
+	totalSize := int64(0)
+	for _, level := range stats.Levels {
+		totalSize += level.Size
+	}
+	if totalSize == 0 {
+		t.Fatalf("TestLevelDBCompact: expected the database to have data on disk after compaction")
+	}
+	if stats.MemoryCompactionCount == 0 {
+		t.Fatalf("TestLevelDBCompact: expected at least one memory compaction")
+	}
+}
@@ -0,0 +1,92 @@
+package logdb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// LogDBCursor iterates over a snapshot of the index of a LogDB, taken
+// when the cursor was opened, and reads the values it points to from
+// the log.
+type LogDBCursor struct {
+	db          *LogDB
+	indexCursor database.Cursor
+
+	isClosed bool
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (db *LogDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	indexCursor, err := db.index.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogDBCursor{
+		db:          db,
+		indexCursor: indexCursor,
+		isClosed:    false,
+	}, nil
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted. Panics if the cursor is closed.
+func (c *LogDBCursor) Next() bool {
+	if c.isClosed {
+		panic("cannot call next on a closed cursor")
+	}
+	return c.indexCursor.Next()
+}
+
+// First moves the iterator to the first key/value pair. It returns false if
+// such a pair does not exist. Panics if the cursor is closed.
+func (c *LogDBCursor) First() bool {
+	if c.isClosed {
+		panic("cannot call first on a closed cursor")
+	}
+	return c.indexCursor.First()
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key. It returns ErrNotFound if such pair does not
+// exist.
+func (c *LogDBCursor) Seek(key *database.Key) error {
+	if c.isClosed {
+		return errors.New("cannot seek a closed cursor")
+	}
+	return c.indexCursor.Seek(key)
+}
+
+// Key returns the key of the current key/value pair, or ErrNotFound if done.
+// Note that the key is trimmed to not include the prefix the cursor was opened
+// with.
+func (c *LogDBCursor) Key() (*database.Key, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the key of a closed cursor")
+	}
+	return c.indexCursor.Key()
+}
+
+// Value returns the value of the current key/value pair, or ErrNotFound if done.
+func (c *LogDBCursor) Value() ([]byte, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the value of a closed cursor")
+	}
+	serializedLocation, err := c.indexCursor.Value()
+	if err != nil {
+		return nil, err
+	}
+	return c.db.readValue(serializedLocation)
+}
+
+// Close releases associated resources.
+func (c *LogDBCursor) Close() error {
+	if c.isClosed {
+		return errors.New("cannot close an already closed cursor")
+	}
+	c.isClosed = true
+	err := c.indexCursor.Close()
+	c.indexCursor = nil
+	c.db = nil
+	return err
+}
@@ -0,0 +1,7 @@
+package logdb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("LGDB")
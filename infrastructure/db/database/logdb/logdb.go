@@ -0,0 +1,463 @@
+package logdb
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/memdb"
+	"github.com/pkg/errors"
+)
+
+// BackendName is the name under which the log-structured backend is registered
+const BackendName = "logdb"
+
+func init() {
+	database.RegisterBackend(BackendName, func(path string, options *database.Options) (database.Database, error) {
+		return NewLogDBWithOptions(path, options)
+	})
+}
+
+const (
+	logFileName = "data.log"
+
+	// The log is compacted on open once it's at least compactionMinimumSize
+	// bytes, and at least compactionRatio times the size of its live data
+	compactionMinimumSize = 64 * 1024 * 1024
+	compactionRatio       = 2
+
+	compactionRecordSize = 4 * 1024 * 1024
+	replayBufferSize     = 1024 * 1024
+)
+
+// LogDB is an append-only log-structured database, in the style of Bitcask.
+// Every write appends a record to a single log file, and an in-memory index
+// maps every key to the location of its latest value in the log. Reads cost
+// a single file read, and writes a single file write.
+//
+// Since the whole index is kept in memory, LogDB fits databases with a
+// modest number of keys. Space taken by overwritten and deleted values is
+// reclaimed by compacting the log when the database is opened. Of the
+// database options, LogDB only supports Sync and ReadOnly.
+type LogDB struct {
+	path     string
+	file     *os.File
+	index    *memdb.MemDB
+	sync     bool
+	readOnly bool
+
+	// size is the offset at which the next record will be written.
+	// It's protected by writeLock, which also makes sure that records
+	// are applied to the index in the order in which they are written.
+	size      int64
+	writeLock sync.Mutex
+}
+
+// NewLogDB opens the LogDB in the given path with the default options.
+// If it doesn't exist, it's created.
+func NewLogDB(path string) (*LogDB, error) {
+	return NewLogDBWithOptions(path, database.DefaultOptions(0))
+}
+
+// NewLogDBWithOptions opens the LogDB in the given path with the given options.
+// If it doesn't exist, it's created.
+func NewLogDBWithOptions(path string, options *database.Options) (*LogDB, error) {
+	db, err := open(path, options)
+	if err != nil {
+		return nil, err
+	}
+	if options.ReadOnly {
+		return db, nil
+	}
+
+	shouldCompact, err := db.shouldCompact()
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if !shouldCompact {
+		return db, nil
+	}
+	err = db.compact()
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return open(path, options)
+}
+
+func open(path string, options *database.Options) (*LogDB, error) {
+	flag := os.O_RDONLY
+	if !options.ReadOnly {
+		err := os.MkdirAll(path, 0700)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		flag = os.O_RDWR | os.O_CREATE
+	}
+	file, err := os.OpenFile(filepath.Join(path, logFileName), flag, 0600)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	db := &LogDB{
+		path:     path,
+		file:     file,
+		index:    memdb.NewMemDB(),
+		sync:     options.Sync,
+		readOnly: options.ReadOnly,
+	}
+	err = db.replay()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// errIncompleteRecord is returned when a record in the log
+// ends past the end of the log
+var errIncompleteRecord = errors.New("incomplete record")
+
+// replay rebuilds the index from the log. If the log ends with a record
+// that is incomplete or corrupted, such as one that was being written when
+// the process crashed, the log is truncated right before it. Read-only
+// databases ignore such a record instead. A corrupted record anywhere
+// else in the log is an error, since discarding it would also discard
+// every record that was written after it.
+func (db *LogDB) replay() error {
+	fileInfo, err := db.file.Stat()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	fileSize := fileInfo.Size()
+
+	reader := bufio.NewReaderSize(io.NewSectionReader(db.file, 0, fileSize), replayBufferSize)
+	offset := int64(0)
+	for {
+		operations, recordLength, err := readRecord(reader, offset, fileSize)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			isLastRecord := errors.Is(err, errIncompleteRecord) || offset+recordLength == fileSize
+			if !isLastRecord {
+				return errors.Wrapf(err, "log %s is corrupted at offset %d, which is followed by %d more bytes",
+					db.file.Name(), offset, fileSize-offset-recordLength)
+			}
+			if db.readOnly {
+				log.Warnf("Ignoring %d bytes at the end of log %s: %s",
+					fileSize-offset, db.file.Name(), err)
+				break
+			}
+			log.Warnf("Discarding %d bytes from the end of log %s: %s",
+				fileSize-offset, db.file.Name(), err)
+			err = db.file.Truncate(offset)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			break
+		}
+		err = db.applyToIndex(operations)
+		if err != nil {
+			return err
+		}
+		offset += recordLength
+	}
+
+	db.size = offset
+	return nil
+}
+
+// readRecord reads the record at offset and returns its operations and its
+// length. The length is returned also when the record is corrupted, unless
+// it's incomplete, in which case errIncompleteRecord is returned. It returns
+// io.EOF if the log ends right at offset.
+func readRecord(reader io.Reader, offset int64, fileSize int64) ([]decodedOperation, int64, error) {
+	var header [recordHeaderLength]byte
+	_, err := io.ReadFull(reader, header[:])
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, 0, errors.Wrap(errIncompleteRecord, "incomplete record header")
+		}
+		return nil, 0, err
+	}
+	payloadLength := binary.LittleEndian.Uint32(header[0:4])
+	checksum := binary.LittleEndian.Uint32(header[4:8])
+	recordLength := recordHeaderLength + int64(payloadLength)
+	if offset+recordLength > fileSize {
+		return nil, 0, errors.Wrapf(errIncompleteRecord, "record payload of length %d "+
+			"ends past the end of the log", payloadLength)
+	}
+	if payloadLength > maxRecordLength {
+		return nil, recordLength, errors.Errorf("record length %d is too large", payloadLength)
+	}
+
+	payload := make([]byte, payloadLength)
+	_, err = io.ReadFull(reader, payload)
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	if crc32.Checksum(payload, castagnoliTable) != checksum {
+		return nil, recordLength, errors.New("record checksum mismatch")
+	}
+
+	operations, err := decodePayload(payload, offset)
+	if err != nil {
+		return nil, recordLength, err
+	}
+	return operations, recordLength, nil
+}
+
+func (db *LogDB) applyToIndex(operations []decodedOperation) error {
+	indexTransaction, err := db.index.Begin()
+	if err != nil {
+		return err
+	}
+	for _, operation := range operations {
+		key := database.MakeBucket(nil).Key(operation.key)
+		if operation.isDelete {
+			err = indexTransaction.Delete(key)
+		} else {
+			err = indexTransaction.Put(key, operation.location.serialize())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return indexTransaction.Commit()
+}
+
+// write appends a record of the given operations to the log,
+// and applies it to the index
+func (db *LogDB) write(operations []operation) error {
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+
+	if db.readOnly {
+		return errors.New("cannot write to a read-only database")
+	}
+	// Such a record would be taken for a corrupted one when the log is replayed
+	length := payloadLength(operations)
+	if length > maxRecordLength {
+		return errors.Errorf("cannot write %d bytes in a single write, which is more than "+
+			"the maximum of %d", length, maxRecordLength)
+	}
+
+	record, valueOffsets := encodeRecord(operations)
+	_, err := db.file.WriteAt(record, db.size)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if db.sync {
+		err = db.file.Sync()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	decodedOperations := make([]decodedOperation, len(operations))
+	for i, operation := range operations {
+		decodedOperations[i] = decodedOperation{key: operation.key, isDelete: operation.isDelete}
+		if !operation.isDelete {
+			decodedOperations[i].location = location{
+				offset: db.size + int64(valueOffsets[i]),
+				length: uint32(len(operation.value)),
+			}
+		}
+	}
+	err = db.applyToIndex(decodedOperations)
+	if err != nil {
+		return err
+	}
+
+	db.size += int64(len(record))
+	return nil
+}
+
+func (db *LogDB) readValue(serializedLocation []byte) ([]byte, error) {
+	valueLocation, err := deserializeLocation(serializedLocation)
+	if err != nil {
+		return nil, err
+	}
+	value := make([]byte, valueLocation.length)
+	_, err = db.file.ReadAt(value, valueLocation.offset)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return value, nil
+}
+
+// shouldCompact returns whether enough of the log is taken
+// by overwritten and deleted values to be worth compacting
+func (db *LogDB) shouldCompact() (bool, error) {
+	if db.size < compactionMinimumSize {
+		return false, nil
+	}
+
+	liveSize, err := db.liveSize()
+	if err != nil {
+		return false, err
+	}
+	return db.size > compactionRatio*liveSize, nil
+}
+
+// liveSize returns the size the log would take if it only held
+// the latest value of every key
+func (db *LogDB) liveSize() (int64, error) {
+	cursor, err := db.index.Cursor(database.MakeBucket(nil))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	liveSize := int64(0)
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return 0, err
+		}
+		serializedLocation, err := cursor.Value()
+		if err != nil {
+			return 0, err
+		}
+		valueLocation, err := deserializeLocation(serializedLocation)
+		if err != nil {
+			return 0, err
+		}
+		liveSize += int64(1 + 2*binary.MaxVarintLen64 + len(key.Bytes()) + int(valueLocation.length))
+	}
+	return liveSize, nil
+}
+
+// compact rewrites the log so that it only holds the latest value of
+// every key, and closes the database. The new log is written next to
+// the old one and then renamed over it, so a crash during compaction
+// leaves the old log intact.
+func (db *LogDB) compact() (err error) {
+	log.Infof("Compacting log %s", db.file.Name())
+
+	compactedFileName := db.file.Name() + ".compact"
+	compactedFile, err := os.OpenFile(compactedFileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = compactedFile.Close()
+			_ = os.Remove(compactedFileName)
+		}
+	}()
+
+	cursor, err := db.Cursor(database.MakeBucket(nil))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	writer := bufio.NewWriter(compactedFile)
+	var operations []operation
+	operationsSize := 0
+	flushOperations := func() error {
+		record, _ := encodeRecord(operations)
+		_, err := writer.Write(record)
+		operations = operations[:0]
+		operationsSize = 0
+		return errors.WithStack(err)
+	}
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		// Every value was written in a record of at most maxRecordLength, so
+		// a record that starts with it can't exceed maxRecordLength either
+		if len(operations) > 0 && operationsSize+len(key.Bytes())+len(value) > compactionRecordSize {
+			err := flushOperations()
+			if err != nil {
+				return err
+			}
+		}
+		operations = append(operations, operation{key: key.Bytes(), value: value})
+		operationsSize += len(key.Bytes()) + len(value)
+	}
+	if len(operations) > 0 {
+		err := flushOperations()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = compactedFile.Sync()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = compactedFile.Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = db.Close()
+	if err != nil {
+		return err
+	}
+	return errors.WithStack(os.Rename(compactedFileName, filepath.Join(db.path, logFileName)))
+}
+
+// Close closes the database.
+func (db *LogDB) Close() error {
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+
+	err := db.index.Close()
+	if err != nil {
+		return err
+	}
+	if !db.readOnly {
+		err = db.file.Sync()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return errors.WithStack(db.file.Close())
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *LogDB) Put(key *database.Key, value []byte) error {
+	return db.write([]operation{{key: key.Bytes(), value: value}})
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *LogDB) Get(key *database.Key) ([]byte, error) {
+	serializedLocation, err := db.index.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return db.readValue(serializedLocation)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *LogDB) Has(key *database.Key) (bool, error) {
+	return db.index.Has(key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *LogDB) Delete(key *database.Key) error {
+	return db.write([]operation{{key: key.Bytes(), isDelete: true}})
+}
@@ -0,0 +1,310 @@
+package logdb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func testKey(i int) *database.Key {
+	return database.MakeBucket([]byte("test")).Key([]byte(fmt.Sprintf("key%d", i)))
+}
+
+func openForTest(t *testing.T, path string) *LogDB {
+	db, err := NewLogDB(path)
+	if err != nil {
+		t.Fatalf("NewLogDB: %s", err)
+	}
+	return db
+}
+
+func checkValue(t *testing.T, db *LogDB, key *database.Key, expectedValue []byte) {
+	value, err := db.Get(key)
+	if err != nil {
+		t.Fatalf("Get %s: %s", key, err)
+	}
+	if !bytes.Equal(value, expectedValue) {
+		t.Fatalf("unexpected value for key %s. Want: %s, got: %s", key, expectedValue, value)
+	}
+}
+
+func checkMissing(t *testing.T, db *LogDB, key *database.Key) {
+	exists, err := db.Has(key)
+	if err != nil {
+		t.Fatalf("Has %s: %s", key, err)
+	}
+	if exists {
+		t.Fatalf("key %s unexpectedly exists", key)
+	}
+}
+
+func TestLogDBReopen(t *testing.T) {
+	path := t.TempDir()
+	db := openForTest(t, path)
+
+	for i := 0; i < 10; i++ {
+		err := db.Put(testKey(i), []byte(fmt.Sprintf("value%d", i)))
+		if err != nil {
+			t.Fatalf("Put: %s", err)
+		}
+	}
+	err := db.Put(testKey(0), []byte("overwritten"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	dbTx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %s", err)
+	}
+	err = dbTx.Delete(testKey(1))
+	if err != nil {
+		t.Fatalf("Delete: %s", err)
+	}
+	err = dbTx.Put(testKey(2), []byte("from a transaction"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	err = dbTx.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	db = openForTest(t, path)
+	defer db.Close()
+	checkValue(t, db, testKey(0), []byte("overwritten"))
+	checkMissing(t, db, testKey(1))
+	checkValue(t, db, testKey(2), []byte("from a transaction"))
+	for i := 3; i < 10; i++ {
+		checkValue(t, db, testKey(i), []byte(fmt.Sprintf("value%d", i)))
+	}
+}
+
+func TestLogDBTornRecord(t *testing.T) {
+	path := t.TempDir()
+	db := openForTest(t, path)
+	err := db.Put(testKey(0), []byte("value0"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	sizeBeforeTornRecord := db.size
+	err = db.Put(testKey(1), []byte("value1"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	// Cut the last record in the middle, as if the process
+	// crashed while it was being written
+	logPath := filepath.Join(path, logFileName)
+	err = os.Truncate(logPath, sizeBeforeTornRecord+recordHeaderLength+2)
+	if err != nil {
+		t.Fatalf("Truncate: %s", err)
+	}
+
+	db = openForTest(t, path)
+	checkValue(t, db, testKey(0), []byte("value0"))
+	checkMissing(t, db, testKey(1))
+	if db.size != sizeBeforeTornRecord {
+		t.Fatalf("expected the torn record to be discarded")
+	}
+
+	// New records should be written right after the last intact one
+	err = db.Put(testKey(2), []byte("value2"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	db = openForTest(t, path)
+	defer db.Close()
+	checkValue(t, db, testKey(0), []byte("value0"))
+	checkValue(t, db, testKey(2), []byte("value2"))
+}
+
+// corruptLogByte flips the byte at the given offset of the log in path
+func corruptLogByte(t *testing.T, path string, offset int64) {
+	file, err := os.OpenFile(filepath.Join(path, logFileName), os.O_RDWR, 0600)
+	if err != nil {
+		t.Fatalf("OpenFile: %s", err)
+	}
+	defer file.Close()
+	b := make([]byte, 1)
+	_, err = file.ReadAt(b, offset)
+	if err != nil {
+		t.Fatalf("ReadAt: %s", err)
+	}
+	_, err = file.WriteAt([]byte{^b[0]}, offset)
+	if err != nil {
+		t.Fatalf("WriteAt: %s", err)
+	}
+}
+
+func TestLogDBCorruptedRecord(t *testing.T) {
+	path := t.TempDir()
+	db := openForTest(t, path)
+	for i := 0; i < 3; i++ {
+		err := db.Put(testKey(i), []byte(fmt.Sprintf("value%d", i)))
+		if err != nil {
+			t.Fatalf("Put: %s", err)
+		}
+	}
+	lastRecordOffset := db.size
+	err := db.Put(testKey(3), []byte("value3"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	// A corrupted last record is discarded like a torn one
+	corruptLogByte(t, path, lastRecordOffset+recordHeaderLength)
+	db = openForTest(t, path)
+	checkValue(t, db, testKey(2), []byte("value2"))
+	checkMissing(t, db, testKey(3))
+	if db.size != lastRecordOffset {
+		t.Fatalf("expected the corrupted last record to be discarded")
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	// Discarding a corrupted record in the middle of the log would
+	// also discard the records after it, so opening the log fails
+	corruptLogByte(t, path, recordHeaderLength)
+	_, err = NewLogDB(path)
+	if err == nil {
+		t.Fatalf("expected opening a log with a corrupted record in its middle to fail")
+	}
+}
+
+func TestLogDBRecordTooLarge(t *testing.T) {
+	db := openForTest(t, t.TempDir())
+	defer db.Close()
+
+	// The operations share a single value, so that the test
+	// doesn't need to allocate more than maxRecordLength bytes
+	value := make([]byte, 1024*1024)
+	operations := make([]operation, maxRecordLength/len(value)+1)
+	for i := range operations {
+		operations[i] = operation{key: testKey(i).Bytes(), value: value}
+	}
+	err := db.write(operations)
+	if err == nil {
+		t.Fatalf("expected writing a record larger than maxRecordLength to fail")
+	}
+	if db.size != 0 {
+		t.Fatalf("expected nothing to be written to the log")
+	}
+	checkMissing(t, db, testKey(0))
+}
+
+func TestLogDBCompact(t *testing.T) {
+	path := t.TempDir()
+	db := openForTest(t, path)
+	for round := 0; round < 10; round++ {
+		for i := 0; i < 100; i++ {
+			err := db.Put(testKey(i), []byte(fmt.Sprintf("value%d-%d", i, round)))
+			if err != nil {
+				t.Fatalf("Put: %s", err)
+			}
+		}
+	}
+	for i := 50; i < 100; i++ {
+		err := db.Delete(testKey(i))
+		if err != nil {
+			t.Fatalf("Delete: %s", err)
+		}
+	}
+	sizeBeforeCompaction := db.size
+
+	err := db.compact()
+	if err != nil {
+		t.Fatalf("compact: %s", err)
+	}
+
+	db = openForTest(t, path)
+	defer db.Close()
+	if db.size*10 > sizeBeforeCompaction {
+		t.Fatalf("expected compaction to shrink the log from %d bytes, but it's %d bytes",
+			sizeBeforeCompaction, db.size)
+	}
+	for i := 0; i < 50; i++ {
+		checkValue(t, db, testKey(i), []byte(fmt.Sprintf("value%d-9", i)))
+	}
+	for i := 50; i < 100; i++ {
+		checkMissing(t, db, testKey(i))
+	}
+}
+
+func TestLogDBReadOnly(t *testing.T) {
+	path := t.TempDir()
+	readOnlyOptions := database.DefaultOptions(0)
+	readOnlyOptions.ReadOnly = true
+
+	_, err := NewLogDBWithOptions(path, readOnlyOptions)
+	if err == nil {
+		t.Fatalf("expected opening a missing database as read-only to fail")
+	}
+
+	db := openForTest(t, path)
+	err = db.Put(testKey(0), []byte("value0"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	sizeBeforeTornRecord := db.size
+	err = db.Put(testKey(1), []byte("value1"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+	logPath := filepath.Join(path, logFileName)
+	tornSize := sizeBeforeTornRecord + recordHeaderLength + 2
+	err = os.Truncate(logPath, tornSize)
+	if err != nil {
+		t.Fatalf("Truncate: %s", err)
+	}
+
+	db, err = NewLogDBWithOptions(path, readOnlyOptions)
+	if err != nil {
+		t.Fatalf("NewLogDBWithOptions: %s", err)
+	}
+	checkValue(t, db, testKey(0), []byte("value0"))
+	checkMissing(t, db, testKey(1))
+	err = db.Put(testKey(2), []byte("value2"))
+	if err == nil {
+		t.Fatalf("expected writing to a read-only database to fail")
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	// The torn record must be left in place for a writable open to discard
+	fileInfo, err := os.Stat(logPath)
+	if err != nil {
+		t.Fatalf("Stat: %s", err)
+	}
+	if fileInfo.Size() != tornSize {
+		t.Fatalf("expected a read-only database to leave the log intact")
+	}
+}
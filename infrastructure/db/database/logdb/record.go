@@ -0,0 +1,164 @@
+package logdb
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"github.com/pkg/errors"
+)
+
+// The log is a sequence of records, each holding the operations of a
+// single write. A record is made of a header, which holds the length
+// of the record's payload and its checksum, followed by the payload:
+//
+//   put:    opPut    | uvarint key length | key | uvarint value length | value
+//   delete: opDelete | uvarint key length | key
+//
+// A record is only applied if it's complete and its checksum matches,
+// which makes every write atomic.
+const (
+	recordHeaderLength = 8
+	maxRecordLength    = 1 << 30
+
+	opPut    = byte(1)
+	opDelete = byte(2)
+
+	locationLength = 12
+)
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// operation is a single modification of the database
+type operation struct {
+	key      []byte
+	value    []byte
+	isDelete bool
+}
+
+// payloadLength returns the length of the payload of the record of the given operations
+func payloadLength(operations []operation) int {
+	length := 0
+	for _, operation := range operations {
+		length += 1 + uvarintLength(uint64(len(operation.key))) + len(operation.key)
+		if !operation.isDelete {
+			length += uvarintLength(uint64(len(operation.value))) + len(operation.value)
+		}
+	}
+	return length
+}
+
+func uvarintLength(value uint64) int {
+	length := 1
+	for value >= 0x80 {
+		value >>= 7
+		length++
+	}
+	return length
+}
+
+// encodeRecord returns the record of the given operations, along with
+// the offsets of the put values within it. The payload of the record must
+// not be longer than maxRecordLength.
+func encodeRecord(operations []operation) (record []byte, valueOffsets []int) {
+	length := recordHeaderLength + payloadLength(operations)
+	record = make([]byte, recordHeaderLength, length)
+	valueOffsets = make([]int, len(operations))
+	var varintBuffer [binary.MaxVarintLen64]byte
+	for i, operation := range operations {
+		if operation.isDelete {
+			record = append(record, opDelete)
+		} else {
+			record = append(record, opPut)
+		}
+		n := binary.PutUvarint(varintBuffer[:], uint64(len(operation.key)))
+		record = append(record, varintBuffer[:n]...)
+		record = append(record, operation.key...)
+		if operation.isDelete {
+			continue
+		}
+		n = binary.PutUvarint(varintBuffer[:], uint64(len(operation.value)))
+		record = append(record, varintBuffer[:n]...)
+		valueOffsets[i] = len(record)
+		record = append(record, operation.value...)
+	}
+
+	payload := record[recordHeaderLength:]
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.Checksum(payload, castagnoliTable))
+	return record, valueOffsets
+}
+
+// decodedOperation is an operation read from the log. Instead of
+// the value itself, it holds the value's location in the log.
+type decodedOperation struct {
+	key      []byte
+	location location
+	isDelete bool
+}
+
+// decodePayload decodes the payload of the record that starts at recordOffset
+func decodePayload(payload []byte, recordOffset int64) ([]decodedOperation, error) {
+	var operations []decodedOperation
+	position := 0
+	readBytes := func() ([]byte, int, error) {
+		length, n := binary.Uvarint(payload[position:])
+		if n <= 0 || length > uint64(len(payload)-position-n) {
+			return nil, 0, errors.Errorf("malformed length at payload position %d", position)
+		}
+		start := position + n
+		position = start + int(length)
+		return payload[start:position], start, nil
+	}
+
+	for position < len(payload) {
+		opType := payload[position]
+		position++
+
+		key, _, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+		switch opType {
+		case opDelete:
+			operations = append(operations, decodedOperation{key: key, isDelete: true})
+		case opPut:
+			value, valueStart, err := readBytes()
+			if err != nil {
+				return nil, err
+			}
+			operations = append(operations, decodedOperation{
+				key: key,
+				location: location{
+					offset: recordOffset + recordHeaderLength + int64(valueStart),
+					length: uint32(len(value)),
+				},
+			})
+		default:
+			return nil, errors.Errorf("unknown operation type %d", opType)
+		}
+	}
+	return operations, nil
+}
+
+// location is the location of a value in the log
+type location struct {
+	offset int64
+	length uint32
+}
+
+func (l location) serialize() []byte {
+	serialized := make([]byte, locationLength)
+	binary.LittleEndian.PutUint64(serialized[0:8], uint64(l.offset))
+	binary.LittleEndian.PutUint32(serialized[8:12], l.length)
+	return serialized
+}
+
+func deserializeLocation(serialized []byte) (location, error) {
+	if len(serialized) != locationLength {
+		return location{}, errors.Errorf("location has an unexpected length %d", len(serialized))
+	}
+	return location{
+		offset: int64(binary.LittleEndian.Uint64(serialized[0:8])),
+		length: binary.LittleEndian.Uint32(serialized[8:12]),
+	}, nil
+}
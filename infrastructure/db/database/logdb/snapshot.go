@@ -0,0 +1,60 @@
+package logdb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// LogDBSnapshot is a snapshot of the index of a LogDB. Since the log is
+// append-only while the database is open, the values the snapshot of the
+// index points to remain in the log for as long as the database is open.
+type LogDBSnapshot struct {
+	db            *LogDB
+	indexSnapshot database.Snapshot
+}
+
+// Snapshot takes a consistent snapshot of the database.
+func (db *LogDB) Snapshot() (database.Snapshot, error) {
+	indexSnapshot, err := db.index.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &LogDBSnapshot{
+		db:            db,
+		indexSnapshot: indexSnapshot,
+	}, nil
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (s *LogDBSnapshot) Get(key *database.Key) ([]byte, error) {
+	serializedLocation, err := s.indexSnapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.readValue(serializedLocation)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (s *LogDBSnapshot) Has(key *database.Key) (bool, error) {
+	return s.indexSnapshot.Has(key)
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (s *LogDBSnapshot) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	indexCursor, err := s.indexSnapshot.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogDBCursor{
+		db:          s.db,
+		indexCursor: indexCursor,
+		isClosed:    false,
+	}, nil
+}
+
+// Release releases the snapshot.
+func (s *LogDBSnapshot) Release() {
+	s.indexSnapshot.Release()
+}
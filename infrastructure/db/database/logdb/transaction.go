@@ -0,0 +1,114 @@
+package logdb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// LogDBTransaction batches modifications of a LogDB and writes
+// them to the log as a single record on commit.
+//
+// Like the LevelDB backend, reads are done from the database
+// directly, so data that was put into the transaction will not
+// be available to get within the same transaction.
+type LogDBTransaction struct {
+	db         *LogDB
+	operations []operation
+	isClosed   bool
+}
+
+// Begin begins a new transaction.
+func (db *LogDB) Begin() (database.Transaction, error) {
+	return &LogDBTransaction{
+		db:       db,
+		isClosed: false,
+	}, nil
+}
+
+// Commit commits whatever changes were made to the database
+// within this transaction.
+func (tx *LogDBTransaction) Commit() error {
+	if tx.isClosed {
+		return errors.New("cannot commit a closed transaction")
+	}
+
+	tx.isClosed = true
+	if len(tx.operations) == 0 {
+		return nil
+	}
+	return tx.db.write(tx.operations)
+}
+
+// Rollback rolls back whatever changes were made to the
+// database within this transaction.
+func (tx *LogDBTransaction) Rollback() error {
+	if tx.isClosed {
+		return errors.New("cannot rollback a closed transaction")
+	}
+
+	tx.isClosed = true
+	tx.operations = nil
+	return nil
+}
+
+// RollbackUnlessClosed rolls back changes that were made to
+// the database within the transaction, unless the transaction
+// had already been closed using either Rollback or Commit.
+func (tx *LogDBTransaction) RollbackUnlessClosed() error {
+	if tx.isClosed {
+		return nil
+	}
+	return tx.Rollback()
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (tx *LogDBTransaction) Put(key *database.Key, value []byte) error {
+	if tx.isClosed {
+		return errors.New("cannot put into a closed transaction")
+	}
+
+	// Copy the value, since the caller is free to reuse it once Put returns
+	valueCopy := make([]byte, len(value))
+	copy(valueCopy, value)
+	tx.operations = append(tx.operations, operation{key: key.Bytes(), value: valueCopy})
+	return nil
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (tx *LogDBTransaction) Get(key *database.Key) ([]byte, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot get from a closed transaction")
+	}
+	return tx.db.Get(key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (tx *LogDBTransaction) Has(key *database.Key) (bool, error) {
+	if tx.isClosed {
+		return false, errors.New("cannot has from a closed transaction")
+	}
+	return tx.db.Has(key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (tx *LogDBTransaction) Delete(key *database.Key) error {
+	if tx.isClosed {
+		return errors.New("cannot delete from a closed transaction")
+	}
+
+	tx.operations = append(tx.operations, operation{key: key.Bytes(), isDelete: true})
+	return nil
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (tx *LogDBTransaction) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot open a cursor from a closed transaction")
+	}
+
+	return tx.db.Cursor(bucket)
+}
@@ -0,0 +1,114 @@
+package memdb
+
+import (
+	"bytes"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// MemDBCursor iterates over a snapshot of a MemDB, taken
+// when the cursor was opened.
+type MemDBCursor struct {
+	iterator  *iterator
+	bucket    *database.Bucket
+	isStarted bool
+
+	isClosed bool
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (db *MemDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	root, err := db.currentRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemDBCursor{
+		iterator: newIterator(root, bucket.Path()),
+		bucket:   bucket,
+		isClosed: false,
+	}, nil
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted. Panics if the cursor is closed.
+func (c *MemDBCursor) Next() bool {
+	if c.isClosed {
+		panic("cannot call next on a closed cursor")
+	}
+	if !c.isStarted {
+		return c.First()
+	}
+	return c.iterator.next()
+}
+
+// First moves the iterator to the first key/value pair. It returns false if
+// such a pair does not exist. Panics if the cursor is closed.
+func (c *MemDBCursor) First() bool {
+	if c.isClosed {
+		panic("cannot call first on a closed cursor")
+	}
+	c.isStarted = true
+	return c.iterator.seek(c.bucket.Path())
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key. It returns ErrNotFound if such pair does not
+// exist.
+func (c *MemDBCursor) Seek(key *database.Key) error {
+	if c.isClosed {
+		return errors.New("cannot seek a closed cursor")
+	}
+
+	c.isStarted = true
+	keyBytes := key.Bytes()
+	found := c.iterator.seek(keyBytes)
+	if !found || !bytes.Equal(c.iterator.current().key, keyBytes) {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+	return nil
+}
+
+// Key returns the key of the current key/value pair, or ErrNotFound if done.
+// Note that the key is trimmed to not include the prefix the cursor was opened
+// with. The caller should not modify the contents of the returned slice, and
+// its contents may change on the next call to Next.
+func (c *MemDBCursor) Key() (*database.Key, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the key of a closed cursor")
+	}
+	current := c.iterator.current()
+	if current == nil {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"key of an exhausted cursor")
+	}
+	suffix := bytes.TrimPrefix(current.key, c.bucket.Path())
+	return c.bucket.Key(suffix), nil
+}
+
+// Value returns the value of the current key/value pair, or ErrNotFound if done.
+// The caller should not modify the contents of the returned slice, and its
+// contents may change on the next call to Next.
+func (c *MemDBCursor) Value() ([]byte, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the value of a closed cursor")
+	}
+	current := c.iterator.current()
+	if current == nil {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"value of an exhausted cursor")
+	}
+	return current.value, nil
+}
+
+// Close releases associated resources.
+func (c *MemDBCursor) Close() error {
+	if c.isClosed {
+		return errors.New("cannot close an already closed cursor")
+	}
+	c.isClosed = true
+	c.iterator = nil
+	c.bucket = nil
+	return nil
+}
@@ -0,0 +1,150 @@
+package memdb
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// BackendName is the name under which the in-memory backend is registered
+const BackendName = "memory"
+
+func init() {
+	database.RegisterBackend(BackendName, func(_ string, _ *database.Options) (database.Database, error) {
+		return NewMemDB(), nil
+	})
+}
+
+// errClosed is returned when accessing a closed database
+var errClosed = errors.New("cannot access a closed database")
+
+// MemDB is a database that keeps all of its data in memory. It's meant
+// for tests and for ephemeral nodes, and loses all its data once closed.
+//
+// The data is kept in an immutable tree, so reading a value or opening
+// a cursor only requires taking a snapshot of the tree's root.
+type MemDB struct {
+	root     *node
+	isClosed bool
+
+	// lock protects root and isClosed. Since the tree itself is immutable,
+	// it's only held while a snapshot of the root is taken or while
+	// writers replace it.
+	lock sync.RWMutex
+}
+
+// NewMemDB returns a new empty in-memory database
+func NewMemDB() *MemDB {
+	return &MemDB{}
+}
+
+func (db *MemDB) currentRoot() (*node, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.isClosed {
+		return nil, errClosed
+	}
+	return db.root, nil
+}
+
+// write applies the given operations atomically
+func (db *MemDB) write(operations []operation) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.isClosed {
+		return errClosed
+	}
+
+	root := db.root
+	for _, operation := range operations {
+		if operation.isDelete {
+			root, _ = remove(root, operation.key)
+			continue
+		}
+		root = put(root, operation.key, operation.value, keyPriority(operation.key))
+	}
+	db.root = root
+	return nil
+}
+
+// Close closes the database and discards all of its data
+func (db *MemDB) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.isClosed {
+		return errClosed
+	}
+	db.isClosed = true
+	db.root = nil
+	return nil
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *MemDB) Put(key *database.Key, value []byte) error {
+	return db.write([]operation{newPutOperation(key, value)})
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *MemDB) Get(key *database.Key) ([]byte, error) {
+	root, err := db.currentRoot()
+	if err != nil {
+		return nil, err
+	}
+	return getValue(root, key)
+}
+
+// getValue gets the value for the given key in the tree under root
+func getValue(root *node, key *database.Key) ([]byte, error) {
+	node, ok := get(root, key.Bytes())
+	if !ok {
+		return nil, errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+
+	// Return a copy, so that callers can't modify the stored value
+	value := make([]byte, len(node.value))
+	copy(value, node.value)
+	return value, nil
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *MemDB) Has(key *database.Key) (bool, error) {
+	root, err := db.currentRoot()
+	if err != nil {
+		return false, err
+	}
+	_, ok := get(root, key.Bytes())
+	return ok, nil
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *MemDB) Delete(key *database.Key) error {
+	return db.write([]operation{newDeleteOperation(key)})
+}
+
+// operation is a single modification of the database
+type operation struct {
+	key      []byte
+	value    []byte
+	isDelete bool
+}
+
+// newPutOperation copies value, since the caller is free
+// to reuse it once Put returns. Note that key.Bytes()
+// already returns a copy.
+func newPutOperation(key *database.Key, value []byte) operation {
+	operationValue := make([]byte, len(value))
+	copy(operationValue, value)
+	return operation{key: key.Bytes(), value: operationValue}
+}
+
+func newDeleteOperation(key *database.Key) operation {
+	return operation{key: key.Bytes(), isDelete: true}
+}
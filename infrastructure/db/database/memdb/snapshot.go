@@ -0,0 +1,59 @@
+package memdb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// MemDBSnapshot is a snapshot of a MemDB. Since the tree of a MemDB
+// is immutable, a snapshot only has to hold on to the tree's root.
+type MemDBSnapshot struct {
+	root       *node
+	isReleased bool
+}
+
+// Snapshot takes a consistent snapshot of the database.
+func (db *MemDB) Snapshot() (database.Snapshot, error) {
+	root, err := db.currentRoot()
+	if err != nil {
+		return nil, err
+	}
+	return &MemDBSnapshot{root: root}, nil
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (s *MemDBSnapshot) Get(key *database.Key) ([]byte, error) {
+	if s.isReleased {
+		return nil, errors.New("cannot get from a released snapshot")
+	}
+	return getValue(s.root, key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (s *MemDBSnapshot) Has(key *database.Key) (bool, error) {
+	if s.isReleased {
+		return false, errors.New("cannot has from a released snapshot")
+	}
+	_, ok := get(s.root, key.Bytes())
+	return ok, nil
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (s *MemDBSnapshot) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	if s.isReleased {
+		return nil, errors.New("cannot open a cursor from a released snapshot")
+	}
+	return &MemDBCursor{
+		iterator: newIterator(s.root, bucket.Path()),
+		bucket:   bucket,
+		isClosed: false,
+	}, nil
+}
+
+// Release releases the snapshot.
+func (s *MemDBSnapshot) Release() {
+	s.isReleased = true
+	s.root = nil
+}
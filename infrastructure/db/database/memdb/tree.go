@@ -0,0 +1,203 @@
+package memdb
+
+import (
+	"bytes"
+	"hash/fnv"
+)
+
+// node is a node in an immutable treap. Modifications copy the path
+// from the root to the modified node, so every root is a snapshot
+// that stays valid, and safe to read concurrently, forever.
+type node struct {
+	key      []byte
+	value    []byte
+	priority uint32
+	left     *node
+	right    *node
+}
+
+// keyPriority derives the treap priority of a key from its hash, so
+// that the shape of the tree only depends on the keys it contains
+func keyPriority(key []byte) uint32 {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(key)
+	return hasher.Sum32()
+}
+
+func get(root *node, key []byte) (*node, bool) {
+	current := root
+	for current != nil {
+		compareResult := bytes.Compare(key, current.key)
+		switch {
+		case compareResult < 0:
+			current = current.left
+		case compareResult > 0:
+			current = current.right
+		default:
+			return current, true
+		}
+	}
+	return nil, false
+}
+
+// put returns a new root of a tree in which key is set to value
+func put(root *node, key []byte, value []byte, priority uint32) *node {
+	if root == nil {
+		return &node{key: key, value: value, priority: priority}
+	}
+
+	newRoot := *root
+	compareResult := bytes.Compare(key, root.key)
+	switch {
+	case compareResult < 0:
+		newRoot.left = put(root.left, key, value, priority)
+		if newRoot.left.priority > newRoot.priority {
+			return rotateRight(&newRoot)
+		}
+	case compareResult > 0:
+		newRoot.right = put(root.right, key, value, priority)
+		if newRoot.right.priority > newRoot.priority {
+			return rotateLeft(&newRoot)
+		}
+	default:
+		newRoot.value = value
+	}
+	return &newRoot
+}
+
+// rotateRight and rotateLeft mutate their argument and its child, so
+// they must only be called on nodes that were copied by the current
+// modification
+func rotateRight(root *node) *node {
+	newRoot := root.left
+	root.left = newRoot.right
+	newRoot.right = root
+	return newRoot
+}
+
+func rotateLeft(root *node) *node {
+	newRoot := root.right
+	root.right = newRoot.left
+	newRoot.left = root
+	return newRoot
+}
+
+// remove returns a new root of a tree that doesn't contain key, and
+// whether key was in the tree at all
+func remove(root *node, key []byte) (*node, bool) {
+	if root == nil {
+		return nil, false
+	}
+
+	compareResult := bytes.Compare(key, root.key)
+	switch {
+	case compareResult < 0:
+		newLeft, removed := remove(root.left, key)
+		if !removed {
+			return root, false
+		}
+		newRoot := *root
+		newRoot.left = newLeft
+		return &newRoot, true
+	case compareResult > 0:
+		newRight, removed := remove(root.right, key)
+		if !removed {
+			return root, false
+		}
+		newRoot := *root
+		newRoot.right = newRight
+		return &newRoot, true
+	default:
+		return merge(root.left, root.right), true
+	}
+}
+
+// merge returns the root of a tree that contains all the nodes of the
+// given trees, where all the keys in left are smaller than the keys in right
+func merge(left *node, right *node) *node {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+
+	if left.priority > right.priority {
+		newRoot := *left
+		newRoot.right = merge(left.right, right)
+		return &newRoot
+	}
+	newRoot := *right
+	newRoot.left = merge(left, right.left)
+	return &newRoot
+}
+
+// iterator iterates in order over the nodes of a tree whose keys
+// start with a given prefix
+type iterator struct {
+	root   *node
+	prefix []byte
+
+	// stack holds the current node at its top, and below it the
+	// ancestors of the current node that come after it
+	stack []*node
+}
+
+func newIterator(root *node, prefix []byte) *iterator {
+	return &iterator{root: root, prefix: prefix}
+}
+
+// seek moves the iterator to the first node whose key is greater
+// than or equal to key, and returns whether there is such a node
+// with the iterator's prefix
+func (it *iterator) seek(key []byte) bool {
+	it.stack = it.stack[:0]
+	current := it.root
+	for current != nil {
+		if bytes.Compare(current.key, key) >= 0 {
+			it.stack = append(it.stack, current)
+			current = current.left
+		} else {
+			current = current.right
+		}
+	}
+	return it.valid()
+}
+
+// next moves the iterator to the following node, and returns
+// whether there is such a node with the iterator's prefix
+func (it *iterator) next() bool {
+	if len(it.stack) == 0 {
+		return false
+	}
+
+	current := it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	for child := current.right; child != nil; child = child.left {
+		it.stack = append(it.stack, child)
+	}
+	return it.valid()
+}
+
+// valid returns whether the iterator is positioned on a node with
+// the iterator's prefix. Once the iterator passes the last such node,
+// it's exhausted.
+func (it *iterator) valid() bool {
+	if len(it.stack) == 0 {
+		return false
+	}
+	if !bytes.HasPrefix(it.stack[len(it.stack)-1].key, it.prefix) {
+		it.stack = it.stack[:0]
+		return false
+	}
+	return true
+}
+
+// current returns the node the iterator is positioned on, or
+// nil if it's exhausted
+func (it *iterator) current() *node {
+	if len(it.stack) == 0 {
+		return nil
+	}
+	return it.stack[len(it.stack)-1]
+}
@@ -0,0 +1,95 @@
+package memdb
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestTreeAgainstMap(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	expected := make(map[string][]byte)
+	var root *node
+	var snapshotRoot *node
+	var snapshotExpected map[string][]byte
+
+	for i := 0; i < 10000; i++ {
+		key := []byte(fmt.Sprintf("key%d", random.Intn(1000)))
+		if random.Intn(3) == 0 {
+			root, _ = remove(root, key)
+			delete(expected, string(key))
+		} else {
+			value := []byte(fmt.Sprintf("value%d", i))
+			root = put(root, key, value, keyPriority(key))
+			expected[string(key)] = value
+		}
+
+		if i == 5000 {
+			snapshotRoot = root
+			snapshotExpected = make(map[string][]byte, len(expected))
+			for key, value := range expected {
+				snapshotExpected[key] = value
+			}
+		}
+	}
+
+	checkTree(t, root, expected)
+
+	// Modifications made after the snapshot was taken must not affect it
+	checkTree(t, snapshotRoot, snapshotExpected)
+}
+
+func checkTree(t *testing.T, root *node, expected map[string][]byte) {
+	for key, expectedValue := range expected {
+		node, ok := get(root, []byte(key))
+		if !ok {
+			t.Fatalf("key %s is missing", key)
+		}
+		if !bytes.Equal(node.value, expectedValue) {
+			t.Fatalf("unexpected value for key %s. Want: %s, got: %s", key, expectedValue, node.value)
+		}
+	}
+
+	expectedKeys := make([]string, 0, len(expected))
+	for key := range expected {
+		expectedKeys = append(expectedKeys, key)
+	}
+	sort.Strings(expectedKeys)
+
+	iterator := newIterator(root, []byte("key"))
+	i := 0
+	for ok := iterator.seek([]byte("key")); ok; ok = iterator.next() {
+		if i >= len(expectedKeys) {
+			t.Fatalf("iterator returned more keys than expected")
+		}
+		if string(iterator.current().key) != expectedKeys[i] {
+			t.Fatalf("unexpected key at position %d. Want: %s, got: %s",
+				i, expectedKeys[i], iterator.current().key)
+		}
+		i++
+	}
+	if i != len(expectedKeys) {
+		t.Fatalf("iterator returned %d keys instead of %d", i, len(expectedKeys))
+	}
+}
+
+func TestIteratorPrefix(t *testing.T) {
+	var root *node
+	for _, key := range []string{"a/1", "a/2", "b/1", "b/2", "c/1"} {
+		root = put(root, []byte(key), []byte(key), keyPriority([]byte(key)))
+	}
+
+	iterator := newIterator(root, []byte("b/"))
+	var keys []string
+	for ok := iterator.seek([]byte("b/")); ok; ok = iterator.next() {
+		keys = append(keys, string(iterator.current().key))
+	}
+	if len(keys) != 2 || keys[0] != "b/1" || keys[1] != "b/2" {
+		t.Fatalf("unexpected keys %s", keys)
+	}
+	if iterator.next() {
+		t.Fatalf("exhausted iterator unexpectedly moved")
+	}
+}
@@ -0,0 +1,36 @@
+package database
+
+// Options are the tunable parameters of a database. Backends
+// ignore the options that don't apply to them.
+type Options struct {
+	// CacheSizeMiB is the size of the cache of recently read data
+	CacheSizeMiB int
+
+	// WriteBufferSizeMiB is the amount of written data that is
+	// kept in memory before it's flushed to disk
+	WriteBufferSizeMiB int
+
+	// Compression enables compressing the data on disk
+	Compression bool
+
+	// Sync makes every write wait until it reaches the disk, so
+	// that it survives an operating system crash or a power loss
+	Sync bool
+
+	// SeeksCompaction enables compactions that are triggered
+	// by reads that have to seek through many files
+	SeeksCompaction bool
+
+	// ReadOnly opens an existing database without allowing any
+	// writes to it, so that it can be inspected safely
+	ReadOnly bool
+}
+
+// DefaultOptions returns the default options of a database
+// with a cache of the given size
+func DefaultOptions(cacheSizeMiB int) *Options {
+	return &Options{
+		CacheSizeMiB:       cacheSizeMiB,
+		WriteBufferSizeMiB: cacheSizeMiB / 2,
+	}
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Snapshot is a read-only view of a database, frozen at the point in
+// time in which it was taken. Writes made to the database after that
+// point are not visible through the snapshot.
+type Snapshot interface {
+	// Get gets the value for the given key. It returns
+	// ErrNotFound if the given key does not exist.
+	Get(key *Key) ([]byte, error)
+
+	// Has returns true if the database does contains the
+	// given key.
+	Has(key *Key) (bool, error)
+
+	// Cursor begins a new cursor over the given bucket.
+	Cursor(bucket *Bucket) (Cursor, error)
+
+	// Release releases the snapshot. It must not be used afterwards.
+	Release()
+}
+
+// snapshotCopyBatchSize is the number of entries that are
+// written in every transaction while copying a snapshot
+const snapshotCopyBatchSize = 10000
+
+// CopySnapshot copies all the data in the given snapshot into a new
+// database in path, using the backend that was registered under
+// backendName. path must not exist. The copy is written next to path
+// and only moved into path once it's complete, so path never holds
+// a partial copy.
+func CopySnapshot(snapshot Snapshot, backendName string, path string, options *Options) (err error) {
+	_, err = os.Stat(path)
+	if err == nil {
+		return errors.Errorf("cannot copy the snapshot to %s: path already exists", path)
+	}
+	if !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+
+	incompletePath := path + ".incomplete"
+	err = os.RemoveAll(incompletePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(incompletePath)
+		}
+	}()
+
+	destination, err := Open(backendName, incompletePath, options)
+	if err != nil {
+		return err
+	}
+	err = copySnapshotEntries(snapshot, destination)
+	closeErr := destination.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	return errors.WithStack(os.Rename(incompletePath, path))
+}
+
+func copySnapshotEntries(snapshot Snapshot, destination Database) error {
+	cursor, err := snapshot.Cursor(MakeBucket(nil))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	transaction, err := destination.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// transaction is replaced after every batch, so it's
+		// only evaluated once copying is done
+		_ = transaction.RollbackUnlessClosed()
+	}()
+
+	batchSize := 0
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		err = transaction.Put(key, value)
+		if err != nil {
+			return err
+		}
+
+		batchSize++
+		if batchSize < snapshotCopyBatchSize {
+			continue
+		}
+		err = transaction.Commit()
+		if err != nil {
+			return err
+		}
+		transaction, err = destination.Begin()
+		if err != nil {
+			return err
+		}
+		batchSize = 0
+	}
+	return transaction.Commit()
+}
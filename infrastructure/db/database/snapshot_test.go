@@ -0,0 +1,159 @@
+// All tests within this file should call testForAllDatabaseTypes
+// over the actual test. This is to make sure that all supported
+// database types adhere to the assumptions defined in the
+// interfaces in this package.
+
+package database_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/logdb"
+)
+
+func TestSnapshot(t *testing.T) {
+	testForAllDatabaseTypes(t, "TestSnapshot", testSnapshot)
+}
+
+func testSnapshot(t *testing.T, db database.Database, testName string) {
+	bucket := database.MakeBucket([]byte("bucket"))
+	key1 := bucket.Key([]byte("key1"))
+	key2 := bucket.Key([]byte("key2"))
+	value1 := []byte("value1")
+	value2 := []byte("value2")
+
+	err := db.Put(key1, value1)
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		t.Fatalf("%s: Snapshot unexpectedly failed: %s", testName, err)
+	}
+
+	// Modify the database after the snapshot was taken
+	err = db.Put(key1, value2)
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+	err = db.Put(key2, value2)
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+
+	// Make sure that the snapshot still sees the old data
+	snapshotValue, err := snapshot.Get(key1)
+	if err != nil {
+		t.Fatalf("%s: Get unexpectedly failed: %s", testName, err)
+	}
+	if !bytes.Equal(snapshotValue, value1) {
+		t.Fatalf("%s: Get returned wrong value. Want: %s, got: %s",
+			testName, value1, snapshotValue)
+	}
+	exists, err := snapshot.Has(key2)
+	if err != nil {
+		t.Fatalf("%s: Has unexpectedly failed: %s", testName, err)
+	}
+	if exists {
+		t.Fatalf("%s: Has unexpectedly returned true for a key "+
+			"that was added after the snapshot was taken", testName)
+	}
+
+	cursor, err := snapshot.Cursor(bucket)
+	if err != nil {
+		t.Fatalf("%s: Cursor unexpectedly failed: %s", testName, err)
+	}
+	entryCount := 0
+	for cursor.Next() {
+		entryCount++
+		cursorValue, err := cursor.Value()
+		if err != nil {
+			t.Fatalf("%s: Value unexpectedly failed: %s", testName, err)
+		}
+		if !bytes.Equal(cursorValue, value1) {
+			t.Fatalf("%s: Value returned wrong value. Want: %s, got: %s",
+				testName, value1, cursorValue)
+		}
+	}
+	if entryCount != 1 {
+		t.Fatalf("%s: Cursor unexpectedly iterated over %d entries. Want: 1",
+			testName, entryCount)
+	}
+	err = cursor.Close()
+	if err != nil {
+		t.Fatalf("%s: Close unexpectedly failed: %s", testName, err)
+	}
+
+	// Make sure that the database itself sees the new data
+	databaseValue, err := db.Get(key1)
+	if err != nil {
+		t.Fatalf("%s: Get unexpectedly failed: %s", testName, err)
+	}
+	if !bytes.Equal(databaseValue, value2) {
+		t.Fatalf("%s: Get returned wrong value. Want: %s, got: %s",
+			testName, value2, databaseValue)
+	}
+
+	// Make sure that a released snapshot can't be used
+	snapshot.Release()
+	_, err = snapshot.Get(key1)
+	if err == nil {
+		t.Fatalf("%s: Get unexpectedly succeeded on a released snapshot", testName)
+	}
+}
+
+func TestCopySnapshot(t *testing.T) {
+	testForAllDatabaseTypes(t, "TestCopySnapshot", testCopySnapshot)
+}
+
+func testCopySnapshot(t *testing.T, db database.Database, testName string) {
+	entries := populateDatabaseForTest(t, db, testName)
+
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		t.Fatalf("%s: Snapshot unexpectedly failed: %s", testName, err)
+	}
+	defer snapshot.Release()
+
+	path, err := ioutil.TempDir("", testName)
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly failed: %s", testName, err)
+	}
+	defer os.RemoveAll(path)
+	copyPath := filepath.Join(path, "copy")
+
+	err = database.CopySnapshot(snapshot, logdb.BackendName, copyPath, database.DefaultOptions(8))
+	if err != nil {
+		t.Fatalf("%s: CopySnapshot unexpectedly failed: %s", testName, err)
+	}
+
+	// Copying into an existing path must fail
+	err = database.CopySnapshot(snapshot, logdb.BackendName, copyPath, database.DefaultOptions(8))
+	if err == nil {
+		t.Fatalf("%s: CopySnapshot unexpectedly succeeded "+
+			"when copying into an existing path", testName)
+	}
+
+	copiedDB, err := database.Open(logdb.BackendName, copyPath, database.DefaultOptions(8))
+	if err != nil {
+		t.Fatalf("%s: Open unexpectedly failed: %s", testName, err)
+	}
+	defer copiedDB.Close()
+
+	for _, entry := range entries {
+		value, err := copiedDB.Get(entry.key)
+		if err != nil {
+			t.Fatalf("%s: Get unexpectedly failed: %s", testName, err)
+		}
+		if !bytes.Equal(value, entry.value) {
+			t.Fatalf("%s: Get returned wrong value. Want: %s, got: %s",
+				testName, entry.value, value)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package migration
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// CopyDatabase copies the database directory in sourcePath to
+// destinationPath, which must not exist. The database must be
+// closed while it's being copied.
+func CopyDatabase(sourcePath string, destinationPath string) error {
+	_, err := os.Stat(destinationPath)
+	if err == nil {
+		return errors.Errorf("cannot copy the database to %s: path already exists", destinationPath)
+	}
+	if !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+
+	return filepath.Walk(sourcePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		relativePath, err := filepath.Rel(sourcePath, path)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		destination := filepath.Join(destinationPath, relativePath)
+
+		if info.IsDir() {
+			return errors.WithStack(os.MkdirAll(destination, 0700))
+		}
+		return copyFile(path, destination)
+	})
+}
+
+func copyFile(sourcePath string, destinationPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer source.Close()
+
+	destination, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer destination.Close()
+
+	_, err = io.Copy(destination, source)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(destination.Sync())
+}
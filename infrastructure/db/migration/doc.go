@@ -0,0 +1,11 @@
+/*
+Package migration upgrades kaspad databases created by older versions of
+kaspad in place, so that serialization changes don't force a resync.
+
+Every change to the way data is stored bumps the database version and adds a
+Migration to that version. On startup, Run applies all the migrations between
+the version stored in the database and the current version, in order, each in
+a transaction of its own. CopyDatabase may be used to back up the database
+before migrating it, or to try the migrations out on a copy.
+*/
+package migration
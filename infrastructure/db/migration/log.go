@@ -0,0 +1,7 @@
+package migration
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("MIGR")
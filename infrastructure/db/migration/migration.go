@@ -0,0 +1,148 @@
+package migration
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+var versionKey = database.MakeBucket(nil).Key([]byte("database-version"))
+
+// Migration upgrades the database from the version before it to Version
+type Migration struct {
+	// Version is the database version after the migration is applied
+	Version int
+
+	// Description briefly describes what the migration changes
+	Description string
+
+	// Migrate applies the migration using the given transaction. Note that
+	// data put into the transaction can't be read back from it, so
+	// migrations should not depend on their own writes.
+	Migrate func(databaseTransaction database.Transaction, progress *Progress) error
+}
+
+// StoredVersion returns the database version that is stored in the database.
+// Databases created before versions were stored in the database don't have one,
+// in which case found is false.
+func StoredVersion(dataAccessor database.DataAccessor) (version int, found bool, err error) {
+	versionBytes, err := dataAccessor.Get(versionKey)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if len(versionBytes) != 8 {
+		return 0, false, errors.Errorf("stored database version has an unexpected length %d", len(versionBytes))
+	}
+	return int(binary.LittleEndian.Uint64(versionBytes)), true, nil
+}
+
+// StoreVersion stores the given database version in the database
+func StoreVersion(dataAccessor database.DataAccessor, version int) error {
+	var versionBytes [8]byte
+	binary.LittleEndian.PutUint64(versionBytes[:], uint64(version))
+	return dataAccessor.Put(versionKey, versionBytes[:])
+}
+
+// Run upgrades the database to targetVersion by applying, in order, all the
+// given migrations above the database's version. Databases without a stored
+// version are assumed to be of unversionedDatabaseVersion.
+// Every migration is applied in a transaction of its own that also stores its
+// version, so a failed migration leaves the database at the previous version.
+func Run(db database.Database, unversionedDatabaseVersion int, targetVersion int, migrations []*Migration) error {
+	version, found, err := StoredVersion(db)
+	if err != nil {
+		return err
+	}
+	if !found {
+		version = unversionedDatabaseVersion
+	}
+	if version > targetVersion {
+		return errors.Errorf("database version %d is newer than the supported version %d", version, targetVersion)
+	}
+
+	pendingMigrations, err := pendingMigrations(version, targetVersion, migrations)
+	if err != nil {
+		return err
+	}
+	if len(pendingMigrations) == 0 {
+		return nil
+	}
+
+	log.Infof("Migrating the database from version %d to version %d", version, targetVersion)
+	for i, migration := range pendingMigrations {
+		log.Infof("Running database migration %d/%d to version %d: %s",
+			i+1, len(pendingMigrations), migration.Version, migration.Description)
+
+		err := runMigration(db, migration)
+		if err != nil {
+			return errors.Wrapf(err, "failed migrating the database to version %d", migration.Version)
+		}
+	}
+	log.Infof("Finished migrating the database to version %d", targetVersion)
+
+	return nil
+}
+
+// pendingMigrations returns the migrations that upgrade the database from
+// version to targetVersion, making sure that none of the versions in between
+// is missing
+func pendingMigrations(version int, targetVersion int, migrations []*Migration) ([]*Migration, error) {
+	pending := make([]*Migration, 0, targetVersion-version)
+	for _, migration := range migrations {
+		if migration.Version <= version || migration.Version > targetVersion {
+			continue
+		}
+		expectedVersion := version + len(pending) + 1
+		if migration.Version != expectedVersion {
+			break
+		}
+		pending = append(pending, migration)
+	}
+
+	if version+len(pending) != targetVersion {
+		return nil, errors.Errorf("no migration path from database version %d to version %d: "+
+			"missing a migration to version %d", version, targetVersion, version+len(pending)+1)
+	}
+	return pending, nil
+}
+
+func runMigration(db database.Database, migration *Migration) (err error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "runMigration")
+	defer onEnd()
+
+	databaseTransaction, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		rollbackErr := databaseTransaction.RollbackUnlessClosed()
+		if err == nil {
+			err = rollbackErr
+		}
+	}()
+
+	progress := newProgress(migration.Version)
+	err = migration.Migrate(databaseTransaction, progress)
+	if err != nil {
+		return err
+	}
+
+	err = StoreVersion(databaseTransaction, migration.Version)
+	if err != nil {
+		return err
+	}
+	err = databaseTransaction.Commit()
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Migrated the database to version %d (%d entries processed in %s)",
+		migration.Version, progress.processed, time.Since(progress.start).Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,154 @@
+package migration
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+var testBucket = database.MakeBucket([]byte("test"))
+
+func putMigration(version int, key string, value string) *Migration {
+	return &Migration{
+		Version:     version,
+		Description: "put " + key,
+		Migrate: func(databaseTransaction database.Transaction, progress *Progress) error {
+			progress.SetTotal(1)
+			defer progress.Add(1)
+			return databaseTransaction.Put(testBucket.Key([]byte(key)), []byte(value))
+		},
+	}
+}
+
+func openTestDatabase(t *testing.T, path string) database.Database {
+	db, err := ldb.NewLevelDB(path, 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %s", err)
+	}
+	return db
+}
+
+func checkVersion(t *testing.T, db database.Database, expectedVersion int) {
+	version, found, err := StoredVersion(db)
+	if err != nil {
+		t.Fatalf("StoredVersion: %s", err)
+	}
+	if !found {
+		t.Fatalf("expected a stored version")
+	}
+	if version != expectedVersion {
+		t.Fatalf("expected version %d but got %d", expectedVersion, version)
+	}
+}
+
+func TestRun(t *testing.T) {
+	db := openTestDatabase(t, t.TempDir())
+	defer db.Close()
+
+	migrations := []*Migration{putMigration(2, "a", "1"), putMigration(3, "b", "2")}
+	err := Run(db, 1, 3, migrations)
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	checkVersion(t, db, 3)
+	for key, expectedValue := range map[string]string{"a": "1", "b": "2"} {
+		value, err := db.Get(testBucket.Key([]byte(key)))
+		if err != nil {
+			t.Fatalf("Get: %s", err)
+		}
+		if string(value) != expectedValue {
+			t.Fatalf("expected %s for key %s but got %s", expectedValue, key, value)
+		}
+	}
+
+	// Running again is a no-op
+	failingMigrations := []*Migration{{Version: 3, Migrate: func(database.Transaction, *Progress) error {
+		return errors.New("should not run")
+	}}}
+	err = Run(db, 1, 3, failingMigrations)
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+}
+
+func TestRunMissingMigration(t *testing.T) {
+	db := openTestDatabase(t, t.TempDir())
+	defer db.Close()
+
+	err := Run(db, 1, 3, []*Migration{putMigration(3, "b", "2")})
+	if err == nil {
+		t.Fatalf("expected an error for a missing migration")
+	}
+	_, found, err := StoredVersion(db)
+	if err != nil {
+		t.Fatalf("StoredVersion: %s", err)
+	}
+	if found {
+		t.Fatalf("expected no stored version")
+	}
+}
+
+func TestRunFailedMigration(t *testing.T) {
+	db := openTestDatabase(t, t.TempDir())
+	defer db.Close()
+
+	failingMigration := &Migration{
+		Version: 3,
+		Migrate: func(databaseTransaction database.Transaction, _ *Progress) error {
+			err := databaseTransaction.Put(testBucket.Key([]byte("c")), []byte("3"))
+			if err != nil {
+				return err
+			}
+			return errors.New("failure")
+		},
+	}
+	err := Run(db, 1, 3, []*Migration{putMigration(2, "a", "1"), failingMigration})
+	if err == nil {
+		t.Fatalf("expected the failing migration to fail")
+	}
+
+	checkVersion(t, db, 2)
+	exists, err := db.Has(testBucket.Key([]byte("c")))
+	if err != nil {
+		t.Fatalf("Has: %s", err)
+	}
+	if exists {
+		t.Fatalf("expected the writes of the failed migration to be rolled back")
+	}
+}
+
+func TestCopyDatabase(t *testing.T) {
+	sourcePath := filepath.Join(t.TempDir(), "source")
+	db := openTestDatabase(t, sourcePath)
+	err := db.Put(testBucket.Key([]byte("a")), []byte("1"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	destinationPath := filepath.Join(t.TempDir(), "destination")
+	err = CopyDatabase(sourcePath, destinationPath)
+	if err != nil {
+		t.Fatalf("CopyDatabase: %s", err)
+	}
+	err = CopyDatabase(sourcePath, destinationPath)
+	if err == nil {
+		t.Fatalf("expected copying to an existing path to fail")
+	}
+
+	copiedDB := openTestDatabase(t, destinationPath)
+	defer copiedDB.Close()
+	value, err := copiedDB.Get(testBucket.Key([]byte("a")))
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if string(value) != "1" {
+		t.Fatalf("expected 1 but got %s", value)
+	}
+}
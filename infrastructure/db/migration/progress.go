@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"time"
+)
+
+// progressLogInterval is the minimal interval between two
+// consecutive progress logs of a single migration
+const progressLogInterval = 10 * time.Second
+
+// Progress logs the progress of a migration as it processes database entries
+type Progress struct {
+	version   int
+	total     uint64
+	processed uint64
+	start     time.Time
+	lastLog   time.Time
+}
+
+func newProgress(version int) *Progress {
+	now := time.Now()
+	return &Progress{
+		version: version,
+		start:   now,
+		lastLog: now,
+	}
+}
+
+// SetTotal sets the total number of entries the migration is going to process,
+// if it's known in advance
+func (p *Progress) SetTotal(total uint64) {
+	p.total = total
+}
+
+// Add marks the given number of entries as processed, and
+// logs the progress if enough time passed since it was last logged
+func (p *Progress) Add(processed uint64) {
+	p.processed += processed
+	if time.Since(p.lastLog) < progressLogInterval {
+		return
+	}
+	p.lastLog = time.Now()
+
+	if p.total > 0 {
+		log.Infof("Migration to version %d: processed %d out of %d entries (%.2f%%)",
+			p.version, p.processed, p.total, float64(p.processed)*100/float64(p.total))
+		return
+	}
+	log.Infof("Migration to version %d: processed %d entries", p.version, p.processed)
+}
@@ -0,0 +1,11 @@
+/*
+Package health serves the health and the readiness of a node over HTTP, so
+that orchestrators and load balancers can tell whether it's alive and whether
+it should be relied upon.
+
+Both /health and /ready respond with the current status of the node as JSON.
+/health responds with 200 OK whenever the status can be retrieved, and /ready
+only does so once the node is ready, that is, synced with the network. Both
+respond with 503 Service Unavailable otherwise.
+*/
+package health
@@ -0,0 +1,9 @@
+package health
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("HLTH")
+var spawn = panics.GoroutineWrapperFunc(log)
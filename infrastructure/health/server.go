@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// StatusFunc returns the current status of the node, which is
+// encoded as JSON, and whether the node is ready
+type StatusFunc func() (status interface{}, isReady bool, err error)
+
+// Server serves the status of a node over HTTP, at /health and /ready
+type Server struct {
+	listenAddress string
+	statusFunc    StatusFunc
+	httpServer    *http.Server
+}
+
+// NewServer returns a new Server that listens on the given address and serves
+// the status that statusFunc returns. Use Start() to begin serving.
+func NewServer(listenAddress string, statusFunc StatusFunc) *Server {
+	server := &Server{
+		listenAddress: listenAddress,
+		statusFunc:    statusFunc,
+	}
+
+	server.httpServer = &http.Server{
+		Handler:           server.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server
+}
+
+// Handler returns the http.Handler that serves /health and /ready
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/ready", s.handleReady)
+	return mux
+}
+
+// Start begins listening and serving the status of the node
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.listenAddress)
+	if err != nil {
+		return errors.Wrapf(err, "error listening on %s", s.listenAddress)
+	}
+
+	spawn("health.Server.Start-Serve", func() {
+		err := s.httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("Error serving health checks on %s: %s", s.listenAddress, err)
+		}
+	})
+
+	log.Infof("Health server listening on %s", listener.Addr())
+	return nil
+}
+
+// Stop stops serving the status of the node
+func (s *Server) Stop() error {
+	const stopTimeout = 2 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	status, _, err := s.statusFunc()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeStatus(w, http.StatusOK, status)
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, _ *http.Request) {
+	status, isReady, err := s.statusFunc()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !isReady {
+		writeStatus(w, http.StatusServiceUnavailable, status)
+		return
+	}
+	writeStatus(w, http.StatusOK, status)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	log.Debugf("Error getting the status of the node: %s", err)
+	writeStatus(w, http.StatusServiceUnavailable, struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+func writeStatus(w http.ResponseWriter, statusCode int, status interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	err := json.NewEncoder(w).Encode(status)
+	if err != nil {
+		log.Debugf("Error writing the status of the node: %s", err)
+	}
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type testStatus struct {
+	IsSynced bool `json:"isSynced"`
+}
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name               string
+		isReady            bool
+		err                error
+		path               string
+		expectedStatusCode int
+	}{
+		{name: "healthy and ready", isReady: true, path: "/health", expectedStatusCode: http.StatusOK},
+		{name: "healthy but not ready", isReady: false, path: "/health", expectedStatusCode: http.StatusOK},
+		{name: "unhealthy", err: errors.New("failure"), path: "/health", expectedStatusCode: http.StatusServiceUnavailable},
+		{name: "ready", isReady: true, path: "/ready", expectedStatusCode: http.StatusOK},
+		{name: "not ready", isReady: false, path: "/ready", expectedStatusCode: http.StatusServiceUnavailable},
+		{name: "ready but failing", isReady: true, err: errors.New("failure"), path: "/ready", expectedStatusCode: http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		server := NewServer("", func() (interface{}, bool, error) {
+			return &testStatus{IsSynced: test.isReady}, test.isReady, test.err
+		})
+
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+		if recorder.Code != test.expectedStatusCode {
+			t.Errorf("%s: got status code %d, want %d", test.name, recorder.Code, test.expectedStatusCode)
+		}
+		if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("%s: got content type %s, want application/json", test.name, contentType)
+		}
+
+		var body map[string]interface{}
+		err := json.Unmarshal(recorder.Body.Bytes(), &body)
+		if err != nil {
+			t.Fatalf("%s: the body is not valid JSON: %s", test.name, err)
+		}
+		_, hasError := body["error"]
+		if hasError != (test.err != nil) {
+			t.Errorf("%s: got body %s, want an error only if the status failed", test.name, recorder.Body)
+		}
+		if test.err == nil && body["isSynced"] != test.isReady {
+			t.Errorf("%s: got body %s, want isSynced to be %t", test.name, recorder.Body, test.isReady)
+		}
+	}
+}
@@ -54,6 +54,7 @@ const logsBuffer = 0
 // subsystems.
 type Backend struct {
 	flag      uint32
+	format    Format
 	isRunning uint32
 	writers   []logWriter
 	writeChan chan logEntry
@@ -91,6 +92,16 @@ func (lw logWriterWrap) LogLevel() Level {
 	return lw.logLevel
 }
 
+// SetFormat sets the format in which the backend writes log entries.
+// It must be called before the backend is running.
+func (b *Backend) SetFormat(format Format) error {
+	if b.IsRunning() {
+		return errors.New("The logger is already running")
+	}
+	b.format = format
+	return nil
+}
+
 // AddLogFile adds a file which the log will write into on a certain
 // log level with the default log rotation settings. It'll create the file if it doesn't exist.
 func (b *Backend) AddLogFile(logFile string, logLevel Level) error {
@@ -187,5 +198,6 @@ func (b *Backend) Close() {
 // Backend b. A tag describes the subsystem and is included in all log
 // messages. The logger uses the info verbosity level by default.
 func (b *Backend) Logger(subsystemTag string) *Logger {
-	return &Logger{LevelOff, subsystemTag, b, b.writeChan}
+	lvl := LevelOff
+	return &Logger{lvl: &lvl, tag: subsystemTag, b: b, writeChan: b.writeChan}
 }
@@ -19,5 +19,9 @@ are recognized:
 
   shortfile: Include the filename and line number in all log messages.
   Overrides longfile.
+
+Backends write log entries as text lines by default. Backends that are set to
+FormatJSON write every log entry as a single-line JSON object instead, which
+also includes the structured fields attached to loggers with WithFields.
 */
 package logger
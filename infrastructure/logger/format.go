@@ -0,0 +1,42 @@
+package logger
+
+import "strings"
+
+// Format is the format in which a Backend writes log entries.
+type Format uint32
+
+// Format constants.
+const (
+	// FormatText writes every log entry as a line in the form
+	// 'YYYY-MM-DD hh:mm:ss.sss [LVL] TAG: message'.
+	FormatText Format = iota
+
+	// FormatJSON writes every log entry as a single-line JSON object
+	// that includes the structured fields of the logger.
+	FormatJSON
+)
+
+// formatStrs defines the names for each logging format.
+var formatStrs = [...]string{"text", "json"}
+
+// FormatFromString returns a format based on the input string s. If the input
+// can't be interpreted as a valid log format, the text format and false is
+// returned.
+func FormatFromString(s string) (f Format, ok bool) {
+	switch strings.ToLower(s) {
+	case "text":
+		return FormatText, true
+	case "json":
+		return FormatJSON, true
+	default:
+		return FormatText, false
+	}
+}
+
+// String returns the name of the format.
+func (f Format) String() string {
+	if int(f) >= len(formatStrs) {
+		return "unknown"
+	}
+	return formatStrs[f]
+}
@@ -45,6 +45,16 @@ func RegisterSubSystem(subsystem string) *Logger {
 	return logger
 }
 
+// SetLogFormat sets the format of the backend log. It must be called
+// before InitLog.
+func SetLogFormat(logFormat string) error {
+	format, ok := FormatFromString(logFormat)
+	if !ok {
+		return errors.Errorf("'%s' Isn't a valid log format", logFormat)
+	}
+	return BackendLog.SetFormat(format)
+}
+
 // InitLogStdout attaches stdout to the backend log and starts the logger.
 func InitLogStdout(logLevel Level) {
 	err := BackendLog.AddLogWriter(os.Stdout, logLevel)
@@ -144,22 +154,45 @@ func getSubsystem(tag string) (logger *Logger, ok bool) {
 
 // ParseAndSetLogLevels attempts to parse the specified debug level and set
 // the levels accordingly. An appropriate error is returned if anything is
-// invalid.
+// invalid, in which case no level is changed.
 func ParseAndSetLogLevels(logLevel string) error {
+	setLogLevels, err := parseLogLevels(logLevel)
+	if err != nil {
+		return err
+	}
+	setLogLevels()
+	return nil
+}
+
+// ValidateLogLevels returns the error ParseAndSetLogLevels would return for
+// the specified debug level, without changing any level.
+func ValidateLogLevels(logLevel string) error {
+	_, err := parseLogLevels(logLevel)
+	return err
+}
+
+// parseLogLevels parses and validates the specified debug level, and returns
+// a function that sets the levels accordingly.
+func parseLogLevels(logLevel string) (setLogLevels func(), err error) {
 	// When the specified string doesn't have any delimters, treat it as
 	// the log level for all subsystems.
 	if !strings.Contains(logLevel, ",") && !strings.Contains(logLevel, "=") {
-		// Validate and change the logging level for all subsystems.
-		return SetLogLevelsString(logLevel)
+		// Validate the logging level for all subsystems.
+		level, ok := LevelFromString(logLevel)
+		if !ok {
+			return nil, errors.Errorf("'%s' Isn't a valid log level", logLevel)
+		}
+		return func() { SetLogLevels(level) }, nil
 	}
 
 	// Split the specified string into subsystem/level pairs while detecting
-	// issues and update the log levels accordingly.
+	// issues, and only update the log levels once all of them are valid.
+	levels := make(map[*Logger]Level)
 	for _, logLevelPair := range strings.Split(logLevel, ",") {
 		if !strings.Contains(logLevelPair, "=") {
 			str := "The specified debug level contains an invalid " +
 				"subsystem/level pair [%s]"
-			return errors.Errorf(str, logLevelPair)
+			return nil, errors.Errorf(str, logLevelPair)
 		}
 
 		// Extract the specified subsystem and log level.
@@ -167,16 +200,36 @@ func ParseAndSetLogLevels(logLevel string) error {
 		subsysID, logLevel := fields[0], fields[1]
 
 		// Validate subsystem.
-		if _, exists := getSubsystem(subsysID); !exists {
+		logger, exists := getSubsystem(subsysID)
+		if !exists {
 			str := "The specified subsystem [%s] is invalid -- " +
 				"supported subsytems %s"
-			return errors.Errorf(str, subsysID, strings.Join(SupportedSubsystems(), ", "))
+			return nil, errors.Errorf(str, subsysID, strings.Join(SupportedSubsystems(), ", "))
 		}
 
-		err := SetLogLevel(subsysID, logLevel)
-		if err != nil {
-			return err
+		// Validate log level.
+		level, ok := LevelFromString(logLevel)
+		if !ok {
+			return nil, errors.Errorf("'%s' Isn't a valid log level", logLevel)
 		}
+		levels[logger] = level
 	}
-	return nil
+
+	return func() {
+		for logger, level := range levels {
+			logger.SetLevel(level)
+		}
+	}, nil
+}
+
+// LogLevels returns the current logging level of every subsystem logger,
+// keyed by subsystem identifier.
+func LogLevels() map[string]Level {
+	subsystemLoggersMutex.Lock()
+	defer subsystemLoggersMutex.Unlock()
+	levels := make(map[string]Level, len(subsystemLoggers))
+	for subsysID, logger := range subsystemLoggers {
+		levels[subsysID] = logger.Level()
+	}
+	return levels
 }
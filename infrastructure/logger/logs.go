@@ -33,22 +33,43 @@
 package logger
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/kaspanet/kaspad/util/mstime"
 	"os"
 	"runtime"
+	"sort"
 	"sync/atomic"
 )
 
 // Logger is a subsystem logger for a Backend.
 type Logger struct {
-	lvl       Level // atomic
+	lvl       *Level // atomic
 	tag       string
+	fields    Fields
 	b         *Backend
 	writeChan chan<- logEntry
 }
 
+// Fields are structured fields that are attached to log entries, such as
+// a block hash or a peer address. They are only written by backends that
+// use FormatJSON.
+type Fields map[string]interface{}
+
+// WithFields returns a logger that writes the given fields, in addition to
+// the fields of l, with every log entry. The returned logger shares its
+// logging level with l.
+func (l *Logger) WithFields(fields Fields) *Logger {
+	mergedFields := make(Fields, len(l.fields)+len(fields))
+	for key, value := range l.fields {
+		mergedFields[key] = value
+	}
+	for key, value := range fields {
+		mergedFields[key] = value
+	}
+	return &Logger{lvl: l.lvl, tag: l.tag, fields: mergedFields, b: l.b, writeChan: l.writeChan}
+}
+
 type logEntry struct {
 	log   []byte
 	level Level
@@ -146,12 +167,12 @@ func (l *Logger) Writef(logLevel Level, format string, args ...interface{}) {
 
 // Level returns the current logging level
 func (l *Logger) Level() Level {
-	return Level(atomic.LoadUint32((*uint32)(&l.lvl)))
+	return Level(atomic.LoadUint32((*uint32)(l.lvl)))
 }
 
 // SetLevel changes the logging level to the passed level.
 func (l *Logger) SetLevel(level Level) {
-	atomic.StoreUint32((*uint32)(&l.lvl), uint32(level))
+	atomic.StoreUint32((*uint32)(l.lvl), uint32(level))
 }
 
 // Backend returns the log backend
@@ -160,9 +181,7 @@ func (l *Logger) Backend() *Backend {
 }
 
 // printf outputs a log message to the writer associated with the backend after
-// creating a prefix for the given level and tag according to the formatHeader
-// function and formatting the provided arguments according to the given format
-// specifier.
+// formatting the provided arguments according to the given format specifier.
 func (l *Logger) printf(lvl Level, tag string, format string, args ...interface{}) {
 	t := mstime.Now() // get as early as possible
 
@@ -172,24 +191,11 @@ func (l *Logger) printf(lvl Level, tag string, format string, args ...interface{
 		file, line = callsite(l.b.flag)
 	}
 
-	buf := make([]byte, 0, normalLogSize)
-
-	formatHeader(&buf, t, lvl.String(), tag, file, line)
-	bytesBuf := bytes.NewBuffer(buf)
-	_, _ = fmt.Fprintf(bytesBuf, format, args...)
-	bytesBuf.WriteByte('\n')
-
-	if !l.b.IsRunning() {
-		_, _ = fmt.Fprintf(os.Stderr, bytesBuf.String())
-		panic("Writing to the logger when it's not running")
-	}
-	l.writeChan <- logEntry{bytesBuf.Bytes(), lvl}
+	l.writeEntry(t, lvl, tag, file, line, fmt.Sprintf(format, args...))
 }
 
 // print outputs a log message to the writer associated with the backend after
-// creating a prefix for the given level and tag according to the formatHeader
-// function and formatting the provided arguments using the default formatting
-// rules.
+// formatting the provided arguments using the default formatting rules.
 func (l *Logger) print(lvl Level, tag string, args ...interface{}) {
 	if atomic.LoadUint32(&l.b.isRunning) == 0 {
 		panic("printing log without initializing")
@@ -202,15 +208,27 @@ func (l *Logger) print(lvl Level, tag string, args ...interface{}) {
 		file, line = callsite(l.b.flag)
 	}
 
+	message := fmt.Sprintln(args...)
+	l.writeEntry(t, lvl, tag, file, line, message[:len(message)-1])
+}
+
+// writeEntry formats a log entry according to the format of the backend and
+// sends it to the backend's writers.
+func (l *Logger) writeEntry(t mstime.Time, lvl Level, tag string, file string, line int, message string) {
 	buf := make([]byte, 0, normalLogSize)
-	formatHeader(&buf, t, lvl.String(), tag, file, line)
-	bytesBuf := bytes.NewBuffer(buf)
-	_, _ = fmt.Fprintln(bytesBuf, args...)
+	if l.b.format == FormatJSON {
+		formatJSON(&buf, t, lvl.String(), tag, file, line, message, l.fields)
+	} else {
+		formatHeader(&buf, t, lvl.String(), tag, file, line)
+		buf = append(buf, message...)
+	}
+	buf = append(buf, '\n')
 
 	if !l.b.IsRunning() {
+		_, _ = fmt.Fprint(os.Stderr, string(buf))
 		panic("Writing to the logger when it's not running")
 	}
-	l.writeChan <- logEntry{bytesBuf.Bytes(), lvl}
+	l.writeChan <- logEntry{buf, lvl}
 }
 
 // From stdlib log package.
@@ -265,6 +283,64 @@ func formatHeader(buf *[]byte, t mstime.Time, lvl, tag string, file string, line
 	*buf = append(*buf, ": "...)
 }
 
+// formatJSON appends a log entry as a single-line JSON object in the form
+// '{"time":...,"level":...,"subsystem":...,"message":...}', followed by the
+// file and line of the callsite if either of the LogFlagShortFile or
+// LogFlagLongFile flags are specified, and by the fields sorted by key.
+func formatJSON(buf *[]byte, t mstime.Time, lvl, tag string, file string, line int,
+	message string, fields Fields) {
+
+	appendJSONField := func(key string, value interface{}) {
+		if len(*buf) > 0 {
+			*buf = append(*buf, ',')
+		} else {
+			*buf = append(*buf, '{')
+		}
+		*buf = append(*buf, marshalJSONValue(key)...)
+		*buf = append(*buf, ':')
+		*buf = append(*buf, marshalJSONValue(value)...)
+	}
+
+	appendJSONField("time", t.ToNativeTime().UTC().Format(jsonTimeFormat))
+	appendJSONField("level", lvl)
+	appendJSONField("subsystem", tag)
+	if file != "" {
+		appendJSONField("file", fmt.Sprintf("%s:%d", file, line))
+	}
+	appendJSONField("message", message)
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		appendJSONField(key, fields[key])
+	}
+	*buf = append(*buf, '}')
+}
+
+// jsonTimeFormat is the format of the time of JSON log entries
+const jsonTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// marshalJSONValue marshals value to JSON. Values that don't marshal
+// to JSON themselves but have a string representation, such as hashes
+// and errors, are written as that string.
+func marshalJSONValue(value interface{}) []byte {
+	switch typedValue := value.(type) {
+	case json.Marshaler:
+	case error:
+		value = typedValue.Error()
+	case fmt.Stringer:
+		value = typedValue.String()
+	}
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		serialized, _ = json.Marshal(fmt.Sprintf("%+v", value))
+	}
+	return serialized
+}
+
 // calldepth is the call depth of the callsite function relative to the
 // caller of the subsystem logger. It is used to recover the filename and line
 // number of the logging call if either the short or long file flags are
@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type closeableBuffer struct {
+	bytes.Buffer
+}
+
+func (b *closeableBuffer) Close() error {
+	return nil
+}
+
+type testStringer struct{}
+
+func (testStringer) String() string {
+	return "stringer"
+}
+
+func runTestBackend(t *testing.T, format Format, logFunc func(log *Logger)) string {
+	backend := NewBackendWithFlags(0)
+	err := backend.SetFormat(format)
+	if err != nil {
+		t.Fatalf("SetFormat: %s", err)
+	}
+	buffer := &closeableBuffer{}
+	err = backend.AddLogWriter(buffer, LevelTrace)
+	if err != nil {
+		t.Fatalf("AddLogWriter: %s", err)
+	}
+	err = backend.Run()
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+
+	log := backend.Logger("TEST")
+	log.SetLevel(LevelInfo)
+	logFunc(log)
+	backend.Close()
+
+	return buffer.String()
+}
+
+func TestTextFormat(t *testing.T) {
+	output := runTestBackend(t, FormatText, func(log *Logger) {
+		log.WithFields(Fields{"peer": "127.0.0.1:16111"}).Infof("Connected to %s", "127.0.0.1:16111")
+		log.Debugf("This should be filtered")
+		log.Warn("Some", "warning")
+	})
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), output)
+	}
+	if !strings.HasSuffix(lines[0], " [INF] TEST: Connected to 127.0.0.1:16111") {
+		t.Errorf("unexpected first line %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], " [WRN] TEST: Some warning") {
+		t.Errorf("unexpected second line %q", lines[1])
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	output := runTestBackend(t, FormatJSON, func(log *Logger) {
+		peerLog := log.WithFields(Fields{"peer": "127.0.0.1:16111"})
+		peerLog.WithFields(Fields{"hash": testStringer{}, "count": 3, "err": errors.New("failure")}).
+			Infof("Accepted block %s", "stringer")
+
+		// Changing the level of a logger also changes the level of
+		// the loggers derived from it
+		log.SetLevel(LevelWarn)
+		peerLog.Infof("This should be filtered")
+		peerLog.Warn("Some", "warning")
+	})
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), output)
+	}
+
+	tests := []struct {
+		line     string
+		expected map[string]interface{}
+	}{
+		{
+			line: lines[0],
+			expected: map[string]interface{}{
+				"level":     "INF",
+				"subsystem": "TEST",
+				"message":   "Accepted block stringer",
+				"peer":      "127.0.0.1:16111",
+				"hash":      "stringer",
+				"count":     float64(3),
+				"err":       "failure",
+			},
+		},
+		{
+			line: lines[1],
+			expected: map[string]interface{}{
+				"level":     "WRN",
+				"subsystem": "TEST",
+				"message":   "Some warning",
+				"peer":      "127.0.0.1:16111",
+			},
+		},
+	}
+	for _, test := range tests {
+		entry := make(map[string]interface{})
+		err := json.Unmarshal([]byte(test.line), &entry)
+		if err != nil {
+			t.Fatalf("line %q is not valid JSON: %s", test.line, err)
+		}
+		if _, ok := entry["time"]; !ok {
+			t.Errorf("line %q has no time", test.line)
+		}
+		delete(entry, "time")
+		if len(entry) != len(test.expected) {
+			t.Errorf("expected %d fields in line %q, got %d", len(test.expected)+1, test.line, len(entry)+1)
+		}
+		for key, expectedValue := range test.expected {
+			if entry[key] != expectedValue {
+				t.Errorf("expected %s to be %v in line %q, got %v", key, expectedValue, test.line, entry[key])
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"bufio"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a metric whose value only goes up
+type Counter struct {
+	name string
+	help string
+
+	// bits holds the float64 value of the counter
+	bits uint64
+}
+
+// NewCounter creates a new counter and registers it in the DefaultRegistry
+func NewCounter(name string, help string) *Counter {
+	counter := newCounter(name, help)
+	DefaultRegistry.MustRegister(counter)
+	return counter
+}
+
+func newCounter(name string, help string) *Counter {
+	return &Counter{name: name, help: help}
+}
+
+// Name returns the name of the counter
+func (c *Counter) Name() string {
+	return c.name
+}
+
+// Inc increments the counter by 1
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add increments the counter by the given non-negative value
+func (c *Counter) Add(value float64) {
+	if value < 0 {
+		panic("counters can't be decreased")
+	}
+	for {
+		oldBits := atomic.LoadUint64(&c.bits)
+		newBits := math.Float64bits(math.Float64frombits(oldBits) + value)
+		if atomic.CompareAndSwapUint64(&c.bits, oldBits, newBits) {
+			return
+		}
+	}
+}
+
+// Value returns the current value of the counter
+func (c *Counter) Value() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&c.bits))
+}
+
+func (c *Counter) write(w *bufio.Writer) error {
+	writeHeader(w, c.name, c.help, counterType)
+	writeSample(w, c.name, nil, c.Value())
+	return nil
+}
+
+// CounterVec is a set of counters that share a name and are
+// told apart by the value of a single label
+type CounterVec struct {
+	name      string
+	help      string
+	labelName string
+
+	lock     sync.RWMutex
+	counters map[string]*Counter
+}
+
+// NewCounterVec creates a new counter vector and registers it in the DefaultRegistry
+func NewCounterVec(name string, help string, labelName string) *CounterVec {
+	counterVec := &CounterVec{
+		name:      name,
+		help:      help,
+		labelName: labelName,
+		counters:  make(map[string]*Counter),
+	}
+	DefaultRegistry.MustRegister(counterVec)
+	return counterVec
+}
+
+// Name returns the name of the counter vector
+func (cv *CounterVec) Name() string {
+	return cv.name
+}
+
+// WithLabel returns the counter for the given label value, creating it if needed
+func (cv *CounterVec) WithLabel(labelValue string) *Counter {
+	cv.lock.RLock()
+	counter, ok := cv.counters[labelValue]
+	cv.lock.RUnlock()
+	if ok {
+		return counter
+	}
+
+	cv.lock.Lock()
+	defer cv.lock.Unlock()
+	counter, ok = cv.counters[labelValue]
+	if !ok {
+		counter = newCounter(cv.name, cv.help)
+		cv.counters[labelValue] = counter
+	}
+	return counter
+}
+
+func (cv *CounterVec) write(w *bufio.Writer) error {
+	cv.lock.RLock()
+	labelValues := make([]string, 0, len(cv.counters))
+	for labelValue := range cv.counters {
+		labelValues = append(labelValues, labelValue)
+	}
+	cv.lock.RUnlock()
+	sort.Strings(labelValues)
+
+	writeHeader(w, cv.name, cv.help, counterType)
+	for _, labelValue := range labelValues {
+		writeSample(w, cv.name, []label{{cv.labelName, labelValue}}, cv.WithLabel(labelValue).Value())
+	}
+	return nil
+}
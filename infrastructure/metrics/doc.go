@@ -0,0 +1,14 @@
+/*
+Package metrics implements a minimal metrics subsystem that exports its
+metrics in the Prometheus text exposition format.
+
+Process-wide metrics, such as the duration of block validation, are defined
+as package-level variables next to the code they measure and are registered
+in the DefaultRegistry. Metrics that are computed from the state of a single
+kaspad instance, such as the size of its mempool, are registered in a
+Registry of their own by the app, using functions that are called every
+time the metrics are collected.
+
+All the registries are served over HTTP at /metrics by a Server.
+*/
+package metrics
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"bufio"
+)
+
+// GaugeFunc is a gauge whose value is computed by a function every
+// time the metrics are collected
+type GaugeFunc struct {
+	name     string
+	help     string
+	function func() (float64, error)
+}
+
+// NewGaugeFunc creates a new GaugeFunc and registers it in the registry
+func (r *Registry) NewGaugeFunc(name string, help string, function func() (float64, error)) *GaugeFunc {
+	gaugeFunc := &GaugeFunc{
+		name:     name,
+		help:     help,
+		function: function,
+	}
+	r.MustRegister(gaugeFunc)
+	return gaugeFunc
+}
+
+// Name returns the name of the gauge
+func (g *GaugeFunc) Name() string {
+	return g.name
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) error {
+	value, err := g.function()
+	if err != nil {
+		return err
+	}
+	writeHeader(w, g.name, g.help, gaugeType)
+	writeSample(w, g.name, nil, value)
+	return nil
+}
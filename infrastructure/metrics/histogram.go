@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"bufio"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DurationBuckets are histogram buckets, in seconds, that suit the
+// durations of most operations in kaspad
+var DurationBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Histogram samples observations and counts them in configurable buckets
+type Histogram struct {
+	name    string
+	help    string
+	buckets []float64
+
+	lock         sync.Mutex
+	bucketCounts []uint64
+	sum          float64
+	count        uint64
+}
+
+// NewHistogram creates a new histogram with the given bucket upper bounds
+// and registers it in the DefaultRegistry
+func NewHistogram(name string, help string, buckets []float64) *Histogram {
+	histogram := newHistogram(name, help, buckets)
+	DefaultRegistry.MustRegister(histogram)
+	return histogram
+}
+
+func newHistogram(name string, help string, buckets []float64) *Histogram {
+	if !sort.Float64sAreSorted(buckets) {
+		panic("histogram buckets must be sorted")
+	}
+	return &Histogram{
+		name:         name,
+		help:         help,
+		buckets:      buckets,
+		bucketCounts: make([]uint64, len(buckets)),
+	}
+}
+
+// Name returns the name of the histogram
+func (h *Histogram) Name() string {
+	return h.name
+}
+
+// Observe adds a single observation to the histogram
+func (h *Histogram) Observe(value float64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.observeNoLock(value)
+}
+
+func (h *Histogram) observeNoLock(value float64) {
+	bucketIndex := sort.SearchFloat64s(h.buckets, value)
+	if bucketIndex < len(h.buckets) {
+		h.bucketCounts[bucketIndex]++
+	}
+	h.sum += value
+	h.count++
+}
+
+// ObserveDuration adds the time that passed since start to the histogram, in seconds
+func (h *Histogram) ObserveDuration(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+func (h *Histogram) write(w *bufio.Writer) error {
+	writeHeader(w, h.name, h.help, histogramType)
+	h.writeSamples(w, nil)
+	return nil
+}
+
+func (h *Histogram) writeSamples(w *bufio.Writer, labels []label) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	bucketLabels := append(labels[:len(labels):len(labels)], label{name: "le"})
+	cumulativeCount := uint64(0)
+	for i, upperBound := range h.buckets {
+		cumulativeCount += h.bucketCounts[i]
+		bucketLabels[len(labels)].value = formatFloat(upperBound)
+		writeSample(w, h.name+"_bucket", bucketLabels, float64(cumulativeCount))
+	}
+	bucketLabels[len(labels)].value = "+Inf"
+	writeSample(w, h.name+"_bucket", bucketLabels, float64(h.count))
+	writeSample(w, h.name+"_sum", labels, h.sum)
+	writeSample(w, h.name+"_count", labels, float64(h.count))
+}
+
+// HistogramVec is a set of histograms that share a name and buckets, and are
+// told apart by the value of a single label
+type HistogramVec struct {
+	name      string
+	help      string
+	buckets   []float64
+	labelName string
+
+	lock       sync.RWMutex
+	histograms map[string]*Histogram
+}
+
+// NewHistogramVec creates a new histogram vector and registers it in the DefaultRegistry
+func NewHistogramVec(name string, help string, labelName string, buckets []float64) *HistogramVec {
+	histogramVec := &HistogramVec{
+		name:       name,
+		help:       help,
+		buckets:    buckets,
+		labelName:  labelName,
+		histograms: make(map[string]*Histogram),
+	}
+	DefaultRegistry.MustRegister(histogramVec)
+	return histogramVec
+}
+
+// Name returns the name of the histogram vector
+func (hv *HistogramVec) Name() string {
+	return hv.name
+}
+
+// WithLabel returns the histogram for the given label value, creating it if needed
+func (hv *HistogramVec) WithLabel(labelValue string) *Histogram {
+	hv.lock.RLock()
+	histogram, ok := hv.histograms[labelValue]
+	hv.lock.RUnlock()
+	if ok {
+		return histogram
+	}
+
+	hv.lock.Lock()
+	defer hv.lock.Unlock()
+	histogram, ok = hv.histograms[labelValue]
+	if !ok {
+		histogram = newHistogram(hv.name, hv.help, hv.buckets)
+		hv.histograms[labelValue] = histogram
+	}
+	return histogram
+}
+
+func (hv *HistogramVec) write(w *bufio.Writer) error {
+	hv.lock.RLock()
+	labelValues := make([]string, 0, len(hv.histograms))
+	for labelValue := range hv.histograms {
+		labelValues = append(labelValues, labelValue)
+	}
+	hv.lock.RUnlock()
+	sort.Strings(labelValues)
+
+	writeHeader(w, hv.name, hv.help, histogramType)
+	for _, labelValue := range labelValues {
+		hv.WithLabel(labelValue).writeSamples(w, []label{{hv.labelName, labelValue}})
+	}
+	return nil
+}
+
+// HistogramFunc is a histogram whose observations are all computed by a
+// function every time the metrics are collected
+type HistogramFunc struct {
+	name     string
+	help     string
+	buckets  []float64
+	function func() ([]float64, error)
+}
+
+// NewHistogramFunc creates a new HistogramFunc and registers it in the registry
+func (r *Registry) NewHistogramFunc(name string, help string, buckets []float64,
+	function func() ([]float64, error)) *HistogramFunc {
+
+	histogramFunc := &HistogramFunc{
+		name:     name,
+		help:     help,
+		buckets:  buckets,
+		function: function,
+	}
+	r.MustRegister(histogramFunc)
+	return histogramFunc
+}
+
+// Name returns the name of the histogram
+func (hf *HistogramFunc) Name() string {
+	return hf.name
+}
+
+func (hf *HistogramFunc) write(w *bufio.Writer) error {
+	observations, err := hf.function()
+	if err != nil {
+		return err
+	}
+	histogram := newHistogram(hf.name, hf.help, hf.buckets)
+	for _, observation := range observations {
+		if math.IsNaN(observation) {
+			continue
+		}
+		histogram.observeNoLock(observation)
+	}
+	return histogram.write(w)
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"bufio"
+	"sync"
+	"time"
+)
+
+// meterWindowSeconds is the length of the moving window, in seconds,
+// over which a meter measures its rate
+const meterWindowSeconds = 60
+
+// Meter is a gauge of the average number of events per second over
+// the last meterWindowSeconds
+type Meter struct {
+	name string
+	help string
+
+	lock sync.Mutex
+
+	// counts holds the number of events in each of the last
+	// meterWindowSeconds seconds. seconds holds the unix time of the
+	// second each count belongs to, so that stale counts are ignored
+	counts  [meterWindowSeconds]uint64
+	seconds [meterWindowSeconds]int64
+
+	now func() time.Time
+}
+
+// NewMeter creates a new meter and registers it in the DefaultRegistry
+func NewMeter(name string, help string) *Meter {
+	meter := newMeter(name, help)
+	DefaultRegistry.MustRegister(meter)
+	return meter
+}
+
+func newMeter(name string, help string) *Meter {
+	return &Meter{name: name, help: help, now: time.Now}
+}
+
+// Name returns the name of the meter
+func (m *Meter) Name() string {
+	return m.name
+}
+
+// Mark records the given number of events
+func (m *Meter) Mark(events uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	second := m.now().Unix()
+	index := second % meterWindowSeconds
+	if m.seconds[index] != second {
+		m.seconds[index] = second
+		m.counts[index] = 0
+	}
+	m.counts[index] += events
+}
+
+// Rate returns the average number of events per second over the last meterWindowSeconds
+func (m *Meter) Rate() float64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := m.now().Unix()
+	total := uint64(0)
+	for i, second := range m.seconds {
+		if now-second < meterWindowSeconds {
+			total += m.counts[i]
+		}
+	}
+	return float64(total) / meterWindowSeconds
+}
+
+func (m *Meter) write(w *bufio.Writer) error {
+	writeHeader(w, m.name, m.help, gaugeType)
+	writeSample(w, m.name, nil, m.Rate())
+	return nil
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Collector is a single named metric family that can be exported
+// by a Registry
+type Collector interface {
+	// Name returns the name of the metric family
+	Name() string
+
+	write(w *bufio.Writer) error
+}
+
+// Registry holds a set of collectors and exports them in the
+// Prometheus text exposition format
+type Registry struct {
+	lock       sync.RWMutex
+	collectors map[string]Collector
+}
+
+// NewRegistry returns a new empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		collectors: make(map[string]Collector),
+	}
+}
+
+// DefaultRegistry is the registry for process-wide metrics, which are
+// defined as package-level variables next to the code they measure
+var DefaultRegistry = NewRegistry()
+
+// Register adds the given collector to the registry. Collector names
+// must be unique within a registry.
+func (r *Registry) Register(collector Collector) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.collectors[collector.Name()]; ok {
+		return fmt.Errorf("a metric named %s is already registered", collector.Name())
+	}
+	r.collectors[collector.Name()] = collector
+	return nil
+}
+
+// MustRegister is like Register, but panics if the collector can't be registered
+func (r *Registry) MustRegister(collector Collector) {
+	err := r.Register(collector)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Write writes all the metrics in the registry to w, ordered by name
+func (r *Registry) Write(w io.Writer) error {
+	r.lock.RLock()
+	collectors := make([]Collector, 0, len(r.collectors))
+	for _, collector := range r.collectors {
+		collectors = append(collectors, collector)
+	}
+	r.lock.RUnlock()
+
+	sort.Slice(collectors, func(i, j int) bool {
+		return collectors[i].Name() < collectors[j].Name()
+	})
+
+	bufferedWriter := bufio.NewWriter(w)
+	for _, collector := range collectors {
+		err := collector.write(bufferedWriter)
+		if err != nil {
+			log.Warnf("Failed collecting metric %s: %s", collector.Name(), err)
+		}
+	}
+	return bufferedWriter.Flush()
+}
+
+type metricType string
+
+const (
+	counterType   metricType = "counter"
+	gaugeType     metricType = "gauge"
+	histogramType metricType = "histogram"
+)
+
+func writeHeader(w *bufio.Writer, name string, help string, typ metricType) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+}
+
+// label is a single name="value" pair of a sample
+type label struct {
+	name, value string
+}
+
+func writeSample(w *bufio.Writer, name string, labels []label, value float64) {
+	w.WriteString(name)
+	if len(labels) > 0 {
+		w.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, `%s="%s"`, label.name, escapeLabelValue(label.value))
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
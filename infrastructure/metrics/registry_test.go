@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	registry := NewRegistry()
+
+	counter := newCounter("test_counter_total", "A test counter")
+	registry.MustRegister(counter)
+	counter.Add(2.5)
+	counter.Inc()
+
+	histogram := newHistogram("test_histogram", "A test histogram", []float64{1, 5})
+	registry.MustRegister(histogram)
+	histogram.Observe(0.5)
+	histogram.Observe(1)
+	histogram.Observe(3)
+	histogram.Observe(10)
+
+	registry.NewGaugeFunc("test_gauge", "A test gauge\nwith two lines", func() (float64, error) {
+		return 42, nil
+	})
+	registry.NewGaugeFunc("test_failing_gauge", "A gauge that fails", func() (float64, error) {
+		return 0, errors.New("failure")
+	})
+	registry.NewHistogramFunc("test_histogram_func", "A test histogram func", []float64{1},
+		func() ([]float64, error) {
+			return []float64{0.5, 2}, nil
+		})
+
+	var builder strings.Builder
+	err := registry.Write(&builder)
+	if err != nil {
+		t.Fatalf("Write: %s", err)
+	}
+
+	expected := `# HELP test_counter_total A test counter
+# TYPE test_counter_total counter
+test_counter_total 3.5
+# HELP test_gauge A test gauge\nwith two lines
+# TYPE test_gauge gauge
+test_gauge 42
+# HELP test_histogram A test histogram
+# TYPE test_histogram histogram
+test_histogram_bucket{le="1"} 2
+test_histogram_bucket{le="5"} 3
+test_histogram_bucket{le="+Inf"} 4
+test_histogram_sum 14.5
+test_histogram_count 4
+# HELP test_histogram_func A test histogram func
+# TYPE test_histogram_func histogram
+test_histogram_func_bucket{le="1"} 1
+test_histogram_func_bucket{le="+Inf"} 2
+test_histogram_func_sum 2.5
+test_histogram_func_count 2
+`
+	if builder.String() != expected {
+		t.Fatalf("unexpected output.\nWant:\n%s\nGot:\n%s", expected, builder.String())
+	}
+
+	err = registry.Register(newCounter("test_counter_total", "A duplicate counter"))
+	if err == nil {
+		t.Fatalf("expected registering a duplicate name to fail")
+	}
+}
+
+func TestVecWrite(t *testing.T) {
+	registry := NewRegistry()
+
+	counterVec := &CounterVec{name: "test_requests_total", help: "Requests",
+		labelName: "method", counters: make(map[string]*Counter)}
+	registry.MustRegister(counterVec)
+	counterVec.WithLabel("getInfo").Inc()
+	counterVec.WithLabel(`say "hi"`).Add(2)
+	counterVec.WithLabel("getInfo").Inc()
+
+	histogramVec := &HistogramVec{name: "test_duration_seconds", help: "Durations", buckets: []float64{1},
+		labelName: "method", histograms: make(map[string]*Histogram)}
+	registry.MustRegister(histogramVec)
+	histogramVec.WithLabel("getInfo").Observe(0.25)
+
+	var builder strings.Builder
+	err := registry.Write(&builder)
+	if err != nil {
+		t.Fatalf("Write: %s", err)
+	}
+
+	expected := `# HELP test_duration_seconds Durations
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{method="getInfo",le="1"} 1
+test_duration_seconds_bucket{method="getInfo",le="+Inf"} 1
+test_duration_seconds_sum{method="getInfo"} 0.25
+test_duration_seconds_count{method="getInfo"} 1
+# HELP test_requests_total Requests
+# TYPE test_requests_total counter
+test_requests_total{method="getInfo"} 2
+test_requests_total{method="say \"hi\""} 2
+`
+	if builder.String() != expected {
+		t.Fatalf("unexpected output.\nWant:\n%s\nGot:\n%s", expected, builder.String())
+	}
+}
+
+func TestMeter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	meter := newMeter("test_per_second", "A test meter")
+	meter.now = func() time.Time { return now }
+
+	meter.Mark(30)
+	now = now.Add(30 * time.Second)
+	meter.Mark(30)
+	if meter.Rate() != 1 {
+		t.Fatalf("expected a rate of 1 but got %f", meter.Rate())
+	}
+
+	// The first mark leaves the window
+	now = now.Add(30 * time.Second)
+	if meter.Rate() != 0.5 {
+		t.Fatalf("expected a rate of 0.5 but got %f", meter.Rate())
+	}
+
+	// Marks in the same slot of a later window replace the old ones
+	meter.Mark(6)
+	if meter.Rate() != 0.6 {
+		t.Fatalf("expected a rate of 0.6 but got %f", meter.Rate())
+	}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// contentType is the content type of the Prometheus text exposition format
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Server serves the metrics of a set of registries over HTTP, at /metrics
+type Server struct {
+	listenAddress string
+	registries    []*Registry
+	httpServer    *http.Server
+}
+
+// NewServer returns a new Server that listens on the given address and serves the
+// metrics in the given registries. Use Start() to begin serving.
+func NewServer(listenAddress string, registries ...*Registry) *Server {
+	server := &Server{
+		listenAddress: listenAddress,
+		registries:    registries,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", server.handleMetrics)
+	server.httpServer = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server
+}
+
+// Start begins listening and serving metrics
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.listenAddress)
+	if err != nil {
+		return errors.Wrapf(err, "error listening on %s", s.listenAddress)
+	}
+
+	spawn("metrics.Server.Start-Serve", func() {
+		err := s.httpServer.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("Error serving metrics on %s: %s", s.listenAddress, err)
+		}
+	})
+
+	log.Infof("Metrics server listening on %s", listener.Addr())
+	return nil
+}
+
+// Stop stops serving metrics
+func (s *Server) Stop() error {
+	const stopTimeout = 2 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	for _, registry := range s.registries {
+		err := registry.Write(w)
+		if err != nil {
+			log.Debugf("Error writing metrics: %s", err)
+			return
+		}
+	}
+}
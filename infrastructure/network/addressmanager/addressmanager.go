@@ -6,7 +6,6 @@ package addressmanager
 
 import (
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
-	"github.com/kaspanet/kaspad/util/mstime"
 	"net"
 	"sync"
 	"time"
@@ -60,6 +59,7 @@ type AddressManager struct {
 	mutex          sync.Mutex
 	cfg            *Config
 	random         addressRandomizer
+	banDuration    time.Duration
 }
 
 // New returns a new Kaspa address manager.
@@ -78,6 +78,7 @@ func New(cfg *Config, database database.Database) (*AddressManager, error) {
 		localAddresses: localAddresses,
 		random:         NewAddressRandomize(),
 		cfg:            cfg,
+		banDuration:    cfg.BanDuration,
 	}, nil
 }
 
@@ -87,6 +88,18 @@ func (am *AddressManager) addAddressNoLock(netAddress *appmessage.NetAddress) er
 	}
 
 	key := netAddressKey(netAddress)
+
+	// Known addresses are only updated with the services they advertise
+	if existing, ok := am.store.getNotBanned(key); ok {
+		if netAddress.Services == 0 || netAddress.Services == existing.netAddress.Services {
+			return nil
+		}
+		updatedNetAddress := *existing.netAddress
+		updatedNetAddress.Services = netAddress.Services
+		updated := &address{netAddress: &updatedNetAddress, connectionFailedCount: existing.connectionFailedCount}
+		return am.store.updateNotBanned(key, updated)
+	}
+
 	address := &address{netAddress: netAddress, connectionFailedCount: 0}
 	err := am.store.add(key, address)
 	if err != nil {
@@ -215,13 +228,41 @@ func (am *AddressManager) RandomAddresses(count int, exceptions []*appmessage.Ne
 	return am.random.RandomAddresses(validAddresses, count)
 }
 
+// RandomAddressesWithServices returns count addresses at random that aren't banned,
+// aren't in exceptions and are known to support all of the given services
+func (am *AddressManager) RandomAddressesWithServices(count int, services appmessage.ServiceFlag,
+	exceptions []*appmessage.NetAddress) []*appmessage.NetAddress {
+
+	validAddresses := am.notBannedAddressesWithException(exceptions)
+	addressesWithServices := make([]*appmessage.NetAddress, 0, len(validAddresses))
+	for _, address := range validAddresses {
+		if address.HasService(services) {
+			addressesWithServices = append(addressesWithServices, address)
+		}
+	}
+	return am.random.RandomAddresses(addressesWithServices, count)
+}
+
 // BestLocalAddress returns the most appropriate local address to use
 // for the given remote address.
 func (am *AddressManager) BestLocalAddress(remoteAddress *appmessage.NetAddress) *appmessage.NetAddress {
 	return am.localAddresses.bestLocalAddress(remoteAddress)
 }
 
-// Ban marks the given address as banned
+// AddLocalAddress adds netAddress to the list of local addresses that are
+// advertised to peers, with the given priority.
+func (am *AddressManager) AddLocalAddress(netAddress *appmessage.NetAddress, priority AddressPriority) error {
+	return am.localAddresses.addLocalNetAddress(netAddress, priority)
+}
+
+// RemoveLocalAddress removes netAddress from the list of local addresses that
+// are advertised to peers
+func (am *AddressManager) RemoveLocalAddress(netAddress *appmessage.NetAddress) {
+	am.localAddresses.removeLocalNetAddress(netAddress)
+}
+
+// Ban marks the given address as banned. The ban starts at the current
+// time of the address manager's clock, regardless of the address's timestamp
 func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
@@ -241,7 +282,9 @@ func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress) error {
 		}
 	}
 
-	address := &address{netAddress: addressToBan}
+	bannedNetAddress := *addressToBan
+	bannedNetAddress.Timestamp = am.cfg.Clock.Now()
+	address := &address{netAddress: &bannedNetAddress}
 	return am.store.addBanned(keyToBan, address)
 }
 
@@ -259,6 +302,14 @@ func (am *AddressManager) Unban(address *appmessage.NetAddress) error {
 	return am.store.removeBanned(key)
 }
 
+// SetBanDuration sets how long addresses stay banned
+func (am *AddressManager) SetBanDuration(banDuration time.Duration) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	am.banDuration = banDuration
+}
+
 // IsBanned returns true if the given address is marked as banned
 func (am *AddressManager) IsBanned(address *appmessage.NetAddress) (bool, error) {
 	am.mutex.Lock()
@@ -286,8 +337,7 @@ func (am *AddressManager) unbanIfOldEnough(key addressKey) error {
 		return nil
 	}
 
-	const maxBanTime = 24 * time.Hour
-	if mstime.Since(address.netAddress.Timestamp) > maxBanTime {
+	if am.cfg.Clock.Now().Sub(address.netAddress.Timestamp) > am.banDuration {
 		err := am.store.removeBanned(key)
 		if err != nil {
 			return err
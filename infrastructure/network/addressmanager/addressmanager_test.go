@@ -8,11 +8,13 @@ import (
 	"net"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
 	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
 )
 
 func newAddressManagerForTest(t *testing.T, testName string) (addressManager *AddressManager, teardown func()) {
@@ -372,3 +374,96 @@ func TestOverfillAddressManager(t *testing.T) {
 		}
 	}
 }
+
+func TestRandomAddressesWithServices(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestRandomAddressesWithServices")
+	defer teardown()
+
+	prunedAddress := &appmessage.NetAddress{IP: net.ParseIP("1.2.3.4"), Timestamp: mstime.Now(),
+		Services: appmessage.SFNodeNetwork}
+	archivalAddress := &appmessage.NetAddress{IP: net.ParseIP("5.6.8.8"), Timestamp: mstime.Now(),
+		Services: appmessage.SFNodeNetwork | appmessage.SFNodeArchival}
+	unknownServicesAddress := &appmessage.NetAddress{IP: net.ParseIP("9.0.1.2"), Timestamp: mstime.Now()}
+
+	err := addressManager.AddAddresses(prunedAddress, archivalAddress, unknownServicesAddress)
+	if err != nil {
+		t.Fatalf("AddAddresses() failed: %s", err)
+	}
+
+	addresses := addressManager.RandomAddressesWithServices(3, appmessage.SFNodeArchival, nil)
+	if len(addresses) != 1 || !reflect.DeepEqual(addresses[0], archivalAddress) {
+		t.Fatalf("Expected only the archival address to be returned, but got %v", addresses)
+	}
+
+	// Re-adding a known address with its services should update them
+	err = addressManager.AddAddress(&appmessage.NetAddress{IP: unknownServicesAddress.IP, Timestamp: mstime.Now(),
+		Services: appmessage.SFNodeNetwork | appmessage.SFNodeArchival})
+	if err != nil {
+		t.Fatalf("AddAddress() failed: %s", err)
+	}
+	addresses = addressManager.RandomAddressesWithServices(3, appmessage.SFNodeArchival, []*appmessage.NetAddress{archivalAddress})
+	if len(addresses) != 1 || !addresses[0].IP.Equal(unknownServicesAddress.IP) {
+		t.Fatalf("Expected only the updated address to be returned, but got %v", addresses)
+	}
+
+	// Re-adding a known address without services should not forget them
+	err = addressManager.AddAddress(&appmessage.NetAddress{IP: archivalAddress.IP, Timestamp: mstime.Now()})
+	if err != nil {
+		t.Fatalf("AddAddress() failed: %s", err)
+	}
+	addresses = addressManager.RandomAddressesWithServices(3, appmessage.SFNodeArchival, nil)
+	if len(addresses) != 2 {
+		t.Fatalf("Expected 2 archival addresses, but got %d", len(addresses))
+	}
+}
+
+func TestBanExpiry(t *testing.T) {
+	datadir := t.TempDir()
+	database, err := ldb.NewLevelDB(datadir, 8)
+	if err != nil {
+		t.Fatalf("could not create a database: %s", err)
+	}
+	defer database.Close()
+
+	// The clock is far behind the wall clock, which the timestamp of
+	// addressToBan comes from
+	clock := mstime.NewFakeClock(mstime.Now().Add(-30 * 24 * time.Hour))
+	addressManagerConfig := NewConfig(config.DefaultConfig())
+	addressManagerConfig.Clock = clock
+	addressManagerConfig.BanDuration = time.Hour
+	addressManager, err := New(addressManagerConfig, database)
+	if err != nil {
+		t.Fatalf("error creating address manager: %s", err)
+	}
+
+	addressToBan := appmessage.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 16111)
+	err = addressManager.AddAddress(addressToBan)
+	if err != nil {
+		t.Fatalf("AddAddress() failed: %s", err)
+	}
+	err = addressManager.Ban(addressToBan)
+	if err != nil {
+		t.Fatalf("Ban() failed: %s", err)
+	}
+
+	// The ban shouldn't expire before the ban duration passes on the clock,
+	// regardless of the wall clock
+	clock.Advance(30 * time.Minute)
+	isBanned, err := addressManager.IsBanned(addressToBan)
+	if err != nil {
+		t.Fatalf("IsBanned() failed: %s", err)
+	}
+	if !isBanned {
+		t.Fatalf("Address %s is unexpectedly not banned", addressToBan.IP)
+	}
+
+	// An expired ban is removed along with the address
+	clock.Advance(time.Hour)
+	_, err = addressManager.IsBanned(addressToBan)
+	if !errors.Is(err, ErrAddressNotFound) {
+		t.Fatalf("Unexpected error from IsBanned(): %v", err)
+	}
+	if len(addressManager.BannedAddresses()) != 0 {
+		t.Fatalf("Address %s is unexpectedly still banned", addressToBan.IP)
+	}
+}
@@ -2,8 +2,10 @@ package addressmanager
 
 import (
 	"net"
+	"time"
 
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/util/mstime"
 )
 
 // Config is a descriptor which specifies the AddressManager instance configuration.
@@ -13,6 +15,10 @@ type Config struct {
 	ExternalIPs      []string
 	Listeners        []string
 	Lookup           func(string) ([]net.IP, error)
+	BanDuration      time.Duration
+
+	// Clock is the clock ban expiry is measured with
+	Clock mstime.Clock
 }
 
 // NewConfig returns a new address manager Config.
@@ -23,5 +29,7 @@ func NewConfig(cfg *config.Config) *Config {
 		ExternalIPs:      cfg.ExternalIPs,
 		Listeners:        cfg.Listeners,
 		Lookup:           cfg.Lookup,
+		BanDuration:      cfg.BanDuration,
+		Clock:            mstime.SystemClock,
 	}
 }
@@ -34,6 +34,7 @@ func TestAddressSerialization(t *testing.T) {
 			IP:        net.ParseIP("2602:100:abcd::102"),
 			Port:      12345,
 			Timestamp: mstime.Now(),
+			Services:  appmessage.SFNodeNetwork | appmessage.SFNodeArchival,
 		},
 		connectionFailedCount: 98465,
 	}
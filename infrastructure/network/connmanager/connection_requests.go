@@ -111,5 +111,22 @@ func (c *ConnectionManager) addConnectionRequest(address string, isPermanent boo
 // RemoveConnection disconnects the connection for the given address
 // and removes it entirely from the connection manager.
 func (c *ConnectionManager) RemoveConnection(address string) {
-	panic("unimplemented")
+	// spawn goroutine so that caller doesn't wait in case connectionManager is in the midst of handling
+	// connection requests
+	spawn("ConnectionManager.RemoveConnection", func() {
+		c.removeConnectionRequest(address)
+
+		for _, connection := range c.netAdapter.P2PConnections() {
+			if connection.Address() == address {
+				connection.Disconnect()
+			}
+		}
+	})
+}
+
+func (c *ConnectionManager) removeConnectionRequest(address string) {
+	c.connectionRequestsLock.Lock()
+	defer c.connectionRequestsLock.Unlock()
+	delete(c.activeRequested, address)
+	delete(c.pendingRequested, address)
 }
@@ -30,16 +30,20 @@ type ConnectionManager struct {
 	netAdapter     *netadapter.NetAdapter
 	addressManager *addressmanager.AddressManager
 
-	activeRequested  map[string]*connectionRequest
-	pendingRequested map[string]*connectionRequest
-	activeOutgoing   map[string]struct{}
-	targetOutgoing   int
-	activeIncoming   map[string]struct{}
-	maxIncoming      int
+	activeRequested        map[string]*connectionRequest
+	pendingRequested       map[string]*connectionRequest
+	activeOutgoing         map[string]struct{}
+	activeArchivalOutgoing map[string]struct{}
+	targetOutgoing         int
+	activeIncoming         map[string]struct{}
+	maxIncoming            int
 
 	stop                   uint32
 	connectionRequestsLock sync.RWMutex
 
+	banPolicy      banPolicy
+	banPolicyMutex sync.RWMutex
+
 	resetLoopChan chan struct{}
 	loopTicker    *time.Ticker
 }
@@ -47,15 +51,16 @@ type ConnectionManager struct {
 // New instantiates a new instance of a ConnectionManager
 func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *addressmanager.AddressManager) (*ConnectionManager, error) {
 	c := &ConnectionManager{
-		cfg:              cfg,
-		netAdapter:       netAdapter,
-		addressManager:   addressManager,
-		activeRequested:  map[string]*connectionRequest{},
-		pendingRequested: map[string]*connectionRequest{},
-		activeOutgoing:   map[string]struct{}{},
-		activeIncoming:   map[string]struct{}{},
-		resetLoopChan:    make(chan struct{}),
-		loopTicker:       time.NewTicker(connectionsLoopInterval),
+		cfg:                    cfg,
+		netAdapter:             netAdapter,
+		addressManager:         addressManager,
+		activeRequested:        map[string]*connectionRequest{},
+		pendingRequested:       map[string]*connectionRequest{},
+		activeOutgoing:         map[string]struct{}{},
+		activeArchivalOutgoing: map[string]struct{}{},
+		activeIncoming:         map[string]struct{}{},
+		resetLoopChan:          make(chan struct{}),
+		loopTicker:             time.NewTicker(connectionsLoopInterval),
 	}
 
 	connectPeers := cfg.AddPeers
@@ -65,6 +70,7 @@ func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *
 
 	c.maxIncoming = cfg.MaxInboundPeers
 	c.targetOutgoing = cfg.TargetOutboundPeers
+	c.SetBanPolicy(cfg.DisableBanning, cfg.Whitelists)
 
 	for _, connectPeer := range connectPeers {
 		c.pendingRequested[connectPeer] = &connectionRequest{
@@ -131,13 +137,62 @@ func (c *ConnectionManager) ConnectionCount() int {
 // ErrCannotBanPermanent is the error returned when trying to ban a permanent peer.
 var ErrCannotBanPermanent = errors.New("ErrCannotBanPermanent")
 
+// ErrCannotBanWhitelisted is the error returned when trying to ban a whitelisted peer.
+var ErrCannotBanWhitelisted = errors.New("ErrCannotBanWhitelisted")
+
+// banPolicy decides which misbehaving peers get banned
+type banPolicy struct {
+	disableBanning bool
+	whitelists     []*net.IPNet
+}
+
+// SetBanPolicy sets whether misbehaving peers are banned, and the IP
+// networks of the peers that are never banned
+func (c *ConnectionManager) SetBanPolicy(disableBanning bool, whitelists []*net.IPNet) {
+	c.banPolicyMutex.Lock()
+	defer c.banPolicyMutex.Unlock()
+
+	c.banPolicy = banPolicy{
+		disableBanning: disableBanning,
+		whitelists:     whitelists,
+	}
+}
+
+// IsBanningDisabled returns whether misbehaving peers should not be banned
+func (c *ConnectionManager) IsBanningDisabled() bool {
+	c.banPolicyMutex.RLock()
+	defer c.banPolicyMutex.RUnlock()
+
+	return c.banPolicy.disableBanning
+}
+
+func (c *ConnectionManager) isWhitelisted(ip net.IP) bool {
+	c.banPolicyMutex.RLock()
+	defer c.banPolicyMutex.RUnlock()
+
+	for _, whitelist := range c.banPolicy.whitelists {
+		if whitelist.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // Ban marks the given netConnection as banned
 func (c *ConnectionManager) Ban(netConnection *netadapter.NetConnection) error {
 	if c.isPermanent(netConnection.Address()) {
 		return errors.Wrapf(ErrCannotBanPermanent, "Cannot ban %s because it's a permanent connection", netConnection.Address())
 	}
+	if c.isWhitelisted(netConnection.NetAddress().IP) {
+		return errors.Wrapf(ErrCannotBanWhitelisted, "Cannot ban %s because it's whitelisted", netConnection.Address())
+	}
 
-	return c.addressManager.Ban(netConnection.NetAddress())
+	err := c.addressManager.Ban(netConnection.NetAddress())
+	if err != nil {
+		return err
+	}
+	bans.Inc()
+	return nil
 }
 
 // BanByIP bans the given IP and disconnects from all the connection with that IP.
@@ -150,6 +205,9 @@ func (c *ConnectionManager) BanByIP(ip net.IP) error {
 	if ipHasPermanentConnection {
 		return errors.Wrapf(ErrCannotBanPermanent, "Cannot ban %s because it's a permanent connection", ip)
 	}
+	if c.isWhitelisted(ip) {
+		return errors.Wrapf(ErrCannotBanWhitelisted, "Cannot ban %s because it's whitelisted", ip)
+	}
 
 	connections := c.netAdapter.P2PConnections()
 	for _, conn := range connections {
@@ -158,7 +216,12 @@ func (c *ConnectionManager) BanByIP(ip net.IP) error {
 		}
 	}
 
-	return c.addressManager.Ban(appmessage.NewNetAddressIPPort(ip, 0))
+	err = c.addressManager.Ban(appmessage.NewNetAddressIPPort(ip, 0))
+	if err != nil {
+		return err
+	}
+	bans.Inc()
+	return nil
 }
 
 // IsBanned returns whether the given netConnection is banned
@@ -166,6 +229,9 @@ func (c *ConnectionManager) IsBanned(netConnection *netadapter.NetConnection) (b
 	if c.isPermanent(netConnection.Address()) {
 		return false, nil
 	}
+	if c.isWhitelisted(netConnection.NetAddress().IP) {
+		return false, nil
+	}
 
 	return c.addressManager.IsBanned(netConnection.NetAddress())
 }
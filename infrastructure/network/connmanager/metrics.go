@@ -0,0 +1,8 @@
+package connmanager
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+)
+
+var bans = metrics.NewCounter("kaspad_p2p_bans_total",
+	"The number of peers and IPs that were banned")
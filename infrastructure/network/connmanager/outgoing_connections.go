@@ -2,6 +2,11 @@ package connmanager
 
 import "github.com/kaspanet/kaspad/app/appmessage"
 
+// targetArchivalOutgoing is the number of outgoing connections that archival
+// nodes dedicate to other archival nodes, which are the only ones that can
+// serve the block history below the pruning point
+const targetArchivalOutgoing = 2
+
 // checkOutgoingConnections goes over all activeOutgoing and makes sure they are still active.
 // Then it opens connections so that we have targetOutgoing active connections
 func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
@@ -14,6 +19,7 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 
 		// if connection is dead - remove from list of active ones
 		delete(c.activeOutgoing, address)
+		delete(c.activeArchivalOutgoing, address)
 	}
 
 	connections := c.netAdapter.P2PConnections()
@@ -31,7 +37,7 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 		liveConnections, c.targetOutgoing, c.targetOutgoing-liveConnections)
 
 	connectionsNeededCount := c.targetOutgoing - len(c.activeOutgoing)
-	netAddresses := c.addressManager.RandomAddresses(connectionsNeededCount, connectedAddresses)
+	netAddresses := c.outgoingAddressesToConnect(connectionsNeededCount, connectedAddresses)
 
 	for _, netAddress := range netAddresses {
 		addressString := netAddress.TCPAddress().String()
@@ -48,5 +54,39 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 		c.addressManager.MarkConnectionSuccess(netAddress)
 
 		c.activeOutgoing[addressString] = struct{}{}
+		if netAddress.HasService(appmessage.SFNodeArchival) {
+			c.activeArchivalOutgoing[addressString] = struct{}{}
+		}
+	}
+}
+
+// outgoingAddressesToConnect returns count random addresses to connect to.
+// Archival nodes first look for archival addresses until they have
+// targetArchivalOutgoing of them.
+func (c *ConnectionManager) outgoingAddressesToConnect(count int,
+	connectedAddresses []*appmessage.NetAddress) []*appmessage.NetAddress {
+
+	if !c.cfg.IsArchivalNode {
+		return c.addressManager.RandomAddresses(count, connectedAddresses)
 	}
+
+	archivalNeededCount := targetArchivalOutgoing - len(c.activeArchivalOutgoing)
+	if archivalNeededCount > count {
+		archivalNeededCount = count
+	}
+	if archivalNeededCount <= 0 {
+		return c.addressManager.RandomAddresses(count, connectedAddresses)
+	}
+
+	netAddresses := c.addressManager.RandomAddressesWithServices(
+		archivalNeededCount, appmessage.SFNodeArchival, connectedAddresses)
+	if len(netAddresses) < archivalNeededCount {
+		log.Debugf("Found only %d known archival addresses out of the %d needed",
+			len(netAddresses), archivalNeededCount)
+	}
+
+	exceptions := make([]*appmessage.NetAddress, 0, len(connectedAddresses)+len(netAddresses))
+	exceptions = append(exceptions, connectedAddresses...)
+	exceptions = append(exceptions, netAddresses...)
+	return append(netAddresses, c.addressManager.RandomAddresses(count-len(netAddresses), exceptions)...)
 }
@@ -0,0 +1,14 @@
+/*
+Package nat implements NAT traversal for kaspad's P2P listener.
+
+Home-hosted nodes usually sit behind a router that performs network address
+translation, which makes them unreachable to inbound peers. This package
+discovers the router (the "gateway") using either UPnP IGD or NAT-PMP, asks it
+to forward the P2P listen port to this machine, and keeps renewing that port
+mapping for as long as the node runs.
+
+The external IP reported by the gateway is registered with the address manager
+under addressmanager.UpnpPrio, so it gets advertised to peers just like an
+address provided through --externalip, but with a lower priority.
+*/
+package nat
@@ -0,0 +1,191 @@
+package nat
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/pkg/errors"
+)
+
+const (
+	discoveryTimeout       = 3 * time.Second
+	discoveryRetryInterval = 5 * time.Minute
+	portMappingLifetime    = 20 * time.Minute
+	portMappingRenewal     = portMappingLifetime / 2
+	portMappingDescription = "kaspad"
+)
+
+// Manager maps kaspad's P2P listen port on the gateway, keeps renewing the
+// mapping, and registers the gateway's external address with the address manager
+type Manager struct {
+	addressManager *addressmanager.AddressManager
+	listenPort     uint16
+	discover       func() (NAT, error)
+
+	nat                NAT
+	mappedExternalPort uint16
+	externalIP         net.IP
+	mutex              sync.Mutex
+
+	// registeredAddress is the external address that was last added to the
+	// address manager, or nil if none was
+	registeredAddress *appmessage.NetAddress
+
+	started uint32
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// New returns a new NAT Manager that maps the port of the first configured P2P listener
+func New(cfg *config.Config, addressManager *addressmanager.AddressManager) (*Manager, error) {
+	if len(cfg.Listeners) == 0 {
+		return nil, errors.New("NAT traversal requires at least one P2P listener")
+	}
+	_, portString, err := net.SplitHostPort(cfg.Listeners[0])
+	if err != nil {
+		return nil, err
+	}
+	listenPort, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid listen port %s", portString)
+	}
+
+	return newManager(addressManager, uint16(listenPort), func() (NAT, error) {
+		return Discover(discoveryTimeout)
+	}), nil
+}
+
+func newManager(addressManager *addressmanager.AddressManager, listenPort uint16, discover func() (NAT, error)) *Manager {
+	return &Manager{
+		addressManager: addressManager,
+		listenPort:     listenPort,
+		discover:       discover,
+		quit:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// Start begins discovering the gateway and maintaining the port mapping
+func (m *Manager) Start() {
+	atomic.StoreUint32(&m.started, 1)
+	spawn("nat.Manager.maintainPortMapping", m.maintainPortMapping)
+}
+
+// Stop halts the Manager and removes the port mapping from the gateway
+func (m *Manager) Stop() {
+	close(m.quit)
+	// done is only closed by maintainPortMapping, which doesn't run if Start was never called
+	if atomic.LoadUint32(&m.started) == 1 {
+		<-m.done
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.nat == nil || m.mappedExternalPort == 0 {
+		return
+	}
+	err := m.nat.DeletePortMapping(ProtocolTCP, m.mappedExternalPort, m.listenPort)
+	if err != nil {
+		log.Warnf("Failed to delete the port mapping from %s: %s", m.nat, err)
+		return
+	}
+	log.Infof("Removed port mapping %d->%d from %s", m.mappedExternalPort, m.listenPort, m.nat)
+}
+
+// ExternalAddress returns the address under which this node is reachable
+// from outside the NAT, or nil if no port mapping is currently active
+func (m *Manager) ExternalAddress() *appmessage.NetAddress {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.externalIP == nil || m.mappedExternalPort == 0 {
+		return nil
+	}
+	return appmessage.NewNetAddressIPPort(m.externalIP, m.mappedExternalPort)
+}
+
+func (m *Manager) maintainPortMapping() {
+	defer close(m.done)
+
+	for {
+		nextAttempt := portMappingRenewal
+		err := m.refreshPortMapping()
+		if err != nil {
+			log.Warnf("NAT traversal failed: %s", err)
+			nextAttempt = discoveryRetryInterval
+		}
+
+		select {
+		case <-m.quit:
+			return
+		case <-time.After(nextAttempt):
+		}
+	}
+}
+
+// refreshPortMapping discovers the gateway if needed, then adds or renews
+// the port mapping and registers the external address with the address manager
+func (m *Manager) refreshPortMapping() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.nat == nil {
+		nat, err := m.discover()
+		if err != nil {
+			return err
+		}
+		log.Infof("Discovered %s", nat)
+		m.nat = nat
+	}
+
+	requestedExternalPort := m.mappedExternalPort
+	if requestedExternalPort == 0 {
+		requestedExternalPort = m.listenPort
+	}
+	mappedExternalPort, err := m.nat.AddPortMapping(ProtocolTCP, requestedExternalPort, m.listenPort,
+		portMappingDescription, portMappingLifetime)
+	if err != nil {
+		// The gateway may have been replaced or restarted. Rediscover it next time
+		m.nat = nil
+		m.mappedExternalPort = 0
+		return errors.Wrap(err, "failed to map the P2P listen port")
+	}
+	previousExternalPort := m.mappedExternalPort
+	if mappedExternalPort != previousExternalPort {
+		log.Infof("Mapped external port %d to local port %d", mappedExternalPort, m.listenPort)
+	}
+	m.mappedExternalPort = mappedExternalPort
+
+	externalIP, err := m.nat.ExternalIP()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the external IP from the gateway")
+	}
+	if externalIP.Equal(m.externalIP) && mappedExternalPort == previousExternalPort {
+		return nil
+	}
+	m.externalIP = externalIP
+
+	externalAddress := appmessage.NewNetAddressIPPort(externalIP, mappedExternalPort)
+	if m.registeredAddress != nil {
+		if m.registeredAddress.TCPAddress().String() == externalAddress.TCPAddress().String() {
+			return nil
+		}
+		// Peers can't reach this node at its previous external address anymore
+		m.addressManager.RemoveLocalAddress(m.registeredAddress)
+		log.Infof("External address %s unregistered", m.registeredAddress.TCPAddress())
+		m.registeredAddress = nil
+	}
+	err = m.addressManager.AddLocalAddress(externalAddress, addressmanager.UpnpPrio)
+	if err != nil {
+		return errors.Wrapf(err, "failed to register external address %s", externalAddress.TCPAddress())
+	}
+	m.registeredAddress = externalAddress
+	log.Infof("External address %s registered", externalAddress.TCPAddress())
+	return nil
+}
@@ -0,0 +1,147 @@
+package nat
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
+	"github.com/pkg/errors"
+)
+
+type fakeNAT struct {
+	externalIP         net.IP
+	mappedPortOffset   uint16
+	failPortMapping    bool
+	portMappingCount   int
+	deletedPortMapping bool
+}
+
+func (n *fakeNAT) ExternalIP() (net.IP, error) {
+	return n.externalIP, nil
+}
+
+func (n *fakeNAT) AddPortMapping(_ string, externalPort, _ uint16, _ string, _ time.Duration) (uint16, error) {
+	if n.failPortMapping {
+		return 0, errors.New("port mapping failed")
+	}
+	n.portMappingCount++
+	return externalPort + n.mappedPortOffset, nil
+}
+
+func (n *fakeNAT) DeletePortMapping(_ string, _, _ uint16) error {
+	n.deletedPortMapping = true
+	return nil
+}
+
+func (n *fakeNAT) String() string {
+	return "fake NAT"
+}
+
+func TestManager(t *testing.T) {
+	database, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %s", err)
+	}
+	defer database.Close()
+
+	cfg := config.DefaultConfig()
+	addressManager, err := addressmanager.New(addressmanager.NewConfig(cfg), database)
+	if err != nil {
+		t.Fatalf("addressmanager.New: %s", err)
+	}
+
+	nat := &fakeNAT{externalIP: net.ParseIP("5.6.7.8"), mappedPortOffset: 10}
+	discoverCount := 0
+	manager := newManager(addressManager, 16111, func() (NAT, error) {
+		discoverCount++
+		return nat, nil
+	})
+
+	if manager.ExternalAddress() != nil {
+		t.Fatalf("ExternalAddress is not nil before the port is mapped")
+	}
+
+	err = manager.refreshPortMapping()
+	if err != nil {
+		t.Fatalf("refreshPortMapping: %s", err)
+	}
+	externalAddress := manager.ExternalAddress()
+	if externalAddress == nil || externalAddress.TCPAddress().String() != "5.6.7.8:16121" {
+		t.Fatalf("unexpected external address %v", externalAddress)
+	}
+
+	bestLocalAddress := addressManager.BestLocalAddress(appmessage.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 16111))
+	if !bestLocalAddress.IP.Equal(net.ParseIP("5.6.7.8")) || bestLocalAddress.Port != 16121 {
+		t.Fatalf("unexpected best local address %s", bestLocalAddress.TCPAddress())
+	}
+
+	// Renewing the mapping should not rediscover the gateway
+	err = manager.refreshPortMapping()
+	if err != nil {
+		t.Fatalf("refreshPortMapping: %s", err)
+	}
+	if discoverCount != 1 || nat.portMappingCount != 2 {
+		t.Fatalf("unexpected discoverCount %d or portMappingCount %d", discoverCount, nat.portMappingCount)
+	}
+
+	// A failed renewal should trigger rediscovery on the next attempt
+	nat.failPortMapping = true
+	err = manager.refreshPortMapping()
+	if err == nil {
+		t.Fatalf("refreshPortMapping unexpectedly succeeded")
+	}
+	nat.failPortMapping = false
+	err = manager.refreshPortMapping()
+	if err != nil {
+		t.Fatalf("refreshPortMapping: %s", err)
+	}
+	if discoverCount != 2 {
+		t.Fatalf("expected the gateway to be rediscovered, discoverCount: %d", discoverCount)
+	}
+
+	// When the external IP changes, the previous external address should
+	// no longer be advertised. Local addresses with the same reachability
+	// and priority are picked in map order, so check several times
+	nat.externalIP = net.ParseIP("9.10.11.12")
+	err = manager.refreshPortMapping()
+	if err != nil {
+		t.Fatalf("refreshPortMapping: %s", err)
+	}
+	externalAddress = manager.ExternalAddress()
+	if externalAddress == nil || !externalAddress.IP.Equal(net.ParseIP("9.10.11.12")) {
+		t.Fatalf("unexpected external address %v after the external IP changed", externalAddress)
+	}
+	for i := 0; i < 20; i++ {
+		bestLocalAddress = addressManager.BestLocalAddress(appmessage.NewNetAddressIPPort(net.ParseIP("1.2.3.4"), 16111))
+		if bestLocalAddress.TCPAddress().String() != externalAddress.TCPAddress().String() {
+			t.Fatalf("unexpected best local address %s after the external IP changed", bestLocalAddress.TCPAddress())
+		}
+	}
+
+	manager.Start()
+	manager.Stop()
+	if !nat.deletedPortMapping {
+		t.Fatalf("Stop did not delete the port mapping")
+	}
+}
+
+func TestManagerStopWithoutStart(t *testing.T) {
+	manager := newManager(nil, 16111, func() (NAT, error) {
+		return nil, errors.New("no gateway")
+	})
+
+	stopped := make(chan struct{})
+	go func() {
+		manager.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("Stop blocked on a Manager that was never started")
+	}
+}
@@ -0,0 +1,90 @@
+package nat
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Protocols that can be passed to NAT.AddPortMapping and NAT.DeletePortMapping
+const (
+	ProtocolTCP = "TCP"
+	ProtocolUDP = "UDP"
+)
+
+// NAT is an interface representing a gateway that is able to forward ports
+// from its external address to this machine
+type NAT interface {
+	// ExternalIP returns the external (usually public) IP address of the gateway
+	ExternalIP() (net.IP, error)
+
+	// AddPortMapping asks the gateway to forward externalPort to internalPort on
+	// this machine for the given lifetime. It returns the external port that was
+	// actually mapped, which may differ from the requested one.
+	AddPortMapping(protocol string, externalPort, internalPort uint16, description string,
+		lifetime time.Duration) (mappedExternalPort uint16, err error)
+
+	// DeletePortMapping removes a port mapping previously added by AddPortMapping
+	DeletePortMapping(protocol string, externalPort, internalPort uint16) error
+
+	// String returns a human readable description of the gateway
+	String() string
+}
+
+// ErrNoGatewayFound is returned from Discover when neither a UPnP nor
+// a NAT-PMP gateway could be found in the local network
+var ErrNoGatewayFound = errors.New("no UPnP or NAT-PMP gateway found")
+
+// Discover searches the local network for a gateway supporting either UPnP or
+// NAT-PMP, in that order, and returns the first one found.
+func Discover(timeout time.Duration) (NAT, error) {
+	upnp, err := discoverUPnP(ssdpMulticastAddress, timeout)
+	if err == nil {
+		return upnp, nil
+	}
+	log.Debugf("UPnP discovery failed: %s", err)
+
+	natPMP, err := discoverNATPMP(potentialGateways(), timeout)
+	if err == nil {
+		return natPMP, nil
+	}
+	log.Debugf("NAT-PMP discovery failed: %s", err)
+
+	return nil, ErrNoGatewayFound
+}
+
+// potentialGateways returns the addresses that are most likely to belong to
+// the default gateway: the first address (x.x.x.1) of every private IPv4
+// network this machine is directly connected to.
+func potentialGateways() []net.IP {
+	interfaceAddresses, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+
+	var gateways []net.IP
+	for _, interfaceAddress := range interfaceAddresses {
+		ipNet, ok := interfaceAddress.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP.To4()
+		if ip == nil || !isPrivateIPv4(ip) {
+			continue
+		}
+		gateway := ip.Mask(ipNet.Mask)
+		gateway[3] |= 1
+		if gateway.Equal(ip) {
+			continue
+		}
+		gateways = append(gateways, gateway)
+	}
+	return gateways
+}
+
+func isPrivateIPv4(ip net.IP) bool {
+	return ip[0] == 10 ||
+		(ip[0] == 172 && ip[1]&0xf0 == 16) ||
+		(ip[0] == 192 && ip[1] == 168)
+}
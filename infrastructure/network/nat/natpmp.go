@@ -0,0 +1,182 @@
+package nat
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The NAT-PMP protocol is specified in RFC 6886
+const (
+	natPMPPort = 5351
+
+	natPMPVersion              = 0
+	natPMPOpExternalAddress    = 0
+	natPMPOpMapUDP             = 1
+	natPMPOpMapTCP             = 2
+	natPMPResponseOpcodeOffset = 128
+
+	natPMPInitialRetryInterval = 250 * time.Millisecond
+	natPMPMaxAttempts          = 9
+)
+
+var natPMPResultDescriptions = map[uint16]string{
+	1: "unsupported version",
+	2: "not authorized/refused",
+	3: "network failure",
+	4: "out of resources",
+	5: "unsupported opcode",
+}
+
+// natPMPNAT is a NAT implementation that talks to a NAT-PMP gateway
+type natPMPNAT struct {
+	gateway *net.UDPAddr
+	timeout time.Duration
+}
+
+// discoverNATPMP returns the first of the given candidate gateways
+// that answers a NAT-PMP external address request
+func discoverNATPMP(candidates []net.IP, timeout time.Duration) (*natPMPNAT, error) {
+	for _, candidate := range candidates {
+		nat := newNATPMP(&net.UDPAddr{IP: candidate, Port: natPMPPort}, timeout)
+		_, err := nat.ExternalIP()
+		if err != nil {
+			log.Debugf("%s did not respond to NAT-PMP: %s", candidate, err)
+			continue
+		}
+		return nat, nil
+	}
+	return nil, errors.New("no NAT-PMP gateway responded")
+}
+
+func newNATPMP(gateway *net.UDPAddr, timeout time.Duration) *natPMPNAT {
+	return &natPMPNAT{
+		gateway: gateway,
+		timeout: timeout,
+	}
+}
+
+// ExternalIP returns the external IP address of the gateway
+func (n *natPMPNAT) ExternalIP() (net.IP, error) {
+	request := []byte{natPMPVersion, natPMPOpExternalAddress}
+	response, err := n.roundTrip(request, natPMPOpExternalAddress, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(response[8], response[9], response[10], response[11]), nil
+}
+
+// AddPortMapping asks the gateway to forward externalPort to internalPort on this machine.
+// The gateway may choose a different external port, which is returned.
+func (n *natPMPNAT) AddPortMapping(protocol string, externalPort, internalPort uint16, _ string,
+	lifetime time.Duration) (uint16, error) {
+
+	opcode, err := natPMPMapOpcode(protocol)
+	if err != nil {
+		return 0, err
+	}
+	response, err := n.roundTrip(natPMPMapRequest(opcode, internalPort, externalPort, lifetime), opcode, 16)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(response[10:12]), nil
+}
+
+// DeletePortMapping removes a port mapping previously added by AddPortMapping
+func (n *natPMPNAT) DeletePortMapping(protocol string, _, internalPort uint16) error {
+	opcode, err := natPMPMapOpcode(protocol)
+	if err != nil {
+		return err
+	}
+	// A mapping is deleted by requesting it again with both the suggested
+	// external port and the lifetime set to zero
+	_, err = n.roundTrip(natPMPMapRequest(opcode, internalPort, 0, 0), opcode, 16)
+	return err
+}
+
+func (n *natPMPNAT) String() string {
+	return fmt.Sprintf("NAT-PMP gateway at %s", n.gateway)
+}
+
+func natPMPMapOpcode(protocol string) (byte, error) {
+	switch protocol {
+	case ProtocolTCP:
+		return natPMPOpMapTCP, nil
+	case ProtocolUDP:
+		return natPMPOpMapUDP, nil
+	default:
+		return 0, errors.Errorf("unsupported protocol %s", protocol)
+	}
+}
+
+func natPMPMapRequest(opcode byte, internalPort, externalPort uint16, lifetime time.Duration) []byte {
+	request := make([]byte, 12)
+	request[0] = natPMPVersion
+	request[1] = opcode
+	// bytes 2-3 are reserved and must be zero
+	binary.BigEndian.PutUint16(request[4:6], internalPort)
+	binary.BigEndian.PutUint16(request[6:8], externalPort)
+	binary.BigEndian.PutUint32(request[8:12], uint32(lifetime/time.Second))
+	return request
+}
+
+// roundTrip sends request to the gateway, retransmitting it with exponential
+// back-off as described in RFC 6886 until a valid response arrives or the
+// timeout is reached
+func (n *natPMPNAT) roundTrip(request []byte, opcode byte, responseLength int) ([]byte, error) {
+	connection, err := net.DialUDP("udp4", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	deadline := time.Now().Add(n.timeout)
+	retryInterval := natPMPInitialRetryInterval
+	buffer := make([]byte, 16)
+	for attempt := 0; attempt < natPMPMaxAttempts && time.Now().Before(deadline); attempt++ {
+		_, err = connection.Write(request)
+		if err != nil {
+			return nil, err
+		}
+
+		attemptDeadline := time.Now().Add(retryInterval)
+		if attemptDeadline.After(deadline) {
+			attemptDeadline = deadline
+		}
+		err = connection.SetReadDeadline(attemptDeadline)
+		if err != nil {
+			return nil, err
+		}
+
+		for {
+			length, err := connection.Read(buffer)
+			if err != nil {
+				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+					break
+				}
+				return nil, err
+			}
+			if length < responseLength || buffer[0] != natPMPVersion ||
+				buffer[1] != natPMPResponseOpcodeOffset+opcode {
+				continue
+			}
+
+			resultCode := binary.BigEndian.Uint16(buffer[2:4])
+			if resultCode != 0 {
+				description, ok := natPMPResultDescriptions[resultCode]
+				if !ok {
+					description = "result code " + strconv.Itoa(int(resultCode))
+				}
+				return nil, errors.Errorf("NAT-PMP request failed: %s", description)
+			}
+			return buffer[:length], nil
+		}
+
+		retryInterval *= 2
+	}
+	return nil, errors.Errorf("timed out waiting for a NAT-PMP response from %s", n.gateway)
+}
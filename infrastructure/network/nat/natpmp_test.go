@@ -0,0 +1,114 @@
+package nat
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeNATPMPGateway answers NAT-PMP requests on a local UDP socket.
+// Every mapping request is granted with mappedPortOffset added to the
+// suggested external port
+type fakeNATPMPGateway struct {
+	connection       *net.UDPConn
+	externalIP       net.IP
+	mappedPortOffset uint16
+	resultCode       uint16
+}
+
+func newFakeNATPMPGateway(t *testing.T, externalIP net.IP, mappedPortOffset uint16, resultCode uint16) *fakeNATPMPGateway {
+	connection, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("ListenUDP: %s", err)
+	}
+	gateway := &fakeNATPMPGateway{
+		connection:       connection,
+		externalIP:       externalIP.To4(),
+		mappedPortOffset: mappedPortOffset,
+		resultCode:       resultCode,
+	}
+	go gateway.serve()
+	return gateway
+}
+
+func (g *fakeNATPMPGateway) address() *net.UDPAddr {
+	return g.connection.LocalAddr().(*net.UDPAddr)
+}
+
+func (g *fakeNATPMPGateway) serve() {
+	buffer := make([]byte, 16)
+	for {
+		n, remoteAddress, err := g.connection.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		if n < 2 {
+			continue
+		}
+		opcode := buffer[1]
+
+		var response []byte
+		if opcode == natPMPOpExternalAddress {
+			response = make([]byte, 12)
+			copy(response[8:12], g.externalIP)
+		} else {
+			response = make([]byte, 16)
+			copy(response[8:10], buffer[4:6])
+			binary.BigEndian.PutUint16(response[10:12], binary.BigEndian.Uint16(buffer[6:8])+g.mappedPortOffset)
+			copy(response[12:16], buffer[8:12])
+		}
+		response[1] = natPMPResponseOpcodeOffset + opcode
+		binary.BigEndian.PutUint16(response[2:4], g.resultCode)
+		_, _ = g.connection.WriteToUDP(response, remoteAddress)
+	}
+}
+
+func TestNATPMP(t *testing.T) {
+	gateway := newFakeNATPMPGateway(t, net.ParseIP("198.51.100.8"), 1, 0)
+	defer gateway.connection.Close()
+
+	nat := newNATPMP(gateway.address(), time.Second)
+
+	externalIP, err := nat.ExternalIP()
+	if err != nil {
+		t.Fatalf("ExternalIP: %s", err)
+	}
+	if !externalIP.Equal(net.ParseIP("198.51.100.8")) {
+		t.Fatalf("unexpected external IP %s", externalIP)
+	}
+
+	mappedPort, err := nat.AddPortMapping(ProtocolTCP, 16111, 16111, "kaspad", time.Minute)
+	if err != nil {
+		t.Fatalf("AddPortMapping: %s", err)
+	}
+	if mappedPort != 16112 {
+		t.Fatalf("expected the gateway-chosen port 16112 but got %d", mappedPort)
+	}
+
+	err = nat.DeletePortMapping(ProtocolTCP, mappedPort, 16111)
+	if err != nil {
+		t.Fatalf("DeletePortMapping: %s", err)
+	}
+}
+
+func TestNATPMPErrors(t *testing.T) {
+	gateway := newFakeNATPMPGateway(t, net.ParseIP("198.51.100.8"), 0, 2)
+	defer gateway.connection.Close()
+
+	nat := newNATPMP(gateway.address(), time.Second)
+	_, err := nat.AddPortMapping(ProtocolTCP, 16111, 16111, "kaspad", time.Minute)
+	if err == nil {
+		t.Fatalf("AddPortMapping unexpectedly succeeded")
+	}
+
+	_, err = nat.AddPortMapping("SCTP", 16111, 16111, "kaspad", time.Minute)
+	if err == nil {
+		t.Fatalf("AddPortMapping with an unsupported protocol unexpectedly succeeded")
+	}
+
+	_, err = discoverNATPMP([]net.IP{net.IPv4(127, 0, 0, 1)}, 300*time.Millisecond)
+	if err == nil {
+		t.Fatalf("discoverNATPMP unexpectedly succeeded")
+	}
+}
@@ -0,0 +1,311 @@
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ssdpMulticastAddress = "239.255.255.250:1900"
+	ssdpSearchTarget     = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+
+	wanIPConnectionServiceType  = "urn:schemas-upnp-org:service:WANIPConnection:1"
+	wanPPPConnectionServiceType = "urn:schemas-upnp-org:service:WANPPPConnection:1"
+)
+
+// upnpNAT is a NAT implementation that talks to a UPnP Internet Gateway Device
+type upnpNAT struct {
+	client      *http.Client
+	controlURL  string
+	serviceType string
+	localIP     net.IP
+}
+
+// discoverUPnP sends an SSDP M-SEARCH request to ssdpAddress and returns
+// the first Internet Gateway Device that exposes a WAN connection service
+func discoverUPnP(ssdpAddress string, timeout time.Duration) (*upnpNAT, error) {
+	deadline := time.Now().Add(timeout)
+
+	destination, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return nil, err
+	}
+	connection, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	err = connection.SetDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	searchMessage := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpMulticastAddress + "\r\n" +
+		"ST: " + ssdpSearchTarget + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n\r\n"
+	_, err = connection.WriteToUDP([]byte(searchMessage), destination)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: timeout}
+	buffer := make([]byte, 1500)
+	for {
+		n, _, err := connection.ReadFromUDP(buffer)
+		if err != nil {
+			return nil, errors.Wrap(err, "no Internet Gateway Device responded to SSDP search")
+		}
+
+		location, ok := parseSSDPResponse(buffer[:n])
+		if !ok {
+			continue
+		}
+
+		nat, err := newUPnPNATFromDeviceDescription(client, location)
+		if err != nil {
+			log.Debugf("Skipping UPnP device at %s: %s", location, err)
+			continue
+		}
+		return nat, nil
+	}
+}
+
+// parseSSDPResponse returns the LOCATION header of an SSDP response if it
+// describes an Internet Gateway Device
+func parseSSDPResponse(data []byte) (location string, ok bool) {
+	response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), nil)
+	if err != nil {
+		return "", false
+	}
+	defer response.Body.Close()
+
+	if !strings.Contains(response.Header.Get("St"), "InternetGatewayDevice") {
+		return "", false
+	}
+	location = response.Header.Get("Location")
+	return location, location != ""
+}
+
+type upnpService struct {
+	ServiceType string `xml:"serviceType"`
+	ControlURL  string `xml:"controlURL"`
+}
+
+type upnpDevice struct {
+	DeviceType string        `xml:"deviceType"`
+	Devices    []upnpDevice  `xml:"deviceList>device"`
+	Services   []upnpService `xml:"serviceList>service"`
+}
+
+type upnpRoot struct {
+	URLBase string     `xml:"URLBase"`
+	Device  upnpDevice `xml:"device"`
+}
+
+// findWANConnectionService searches the device tree for
+// a WANIPConnection or WANPPPConnection service
+func (device *upnpDevice) findWANConnectionService() (*upnpService, bool) {
+	for i := range device.Services {
+		service := &device.Services[i]
+		if service.ServiceType == wanIPConnectionServiceType || service.ServiceType == wanPPPConnectionServiceType {
+			return service, true
+		}
+	}
+	for i := range device.Devices {
+		service, ok := device.Devices[i].findWANConnectionService()
+		if ok {
+			return service, true
+		}
+	}
+	return nil, false
+}
+
+func newUPnPNATFromDeviceDescription(client *http.Client, location string) (*upnpNAT, error) {
+	response, err := client.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s when fetching device description", response.Status)
+	}
+
+	var root upnpRoot
+	err = xml.NewDecoder(response.Body).Decode(&root)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse device description")
+	}
+
+	service, ok := root.Device.findWANConnectionService()
+	if !ok {
+		return nil, errors.New("device has no WAN connection service")
+	}
+
+	baseURL, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if root.URLBase != "" {
+		baseURL, err = url.Parse(root.URLBase)
+		if err != nil {
+			return nil, err
+		}
+	}
+	controlURL, err := baseURL.Parse(service.ControlURL)
+	if err != nil {
+		return nil, err
+	}
+
+	localIP, err := localIPTowards(controlURL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	return &upnpNAT{
+		client:      client,
+		controlURL:  controlURL.String(),
+		serviceType: service.ServiceType,
+		localIP:     localIP,
+	}, nil
+}
+
+// localIPTowards returns the IP address of the local interface that would be
+// used to reach the given host
+func localIPTowards(host string) (net.IP, error) {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "80")
+	}
+	connection, err := net.Dial("udp4", host)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+	return connection.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// soapRequest invokes the given action on the gateway's WAN connection service
+// and returns the raw response body
+func (n *upnpNAT) soapRequest(action string, arguments string) ([]byte, error) {
+	envelope := `<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" ` +
+		`s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+		`<s:Body><u:` + action + ` xmlns:u="` + n.serviceType + `">` + arguments +
+		`</u:` + action + `></s:Body></s:Envelope>`
+
+	request, err := http.NewRequest(http.MethodPost, n.controlURL, strings.NewReader(envelope))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	request.Header.Set("SOAPAction", `"`+n.serviceType+`#`+action+`"`)
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("UPnP action %s failed with status %s", action, response.Status)
+	}
+	return body, nil
+}
+
+// ExternalIP returns the external IP address of the gateway
+func (n *upnpNAT) ExternalIP() (net.IP, error) {
+	body, err := n.soapRequest("GetExternalIPAddress", "")
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := findXMLElementValue(body, "NewExternalIPAddress")
+	if !ok {
+		return nil, errors.New("GetExternalIPAddress response is missing NewExternalIPAddress")
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, errors.Errorf("gateway returned an invalid external IP %q", value)
+	}
+	return ip, nil
+}
+
+// AddPortMapping asks the gateway to forward externalPort to internalPort on this machine.
+// UPnP always maps the requested external port, so that is what's returned.
+func (n *upnpNAT) AddPortMapping(protocol string, externalPort, internalPort uint16, description string,
+	lifetime time.Duration) (uint16, error) {
+
+	arguments := "<NewRemoteHost></NewRemoteHost>" +
+		"<NewExternalPort>" + strconv.Itoa(int(externalPort)) + "</NewExternalPort>" +
+		"<NewProtocol>" + protocol + "</NewProtocol>" +
+		"<NewInternalPort>" + strconv.Itoa(int(internalPort)) + "</NewInternalPort>" +
+		"<NewInternalClient>" + n.localIP.String() + "</NewInternalClient>" +
+		"<NewEnabled>1</NewEnabled>" +
+		"<NewPortMappingDescription>" + xmlEscape(description) + "</NewPortMappingDescription>" +
+		"<NewLeaseDuration>" + strconv.Itoa(int(lifetime/time.Second)) + "</NewLeaseDuration>"
+
+	_, err := n.soapRequest("AddPortMapping", arguments)
+	if err != nil {
+		return 0, err
+	}
+	return externalPort, nil
+}
+
+// DeletePortMapping removes a port mapping previously added by AddPortMapping
+func (n *upnpNAT) DeletePortMapping(protocol string, externalPort, _ uint16) error {
+	arguments := "<NewRemoteHost></NewRemoteHost>" +
+		"<NewExternalPort>" + strconv.Itoa(int(externalPort)) + "</NewExternalPort>" +
+		"<NewProtocol>" + protocol + "</NewProtocol>"
+
+	_, err := n.soapRequest("DeletePortMapping", arguments)
+	return err
+}
+
+func (n *upnpNAT) String() string {
+	return fmt.Sprintf("UPnP gateway at %s", n.controlURL)
+}
+
+// findXMLElementValue returns the character data of the first element named
+// name anywhere in the given XML document, ignoring namespaces
+func findXMLElementValue(document []byte, name string) (string, bool) {
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", false
+		}
+		startElement, ok := token.(xml.StartElement)
+		if !ok || startElement.Name.Local != name {
+			continue
+		}
+		var value string
+		err = decoder.DecodeElement(&value, &startElement)
+		if err != nil {
+			return "", false
+		}
+		return strings.TrimSpace(value), true
+	}
+}
+
+func xmlEscape(s string) string {
+	var buffer bytes.Buffer
+	_ = xml.EscapeText(&buffer, []byte(s))
+	return buffer.String()
+}
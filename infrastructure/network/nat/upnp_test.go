@@ -0,0 +1,189 @@
+package nat
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const fakeIGDDeviceDescription = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+	<device>
+		<deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+		<deviceList>
+			<device>
+				<deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+				<deviceList>
+					<device>
+						<deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+						<serviceList>
+							<service>
+								<serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+								<controlURL>/ctl/IPConn</controlURL>
+							</service>
+						</serviceList>
+					</device>
+				</deviceList>
+			</device>
+		</deviceList>
+	</device>
+</root>`
+
+// fakeIGD is a minimal UPnP Internet Gateway Device that answers SSDP
+// searches on a local UDP socket and SOAP requests over HTTP
+type fakeIGD struct {
+	ssdpConnection *net.UDPConn
+	httpServer     *httptest.Server
+	externalIP     string
+
+	mutex    sync.Mutex
+	mappings map[string]string
+}
+
+func newFakeIGD(t *testing.T, externalIP string) *fakeIGD {
+	igd := &fakeIGD{
+		externalIP: externalIP,
+		mappings:   map[string]string{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rootDesc.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, fakeIGDDeviceDescription)
+	})
+	mux.HandleFunc("/ctl/IPConn", igd.handleControl)
+	igd.httpServer = httptest.NewServer(mux)
+
+	ssdpConnection, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("ListenUDP: %s", err)
+	}
+	igd.ssdpConnection = ssdpConnection
+	go igd.serveSSDP()
+
+	return igd
+}
+
+func (igd *fakeIGD) ssdpAddress() string {
+	return igd.ssdpConnection.LocalAddr().String()
+}
+
+func (igd *fakeIGD) close() {
+	igd.ssdpConnection.Close()
+	igd.httpServer.Close()
+}
+
+func (igd *fakeIGD) mapping(externalPort string) (string, bool) {
+	igd.mutex.Lock()
+	defer igd.mutex.Unlock()
+
+	mapping, ok := igd.mappings[externalPort]
+	return mapping, ok
+}
+
+func (igd *fakeIGD) serveSSDP() {
+	buffer := make([]byte, 1500)
+	for {
+		n, remoteAddress, err := igd.ssdpConnection.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		if !strings.HasPrefix(string(buffer[:n]), "M-SEARCH") {
+			continue
+		}
+		response := "HTTP/1.1 200 OK\r\n" +
+			"CACHE-CONTROL: max-age=120\r\n" +
+			"ST: " + ssdpSearchTarget + "\r\n" +
+			"USN: uuid:fake-igd::" + ssdpSearchTarget + "\r\n" +
+			"LOCATION: " + igd.httpServer.URL + "/rootDesc.xml\r\n\r\n"
+		_, _ = igd.ssdpConnection.WriteToUDP([]byte(response), remoteAddress)
+	}
+}
+
+func (igd *fakeIGD) handleControl(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	soapAction := r.Header.Get("SOAPAction")
+	action := soapAction[strings.Index(soapAction, "#")+1 : len(soapAction)-1]
+
+	igd.mutex.Lock()
+	defer igd.mutex.Unlock()
+
+	switch action {
+	case "GetExternalIPAddress":
+		fmt.Fprintf(w, `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">`+
+			`<s:Body><u:GetExternalIPAddressResponse xmlns:u="%s">`+
+			`<NewExternalIPAddress>%s</NewExternalIPAddress>`+
+			`</u:GetExternalIPAddressResponse></s:Body></s:Envelope>`, wanIPConnectionServiceType, igd.externalIP)
+	case "AddPortMapping":
+		externalPort, _ := findXMLElementValue(body, "NewExternalPort")
+		internalClient, _ := findXMLElementValue(body, "NewInternalClient")
+		internalPort, _ := findXMLElementValue(body, "NewInternalPort")
+		igd.mappings[externalPort] = net.JoinHostPort(internalClient, internalPort)
+	case "DeletePortMapping":
+		externalPort, _ := findXMLElementValue(body, "NewExternalPort")
+		delete(igd.mappings, externalPort)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func TestUPnP(t *testing.T) {
+	igd := newFakeIGD(t, "198.51.100.7")
+	defer igd.close()
+
+	nat, err := discoverUPnP(igd.ssdpAddress(), time.Second)
+	if err != nil {
+		t.Fatalf("discoverUPnP: %s", err)
+	}
+	if nat.serviceType != wanIPConnectionServiceType {
+		t.Fatalf("unexpected service type %s", nat.serviceType)
+	}
+	if nat.controlURL != igd.httpServer.URL+"/ctl/IPConn" {
+		t.Fatalf("unexpected control URL %s", nat.controlURL)
+	}
+
+	externalIP, err := nat.ExternalIP()
+	if err != nil {
+		t.Fatalf("ExternalIP: %s", err)
+	}
+	if !externalIP.Equal(net.ParseIP("198.51.100.7")) {
+		t.Fatalf("unexpected external IP %s", externalIP)
+	}
+
+	mappedPort, err := nat.AddPortMapping(ProtocolTCP, 16111, 16112, "kaspad", time.Minute)
+	if err != nil {
+		t.Fatalf("AddPortMapping: %s", err)
+	}
+	if mappedPort != 16111 {
+		t.Fatalf("unexpected mapped port %d", mappedPort)
+	}
+	if mapping, _ := igd.mapping("16111"); mapping != "127.0.0.1:16112" {
+		t.Fatalf("unexpected mapping %q", mapping)
+	}
+
+	err = nat.DeletePortMapping(ProtocolTCP, 16111, 16112)
+	if err != nil {
+		t.Fatalf("DeletePortMapping: %s", err)
+	}
+	if _, ok := igd.mapping("16111"); ok {
+		t.Fatalf("mapping was not deleted")
+	}
+}
+
+func TestUPnPNoGateway(t *testing.T) {
+	silentConnection, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("ListenUDP: %s", err)
+	}
+	defer silentConnection.Close()
+
+	_, err = discoverUPnP(silentConnection.LocalAddr().String(), 200*time.Millisecond)
+	if err == nil {
+		t.Fatalf("discoverUPnP unexpectedly succeeded")
+	}
+}
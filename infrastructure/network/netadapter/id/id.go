@@ -5,6 +5,8 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"io"
+
+	"github.com/pkg/errors"
 )
 
 // IDLength of array used to store the ID.
@@ -58,12 +60,17 @@ func (id *ID) SerializeToBytes() ([]byte, error) {
 }
 
 // FromBytes returns an ID deserialized from the given byte slice.
-func FromBytes(serializedID []byte) *ID {
+// serializedID usually comes from a remote peer, so it's an error rather
+// than a panic for it to have the wrong length.
+func FromBytes(serializedID []byte) (*ID, error) {
+	if len(serializedID) != IDLength {
+		return nil, errors.Errorf("invalid ID length: got %d but expected %d", len(serializedID), IDLength)
+	}
 	r := bytes.NewReader(serializedID)
 	newID := new(ID)
 	err := newID.Deserialize(r)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return newID
+	return newID, nil
 }
@@ -38,11 +38,17 @@ type NetAdapter struct {
 // NewNetAdapter creates and starts a new NetAdapter on the
 // given listeningPort
 func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
-	netAdapterID, err := id.GenerateID()
+	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners)
 	if err != nil {
 		return nil, err
 	}
-	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners)
+	return NewNetAdapterWithP2PServer(cfg, p2pServer)
+}
+
+// NewNetAdapterWithP2PServer creates a new NetAdapter that uses the given
+// p2pServer instead of a gRPC server listening on cfg.Listeners
+func NewNetAdapterWithP2PServer(cfg *config.Config, p2pServer server.P2PServer) (*NetAdapter, error) {
+	netAdapterID, err := id.GenerateID()
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +134,7 @@ func (na *NetAdapter) P2PConnectionCount() int {
 }
 
 func (na *NetAdapter) onP2PConnectedHandler(connection server.Connection) error {
-	netConnection := newNetConnection(connection, na.p2pRouterInitializer)
+	netConnection := newNetConnection(connection, routerpkg.NewP2PRouter(), na.p2pRouterInitializer)
 
 	na.p2pConnectionsLock.Lock()
 	defer na.p2pConnectionsLock.Unlock()
@@ -148,7 +154,7 @@ func (na *NetAdapter) onP2PConnectedHandler(connection server.Connection) error
 }
 
 func (na *NetAdapter) onRPCConnectedHandler(connection server.Connection) error {
-	netConnection := newNetConnection(connection, na.rpcRouterInitializer)
+	netConnection := newNetConnection(connection, routerpkg.NewRouter(), na.rpcRouterInitializer)
 	netConnection.setOnDisconnectedHandler(func() {})
 	netConnection.start()
 
@@ -173,18 +179,24 @@ func (na *NetAdapter) ID() *id.ID {
 }
 
 // P2PBroadcast sends the given `message` to every peer corresponding
-// to each NetConnection in the given netConnections
+// to each NetConnection in the given netConnections.
+// Peers whose outgoing queue is full are skipped, so that a single slow
+// peer can't stall the broadcast to everyone else.
 func (na *NetAdapter) P2PBroadcast(netConnections []*NetConnection, message appmessage.Message) error {
 	na.p2pConnectionsLock.RLock()
 	defer na.p2pConnectionsLock.RUnlock()
 
 	for _, netConnection := range netConnections {
-		err := netConnection.router.OutgoingRoute().Enqueue(message)
+		err := netConnection.router.OutgoingRoute().TryEnqueue(message)
 		if err != nil {
 			if errors.Is(err, routerpkg.ErrRouteClosed) {
 				log.Debugf("Cannot enqueue message to %s: router is closed", netConnection)
 				continue
 			}
+			if errors.Is(err, routerpkg.ErrRouteCapacityReached) {
+				log.Debugf("Skipping broadcast of '%s' to %s: %s", message.Command(), netConnection, err)
+				continue
+			}
 			return err
 		}
 	}
@@ -3,6 +3,7 @@ package netadapter
 import (
 	"fmt"
 	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
 	"sync/atomic"
@@ -20,8 +21,8 @@ type NetConnection struct {
 	isRouterClosed        uint32
 }
 
-func newNetConnection(connection server.Connection, routerInitializer RouterInitializer) *NetConnection {
-	router := routerpkg.NewRouter()
+func newNetConnection(connection server.Connection, router *routerpkg.Router,
+	routerInitializer RouterInitializer) *NetConnection {
 
 	netConnection := &NetConnection{
 		connection: connection,
@@ -29,7 +30,7 @@ func newNetConnection(connection server.Connection, routerInitializer RouterInit
 	}
 
 	netConnection.connection.SetOnDisconnectedHandler(func() {
-		log.Infof("Disconnected from %s", netConnection)
+		log.WithFields(logger.Fields{"peer": netConnection.Address()}).Infof("Disconnected from %s", netConnection)
 		// If the disconnection came because of a network error and not because of the application layer, we
 		// need to close the router as well.
 		if atomic.AddUint32(&netConnection.isRouterClosed, 1) == 1 {
@@ -0,0 +1,95 @@
+package router
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// MessagePriority is the scheduling class of an outgoing P2P message.
+// Lower values are sent first.
+type MessagePriority int
+
+const (
+	// MessagePriorityControl is the priority of handshake, ping/pong and reject messages
+	MessagePriorityControl MessagePriority = iota
+
+	// MessagePriorityBlockRelay is the priority of block relay messages
+	MessagePriorityBlockRelay
+
+	// MessagePriorityIBD is the priority of headers, IBD and pruning point messages
+	MessagePriorityIBD
+
+	// MessagePriorityTransactionRelay is the priority of transaction relay messages
+	MessagePriorityTransactionRelay
+
+	// MessagePriorityAddresses is the priority of address exchange messages
+	MessagePriorityAddresses
+
+	numberOfMessagePriorities = iota
+)
+
+// messagePriorityWeights are the number of messages of each priority that
+// may be dequeued in a single scheduling round while messages of lower
+// priority are waiting. This guarantees that lower priorities are never
+// starved by a burst of higher priority messages.
+var messagePriorityWeights = [numberOfMessagePriorities]int{
+	MessagePriorityControl:          16,
+	MessagePriorityBlockRelay:       8,
+	MessagePriorityIBD:              4,
+	MessagePriorityTransactionRelay: 2,
+	MessagePriorityAddresses:        1,
+}
+
+// Messages that belong to the same exchange must share a priority, since
+// ordering is only preserved within a single priority
+var messageCommandToPriority = map[appmessage.MessageCommand]MessagePriority{
+	appmessage.CmdVersion: MessagePriorityControl,
+	appmessage.CmdVerAck:  MessagePriorityControl,
+	appmessage.CmdPing:    MessagePriorityControl,
+	appmessage.CmdPong:    MessagePriorityControl,
+	appmessage.CmdReject:  MessagePriorityControl,
+
+	appmessage.CmdInvRelayBlock:            MessagePriorityBlockRelay,
+	appmessage.CmdRequestRelayBlocks:       MessagePriorityBlockRelay,
+	appmessage.CmdBlock:                    MessagePriorityBlockRelay,
+	appmessage.CmdCompactBlock:             MessagePriorityBlockRelay,
+	appmessage.CmdRequestBlockTransactions: MessagePriorityBlockRelay,
+	appmessage.CmdBlockTransactions:        MessagePriorityBlockRelay,
+
+	appmessage.CmdRequestHeaders:                      MessagePriorityIBD,
+	appmessage.CmdRequestNextHeaders:                  MessagePriorityIBD,
+	appmessage.CmdHeader:                              MessagePriorityIBD,
+	appmessage.CmdBlockHeaders:                        MessagePriorityIBD,
+	appmessage.CmdDoneHeaders:                         MessagePriorityIBD,
+	appmessage.CmdRequestBlockLocator:                 MessagePriorityIBD,
+	appmessage.CmdBlockLocator:                        MessagePriorityIBD,
+	appmessage.CmdIBDBlockLocator:                     MessagePriorityIBD,
+	appmessage.CmdIBDBlockLocatorHighestHash:          MessagePriorityIBD,
+	appmessage.CmdIBDBlockLocatorHighestHashNotFound:  MessagePriorityIBD,
+	appmessage.CmdRequestIBDBlocks:                    MessagePriorityIBD,
+	appmessage.CmdIBDBlock:                            MessagePriorityIBD,
+	appmessage.CmdRequestPruningPointHash:             MessagePriorityIBD,
+	appmessage.CmdPruningPointHash:                    MessagePriorityIBD,
+	appmessage.CmdRequestPruningPointUTXOSetAndBlock:  MessagePriorityIBD,
+	appmessage.CmdPruningPointUTXOSetChunk:            MessagePriorityIBD,
+	appmessage.CmdRequestNextPruningPointUTXOSetChunk: MessagePriorityIBD,
+	appmessage.CmdDonePruningPointUTXOSetChunks:       MessagePriorityIBD,
+	appmessage.CmdUnexpectedPruningPoint:              MessagePriorityIBD,
+
+	appmessage.CmdInvTransaction:      MessagePriorityTransactionRelay,
+	appmessage.CmdRequestTransactions: MessagePriorityTransactionRelay,
+	appmessage.CmdTx:                  MessagePriorityTransactionRelay,
+	appmessage.CmdTransactionNotFound: MessagePriorityTransactionRelay,
+
+	appmessage.CmdRequestAddresses: MessagePriorityAddresses,
+	appmessage.CmdAddresses:        MessagePriorityAddresses,
+}
+
+// MessagePriorityOf returns the scheduling priority of messages with the given command.
+// Commands without an explicit priority are treated as control messages.
+func MessagePriorityOf(command appmessage.MessageCommand) MessagePriority {
+	priority, ok := messageCommandToPriority[command]
+	if !ok {
+		return MessagePriorityControl
+	}
+	return priority
+}
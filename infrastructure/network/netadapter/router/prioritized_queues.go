@@ -0,0 +1,195 @@
+package router
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+// prioritizedQueues holds a separate bounded queue for every MessagePriority
+// and schedules between them using weighted round-robin.
+//
+// A full queue applies backpressure: Enqueue blocks until the queue has room,
+// the route is closed, or enqueueTimeout expires.
+//
+// The queues themselves are slices that grow as needed, since a node keeps
+// one prioritizedQueues per peer and preallocating capacityPerPriority
+// messages for each of them costs several megabytes per connection. The
+// bounds are enforced by slots instead, whose element type takes no memory.
+type prioritizedQueues struct {
+	queues     [numberOfMessagePriorities][]appmessage.Message
+	queuesLock sync.Mutex
+
+	// slots holds one token for every message in the matching queue, and is
+	// what makes enqueuing block once capacityPerPriority is reached
+	slots [numberOfMessagePriorities]chan struct{}
+
+	// ready holds one token for every message in queues, so that dequeuers
+	// may block on a single channel regardless of which queue is non-empty
+	ready chan struct{}
+
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	capacityPerPriority int
+	enqueueTimeout      time.Duration
+
+	schedulerLock sync.Mutex
+	credits       [numberOfMessagePriorities]int
+}
+
+func newPrioritizedQueues(capacityPerPriority int, enqueueTimeout time.Duration) *prioritizedQueues {
+	pq := &prioritizedQueues{
+		ready:               make(chan struct{}, capacityPerPriority*numberOfMessagePriorities),
+		closed:              make(chan struct{}),
+		capacityPerPriority: capacityPerPriority,
+		enqueueTimeout:      enqueueTimeout,
+	}
+	for i := range pq.slots {
+		pq.slots[i] = make(chan struct{}, capacityPerPriority)
+	}
+	pq.resetCredits()
+	return pq
+}
+
+func (pq *prioritizedQueues) isClosed() bool {
+	select {
+	case <-pq.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (pq *prioritizedQueues) enqueue(message appmessage.Message) error {
+	if pq.isClosed() {
+		return errors.WithStack(ErrRouteClosed)
+	}
+
+	priority := MessagePriorityOf(message.Command())
+	slots := pq.slots[priority]
+	select {
+	case slots <- struct{}{}:
+		pq.push(priority, message)
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(pq.enqueueTimeout)
+	defer timer.Stop()
+	select {
+	case slots <- struct{}{}:
+		pq.push(priority, message)
+		return nil
+	case <-pq.closed:
+		return errors.WithStack(ErrRouteClosed)
+	case <-timer.C:
+		return errors.Wrapf(ErrRouteCapacityReached, "reached capacity of %d for priority %d and "+
+			"it did not drain within %s", pq.capacityPerPriority, priority, pq.enqueueTimeout)
+	}
+}
+
+func (pq *prioritizedQueues) tryEnqueue(message appmessage.Message) error {
+	if pq.isClosed() {
+		return errors.WithStack(ErrRouteClosed)
+	}
+
+	priority := MessagePriorityOf(message.Command())
+	select {
+	case pq.slots[priority] <- struct{}{}:
+		pq.push(priority, message)
+		return nil
+	default:
+		return errors.Wrapf(ErrRouteCapacityReached, "reached capacity of %d for priority %d",
+			pq.capacityPerPriority, priority)
+	}
+}
+
+// push appends message to the queue of the given priority. The caller must
+// already hold a slot for it.
+func (pq *prioritizedQueues) push(priority MessagePriority, message appmessage.Message) {
+	pq.queuesLock.Lock()
+	pq.queues[priority] = append(pq.queues[priority], message)
+	pq.queuesLock.Unlock()
+
+	pq.ready <- struct{}{}
+}
+
+// dequeue waits for a message until one is available, the route is closed or
+// timeoutChan fires. Messages enqueued before the route was closed are still
+// returned, so that e.g. a reject message may be sent before disconnecting.
+func (pq *prioritizedQueues) dequeue(timeoutChan <-chan time.Time, timeout time.Duration) (appmessage.Message, error) {
+	select {
+	case <-pq.ready:
+		return pq.next(), nil
+	default:
+	}
+
+	select {
+	case <-pq.ready:
+		return pq.next(), nil
+	case <-pq.closed:
+		select {
+		case <-pq.ready:
+			return pq.next(), nil
+		default:
+			return nil, errors.WithStack(ErrRouteClosed)
+		}
+	case <-timeoutChan:
+		return nil, errors.Wrapf(ErrTimeout, "got timeout after %s", timeout)
+	}
+}
+
+// next picks the highest priority non-empty queue that still has credits
+// left in the current round. Once no such queue exists a new round begins.
+// The caller must hold a token from pq.ready, which guarantees that at least
+// one message is waiting.
+func (pq *prioritizedQueues) next() appmessage.Message {
+	pq.schedulerLock.Lock()
+	defer pq.schedulerLock.Unlock()
+
+	for {
+		for priority := range pq.queues {
+			if pq.credits[priority] == 0 {
+				continue
+			}
+			message, ok := pq.pop(MessagePriority(priority))
+			if ok {
+				pq.credits[priority]--
+				return message
+			}
+		}
+		pq.resetCredits()
+	}
+}
+
+// pop removes the oldest message of the given priority, if there is one,
+// and releases its slot.
+func (pq *prioritizedQueues) pop(priority MessagePriority) (appmessage.Message, bool) {
+	pq.queuesLock.Lock()
+	defer pq.queuesLock.Unlock()
+
+	queue := pq.queues[priority]
+	if len(queue) == 0 {
+		return nil, false
+	}
+	message := queue[0]
+	queue[0] = nil
+	pq.queues[priority] = queue[1:]
+	<-pq.slots[priority]
+	return message, true
+}
+
+func (pq *prioritizedQueues) resetCredits() {
+	for priority, weight := range messagePriorityWeights {
+		pq.credits[priority] = weight
+	}
+}
+
+func (pq *prioritizedQueues) close() {
+	pq.closeOnce.Do(func() {
+		close(pq.closed)
+	})
+}
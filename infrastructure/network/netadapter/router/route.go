@@ -34,6 +34,10 @@ type Route struct {
 	closed    bool
 	closeLock sync.Mutex
 	capacity  int
+
+	// prioritizedQueues is set only for prioritized routes, in which case
+	// channel is unused
+	prioritizedQueues *prioritizedQueues
 }
 
 // NewRoute create a new Route
@@ -49,8 +53,32 @@ func newRouteWithCapacity(capacity int) *Route {
 	}
 }
 
-// Enqueue enqueues a message to the Route
+// newPrioritizedRoute creates a route that schedules messages by their
+// MessagePriority. Each priority is bounded by capacityPerPriority, and
+// Enqueue blocks for up to enqueueTimeout while the relevant queue is full.
+func newPrioritizedRoute(capacityPerPriority int, enqueueTimeout time.Duration) *Route {
+	return &Route{
+		capacity:          capacityPerPriority,
+		prioritizedQueues: newPrioritizedQueues(capacityPerPriority, enqueueTimeout),
+	}
+}
+
+// Enqueue enqueues a message to the Route.
+// For prioritized routes this blocks while the message's priority queue is full.
 func (r *Route) Enqueue(message appmessage.Message) error {
+	if r.prioritizedQueues != nil {
+		return r.prioritizedQueues.enqueue(message)
+	}
+	return r.TryEnqueue(message)
+}
+
+// TryEnqueue enqueues a message to the Route, returning
+// ErrRouteCapacityReached immediately if there's no room for it
+func (r *Route) TryEnqueue(message appmessage.Message) error {
+	if r.prioritizedQueues != nil {
+		return r.prioritizedQueues.tryEnqueue(message)
+	}
+
 	r.closeLock.Lock()
 	defer r.closeLock.Unlock()
 
@@ -66,6 +94,9 @@ func (r *Route) Enqueue(message appmessage.Message) error {
 
 // Dequeue dequeues a message from the Route
 func (r *Route) Dequeue() (appmessage.Message, error) {
+	if r.prioritizedQueues != nil {
+		return r.prioritizedQueues.dequeue(nil, 0)
+	}
 	message, isOpen := <-r.channel
 	if !isOpen {
 		return nil, errors.WithStack(ErrRouteClosed)
@@ -76,6 +107,11 @@ func (r *Route) Dequeue() (appmessage.Message, error) {
 // DequeueWithTimeout attempts to dequeue a message from the Route
 // and returns an error if the given timeout expires first.
 func (r *Route) DequeueWithTimeout(timeout time.Duration) (appmessage.Message, error) {
+	if r.prioritizedQueues != nil {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		return r.prioritizedQueues.dequeue(timer.C, timeout)
+	}
 	select {
 	case <-time.After(timeout):
 		return nil, errors.Wrapf(ErrTimeout, "got timeout after %s", timeout)
@@ -89,6 +125,11 @@ func (r *Route) DequeueWithTimeout(timeout time.Duration) (appmessage.Message, e
 
 // Close closes this route
 func (r *Route) Close() {
+	if r.prioritizedQueues != nil {
+		r.prioritizedQueues.close()
+		return
+	}
+
 	r.closeLock.Lock()
 	defer r.closeLock.Unlock()
 
@@ -0,0 +1,155 @@
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func TestPrioritizedRouteOrdering(t *testing.T) {
+	route := newPrioritizedRoute(10, time.Second)
+
+	messages := []appmessage.Message{
+		appmessage.NewMsgRequestAddresses(false, nil),
+		appmessage.NewMsgInvTransaction(nil),
+		appmessage.NewMsgDoneHeaders(),
+		appmessage.NewMsgInvBlock(nil),
+		appmessage.NewMsgPing(1),
+	}
+	for _, message := range messages {
+		err := route.Enqueue(message)
+		if err != nil {
+			t.Fatalf("Enqueue: %s", err)
+		}
+	}
+
+	expectedCommands := []appmessage.MessageCommand{
+		appmessage.CmdPing,
+		appmessage.CmdInvRelayBlock,
+		appmessage.CmdDoneHeaders,
+		appmessage.CmdInvTransaction,
+		appmessage.CmdRequestAddresses,
+	}
+	for i, expectedCommand := range expectedCommands {
+		message, err := route.DequeueWithTimeout(time.Second)
+		if err != nil {
+			t.Fatalf("DequeueWithTimeout: %s", err)
+		}
+		if message.Command() != expectedCommand {
+			t.Fatalf("message %d: expected %s but got %s", i, expectedCommand, message.Command())
+		}
+	}
+}
+
+func TestPrioritizedRouteFairness(t *testing.T) {
+	route := newPrioritizedRoute(100, time.Second)
+
+	for i := 0; i < 50; i++ {
+		err := route.Enqueue(appmessage.NewMsgInvBlock(nil))
+		if err != nil {
+			t.Fatalf("Enqueue: %s", err)
+		}
+	}
+	err := route.Enqueue(appmessage.NewMsgRequestAddresses(false, nil))
+	if err != nil {
+		t.Fatalf("Enqueue: %s", err)
+	}
+
+	// The address message must be sent once the block relay
+	// priority exhausts its weight for the current round
+	blockRelayWeight := messagePriorityWeights[MessagePriorityBlockRelay]
+	for i := 0; i <= blockRelayWeight; i++ {
+		message, err := route.Dequeue()
+		if err != nil {
+			t.Fatalf("Dequeue: %s", err)
+		}
+		if message.Command() == appmessage.CmdRequestAddresses {
+			if i != blockRelayWeight {
+				t.Fatalf("address message was sent after %d block messages instead of %d", i, blockRelayWeight)
+			}
+			return
+		}
+	}
+	t.Fatalf("address message was starved by block relay messages")
+}
+
+func TestPrioritizedRouteBackpressure(t *testing.T) {
+	route := newPrioritizedRoute(1, 100*time.Millisecond)
+
+	err := route.Enqueue(appmessage.NewMsgInvTransaction(nil))
+	if err != nil {
+		t.Fatalf("Enqueue: %s", err)
+	}
+
+	// Other priorities are unaffected by a full transaction queue
+	err = route.TryEnqueue(appmessage.NewMsgInvBlock(nil))
+	if err != nil {
+		t.Fatalf("TryEnqueue: %s", err)
+	}
+
+	err = route.TryEnqueue(appmessage.NewMsgInvTransaction(nil))
+	if !errors.Is(err, ErrRouteCapacityReached) {
+		t.Fatalf("expected ErrRouteCapacityReached but got %v", err)
+	}
+
+	// Enqueue blocks until the queue drains
+	dequeued := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = route.Dequeue()
+		_, _ = route.Dequeue()
+		close(dequeued)
+	}()
+	err = route.Enqueue(appmessage.NewMsgInvTransaction(nil))
+	if err != nil {
+		t.Fatalf("Enqueue: %s", err)
+	}
+	<-dequeued
+
+	// Enqueue gives up if the queue doesn't drain in time
+	err = route.Enqueue(appmessage.NewMsgInvTransaction(nil))
+	if !errors.Is(err, ErrRouteCapacityReached) {
+		t.Fatalf("expected ErrRouteCapacityReached but got %v", err)
+	}
+}
+
+func TestPrioritizedRouteClose(t *testing.T) {
+	route := newPrioritizedRoute(1, time.Minute)
+
+	err := route.Enqueue(appmessage.NewMsgReject("reason"))
+	if err != nil {
+		t.Fatalf("Enqueue: %s", err)
+	}
+
+	// A blocked Enqueue must be released by Close
+	enqueueErr := make(chan error)
+	go func() {
+		enqueueErr <- route.Enqueue(appmessage.NewMsgReject("another reason"))
+	}()
+	time.Sleep(50 * time.Millisecond)
+	route.Close()
+	err = <-enqueueErr
+	if !errors.Is(err, ErrRouteClosed) {
+		t.Fatalf("expected ErrRouteClosed but got %v", err)
+	}
+
+	// Messages enqueued before Close are still delivered
+	message, err := route.Dequeue()
+	if err != nil {
+		t.Fatalf("Dequeue: %s", err)
+	}
+	if message.Command() != appmessage.CmdReject {
+		t.Fatalf("unexpected message %s", message.Command())
+	}
+
+	_, err = route.Dequeue()
+	if !errors.Is(err, ErrRouteClosed) {
+		t.Fatalf("expected ErrRouteClosed but got %v", err)
+	}
+	err = route.Enqueue(appmessage.NewMsgPing(1))
+	if !errors.Is(err, ErrRouteClosed) {
+		t.Fatalf("expected ErrRouteClosed but got %v", err)
+	}
+}
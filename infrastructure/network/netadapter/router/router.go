@@ -2,12 +2,19 @@ package router
 
 import (
 	"sync"
+	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/pkg/errors"
 )
 
-const outgoingRouteMaxMessages = appmessage.MaxInvPerMsg + DefaultMaxMessages
+const (
+	outgoingRouteMaxMessages = appmessage.MaxInvPerMsg + DefaultMaxMessages
+
+	// p2pOutgoingRouteEnqueueTimeout is how long a flow may be blocked on a full
+	// outgoing P2P queue before the peer is deemed unresponsive
+	p2pOutgoingRouteEnqueueTimeout = 30 * time.Second
+)
 
 // OnRouteCapacityReachedHandler is a function that is to
 // be called when one of the routes reaches capacity.
@@ -19,6 +26,12 @@ type Router struct {
 	incomingRoutes     map[appmessage.MessageCommand]*Route
 	incomingRoutesLock sync.RWMutex
 
+	// emptyIncomingRoutes are incoming routes that were added without any
+	// message types. No message is ever routed to them, but they're closed
+	// along with the router, so flows can wait on them to learn that the
+	// router was closed.
+	emptyIncomingRoutes []*Route
+
 	outgoingRoute *Route
 }
 
@@ -31,6 +44,17 @@ func NewRouter() *Router {
 	return &router
 }
 
+// NewP2PRouter creates a new empty router whose outgoing route schedules
+// messages by MessagePriority and applies backpressure when it's full,
+// instead of failing immediately
+func NewP2PRouter() *Router {
+	router := Router{
+		incomingRoutes: make(map[appmessage.MessageCommand]*Route),
+		outgoingRoute:  newPrioritizedRoute(outgoingRouteMaxMessages, p2pOutgoingRouteEnqueueTimeout),
+	}
+	return &router
+}
+
 // AddIncomingRoute registers the messages of types `messageTypes` to
 // be routed to the given `route`
 func (r *Router) AddIncomingRoute(messageTypes []appmessage.MessageCommand) (*Route, error) {
@@ -54,6 +78,13 @@ func (r *Router) AddIncomingRouteWithCapacity(capacity int, messageTypes []appme
 }
 
 func (r *Router) initializeIncomingRoute(route *Route, messageTypes []appmessage.MessageCommand) error {
+	if len(messageTypes) == 0 {
+		r.incomingRoutesLock.Lock()
+		defer r.incomingRoutesLock.Unlock()
+
+		r.emptyIncomingRoutes = append(r.emptyIncomingRoutes, route)
+		return nil
+	}
 	for _, messageType := range messageTypes {
 		if r.doesIncomingRouteExist(messageType) {
 			return errors.Errorf("a route for '%s' already exists", messageType)
@@ -100,6 +131,9 @@ func (r *Router) Close() {
 	for _, route := range r.incomingRoutes {
 		incomingRoutes[route] = struct{}{}
 	}
+	for _, route := range r.emptyIncomingRoutes {
+		incomingRoutes[route] = struct{}{}
+	}
 	for route := range incomingRoutes {
 		route.Close()
 	}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func TestCloseClosesRoutesWithoutMessageTypes(t *testing.T) {
+	router := NewRouter()
+	route, err := router.AddIncomingRoute([]appmessage.MessageCommand{})
+	if err != nil {
+		t.Fatalf("AddIncomingRoute: %s", err)
+	}
+
+	_, err = route.DequeueWithTimeout(10 * time.Millisecond)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Expected ErrTimeout before the router is closed, but got: %v", err)
+	}
+
+	router.Close()
+	_, err = route.DequeueWithTimeout(time.Second)
+	if !errors.Is(err, ErrRouteClosed) {
+		t.Fatalf("Expected ErrRouteClosed after the router is closed, but got: %v", err)
+	}
+}
@@ -22,8 +22,10 @@ type gRPCServer struct {
 // newGRPCServer creates a gRPC server
 func newGRPCServer(listeningAddresses []string, maxMessageSize int, name string) *gRPCServer {
 	log.Debugf("Created new %s GRPC server with maxMessageSize %d", name, maxMessageSize)
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize),
+		grpc.StatsHandler(newMessageBytesStatsHandler(name)))
 	return &gRPCServer{
-		server:             grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)),
+		server:             server,
 		listeningAddresses: listeningAddresses,
 		name:               name,
 	}
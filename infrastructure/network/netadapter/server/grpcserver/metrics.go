@@ -0,0 +1,50 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/infrastructure/metrics"
+	"google.golang.org/grpc/stats"
+)
+
+var (
+	sentBytes = metrics.NewCounterVec("kaspad_network_sent_bytes_total",
+		"The number of message bytes sent, by server (P2P or RPC)", "server")
+
+	receivedBytes = metrics.NewCounterVec("kaspad_network_received_bytes_total",
+		"The number of message bytes received, by server (P2P or RPC)", "server")
+)
+
+// messageBytesStatsHandler counts the bytes of the messages that gRPC
+// serializes and deserializes, so that they don't have to be marshalled
+// again just to measure their size
+type messageBytesStatsHandler struct {
+	sentBytes     *metrics.Counter
+	receivedBytes *metrics.Counter
+}
+
+func newMessageBytesStatsHandler(serverName string) *messageBytesStatsHandler {
+	return &messageBytesStatsHandler{
+		sentBytes:     sentBytes.WithLabel(serverName),
+		receivedBytes: receivedBytes.WithLabel(serverName),
+	}
+}
+
+func (h *messageBytesStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *messageBytesStatsHandler) HandleRPC(_ context.Context, rpcStats stats.RPCStats) {
+	switch payload := rpcStats.(type) {
+	case *stats.OutPayload:
+		h.sentBytes.Add(float64(payload.Length))
+	case *stats.InPayload:
+		h.receivedBytes.Add(float64(payload.Length))
+	}
+}
+
+func (h *messageBytesStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *messageBytesStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -2,6 +2,7 @@ package grpcserver
 
 import (
 	"context"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/kaspanet/kaspad/util/panics"
@@ -43,7 +44,8 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	gRPCClientConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	gRPCClientConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithStatsHandler(newMessageBytesStatsHandler(p.name)))
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s error connecting to %s", p.name, address)
 	}
@@ -71,7 +73,7 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 		return nil, err
 	}
 
-	log.Infof("%s Connected to %s", p.name, address)
+	log.WithFields(logger.Fields{"peer": address}).Infof("%s Connected to %s", p.name, address)
 
 	return connection, nil
 }
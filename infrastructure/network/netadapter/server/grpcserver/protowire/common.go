@@ -105,6 +105,7 @@ func (x *NetAddress) toAppMessage() (*appmessage.NetAddress, error) {
 	}
 	return &appmessage.NetAddress{
 		Timestamp: mstime.UnixMilliseconds(x.Timestamp),
+		Services:  appmessage.ServiceFlag(x.Services),
 		IP:        x.Ip,
 		Port:      uint16(x.Port),
 	}, nil
@@ -113,6 +114,7 @@ func (x *NetAddress) toAppMessage() (*appmessage.NetAddress, error) {
 func appMessageNetAddressToProto(address *appmessage.NetAddress) *NetAddress {
 	return &NetAddress{
 		Timestamp: address.Timestamp.UnixMilliseconds(),
+		Services:  uint64(address.Services),
 		Ip:        address.IP,
 		Port:      uint32(address.Port),
 	}
@@ -0,0 +1,85 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_CompactBlock) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_CompactBlock is nil")
+	}
+	return x.CompactBlock.toAppMessage()
+}
+
+func (x *KaspadMessage_CompactBlock) fromAppMessage(msgCompactBlock *appmessage.MsgCompactBlock) error {
+	x.CompactBlock = new(CompactBlockMessage)
+	return x.CompactBlock.fromAppMessage(msgCompactBlock)
+}
+
+func (x *CompactBlockMessage) toAppMessage() (*appmessage.MsgCompactBlock, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CompactBlockMessage is nil")
+	}
+	header, err := x.Header.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	prefilledTransactions := make([]*appmessage.PrefilledTransaction, len(x.PrefilledTransactions))
+	for i, protoPrefilledTransaction := range x.PrefilledTransactions {
+		prefilledTransaction, err := protoPrefilledTransaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		prefilledTransactions[i] = prefilledTransaction
+	}
+
+	return appmessage.NewMsgCompactBlock(header, x.ShortIdSalt, x.ShortIds, prefilledTransactions), nil
+}
+
+func (x *CompactBlockMessage) fromAppMessage(msgCompactBlock *appmessage.MsgCompactBlock) error {
+	protoHeader := new(BlockHeaderMessage)
+	err := protoHeader.fromAppMessage(&msgCompactBlock.Header)
+	if err != nil {
+		return err
+	}
+
+	protoPrefilledTransactions := make([]*PrefilledTransactionMessage, len(msgCompactBlock.PrefilledTransactions))
+	for i, prefilledTransaction := range msgCompactBlock.PrefilledTransactions {
+		protoPrefilledTransaction := new(PrefilledTransactionMessage)
+		protoPrefilledTransaction.fromAppMessage(prefilledTransaction)
+		protoPrefilledTransactions[i] = protoPrefilledTransaction
+	}
+
+	*x = CompactBlockMessage{
+		Header:                protoHeader,
+		ShortIdSalt:           msgCompactBlock.ShortIDSalt,
+		ShortIds:              msgCompactBlock.ShortIDs,
+		PrefilledTransactions: protoPrefilledTransactions,
+	}
+	return nil
+}
+
+func (x *PrefilledTransactionMessage) toAppMessage() (*appmessage.PrefilledTransaction, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "PrefilledTransactionMessage is nil")
+	}
+	msgTx, err := x.Transaction.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.PrefilledTransaction{
+		Index:       x.Index,
+		Transaction: msgTx.(*appmessage.MsgTx),
+	}, nil
+}
+
+func (x *PrefilledTransactionMessage) fromAppMessage(prefilledTransaction *appmessage.PrefilledTransaction) {
+	protoTransaction := new(TransactionMessage)
+	protoTransaction.fromAppMessage(prefilledTransaction.Transaction)
+	*x = PrefilledTransactionMessage{
+		Index:       prefilledTransaction.Index,
+		Transaction: protoTransaction,
+	}
+}
@@ -0,0 +1,49 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_CreateSnapshotRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.CreateSnapshotRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_CreateSnapshotRequest) fromAppMessage(_ *appmessage.CreateSnapshotRequestMessage) error {
+	x.CreateSnapshotRequest = &CreateSnapshotRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_CreateSnapshotResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_CreateSnapshotResponse is nil")
+	}
+	return x.CreateSnapshotResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_CreateSnapshotResponse) fromAppMessage(message *appmessage.CreateSnapshotResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.CreateSnapshotResponse = &CreateSnapshotResponseMessage{
+		SnapshotPath: message.SnapshotPath,
+		Error:        err,
+	}
+	return nil
+}
+
+func (x *CreateSnapshotResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CreateSnapshotResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.CreateSnapshotResponseMessage{
+		SnapshotPath: x.SnapshotPath,
+		Error:        rpcErr,
+	}, nil
+}
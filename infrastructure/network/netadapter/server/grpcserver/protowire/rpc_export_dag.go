@@ -0,0 +1,129 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ExportDagRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ExportDagRequest is nil")
+	}
+	return x.ExportDagRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_ExportDagRequest) fromAppMessage(message *appmessage.ExportDAGRequestMessage) error {
+	x.ExportDagRequest = &ExportDagRequestMessage{
+		Depth: message.Depth,
+	}
+	return nil
+}
+
+func (x *ExportDagRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ExportDagRequestMessage is nil")
+	}
+	return &appmessage.ExportDAGRequestMessage{
+		Depth: x.Depth,
+	}, nil
+}
+
+func (x *KaspadMessage_ExportDagResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ExportDagResponse is nil")
+	}
+	return x.ExportDagResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_ExportDagResponse) fromAppMessage(message *appmessage.ExportDAGResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	blocks := make([]*RpcDagExportBlock, len(message.Blocks))
+	for i, block := range message.Blocks {
+		blocks[i] = &RpcDagExportBlock{}
+		blocks[i].fromAppMessage(block)
+	}
+	x.ExportDagResponse = &ExportDagResponseMessage{
+		VirtualParentHashes: message.VirtualParentHashes,
+		VirtualBlueScore:    message.VirtualBlueScore,
+		VirtualDaaScore:     message.VirtualDAAScore,
+		PruningPointHash:    message.PruningPointHash,
+		FinalityPointHash:   message.FinalityPointHash,
+		Blocks:              blocks,
+		Error:               err,
+	}
+	return nil
+}
+
+func (x *ExportDagResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ExportDagResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	if rpcErr != nil && len(x.Blocks) != 0 {
+		return nil, errors.New("ExportDagResponseMessage contains both an error and a response")
+	}
+	blocks := make([]*appmessage.DAGExportBlock, len(x.Blocks))
+	for i, block := range x.Blocks {
+		appBlock, err := block.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = appBlock
+	}
+
+	return &appmessage.ExportDAGResponseMessage{
+		VirtualParentHashes: x.VirtualParentHashes,
+		VirtualBlueScore:    x.VirtualBlueScore,
+		VirtualDAAScore:     x.VirtualDaaScore,
+		PruningPointHash:    x.PruningPointHash,
+		FinalityPointHash:   x.FinalityPointHash,
+		Blocks:              blocks,
+		Error:               rpcErr,
+	}, nil
+}
+
+func (x *RpcDagExportBlock) toAppMessage() (*appmessage.DAGExportBlock, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RpcDagExportBlock is nil")
+	}
+	return &appmessage.DAGExportBlock{
+		Hash:                           x.Hash,
+		ParentHashes:                   x.ParentHashes,
+		SelectedParentHash:             x.SelectedParentHash,
+		MergeSetBluesHashes:            x.MergeSetBluesHashes,
+		MergeSetRedsHashes:             x.MergeSetRedsHashes,
+		Status:                         x.Status,
+		BlueScore:                      x.BlueScore,
+		BlueWork:                       x.BlueWork,
+		DAAScore:                       x.DaaScore,
+		Timestamp:                      x.Timestamp,
+		Color:                          x.Color,
+		IsInVirtualSelectedParentChain: x.IsInVirtualSelectedParentChain,
+		IsInVirtualDAAWindow:           x.IsInVirtualDaaWindow,
+	}, nil
+}
+
+func (x *RpcDagExportBlock) fromAppMessage(message *appmessage.DAGExportBlock) {
+	*x = RpcDagExportBlock{
+		Hash:                           message.Hash,
+		ParentHashes:                   message.ParentHashes,
+		SelectedParentHash:             message.SelectedParentHash,
+		MergeSetBluesHashes:            message.MergeSetBluesHashes,
+		MergeSetRedsHashes:             message.MergeSetRedsHashes,
+		Status:                         message.Status,
+		BlueScore:                      message.BlueScore,
+		BlueWork:                       message.BlueWork,
+		DaaScore:                       message.DAAScore,
+		Timestamp:                      message.Timestamp,
+		Color:                          message.Color,
+		IsInVirtualSelectedParentChain: message.IsInVirtualSelectedParentChain,
+		IsInVirtualDaaWindow:           message.IsInVirtualDAAWindow,
+	}
+}
@@ -0,0 +1,88 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetCacheStatsRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetCacheStatsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetCacheStatsRequest) fromAppMessage(_ *appmessage.GetCacheStatsRequestMessage) error {
+	x.GetCacheStatsRequest = &GetCacheStatsRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetCacheStatsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetCacheStatsResponse is nil")
+	}
+	return x.GetCacheStatsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetCacheStatsResponse) fromAppMessage(message *appmessage.GetCacheStatsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	cacheStats := make([]*CacheStatsMessage, len(message.CacheStats))
+	for i, stats := range message.CacheStats {
+		cacheStats[i] = &CacheStatsMessage{
+			Name:      stats.Name,
+			Entries:   stats.Entries,
+			Size:      stats.Size,
+			Capacity:  stats.Capacity,
+			Hits:      stats.Hits,
+			Misses:    stats.Misses,
+			Evictions: stats.Evictions,
+		}
+	}
+	x.GetCacheStatsResponse = &GetCacheStatsResponseMessage{
+		CacheStats: cacheStats,
+		Error:      err,
+	}
+	return nil
+}
+
+func (x *GetCacheStatsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetCacheStatsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	if rpcErr != nil && len(x.CacheStats) != 0 {
+		return nil, errors.New("GetCacheStatsResponseMessage contains both an error and a response")
+	}
+	cacheStats := make([]*appmessage.CacheStatsMessage, len(x.CacheStats))
+	for i, stats := range x.CacheStats {
+		appStats, err := stats.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		cacheStats[i] = appStats
+	}
+
+	return &appmessage.GetCacheStatsResponseMessage{
+		CacheStats: cacheStats,
+		Error:      rpcErr,
+	}, nil
+}
+
+func (x *CacheStatsMessage) toAppMessage() (*appmessage.CacheStatsMessage, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CacheStatsMessage is nil")
+	}
+	return &appmessage.CacheStatsMessage{
+		Name:      x.Name,
+		Entries:   x.Entries,
+		Size:      x.Size,
+		Capacity:  x.Capacity,
+		Hits:      x.Hits,
+		Misses:    x.Misses,
+		Evictions: x.Evictions,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetDatabaseStatsRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetDatabaseStatsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetDatabaseStatsRequest) fromAppMessage(_ *appmessage.GetDatabaseStatsRequestMessage) error {
+	x.GetDatabaseStatsRequest = &GetDatabaseStatsRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetDatabaseStatsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetDatabaseStatsResponse is nil")
+	}
+	return x.GetDatabaseStatsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetDatabaseStatsResponse) fromAppMessage(message *appmessage.GetDatabaseStatsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	levels := make([]*DatabaseLevelStats, len(message.Levels))
+	for i, level := range message.Levels {
+		levels[i] = &DatabaseLevelStats{
+			Level:                          level.Level,
+			TableCount:                     level.TableCount,
+			Size:                           level.Size,
+			ReadBytes:                      level.ReadBytes,
+			WrittenBytes:                   level.WrittenBytes,
+			CompactionDurationMilliseconds: level.CompactionDurationMilliseconds,
+		}
+	}
+	x.GetDatabaseStatsResponse = &GetDatabaseStatsResponseMessage{
+		Levels:                         levels,
+		IoReadBytes:                    message.IOReadBytes,
+		IoWrittenBytes:                 message.IOWrittenBytes,
+		WriteDelayCount:                message.WriteDelayCount,
+		WriteDelayDurationMilliseconds: message.WriteDelayDurationMilliseconds,
+		BlockCacheSize:                 message.BlockCacheSize,
+		OpenedTableCount:               message.OpenedTableCount,
+		MemoryCompactionCount:          message.MemoryCompactionCount,
+		Level0CompactionCount:          message.Level0CompactionCount,
+		NonLevel0CompactionCount:       message.NonLevel0CompactionCount,
+		SeekCompactionCount:            message.SeekCompactionCount,
+		Error:                          err,
+	}
+	return nil
+}
+
+func (x *GetDatabaseStatsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetDatabaseStatsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	if rpcErr != nil && len(x.Levels) != 0 {
+		return nil, errors.New("GetDatabaseStatsResponseMessage contains both an error and a response")
+	}
+	levels := make([]*appmessage.DatabaseLevelStats, len(x.Levels))
+	for i, level := range x.Levels {
+		appLevel, err := level.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		levels[i] = appLevel
+	}
+
+	return &appmessage.GetDatabaseStatsResponseMessage{
+		Levels:                         levels,
+		IOReadBytes:                    x.IoReadBytes,
+		IOWrittenBytes:                 x.IoWrittenBytes,
+		WriteDelayCount:                x.WriteDelayCount,
+		WriteDelayDurationMilliseconds: x.WriteDelayDurationMilliseconds,
+		BlockCacheSize:                 x.BlockCacheSize,
+		OpenedTableCount:               x.OpenedTableCount,
+		MemoryCompactionCount:          x.MemoryCompactionCount,
+		Level0CompactionCount:          x.Level0CompactionCount,
+		NonLevel0CompactionCount:       x.NonLevel0CompactionCount,
+		SeekCompactionCount:            x.SeekCompactionCount,
+		Error:                          rpcErr,
+	}, nil
+}
+
+func (x *DatabaseLevelStats) toAppMessage() (*appmessage.DatabaseLevelStats, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DatabaseLevelStats is nil")
+	}
+	return &appmessage.DatabaseLevelStats{
+		Level:                          x.Level,
+		TableCount:                     x.TableCount,
+		Size:                           x.Size,
+		ReadBytes:                      x.ReadBytes,
+		WrittenBytes:                   x.WrittenBytes,
+		CompactionDurationMilliseconds: x.CompactionDurationMilliseconds,
+	}, nil
+}
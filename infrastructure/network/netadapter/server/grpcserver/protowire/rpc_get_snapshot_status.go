@@ -0,0 +1,53 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetSnapshotStatusRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetSnapshotStatusRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetSnapshotStatusRequest) fromAppMessage(_ *appmessage.GetSnapshotStatusRequestMessage) error {
+	x.GetSnapshotStatusRequest = &GetSnapshotStatusRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetSnapshotStatusResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetSnapshotStatusResponse is nil")
+	}
+	return x.GetSnapshotStatusResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetSnapshotStatusResponse) fromAppMessage(message *appmessage.GetSnapshotStatusResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetSnapshotStatusResponse = &GetSnapshotStatusResponseMessage{
+		SnapshotPath:   message.SnapshotPath,
+		IsInProgress:   message.IsInProgress,
+		FailureMessage: message.FailureMessage,
+		Error:          err,
+	}
+	return nil
+}
+
+func (x *GetSnapshotStatusResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetSnapshotStatusResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.GetSnapshotStatusResponseMessage{
+		SnapshotPath:   x.SnapshotPath,
+		IsInProgress:   x.IsInProgress,
+		FailureMessage: x.FailureMessage,
+		Error:          rpcErr,
+	}, nil
+}
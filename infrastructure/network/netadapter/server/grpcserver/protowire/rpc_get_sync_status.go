@@ -0,0 +1,61 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetSyncStatusRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetSyncStatusRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetSyncStatusRequest) fromAppMessage(_ *appmessage.GetSyncStatusRequestMessage) error {
+	x.GetSyncStatusRequest = &GetSyncStatusRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetSyncStatusResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetSyncStatusResponse is nil")
+	}
+	return x.GetSyncStatusResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetSyncStatusResponse) fromAppMessage(message *appmessage.GetSyncStatusResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetSyncStatusResponse = &GetSyncStatusResponseMessage{
+		IsSynced:                       message.IsSynced,
+		IsIbdRunning:                   message.IsIBDRunning,
+		HeaderCount:                    message.HeaderCount,
+		BlockCount:                     message.BlockCount,
+		EstimatedMillisecondsRemaining: message.EstimatedMillisecondsRemaining,
+		PeerCount:                      message.PeerCount,
+		LastBlockAgeMilliseconds:       message.LastBlockAgeMilliseconds,
+		Error:                          err,
+	}
+	return nil
+}
+
+func (x *GetSyncStatusResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetSyncStatusResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.GetSyncStatusResponseMessage{
+		IsSynced:                       x.IsSynced,
+		IsIBDRunning:                   x.IsIbdRunning,
+		HeaderCount:                    x.HeaderCount,
+		BlockCount:                     x.BlockCount,
+		EstimatedMillisecondsRemaining: x.EstimatedMillisecondsRemaining,
+		PeerCount:                      x.PeerCount,
+		LastBlockAgeMilliseconds:       x.LastBlockAgeMilliseconds,
+		Error:                          rpcErr,
+	}, nil
+}
@@ -282,6 +282,27 @@ func toP2PPayload(message appmessage.Message) (isKaspadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.MsgCompactBlock:
+		payload := new(KaspadMessage_CompactBlock)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgRequestBlockTransactions:
+		payload := new(KaspadMessage_RequestBlockTransactions)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.MsgBlockTransactions:
+		payload := new(KaspadMessage_BlockTransactions)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return nil, nil
 	}
@@ -779,6 +800,13 @@ func toRPCPayload(message appmessage.Message) (isKaspadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.StopNotifyingPruningPointUTXOSetOverrideResponseMessage:
+		payload := new(KaspadMessage_StopNotifyingPruningPointUTXOSetOverrideResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	case *appmessage.NotifyVirtualDaaScoreChangedRequestMessage:
 		payload := new(KaspadMessage_NotifyVirtualDaaScoreChangedRequest)
 		err := payload.fromAppMessage(message)
@@ -800,6 +828,146 @@ func toRPCPayload(message appmessage.Message) (isKaspadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.CompactDatabaseRequestMessage:
+		payload := new(KaspadMessage_CompactDatabaseRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CompactDatabaseResponseMessage:
+		payload := new(KaspadMessage_CompactDatabaseResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetDatabaseStatsRequestMessage:
+		payload := new(KaspadMessage_GetDatabaseStatsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetDatabaseStatsResponseMessage:
+		payload := new(KaspadMessage_GetDatabaseStatsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CreateSnapshotRequestMessage:
+		payload := new(KaspadMessage_CreateSnapshotRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CreateSnapshotResponseMessage:
+		payload := new(KaspadMessage_CreateSnapshotResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetSyncStatusRequestMessage:
+		payload := new(KaspadMessage_GetSyncStatusRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetSyncStatusResponseMessage:
+		payload := new(KaspadMessage_GetSyncStatusResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SetLogLevelRequestMessage:
+		payload := new(KaspadMessage_SetLogLevelRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SetLogLevelResponseMessage:
+		payload := new(KaspadMessage_SetLogLevelResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ReloadConfigRequestMessage:
+		payload := new(KaspadMessage_ReloadConfigRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ReloadConfigResponseMessage:
+		payload := new(KaspadMessage_ReloadConfigResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetCacheStatsRequestMessage:
+		payload := new(KaspadMessage_GetCacheStatsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetCacheStatsResponseMessage:
+		payload := new(KaspadMessage_GetCacheStatsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GenerateBlocksRequestMessage:
+		payload := new(KaspadMessage_GenerateBlocksRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GenerateBlocksResponseMessage:
+		payload := new(KaspadMessage_GenerateBlocksResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ExportDAGRequestMessage:
+		payload := new(KaspadMessage_ExportDagRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ExportDAGResponseMessage:
+		payload := new(KaspadMessage_ExportDagResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetSnapshotStatusRequestMessage:
+		payload := new(KaspadMessage_GetSnapshotStatusRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetSnapshotStatusResponseMessage:
+		payload := new(KaspadMessage_GetSnapshotStatusResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return nil, nil
 	}
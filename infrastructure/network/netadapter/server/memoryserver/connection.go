@@ -0,0 +1,228 @@
+package memoryserver
+
+import (
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// inFlightCapacity is the number of messages that may be on their way
+// over a link in one direction before the sender has to wait
+const inFlightCapacity = 200
+
+type delivery struct {
+	message   *protowire.KaspadMessage
+	deliverAt time.Time
+}
+
+// connection is one end of a connection between two servers in a Network
+type connection struct {
+	network       *Network
+	localAddress  string
+	remoteAddress string
+	address       *net.TCPAddr
+	isOutbound    bool
+	peer          *connection
+	router        *router.Router
+
+	deliveries chan *delivery
+	stopChan   chan struct{}
+
+	onDisconnectedHandler   server.OnDisconnectedHandler
+	onInvalidMessageHandler server.OnInvalidMessageHandler
+
+	isConnected uint32
+}
+
+func newConnection(network *Network, localAddress string, remoteAddress string, address *net.TCPAddr,
+	isOutbound bool) *connection {
+
+	return &connection{
+		network:       network,
+		localAddress:  localAddress,
+		remoteAddress: remoteAddress,
+		address:       address,
+		isOutbound:    isOutbound,
+		deliveries:    make(chan *delivery, inFlightCapacity),
+		stopChan:      make(chan struct{}),
+		isConnected:   1,
+	}
+}
+
+func (c *connection) Start(router *router.Router) {
+	if c.onDisconnectedHandler == nil {
+		panic(errors.New("onDisconnectedHandler is nil"))
+	}
+
+	c.router = router
+
+	spawn("memoryserver.connection.Start-connectionLoops", func() {
+		err := c.connectionLoops()
+		if err != nil {
+			log.Errorf("error from connectionLoops for %s: %s", c.address, err)
+		}
+	})
+}
+
+func (c *connection) String() string {
+	return c.Address().String()
+}
+
+func (c *connection) IsConnected() bool {
+	return atomic.LoadUint32(&c.isConnected) != 0
+}
+
+func (c *connection) SetOnDisconnectedHandler(onDisconnectedHandler server.OnDisconnectedHandler) {
+	c.onDisconnectedHandler = onDisconnectedHandler
+}
+
+func (c *connection) SetOnInvalidMessageHandler(onInvalidMessageHandler server.OnInvalidMessageHandler) {
+	c.onInvalidMessageHandler = onInvalidMessageHandler
+}
+
+func (c *connection) IsOutbound() bool {
+	return c.isOutbound
+}
+
+// Disconnect disconnects both ends of the connection
+// Calling this function a second time doesn't do anything
+//
+// This is part of the Connection interface
+func (c *connection) Disconnect() {
+	if !atomic.CompareAndSwapUint32(&c.isConnected, 1, 0) {
+		return
+	}
+
+	close(c.stopChan)
+	c.network.removeConnection(c)
+
+	log.Debugf("Disconnecting from %s", c)
+	if c.onDisconnectedHandler != nil {
+		c.onDisconnectedHandler()
+	}
+
+	c.peer.Disconnect()
+}
+
+func (c *connection) Address() *net.TCPAddr {
+	return c.address
+}
+
+func (c *connection) connectionLoops() error {
+	errChan := make(chan error, 1) // buffered channel because one of the loops might try write after disconnect
+
+	spawn("memoryserver.connection.receiveLoop", func() { errChan <- c.receiveLoop() })
+	spawn("memoryserver.connection.sendLoop", func() { errChan <- c.sendLoop() })
+
+	err := <-errChan
+
+	c.Disconnect()
+
+	return err
+}
+
+func (c *connection) sendLoop() error {
+	outgoingRoute := c.router.OutgoingRoute()
+	for c.IsConnected() {
+		message, err := outgoingRoute.Dequeue()
+		if err != nil {
+			if errors.Is(err, router.ErrRouteClosed) {
+				return nil
+			}
+			return err
+		}
+
+		log.Debugf("outgoing '%s' message to %s", message.Command(), c)
+
+		messageProto, err := protowire.FromAppMessage(message)
+		if err != nil {
+			return err
+		}
+
+		conditions := c.network.conditionsOf(c.localAddress, c.remoteAddress)
+		if conditions.Bandwidth > 0 {
+			transmissionTime := time.Duration(uint64(proto.Size(messageProto)) * uint64(time.Second) / conditions.Bandwidth)
+			if !c.sleep(transmissionTime) {
+				return nil
+			}
+		}
+
+		select {
+		case c.peer.deliveries <- &delivery{message: messageProto, deliverAt: time.Now().Add(conditions.Latency)}:
+		case <-c.stopChan:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (c *connection) receiveLoop() error {
+	messageNumber := uint64(0)
+	for c.IsConnected() {
+		var nextDelivery *delivery
+		select {
+		case nextDelivery = <-c.deliveries:
+		case <-c.stopChan:
+			return nil
+		}
+		if !c.sleep(time.Until(nextDelivery.deliverAt)) {
+			return nil
+		}
+
+		message, err := nextDelivery.message.ToAppMessage()
+		if err != nil {
+			if c.onInvalidMessageHandler != nil {
+				c.onInvalidMessageHandler(err)
+			}
+			return err
+		}
+
+		messageNumber++
+		message.SetMessageNumber(messageNumber)
+		message.SetReceivedAt(time.Now())
+
+		log.Debugf("incoming '%s' message from %s (message number %d)", message.Command(), c,
+			message.MessageNumber())
+
+		err = c.router.EnqueueIncomingMessage(message)
+		if err != nil {
+			if errors.Is(err, router.ErrRouteClosed) {
+				return nil
+			}
+
+			// ErrRouteCapacityReached isn't an invalid message error, so
+			// we return it in order to log it later on.
+			if errors.Is(err, router.ErrRouteCapacityReached) {
+				return err
+			}
+			if c.onInvalidMessageHandler != nil {
+				c.onInvalidMessageHandler(err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// sleep waits for the given duration, and returns false
+// if the connection was disconnected in the meantime
+func (c *connection) sleep(duration time.Duration) bool {
+	if duration <= 0 {
+		return c.IsConnected()
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-c.stopChan:
+		return false
+	}
+}
@@ -0,0 +1,9 @@
+package memoryserver
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("NTAR")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,166 @@
+package memoryserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+)
+
+type testConnection struct {
+	server.Connection
+	router        *router.Router
+	incomingRoute *router.Route
+}
+
+// newTestServer returns a started P2PServer that routes incoming pings
+// of every new connection to a route, and a channel to which it sends
+// every new connection
+func newTestServer(t *testing.T, network *Network, listenAddress string) (server.P2PServer, chan *testConnection) {
+	p2pServer, err := NewP2PServer(network, listenAddress)
+	if err != nil {
+		t.Fatalf("NewP2PServer: %+v", err)
+	}
+	connections := make(chan *testConnection, 10)
+	p2pServer.SetOnConnectedHandler(func(connection server.Connection) error {
+		connectionRouter := router.NewRouter()
+		incomingRoute, err := connectionRouter.AddIncomingRoute([]appmessage.MessageCommand{appmessage.CmdPing})
+		if err != nil {
+			return err
+		}
+		connection.SetOnDisconnectedHandler(connectionRouter.Close)
+		connection.Start(connectionRouter)
+		connections <- &testConnection{Connection: connection, router: connectionRouter, incomingRoute: incomingRoute}
+		return nil
+	})
+	err = p2pServer.Start()
+	if err != nil {
+		t.Fatalf("Start: %+v", err)
+	}
+	return p2pServer, connections
+}
+
+func TestMemoryServer(t *testing.T) {
+	const (
+		addressA = "10.0.0.1:16111"
+		addressB = "10.0.0.2:16111"
+		latency  = 100 * time.Millisecond
+	)
+
+	network := NewNetwork()
+	serverA, connectionsA := newTestServer(t, network, addressA)
+	serverB, connectionsB := newTestServer(t, network, addressB)
+
+	_, err := serverA.Connect("10.0.0.3:16111")
+	if err == nil {
+		t.Fatalf("Connect: expected an error when connecting to an address no server listens on")
+	}
+
+	network.SetLinkConditions(addressA, addressB, LinkConditions{Latency: latency})
+	_, err = serverA.Connect(addressB)
+	if err != nil {
+		t.Fatalf("Connect: %+v", err)
+	}
+	outbound := <-connectionsA
+	inbound := <-connectionsB
+
+	if !outbound.IsOutbound() || inbound.IsOutbound() {
+		t.Fatalf("Expected only the dialing side of the connection to be outbound")
+	}
+	if outbound.Address().String() != addressB {
+		t.Fatalf("Expected the outbound connection's address to be %s, but got %s", addressB, outbound.Address())
+	}
+	if inbound.Address().IP.String() != "10.0.0.1" {
+		t.Fatalf("Expected the inbound connection's IP to be 10.0.0.1, but got %s", inbound.Address().IP)
+	}
+
+	start := time.Now()
+	err = outbound.router.OutgoingRoute().Enqueue(appmessage.NewMsgPing(1))
+	if err != nil {
+		t.Fatalf("Enqueue: %+v", err)
+	}
+	message, err := inbound.incomingRoute.DequeueWithTimeout(5 * time.Second)
+	if err != nil {
+		t.Fatalf("DequeueWithTimeout: %+v", err)
+	}
+	if elapsed := time.Since(start); elapsed < latency {
+		t.Fatalf("Expected the message to arrive after at least %s, but it arrived after %s", latency, elapsed)
+	}
+	if message.(*appmessage.MsgPing).Nonce != 1 {
+		t.Fatalf("Received an unexpected message: %v", message)
+	}
+
+	network.Partition([]string{addressA})
+	if outbound.IsConnected() || inbound.IsConnected() {
+		t.Fatalf("Expected the partition to disconnect both ends of the connection")
+	}
+	_, err = serverA.Connect(addressB)
+	if err == nil {
+		t.Fatalf("Connect: expected an error when connecting across a partition")
+	}
+
+	network.Heal()
+	_, err = serverA.Connect(addressB)
+	if err != nil {
+		t.Fatalf("Connect: %+v", err)
+	}
+	outbound = <-connectionsA
+	inbound = <-connectionsB
+
+	err = serverB.Stop()
+	if err != nil {
+		t.Fatalf("Stop: %+v", err)
+	}
+	if outbound.IsConnected() || inbound.IsConnected() {
+		t.Fatalf("Expected stopping a server to disconnect its connections")
+	}
+	_, err = serverA.Connect(addressB)
+	if err == nil {
+		t.Fatalf("Connect: expected an error when connecting to a stopped server")
+	}
+}
+
+func TestMemoryServerBandwidth(t *testing.T) {
+	const (
+		addressA  = "10.0.0.1:16111"
+		addressB  = "10.0.0.2:16111"
+		bandwidth = 1000 // bytes per second
+	)
+
+	network := NewNetwork()
+	network.SetDefaultLinkConditions(LinkConditions{Bandwidth: bandwidth})
+	serverA, connectionsA := newTestServer(t, network, addressA)
+	_, connectionsB := newTestServer(t, network, addressB)
+
+	_, err := serverA.Connect(addressB)
+	if err != nil {
+		t.Fatalf("Connect: %+v", err)
+	}
+	outbound := <-connectionsA
+	inbound := <-connectionsB
+
+	// Every serialized ping is at least 3 bytes long, so a
+	// hundred of them can't be sent in less than 300ms
+	const pingCount = 100
+	start := time.Now()
+	for i := uint64(0); i < pingCount; i++ {
+		err := outbound.router.OutgoingRoute().Enqueue(appmessage.NewMsgPing(i))
+		if err != nil {
+			t.Fatalf("Enqueue: %+v", err)
+		}
+	}
+	for i := uint64(0); i < pingCount; i++ {
+		message, err := inbound.incomingRoute.DequeueWithTimeout(5 * time.Second)
+		if err != nil {
+			t.Fatalf("DequeueWithTimeout: %+v", err)
+		}
+		if message.(*appmessage.MsgPing).Nonce != i {
+			t.Fatalf("Expected ping %d, but got ping %d", i, message.(*appmessage.MsgPing).Nonce)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Fatalf("Expected the pings to take at least 300ms to arrive, but they arrived after %s", elapsed)
+	}
+}
@@ -0,0 +1,253 @@
+package memoryserver
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// LinkConditions describe the quality of the link between two nodes
+type LinkConditions struct {
+	// Latency is the time it takes a message to arrive once it was sent
+	Latency time.Duration
+
+	// Bandwidth is the number of bytes per second that can be sent over the
+	// link in each direction. Zero means that the bandwidth is unlimited.
+	Bandwidth uint64
+}
+
+type link struct {
+	address1, address2 string
+}
+
+func newLink(address1, address2 string) link {
+	if address1 > address2 {
+		address1, address2 = address2, address1
+	}
+	return link{address1: address1, address2: address2}
+}
+
+const (
+	minEphemeralPort = 49152
+	maxEphemeralPort = 65535
+)
+
+// Network is an in-memory network that delivers messages between the P2P servers
+// created with NewP2PServer, without going through TCP. Messages are serialized to
+// and from protobuf as they would be over the wire, and are delayed according to
+// the conditions of the link they're sent over.
+//
+// Nodes are identified by the address they listen on, which must be of the form
+// ip:port.
+type Network struct {
+	lock sync.RWMutex
+
+	servers           map[string]*p2pServer
+	connections       map[*connection]struct{}
+	defaultConditions LinkConditions
+	linkConditions    map[link]LinkConditions
+	partitionGroups   map[string]int
+	nextEphemeralPort int
+}
+
+// NewNetwork creates a new empty in-memory Network
+func NewNetwork() *Network {
+	return &Network{
+		servers:           make(map[string]*p2pServer),
+		connections:       make(map[*connection]struct{}),
+		linkConditions:    make(map[link]LinkConditions),
+		nextEphemeralPort: minEphemeralPort,
+	}
+}
+
+// SetDefaultLinkConditions sets the conditions of all the links that weren't
+// given conditions with SetLinkConditions
+func (n *Network) SetDefaultLinkConditions(conditions LinkConditions) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.defaultConditions = conditions
+}
+
+// SetLinkConditions sets the conditions of the link between the nodes listening
+// on address1 and address2, in both directions. The conditions apply to messages
+// sent from now on, including over existing connections.
+func (n *Network) SetLinkConditions(address1, address2 string, conditions LinkConditions) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.linkConditions[newLink(address1, address2)] = conditions
+}
+
+func (n *Network) conditionsOf(address1, address2 string) LinkConditions {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	conditions, ok := n.linkConditions[newLink(address1, address2)]
+	if !ok {
+		return n.defaultConditions
+	}
+	return conditions
+}
+
+// Partition splits the network into the given groups of addresses. Nodes can only
+// reach nodes in their own group, and all the nodes that aren't in any group form
+// one more group together. Connections between nodes in different groups are
+// disconnected, and new ones are refused until Heal is called.
+func (n *Network) Partition(groups ...[]string) {
+	n.lock.Lock()
+	n.partitionGroups = make(map[string]int)
+	for i, group := range groups {
+		for _, address := range group {
+			n.partitionGroups[address] = i + 1
+		}
+	}
+	crossingConnections := n.crossingConnections()
+	n.lock.Unlock()
+
+	for _, crossingConnection := range crossingConnections {
+		crossingConnection.Disconnect()
+	}
+}
+
+// Heal removes the partition set by Partition. Connections that were disconnected
+// by the partition are not restored.
+func (n *Network) Heal() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.partitionGroups = nil
+}
+
+// crossingConnections returns all the connections between nodes
+// that can't reach each other. It must be called with the lock held.
+func (n *Network) crossingConnections() []*connection {
+	var crossingConnections []*connection
+	for connection := range n.connections {
+		if !n.canReach(connection.localAddress, connection.remoteAddress) {
+			crossingConnections = append(crossingConnections, connection)
+		}
+	}
+	return crossingConnections
+}
+
+// canReach returns whether a node listening on address1 can reach a node
+// listening on address2. It must be called with the lock held.
+func (n *Network) canReach(address1, address2 string) bool {
+	if n.partitionGroups == nil {
+		return true
+	}
+	return n.partitionGroups[address1] == n.partitionGroups[address2]
+}
+
+func (n *Network) addServer(server *p2pServer) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.servers[server.listenAddress]; ok {
+		return errors.Errorf("a server is already listening on %s", server.listenAddress)
+	}
+	n.servers[server.listenAddress] = server
+	return nil
+}
+
+func (n *Network) removeServer(server *p2pServer) {
+	n.lock.Lock()
+	delete(n.servers, server.listenAddress)
+	var serverConnections []*connection
+	for connection := range n.connections {
+		if connection.localAddress == server.listenAddress {
+			serverConnections = append(serverConnections, connection)
+		}
+	}
+	n.lock.Unlock()
+
+	for _, serverConnection := range serverConnections {
+		serverConnection.Disconnect()
+	}
+}
+
+func (n *Network) connect(dialer *p2pServer, address string) (*connection, error) {
+	n.lock.Lock()
+	listener, ok := n.servers[address]
+	if !ok {
+		n.lock.Unlock()
+		return nil, errors.Errorf("%s error connecting to %s: no server is listening on this address",
+			dialer.listenAddress, address)
+	}
+	if !n.canReach(dialer.listenAddress, address) {
+		n.lock.Unlock()
+		return nil, errors.Errorf("%s error connecting to %s: the network is partitioned",
+			dialer.listenAddress, address)
+	}
+	dialerAddress := &net.TCPAddr{IP: dialer.ip, Port: n.ephemeralPort()}
+	n.lock.Unlock()
+
+	outbound := newConnection(n, dialer.listenAddress, address, &net.TCPAddr{IP: listener.ip, Port: listener.port}, true)
+	inbound := newConnection(n, address, dialer.listenAddress, dialerAddress, false)
+	outbound.peer = inbound
+	inbound.peer = outbound
+
+	err := listener.onConnectedHandler(inbound)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s error connecting to %s", dialer.listenAddress, address)
+	}
+	err = dialer.onConnectedHandler(outbound)
+	if err != nil {
+		inbound.Disconnect()
+		return nil, err
+	}
+
+	n.lock.Lock()
+	n.connections[outbound] = struct{}{}
+	n.connections[inbound] = struct{}{}
+	isPartitioned := !n.canReach(dialer.listenAddress, address)
+	n.lock.Unlock()
+
+	// The network might have been partitioned while the connection was being set up
+	if isPartitioned {
+		outbound.Disconnect()
+		return nil, errors.Errorf("%s error connecting to %s: the network is partitioned",
+			dialer.listenAddress, address)
+	}
+
+	return outbound, nil
+}
+
+func (n *Network) removeConnection(connection *connection) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.connections, connection)
+}
+
+// ephemeralPort returns the port the next outbound connection is made from,
+// so that every connection has a distinct address like over TCP.
+// It must be called with the lock held.
+func (n *Network) ephemeralPort() int {
+	port := n.nextEphemeralPort
+	n.nextEphemeralPort++
+	if n.nextEphemeralPort > maxEphemeralPort {
+		n.nextEphemeralPort = minEphemeralPort
+	}
+	return port
+}
+
+func parseListenAddress(listenAddress string) (net.IP, int, error) {
+	host, portString, err := net.SplitHostPort(listenAddress)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid listen address %s", listenAddress)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, 0, errors.Errorf("invalid listen address %s: %s is not an IP address", listenAddress, host)
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "invalid listen address %s", listenAddress)
+	}
+	return ip, port, nil
+}
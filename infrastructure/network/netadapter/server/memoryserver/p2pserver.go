@@ -0,0 +1,84 @@
+package memoryserver
+
+import (
+	"net"
+	"sync/atomic"
+
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/pkg/errors"
+)
+
+type p2pServer struct {
+	network            *Network
+	listenAddress      string
+	ip                 net.IP
+	port               int
+	onConnectedHandler server.OnConnectedHandler
+	isRunning          uint32
+}
+
+// NewP2PServer creates a new P2PServer that listens on listenAddress in the
+// given in-memory network, and connects only to other servers in it
+func NewP2PServer(network *Network, listenAddress string) (server.P2PServer, error) {
+	ip, port, err := parseListenAddress(listenAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &p2pServer{
+		network:       network,
+		listenAddress: listenAddress,
+		ip:            ip,
+		port:          port,
+	}, nil
+}
+
+// Start starts listening on the server's address
+// This is part of the Server interface
+func (p *p2pServer) Start() error {
+	if p.onConnectedHandler == nil {
+		return errors.New("onConnectedHandler is nil")
+	}
+	err := p.network.addServer(p)
+	if err != nil {
+		return err
+	}
+	atomic.StoreUint32(&p.isRunning, 1)
+	log.Infof("P2P Server listening on %s in memory", p.listenAddress)
+	return nil
+}
+
+// Stop stops listening and disconnects all of the server's connections
+// This is part of the Server interface
+func (p *p2pServer) Stop() error {
+	if !atomic.CompareAndSwapUint32(&p.isRunning, 1, 0) {
+		return nil
+	}
+	p.network.removeServer(p)
+	return nil
+}
+
+// SetOnConnectedHandler sets the peer connected handler
+// function for the server
+// This is part of the Server interface
+func (p *p2pServer) SetOnConnectedHandler(onConnectedHandler server.OnConnectedHandler) {
+	p.onConnectedHandler = onConnectedHandler
+}
+
+// Connect connects to the given address
+// This is part of the P2PServer interface
+func (p *p2pServer) Connect(address string) (server.Connection, error) {
+	if atomic.LoadUint32(&p.isRunning) == 0 {
+		return nil, errors.Errorf("%s error connecting to %s: the server is not running", p.listenAddress, address)
+	}
+	log.Debugf("%s Dialing to %s", p.listenAddress, address)
+
+	connection, err := p.network.connect(p, address)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(logger.Fields{"peer": address}).Infof("%s Connected to %s", p.listenAddress, address)
+
+	return connection, nil
+}
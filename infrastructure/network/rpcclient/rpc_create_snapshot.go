@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// CreateSnapshot sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) CreateSnapshot() (*appmessage.CreateSnapshotResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewCreateSnapshotRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdCreateSnapshotResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	createSnapshotResponse := response.(*appmessage.CreateSnapshotResponseMessage)
+	if createSnapshotResponse.Error != nil {
+		return nil, c.convertRPCError(createSnapshotResponse.Error)
+	}
+	return createSnapshotResponse, nil
+}
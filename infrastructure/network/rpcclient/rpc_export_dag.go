@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// ExportDAG sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ExportDAG(depth uint64) (*appmessage.ExportDAGResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewExportDAGRequestMessage(depth))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdExportDAGResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	exportDAGResponse := response.(*appmessage.ExportDAGResponseMessage)
+	if exportDAGResponse.Error != nil {
+		return nil, c.convertRPCError(exportDAGResponse.Error)
+	}
+	return exportDAGResponse, nil
+}
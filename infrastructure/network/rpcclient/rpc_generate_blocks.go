@@ -0,0 +1,23 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GenerateBlocks sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GenerateBlocks(payAddress string, blockCount uint32,
+	parentHashes []string, timestamp int64) (*appmessage.GenerateBlocksResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(
+		appmessage.NewGenerateBlocksRequestMessage(payAddress, blockCount, parentHashes, timestamp))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGenerateBlocksResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	generateBlocksResponse := response.(*appmessage.GenerateBlocksResponseMessage)
+	if generateBlocksResponse.Error != nil {
+		return nil, c.convertRPCError(generateBlocksResponse.Error)
+	}
+	return generateBlocksResponse, nil
+}
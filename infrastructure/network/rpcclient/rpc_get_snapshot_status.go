@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetSnapshotStatus sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetSnapshotStatus() (*appmessage.GetSnapshotStatusResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetSnapshotStatusRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetSnapshotStatusResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getSnapshotStatusResponse := response.(*appmessage.GetSnapshotStatusResponseMessage)
+	if getSnapshotStatusResponse.Error != nil {
+		return nil, c.convertRPCError(getSnapshotStatusResponse.Error)
+	}
+	return getSnapshotStatusResponse, nil
+}
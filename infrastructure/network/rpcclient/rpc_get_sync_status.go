@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// GetSyncStatus sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetSyncStatus() (*appmessage.GetSyncStatusResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetSyncStatusRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetSyncStatusResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getSyncStatusResponse := response.(*appmessage.GetSyncStatusResponseMessage)
+	if getSyncStatusResponse.Error != nil {
+		return nil, c.convertRPCError(getSyncStatusResponse.Error)
+	}
+	return getSyncStatusResponse, nil
+}
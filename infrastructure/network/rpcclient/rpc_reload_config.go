@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/kaspanet/kaspad/app/appmessage"
+
+// ReloadConfig sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ReloadConfig() (*appmessage.ReloadConfigResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewReloadConfigRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdReloadConfigResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	reloadConfigResponse := response.(*appmessage.ReloadConfigResponseMessage)
+	if reloadConfigResponse.Error != nil {
+		return nil, c.convertRPCError(reloadConfigResponse.Error)
+	}
+	return reloadConfigResponse, nil
+}
@@ -17,6 +17,10 @@ var ShutdownRequestChannel = make(chan struct{})
 // shutdown. This may be modified during init depending on the platform.
 var interruptSignals = []os.Signal{os.Interrupt}
 
+// reloadSignals defines the signals to catch in order to reload the
+// configuration. It is only set during init on platforms that support it.
+var reloadSignals []os.Signal
+
 // InterruptListener listens for OS Signals such as SIGINT (Ctrl+C) and shutdown
 // requests from shutdownRequestChannel. It returns a channel that is closed
 // when either signal is received.
@@ -57,6 +61,45 @@ func InterruptListener() chan struct{} {
 	return c
 }
 
+// ReloadListener listens for OS signals that request to reload the
+// configuration, such as SIGHUP. It returns a channel that receives a value
+// every time such a signal is received, until quit is closed. The channel
+// never receives on platforms without such signals.
+func ReloadListener(quit <-chan struct{}) <-chan struct{} {
+	c := make(chan struct{})
+	if len(reloadSignals) == 0 {
+		return c
+	}
+
+	reloadChannel := make(chan os.Signal, 1)
+	signal.Notify(reloadChannel, reloadSignals...)
+	go func() {
+		defer signal.Stop(reloadChannel)
+		deliverReloadRequests(reloadChannel, c, quit)
+	}()
+
+	return c
+}
+
+// deliverReloadRequests sends a value to c for every signal received from
+// reloadChannel. It returns once quit is closed, even if nothing receives
+// from c anymore.
+func deliverReloadRequests(reloadChannel <-chan os.Signal, c chan<- struct{}, quit <-chan struct{}) {
+	for {
+		select {
+		case sig := <-reloadChannel:
+			kasdLog.Infof("Received signal (%s). Reloading the configuration...", sig)
+			select {
+			case c <- struct{}{}:
+			case <-quit:
+				return
+			}
+		case <-quit:
+			return
+		}
+	}
+}
+
 // InterruptRequested returns true when the channel returned by
 // InterruptListener was closed. This simplifies early shutdown slightly since
 // the caller can just use an if statement instead of a select.
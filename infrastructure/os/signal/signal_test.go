@@ -0,0 +1,38 @@
+package signal
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+func TestDeliverReloadRequests(t *testing.T) {
+	logger.InitLogStdout(logger.LevelInfo)
+
+	reloadChannel := make(chan os.Signal, 1)
+	c := make(chan struct{})
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		deliverReloadRequests(reloadChannel, c, quit)
+		close(done)
+	}()
+
+	reloadChannel <- os.Interrupt
+	select {
+	case <-c:
+	case <-time.After(time.Second):
+		t.Fatalf("The reload request was not delivered")
+	}
+
+	// Nothing receives the second request, like after shutdown
+	reloadChannel <- os.Interrupt
+	close(quit)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("deliverReloadRequests blocked after quit was closed")
+	}
+}
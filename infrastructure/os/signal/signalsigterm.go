@@ -13,4 +13,5 @@ import (
 
 func init() {
 	interruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	reloadSignals = []os.Signal{syscall.SIGHUP}
 }
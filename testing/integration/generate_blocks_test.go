@@ -0,0 +1,96 @@
+package integration
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/util"
+)
+
+func TestGenerateBlocks(t *testing.T) {
+	kaspad, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	getBlock := func(blockHash string) *externalapi.DomainBlock {
+		response, err := kaspad.rpcClient.GetBlock(blockHash, true)
+		if err != nil {
+			t.Fatalf("GetBlock: %+v", err)
+		}
+		block, err := appmessage.RPCBlockToDomainBlock(response.Block)
+		if err != nil {
+			t.Fatalf("RPCBlockToDomainBlock: %+v", err)
+		}
+		return block
+	}
+
+	_, err := kaspad.rpcClient.GenerateBlocks("not an address", 1, nil, 0)
+	if err == nil {
+		t.Fatalf("Expected GenerateBlocks to fail with an invalid pay address")
+	}
+
+	// The generated blocks pay the given address, and are
+	// mined over the virtual when no parents are given
+	payAddress, err := util.DecodeAddress(miningAddress3, kaspad.config.ActiveNetParams.Prefix)
+	if err != nil {
+		t.Fatalf("DecodeAddress: %+v", err)
+	}
+	payScriptPublicKey, err := txscript.PayToAddrScript(payAddress)
+	if err != nil {
+		t.Fatalf("PayToAddrScript: %+v", err)
+	}
+	blueScoreBefore, err := kaspad.rpcClient.GetVirtualSelectedParentBlueScore()
+	if err != nil {
+		t.Fatalf("GetVirtualSelectedParentBlueScore: %+v", err)
+	}
+	response, err := kaspad.rpcClient.GenerateBlocks(miningAddress3, 3, nil, 0)
+	if err != nil {
+		t.Fatalf("GenerateBlocks: %+v", err)
+	}
+	if len(response.BlockHashes) != 3 {
+		t.Fatalf("Expected 3 generated blocks, but got %d", len(response.BlockHashes))
+	}
+	for _, blockHash := range response.BlockHashes {
+		coinbasePayload := getBlock(blockHash).Transactions[0].Payload
+		if !bytes.Contains(coinbasePayload, payScriptPublicKey.Script) {
+			t.Fatalf("The coinbase of block %s doesn't pay %s", blockHash, miningAddress3)
+		}
+	}
+	blueScoreAfter, err := kaspad.rpcClient.GetVirtualSelectedParentBlueScore()
+	if err != nil {
+		t.Fatalf("GetVirtualSelectedParentBlueScore: %+v", err)
+	}
+	if blueScoreAfter.BlueScore != blueScoreBefore.BlueScore+3 {
+		t.Fatalf("Expected the generated blocks to be chained over the virtual, but "+
+			"the blue score went from %d to %d", blueScoreBefore.BlueScore, blueScoreAfter.BlueScore)
+	}
+
+	// Each block generated over the given parents is the parent of the next one
+	genesisHash := kaspad.config.ActiveNetParams.GenesisHash.String()
+	response, err = kaspad.rpcClient.GenerateBlocks(miningAddress1, 3, []string{genesisHash}, 0)
+	if err != nil {
+		t.Fatalf("GenerateBlocks: %+v", err)
+	}
+	expectedParentHash := genesisHash
+	for _, blockHash := range response.BlockHashes {
+		parentHashes := getBlock(blockHash).Header.ParentHashes()
+		if len(parentHashes) != 1 || parentHashes[0].String() != expectedParentHash {
+			t.Fatalf("Expected block %s to have the single parent %s, but its parents are %s",
+				blockHash, expectedParentHash, parentHashes)
+		}
+		expectedParentHash = blockHash
+	}
+
+	_, err = kaspad.rpcClient.GenerateBlocks(miningAddress1, 1, []string{
+		externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1}).String()}, 0)
+	if err == nil {
+		t.Fatalf("Expected GenerateBlocks to fail with an unknown parent")
+	}
+}
@@ -0,0 +1,7 @@
+package simulation
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("SIMU")
@@ -0,0 +1,87 @@
+package simulation
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// miner mines a block on a node every interval of virtual time
+type miner struct {
+	node          *Node
+	interval      time.Duration
+	nextBlockTime mstime.Time
+}
+
+// AddMiner makes node mine a block every interval of virtual time while the
+// simulation runs. The first block is mined one interval from now.
+// The virtual clock has a precision of one millisecond, so interval is
+// rounded to the nearest millisecond, and is at least a millisecond.
+func (s *Simulation) AddMiner(node *Node, interval time.Duration) {
+	interval = interval.Round(time.Millisecond)
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	s.miners = append(s.miners, &miner{
+		node:          node,
+		interval:      interval,
+		nextBlockTime: s.clock.Now().Add(interval),
+	})
+}
+
+// RemoveMiners removes all the miners added with AddMiner
+func (s *Simulation) RemoveMiners() {
+	s.miners = nil
+}
+
+// Run advances the virtual clock by the given duration, at the simulation's
+// speed, and has every miner mine the blocks that are due in that time when
+// they're due. Run doesn't wait for the last blocks to propagate: use
+// WaitForSync for that.
+// The virtual clock has a precision of one millisecond, so duration is
+// rounded to the nearest millisecond.
+func (s *Simulation) Run(duration time.Duration) error {
+	duration = duration.Round(time.Millisecond)
+	realStart := time.Now()
+	virtualStart := s.clock.Now()
+	end := virtualStart.Add(duration)
+	sleepUntil := func(virtualTime mstime.Time) {
+		time.Sleep(time.Until(realStart.Add(s.realDuration(virtualTime.Sub(virtualStart)))))
+	}
+
+	for {
+		nextMiner := s.nextMiner()
+		if nextMiner == nil || nextMiner.nextBlockTime.After(end) {
+			break
+		}
+		sleepUntil(nextMiner.nextBlockTime)
+		s.advanceClockTo(nextMiner.nextBlockTime)
+		_, err := nextMiner.node.MineBlock()
+		if err != nil {
+			return errors.Wrapf(err, "error mining a block on %s", nextMiner.node)
+		}
+		nextMiner.nextBlockTime = nextMiner.nextBlockTime.Add(nextMiner.interval)
+	}
+	sleepUntil(end)
+	s.advanceClockTo(end)
+	return nil
+}
+
+// advanceClockTo sets the virtual clock to the given time, unless the clock
+// was already advanced past it
+func (s *Simulation) advanceClockTo(virtualTime mstime.Time) {
+	if virtualTime.After(s.clock.Now()) {
+		s.clock.Set(virtualTime)
+	}
+}
+
+func (s *Simulation) nextMiner() *miner {
+	var nextMiner *miner
+	for _, miner := range s.miners {
+		if nextMiner == nil || miner.nextBlockTime.Before(nextMiner.nextBlockTime) {
+			nextMiner = miner
+		}
+	}
+	return nextMiner
+}
@@ -0,0 +1,144 @@
+package simulation
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+
+	"github.com/kaspanet/kaspad/app"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/memoryserver"
+	"github.com/pkg/errors"
+)
+
+// nodeCacheSize is the memory, in bytes, that the consensus caches of every
+// node may use. The default is meant for a single node per machine, and the
+// caches preallocate their capacity, so it's kept small to fit many nodes
+// into one process.
+const nodeCacheSize = 20_000_000
+
+// Node is a single kaspad instance in a simulation
+type Node struct {
+	simulation *Simulation
+	index      int
+	address    string
+	config     *config.Config
+	database   database.Database
+	app        *app.ComponentManager
+}
+
+func newNode(simulation *Simulation, index int) (*Node, error) {
+	// Every node gets an IP of its own, so that nodes can be told apart by IP
+	// like on a real network, e.g. when they're banned
+	ip := net.IPv4(10, byte(index>>16), byte(index>>8), byte(index))
+	address := net.JoinHostPort(ip.String(), simulation.params.DefaultPort)
+
+	cfg := config.DefaultConfig()
+	cfg.ActiveNetParams = simulation.params
+	cfg.Simnet = true
+	cfg.AppDir = filepath.Join(simulation.dataDir, fmt.Sprintf("node%d", index))
+	cfg.Listeners = []string{address}
+	cfg.TargetOutboundPeers = 0
+	cfg.DisableDNSSeed = true
+	cfg.MaxUTXOCacheSize = nodeCacheSize
+
+	db, err := ldb.NewLevelDB(filepath.Join(cfg.AppDir, "db"), 8)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer, err := memoryserver.NewP2PServer(simulation.network, address)
+	if err != nil {
+		return nil, err
+	}
+	componentManager, err := app.NewComponentManagerWithP2PServer(cfg, db, p2pServer, simulation.clock, make(chan struct{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		simulation: simulation,
+		index:      index,
+		address:    address,
+		config:     cfg,
+		database:   db,
+		app:        componentManager,
+	}, nil
+}
+
+func (n *Node) String() string {
+	return fmt.Sprintf("node %d (%s)", n.index, n.address)
+}
+
+// Index returns the index of the node in the simulation
+func (n *Node) Index() int {
+	return n.index
+}
+
+// Address returns the address the node listens on in the simulation's network
+func (n *Node) Address() string {
+	return n.address
+}
+
+// App returns the node's ComponentManager
+func (n *Node) App() *app.ComponentManager {
+	return n.app
+}
+
+// Domain returns the node's Domain
+func (n *Node) Domain() domain.Domain {
+	return n.app.Domain()
+}
+
+// ID returns the node's P2P ID
+func (n *Node) ID() *id.ID {
+	return n.app.P2PNodeID()
+}
+
+// IsConnectedTo returns whether the node has finished the handshake with the given node
+func (n *Node) IsConnectedTo(other *Node) bool {
+	for _, peer := range n.app.ProtocolManager().Peers() {
+		if peer.ID().IsEqual(other.ID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// MineBlock mines a block over the node's virtual, with a timestamp taken from
+// the simulation's clock, and submits it to the node, which relays it to its peers
+func (n *Node) MineBlock() (*externalapi.DomainBlock, error) {
+	templateBlock, err := n.Domain().MiningManager().GetBlockTemplate(n.simulation.coinbaseData)
+	if err != nil {
+		return nil, err
+	}
+
+	block := miningmanager.BlockFromTemplate(templateBlock)
+	header := block.Header.ToMutable()
+	header.SetTimeInMilliseconds(n.simulation.nextBlockTime())
+	block.Header = header.ToImmutable()
+	if !n.simulation.params.SkipProofOfWork {
+		n.simulation.solveBlock(block)
+	}
+
+	err = n.app.ProtocolManager().AddBlock(block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s rejected its own block", n)
+	}
+	return block, nil
+}
+
+func (n *Node) start() {
+	n.app.Start()
+}
+
+func (n *Node) stop() error {
+	n.app.Stop()
+	return n.database.Close()
+}
@@ -0,0 +1,307 @@
+package simulation
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/memoryserver"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// Config is the configuration of a simulation
+type Config struct {
+	// NodeCount is the number of nodes in the simulation
+	NodeCount int
+
+	// Params are the DAG params of the simulated network. Defaults to simnet's params.
+	Params *dagconfig.Params
+
+	// StartTime is the initial time of the simulation's clock. Defaults to an hour ago.
+	// The nodes read the time from the simulation's clock too, so it may pass the wall
+	// clock freely.
+	StartTime time.Time
+
+	// Speed is how many times faster than real time the simulation runs. Link
+	// latencies and bandwidths, like miner intervals, are in virtual time, and are
+	// scaled by Speed on the underlying network. Defaults to 1.
+	Speed float64
+
+	// LinkConditions are the conditions of all the links in the network that weren't
+	// given other conditions with SetLinkConditions
+	LinkConditions memoryserver.LinkConditions
+
+	// Seed seeds the random source used for solving blocks. Defaults to 0.
+	Seed int64
+}
+
+type link struct {
+	from, to *Node
+}
+
+// Simulation runs a network of kaspad nodes in a single process. The nodes are
+// complete kaspad instances that communicate through an in-memory network with
+// configurable latency, bandwidth and partitions, and blocks are mined by the
+// simulation itself with timestamps taken from a virtual clock, which is also the
+// clock of the nodes.
+type Simulation struct {
+	params       *dagconfig.Params
+	network      *memoryserver.Network
+	clock        *mstime.FakeClock
+	speed        float64
+	dataDir      string
+	nodes        []*Node
+	coinbaseData *externalapi.DomainCoinbaseData
+
+	linksLock sync.Mutex
+	links     []*link
+
+	miningLock    sync.Mutex
+	random        *rand.Rand
+	lastBlockTime int64
+
+	miners []*miner
+}
+
+// New creates a new Simulation and starts all its nodes. The nodes aren't connected
+// to each other until Connect is called.
+func New(cfg *Config) (*Simulation, error) {
+	if cfg.NodeCount <= 0 {
+		return nil, errors.Errorf("a simulation must have at least one node")
+	}
+
+	params := cfg.Params
+	if params == nil {
+		simnetParams := dagconfig.SimnetParams
+		params = &simnetParams
+	}
+	startTime := cfg.StartTime
+	if startTime.IsZero() {
+		startTime = time.Now().Add(-time.Hour)
+	}
+	speed := cfg.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+
+	dataDir, err := ioutil.TempDir("", "kaspad-simulation")
+	if err != nil {
+		return nil, err
+	}
+
+	opTrueScript, _ := testutils.OpTrueScript()
+	simulation := &Simulation{
+		params:       params,
+		network:      memoryserver.NewNetwork(),
+		clock:        mstime.NewFakeClock(mstime.ToMSTime(startTime)),
+		speed:        speed,
+		dataDir:      dataDir,
+		coinbaseData: &externalapi.DomainCoinbaseData{ScriptPublicKey: opTrueScript, ExtraData: []byte{}},
+		random:       rand.New(rand.NewSource(cfg.Seed)),
+	}
+	simulation.network.SetDefaultLinkConditions(simulation.realLinkConditions(cfg.LinkConditions))
+
+	for i := 0; i < cfg.NodeCount; i++ {
+		node, err := newNode(simulation, i)
+		if err != nil {
+			simulation.Stop()
+			return nil, errors.Wrapf(err, "error creating node %d", i)
+		}
+		simulation.nodes = append(simulation.nodes, node)
+		node.start()
+	}
+
+	log.Infof("Started a simulation of %d nodes in %s", cfg.NodeCount, dataDir)
+	return simulation, nil
+}
+
+// Stop stops all the nodes in the simulation and removes their data
+func (s *Simulation) Stop() {
+	for _, node := range s.nodes {
+		err := node.stop()
+		if err != nil {
+			log.Errorf("Error stopping %s: %s", node, err)
+		}
+	}
+	err := os.RemoveAll(s.dataDir)
+	if err != nil {
+		log.Errorf("Error removing the simulation's data directory: %s", err)
+	}
+}
+
+// Nodes returns all the nodes in the simulation
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+// Node returns the node at the given index
+func (s *Simulation) Node(index int) *Node {
+	return s.nodes[index]
+}
+
+// Clock returns the simulation's virtual clock
+func (s *Simulation) Clock() *mstime.FakeClock {
+	return s.clock
+}
+
+// Params returns the DAG params of the simulated network
+func (s *Simulation) Params() *dagconfig.Params {
+	return s.params
+}
+
+// Connect makes from connect to to, and waits until both nodes finish the handshake.
+// The connection is permanent: it's restored by Heal if it's cut by a partition.
+func (s *Simulation) Connect(from, to *Node) error {
+	s.linksLock.Lock()
+	s.links = append(s.links, &link{from: from, to: to})
+	s.linksLock.Unlock()
+
+	from.app.ConnectionManager().AddConnectionRequest(to.address, true)
+	return waitFor(connectionTimeout, func() bool {
+		return from.IsConnectedTo(to) && to.IsConnectedTo(from)
+	}, "%s to connect to %s", from, to)
+}
+
+// SetLinkConditions sets the conditions of the link between node1 and node2,
+// in both directions. The conditions are in virtual time.
+func (s *Simulation) SetLinkConditions(node1, node2 *Node, conditions memoryserver.LinkConditions) {
+	s.network.SetLinkConditions(node1.address, node2.address, s.realLinkConditions(conditions))
+}
+
+// realLinkConditions converts link conditions in virtual time to the conditions
+// in real time that the network applies
+func (s *Simulation) realLinkConditions(conditions memoryserver.LinkConditions) memoryserver.LinkConditions {
+	return memoryserver.LinkConditions{
+		Latency:   s.realDuration(conditions.Latency),
+		Bandwidth: uint64(float64(conditions.Bandwidth) * s.speed),
+	}
+}
+
+// realDuration converts a duration in virtual time to a duration in real time
+func (s *Simulation) realDuration(virtualDuration time.Duration) time.Duration {
+	return time.Duration(float64(virtualDuration) / s.speed)
+}
+
+// Partition splits the network into the given groups of nodes. Nodes can only
+// reach nodes in their own group, and all the nodes that aren't in any group
+// form one more group together. Connections between groups are cut.
+func (s *Simulation) Partition(groups ...[]*Node) {
+	addressGroups := make([][]string, len(groups))
+	for i, group := range groups {
+		for _, node := range group {
+			addressGroups[i] = append(addressGroups[i], node.address)
+		}
+	}
+	s.network.Partition(addressGroups...)
+}
+
+// Heal removes the partition set by Partition, restores the connections it cut,
+// and waits until they're all established again
+func (s *Simulation) Heal() error {
+	s.network.Heal()
+
+	s.linksLock.Lock()
+	links := s.links
+	s.linksLock.Unlock()
+
+	for _, link := range links {
+		if link.from.IsConnectedTo(link.to) {
+			continue
+		}
+		// Requesting the connection again makes the connection manager retry it
+		// right away, rather than after its regular retry interval
+		link.from.app.ConnectionManager().AddConnectionRequest(link.to.address, true)
+	}
+	for _, link := range links {
+		from, to := link.from, link.to
+		err := waitFor(connectionTimeout, func() bool {
+			return from.IsConnectedTo(to) && to.IsConnectedTo(from)
+		}, "%s to reconnect to %s", from, to)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitForSync waits until all the nodes have the same tips and none of them is
+// in IBD, or until the timeout expires
+func (s *Simulation) WaitForSync(timeout time.Duration) error {
+	return waitFor(timeout, s.isSynced, "all the nodes to sync")
+}
+
+func (s *Simulation) isSynced() bool {
+	var expectedTips []*externalapi.DomainHash
+	for i, node := range s.nodes {
+		if node.app.ProtocolManager().IsIBDRunning() {
+			return false
+		}
+		tips, err := node.Domain().Consensus().Tips()
+		if err != nil {
+			log.Errorf("Error getting the tips of %s: %s", node, err)
+			return false
+		}
+		if i == 0 {
+			expectedTips = tips
+			continue
+		}
+		if !externalapi.HashesEqual(sortedHashes(expectedTips), sortedHashes(tips)) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedHashes(hashes []*externalapi.DomainHash) []*externalapi.DomainHash {
+	sorted := externalapi.CloneHashes(hashes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Less(sorted[j])
+	})
+	return sorted
+}
+
+// nextBlockTime returns the timestamp, in milliseconds, of the next block mined
+// in the simulation. Timestamps are strictly increasing, so that every block is
+// after the past median time of its parents even if the clock didn't move.
+func (s *Simulation) nextBlockTime() int64 {
+	s.miningLock.Lock()
+	defer s.miningLock.Unlock()
+
+	blockTime := s.clock.Now().UnixMilliseconds()
+	if blockTime <= s.lastBlockTime {
+		blockTime = s.lastBlockTime + 1
+	}
+	s.lastBlockTime = blockTime
+	return blockTime
+}
+
+func (s *Simulation) solveBlock(block *externalapi.DomainBlock) {
+	s.miningLock.Lock()
+	defer s.miningLock.Unlock()
+
+	mining.SolveBlock(block, s.random)
+}
+
+const connectionTimeout = 10 * time.Second
+
+func waitFor(timeout time.Duration, condition func() bool, format string, args ...interface{}) error {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for !condition() {
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out after %s waiting for "+format, append([]interface{}{timeout}, args...)...)
+		}
+		<-ticker.C
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/memoryserver"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+const syncTimeout = time.Minute
+
+func setup(t *testing.T, cfg *Config) *Simulation {
+	simulation, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %+v", err)
+	}
+	t.Cleanup(simulation.Stop)
+	return simulation
+}
+
+func connect(t *testing.T, simulation *Simulation, from, to int) {
+	err := simulation.Connect(simulation.Node(from), simulation.Node(to))
+	if err != nil {
+		t.Fatalf("Connect: %+v", err)
+	}
+}
+
+func run(t *testing.T, simulation *Simulation, duration time.Duration) {
+	err := simulation.Run(duration)
+	if err != nil {
+		t.Fatalf("Run: %+v", err)
+	}
+}
+
+func waitForSync(t *testing.T, simulation *Simulation) {
+	err := simulation.WaitForSync(syncTimeout)
+	if err != nil {
+		t.Fatalf("WaitForSync: %+v", err)
+	}
+}
+
+// mineOnEveryNode mines a block on every node in turn, and waits for each block to
+// reach all the nodes before mining the next one, so that the last block merges
+// every tip in the network. This is needed when some blocks might have been received
+// only through IBD, since such blocks aren't relayed. It returns the last block's hash.
+func mineOnEveryNode(t *testing.T, simulation *Simulation) *externalapi.DomainHash {
+	var blockHash *externalapi.DomainHash
+	for _, node := range simulation.Nodes() {
+		err := waitFor(syncTimeout, func() bool {
+			for _, node := range simulation.Nodes() {
+				if node.app.ProtocolManager().IsIBDRunning() {
+					return false
+				}
+			}
+			return true
+		}, "all the nodes to finish IBD")
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+
+		block, err := node.MineBlock()
+		if err != nil {
+			t.Fatalf("MineBlock: %+v", err)
+		}
+		blockHash = consensushashing.BlockHash(block)
+		err = waitFor(syncTimeout, func() bool {
+			for _, node := range simulation.Nodes() {
+				blockInfo, err := node.Domain().Consensus().GetBlockInfo(blockHash)
+				if err != nil || !blockInfo.Exists || blockInfo.BlockStatus == externalapi.StatusHeaderOnly {
+					return false
+				}
+			}
+			return true
+		}, "block %s to reach all the nodes", blockHash)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	return blockHash
+}
+
+func virtualSelectedParent(t *testing.T, node *Node) *externalapi.DomainHash {
+	virtualSelectedParent, err := node.Domain().Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		t.Fatalf("GetVirtualSelectedParent: %+v", err)
+	}
+	return virtualSelectedParent
+}
+
+func TestBlockRelay(t *testing.T) {
+	simulation := setup(t, &Config{
+		NodeCount:      10,
+		Speed:          10,
+		LinkConditions: memoryserver.LinkConditions{Latency: 10 * time.Millisecond},
+	})
+
+	// Connect the nodes in a line, so that every block has to be relayed through all of them
+	for i := 1; i < 10; i++ {
+		connect(t, simulation, i-1, i)
+	}
+
+	simulation.AddMiner(simulation.Node(0), time.Second)
+	simulation.AddMiner(simulation.Node(9), 3*time.Second)
+	run(t, simulation, 30*time.Second)
+	waitForSync(t, simulation)
+
+	// A block that merges all the others is expected to have all of them,
+	// 30 blocks from node 0 and 10 from node 9, as blue blocks in its past
+	block, err := simulation.Node(5).MineBlock()
+	if err != nil {
+		t.Fatalf("MineBlock: %+v", err)
+	}
+	waitForSync(t, simulation)
+	blockInfo, err := simulation.Node(0).Domain().Consensus().GetBlockInfo(consensushashing.BlockHash(block))
+	if err != nil {
+		t.Fatalf("GetBlockInfo: %+v", err)
+	}
+	const expectedBlueScore = 41
+	if blockInfo.BlueScore != expectedBlueScore {
+		t.Fatalf("Expected the blue score of the merging block to be %d, but got %d",
+			expectedBlueScore, blockInfo.BlueScore)
+	}
+}
+
+func TestIBD(t *testing.T) {
+	simulation := setup(t, &Config{NodeCount: 2, Speed: 100})
+
+	simulation.AddMiner(simulation.Node(0), time.Second)
+	run(t, simulation, 300*time.Second)
+
+	connect(t, simulation, 1, 0)
+	waitForSync(t, simulation)
+}
+
+func TestPartitionReorg(t *testing.T) {
+	simulation := setup(t, &Config{
+		NodeCount:      6,
+		Speed:          10,
+		LinkConditions: memoryserver.LinkConditions{Latency: 5 * time.Millisecond},
+	})
+	nodes := simulation.Nodes()
+
+	// Connect the nodes in a ring
+	for i := range nodes {
+		connect(t, simulation, i, (i+1)%len(nodes))
+	}
+	simulation.AddMiner(nodes[0], time.Second)
+	run(t, simulation, 5*time.Second)
+	waitForSync(t, simulation)
+
+	// Mine on both sides of a partition, with the second side mining twice as fast
+	simulation.Partition(nodes[:3], nodes[3:])
+	simulation.RemoveMiners()
+	simulation.AddMiner(nodes[1], time.Second)
+	simulation.AddMiner(nodes[4], 500*time.Millisecond)
+	run(t, simulation, 20*time.Second)
+
+	heavierTip := virtualSelectedParent(t, nodes[4])
+	lighterTip := virtualSelectedParent(t, nodes[1])
+	if heavierTip.Equal(lighterTip) {
+		t.Fatalf("Expected the two sides of the partition to have different selected tips")
+	}
+
+	err := simulation.Heal()
+	if err != nil {
+		t.Fatalf("Heal: %+v", err)
+	}
+
+	// Nodes only learn about each other's blocks when new blocks are relayed,
+	// relayed blocks are ignored while a node is in IBD, and blocks received
+	// in IBD aren't relayed, so keep mining on both sides for a while after
+	// the partition is healed
+	simulation.RemoveMiners()
+	simulation.AddMiner(nodes[1], time.Second)
+	simulation.AddMiner(nodes[4], time.Second)
+	run(t, simulation, 10*time.Second)
+	simulation.RemoveMiners()
+	mineOnEveryNode(t, simulation)
+	waitForSync(t, simulation)
+
+	// The lighter side is expected to reorg to the heavier side's selected chain
+	for _, node := range nodes {
+		isInSelectedParentChain, err := node.Domain().Consensus().IsInSelectedParentChainOf(
+			heavierTip, virtualSelectedParent(t, node))
+		if err != nil {
+			t.Fatalf("IsInSelectedParentChainOf: %+v", err)
+		}
+		if !isInSelectedParentChain {
+			t.Fatalf("Expected %s to reorg to the selected chain of %s", node, heavierTip)
+		}
+	}
+}
+
+func TestLargeNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping the large network simulation in short mode")
+	}
+
+	const nodeCount = 50
+	simulation := setup(t, &Config{
+		NodeCount: nodeCount,
+		LinkConditions: memoryserver.LinkConditions{
+			Latency:   20 * time.Millisecond,
+			Bandwidth: 1024 * 1024,
+		},
+	})
+
+	// Connect every node to the one before it, so that the network is connected,
+	// and to two random nodes before it
+	random := rand.New(rand.NewSource(0))
+	for i := 1; i < nodeCount; i++ {
+		connect(t, simulation, i, i-1)
+		for j := 0; j < 2 && i > 1; j++ {
+			peer := random.Intn(i - 1)
+			if !simulation.Node(i).IsConnectedTo(simulation.Node(peer)) {
+				connect(t, simulation, i, peer)
+			}
+		}
+	}
+
+	for i := 0; i < nodeCount; i += 10 {
+		simulation.AddMiner(simulation.Node(i), 2*time.Second)
+	}
+	run(t, simulation, 10*time.Second)
+
+	// Blocks mined concurrently by distant miners often arrive as orphans, and
+	// the ones fetched through IBD aren't relayed, so the nodes are synced by
+	// mining a block on each of them in turn
+	simulation.RemoveMiners()
+	lastBlockHash := mineOnEveryNode(t, simulation)
+	waitForSync(t, simulation)
+	if !virtualSelectedParent(t, simulation.Node(0)).Equal(lastBlockHash) {
+		t.Fatalf("Expected the block mined by the last node to be the selected tip of the first node")
+	}
+}
+
+func TestUnorphanedBlockRelay(t *testing.T) {
+	simulation := setup(t, &Config{NodeCount: 3})
+	nodes := simulation.Nodes()
+	connect(t, simulation, 2, 1)
+
+	// Node 0 announces its selected tip to node 1 when they connect. Node 1
+	// doesn't have the tip's parent, so it adds the tip to its orphan pool
+	// and requests the parent as its missing ancestor
+	_, err := nodes[0].MineBlock()
+	if err != nil {
+		t.Fatalf("MineBlock: %+v", err)
+	}
+	child, err := nodes[0].MineBlock()
+	if err != nil {
+		t.Fatalf("MineBlock: %+v", err)
+	}
+	connect(t, simulation, 1, 0)
+
+	// The child is only added to the DAG of node 1 when the parent unorphans it,
+	// and node 1 is expected to relay it to node 2 then
+	childHash := consensushashing.BlockHash(child)
+	err = waitFor(syncTimeout, func() bool {
+		blockInfo, err := nodes[2].Domain().Consensus().GetBlockInfo(childHash)
+		if err != nil {
+			t.Fatalf("GetBlockInfo: %+v", err)
+		}
+		return blockInfo.Exists && blockInfo.BlockStatus != externalapi.StatusHeaderOnly
+	}, "%s to receive the unorphaned block %s", nodes[2], childHash)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
+
+func TestRunAheadOfWallClock(t *testing.T) {
+	simulation := setup(t, &Config{NodeCount: 2, StartTime: time.Now(), Speed: 1000})
+	connect(t, simulation, 1, 0)
+
+	// The nodes read the time from the simulation's clock, so they accept blocks
+	// that are hours ahead of the wall clock
+	simulation.AddMiner(simulation.Node(0), time.Minute)
+	run(t, simulation, 3*time.Hour)
+	waitForSync(t, simulation)
+
+	tip := virtualSelectedParent(t, simulation.Node(1))
+	tipHeader, err := simulation.Node(1).Domain().Consensus().GetBlockHeader(tip)
+	if err != nil {
+		t.Fatalf("GetBlockHeader: %+v", err)
+	}
+	tipTime := mstime.UnixMilliseconds(tipHeader.TimeInMilliseconds())
+	if tipTime.Sub(mstime.Now()) < 2*time.Hour {
+		t.Fatalf("Expected the selected tip to be hours ahead of the wall clock, but its time is %s", tipTime)
+	}
+}
+
+func TestSubMillisecondDurations(t *testing.T) {
+	simulation := setup(t, &Config{NodeCount: 1, Speed: 100})
+	node := simulation.Node(0)
+
+	// The virtual clock has a precision of one millisecond, so the
+	// durations are rounded rather than making the clock panic
+	simulation.AddMiner(node, time.Second/3)
+	run(t, simulation, time.Second+time.Second/7)
+
+	syncInfo, err := node.Domain().Consensus().GetSyncInfo()
+	if err != nil {
+		t.Fatalf("GetSyncInfo: %+v", err)
+	}
+	// The block count includes the genesis block
+	if syncInfo.BlockCount != 4 {
+		t.Fatalf("Expected 3 blocks to be mined, but the block count is %d", syncInfo.BlockCount)
+	}
+}
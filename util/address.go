@@ -5,6 +5,8 @@
 package util
 
 import (
+	"sync"
+
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 
@@ -60,8 +62,54 @@ var stringsToBech32Prefixes = map[string]Bech32Prefix{
 	"kaspasim":  Bech32PrefixKaspaSim,
 }
 
+// bech32PrefixesLock protects stringsToBech32Prefixes, which custom networks
+// may add prefixes to
+var bech32PrefixesLock sync.RWMutex
+
+// RegisterBech32Prefix registers the given string as the prefix of a custom
+// network, and returns its Bech32Prefix. If the prefix is already registered,
+// its existing Bech32Prefix is returned. Prefixes should be registered before
+// any address with them is encoded or decoded.
+func RegisterBech32Prefix(prefixString string) (Bech32Prefix, error) {
+	if !isValidBech32PrefixString(prefixString) {
+		return Bech32PrefixUnknown, errors.Errorf("invalid prefix %s: a prefix must consist of "+
+			"lowercase letters and digits", prefixString)
+	}
+
+	bech32PrefixesLock.Lock()
+	defer bech32PrefixesLock.Unlock()
+
+	if prefix, ok := stringsToBech32Prefixes[prefixString]; ok {
+		return prefix, nil
+	}
+	prefix := Bech32Prefix(0)
+	for _, existingPrefix := range stringsToBech32Prefixes {
+		if existingPrefix > prefix {
+			prefix = existingPrefix
+		}
+	}
+	prefix++
+	stringsToBech32Prefixes[prefixString] = prefix
+	return prefix, nil
+}
+
+func isValidBech32PrefixString(prefixString string) bool {
+	if len(prefixString) == 0 {
+		return false
+	}
+	for _, character := range prefixString {
+		if (character < 'a' || character > 'z') && (character < '0' || character > '9') {
+			return false
+		}
+	}
+	return true
+}
+
 // ParsePrefix attempts to parse a Bech32 address prefix.
 func ParsePrefix(prefixString string) (Bech32Prefix, error) {
+	bech32PrefixesLock.RLock()
+	defer bech32PrefixesLock.RUnlock()
+
 	prefix, ok := stringsToBech32Prefixes[prefixString]
 	if !ok {
 		return Bech32PrefixUnknown, errors.Errorf("could not parse prefix %s", prefixString)
@@ -72,6 +120,9 @@ func ParsePrefix(prefixString string) (Bech32Prefix, error) {
 
 // Converts from Bech32 address prefixes to their string values
 func (prefix Bech32Prefix) String() string {
+	bech32PrefixesLock.RLock()
+	defer bech32PrefixesLock.RUnlock()
+
 	for key, value := range stringsToBech32Prefixes {
 		if prefix == value {
 			return key
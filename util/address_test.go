@@ -462,3 +462,56 @@ func TestPrefixToString(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterBech32Prefix(t *testing.T) {
+	prefix, err := util.RegisterBech32Prefix("kaspacustom")
+	if err != nil {
+		t.Fatalf("TestRegisterBech32Prefix: unexpected error: %s", err)
+	}
+	if prefix.String() != "kaspacustom" {
+		t.Errorf("TestRegisterBech32Prefix: expected string: kaspacustom, but got %s", prefix)
+	}
+	parsedPrefix, err := util.ParsePrefix("kaspacustom")
+	if err != nil {
+		t.Fatalf("TestRegisterBech32Prefix: unexpected error parsing the prefix: %s", err)
+	}
+	if parsedPrefix != prefix {
+		t.Errorf("TestRegisterBech32Prefix: expected parsed prefix: %d, but got %d", prefix, parsedPrefix)
+	}
+
+	// Registering a prefix again, or registering a standard prefix, returns the existing prefix
+	reregisteredPrefix, err := util.RegisterBech32Prefix("kaspacustom")
+	if err != nil {
+		t.Fatalf("TestRegisterBech32Prefix: unexpected error: %s", err)
+	}
+	if reregisteredPrefix != prefix {
+		t.Errorf("TestRegisterBech32Prefix: expected prefix: %d, but got %d", prefix, reregisteredPrefix)
+	}
+	kaspaPrefix, err := util.RegisterBech32Prefix("kaspa")
+	if err != nil {
+		t.Fatalf("TestRegisterBech32Prefix: unexpected error: %s", err)
+	}
+	if kaspaPrefix != util.Bech32PrefixKaspa {
+		t.Errorf("TestRegisterBech32Prefix: expected prefix: %d, but got %d", util.Bech32PrefixKaspa, kaspaPrefix)
+	}
+
+	publicKey := make([]byte, util.PublicKeySize)
+	address, err := util.NewAddressPublicKey(publicKey, prefix)
+	if err != nil {
+		t.Fatalf("TestRegisterBech32Prefix: unexpected error creating an address: %s", err)
+	}
+	decodedAddress, err := util.DecodeAddress(address.String(), prefix)
+	if err != nil {
+		t.Fatalf("TestRegisterBech32Prefix: unexpected error decoding %s: %s", address, err)
+	}
+	if decodedAddress.Prefix() != prefix {
+		t.Errorf("TestRegisterBech32Prefix: expected decoded prefix: %s, but got %s", prefix, decodedAddress.Prefix())
+	}
+
+	for _, invalidPrefix := range []string{"", "Kaspa", "kaspa:", "kaspa-custom"} {
+		_, err := util.RegisterBech32Prefix(invalidPrefix)
+		if err == nil {
+			t.Errorf("TestRegisterBech32Prefix: expected an error registering '%s'", invalidPrefix)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package mstime
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Code that reads the time through a Clock
+// instead of through Now can run on simulated time.
+type Clock interface {
+	Now() Time
+}
+
+// SystemClock is the Clock of the system, which tells the same time as Now
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() Time {
+	return Now()
+}
+
+// FakeClock is a Clock whose time changes only when it's set or advanced, for
+// tests and simulations. It's safe for concurrent use.
+type FakeClock struct {
+	lock sync.RWMutex
+	now  Time
+}
+
+// NewFakeClock returns a new FakeClock that starts at the given time
+func NewFakeClock(now Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock
+func (c *FakeClock) Now() Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.now
+}
+
+// Set sets the current time of the clock
+func (c *FakeClock) Set(now Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = now
+}
+
+// Advance moves the clock forward by the given duration.
+// Like Time.Add, it panics if d has a precision greater than one millisecond,
+// so callers that compute d, such as by dividing a duration, should round it
+// with d.Round(time.Millisecond) first.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,24 @@
+package mstime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := UnixMilliseconds(1000)
+	clock := NewFakeClock(start)
+	if clock.Now().UnixMilliseconds() != start.UnixMilliseconds() {
+		t.Fatalf("Unexpected initial time. Want: %s, got: %s", start, clock.Now())
+	}
+
+	clock.Advance(2 * time.Hour)
+	if want := start.Add(2 * time.Hour); clock.Now().UnixMilliseconds() != want.UnixMilliseconds() {
+		t.Fatalf("Unexpected time after Advance. Want: %s, got: %s", want, clock.Now())
+	}
+
+	clock.Set(start)
+	if clock.Now().UnixMilliseconds() != start.UnixMilliseconds() {
+		t.Fatalf("Unexpected time after Set. Want: %s, got: %s", start, clock.Now())
+	}
+}